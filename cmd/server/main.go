@@ -1,28 +1,126 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+	"github.com/dkooll/aztfmcp/internal/indexer"
 	"github.com/dkooll/aztfmcp/pkg/mcp"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		os.Exit(runExport(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	org := flag.String("org", "hashicorp", "GitHub organization name")
 	repo := flag.String("repo", "terraform-provider-azurerm", "GitHub repository to index")
 	token := flag.String("token", "", "GitHub personal access token (optional, for higher rate limits)")
 	dbPath := flag.String("db", "azurerm-provider.db", "Path to SQLite database file")
+	githubBaseURL := flag.String("github-base-url", indexer.DefaultGitHubBaseURL, "GitHub API base URL (e.g., https://ghes.example.com/api/v3 for GitHub Enterprise Server)")
+	descMaxChars := flag.Int("desc-max-chars", 0, "Default truncation length for attribute descriptions in schema/search output (0 = no truncation)")
+	maxTagPages := flag.Int("max-tag-pages", 5, "Pages of 100 tags fetched from GitHub for release metadata and list_tags")
+	workers := flag.Int("workers", 0, fmt.Sprintf("Concurrent sync workers for the repository queue and Go file parsing; 0 uses %d for the queue and one parser per CPU. The queue is capped by the GitHub rate-limit budget", indexer.DefaultWorkerCount))
+	dbModeFlag := flag.String("db-mode", string(database.ModeReadWrite), "Database open mode: readwrite, or readonly to serve a pre-built index without writing (sync tools disabled)")
+	autoRepair := flag.Bool("auto-repair", false, "Move a corrupt database file aside and create a fresh index instead of failing (readwrite mode only)")
+	ref := flag.String("ref", "", "Sync a tag, branch or commit instead of the default branch; release tags (e.g., v4.52.0) also record a schema snapshot for that version")
+	cacheTTL := flag.Duration("cache-ttl", indexer.DefaultCacheTTL, "How long GitHub API responses are cached before being fetched again")
+	includeTests := flag.Bool("include-tests", true, "Index *_test.go files; disable to shrink the database when acceptance tests (list_resource_tests) are not needed")
+	format := flag.String("format", formatter.StyleMarkdown, "Tool output format: markdown, or plain for clients that show raw text (aligned columns instead of markdown tables)")
+	pollInterval := flag.Duration("poll-interval", 0, "Fixed \"check again\" interval that sync_status suggests for running jobs (0 = adapt to the previous sync duration)")
+	profile := flag.Bool("profile", false, "Record per-stage sync timings (download, file inserts, parse, releases) and report them in sync results")
+	maxArchiveBytes := flag.Int64("max-archive-bytes", indexer.DefaultMaxArchiveBytes, "Maximum size in bytes of a downloaded repository tarball; larger downloads fail the sync")
+	rateWait := flag.Duration("rate-wait", 0, "How long GitHub requests wait for an exhausted rate limit to refill before failing, e.g. 1h (0 = fail immediately)")
+	toolTimeout := flag.Duration("tool-timeout", 2*time.Minute, "Deadline for a single tool call (sync_updates_provider keeps a longer built-in deadline)")
+	serverName := flag.String("server-name", mcp.DefaultServerName, "Server name reported to MCP clients in the initialize response")
+	serverVersion := flag.String("server-version", mcp.DefaultServerVersion, "Server version reported to MCP clients in the initialize response")
 	flag.Parse()
 
+	dbMode, err := database.ParseMode(*dbModeFlag)
+	if err != nil {
+		log.Fatalf("Invalid --db-mode: %v", err)
+	}
+	style, err := formatter.ParseStyle(*format)
+	if err != nil {
+		log.Fatalf("Invalid --format: %v", err)
+	}
+
 	log.SetOutput(os.Stderr)
 	log.Println("Starting AzureRM Provider MCP Server")
 	log.Printf("Repository: %s/%s", *org, *repo)
-	log.Printf("Database will be initialized at: %s (on first sync)", *dbPath)
+	if *githubBaseURL != indexer.DefaultGitHubBaseURL {
+		log.Printf("GitHub API: %s", *githubBaseURL)
+	}
+	if dbMode == database.ModeReadOnly {
+		log.Printf("Database will be opened read-only at: %s", *dbPath)
+	} else {
+		log.Printf("Database will be initialized at: %s (on first sync)", *dbPath)
+	}
+
+	server := mcp.NewServer(*dbPath, *token, *org, *repo,
+		mcp.WithGitHubBaseURL(*githubBaseURL),
+		mcp.WithDescriptionMaxChars(*descMaxChars),
+		mcp.WithToolTimeout(*toolTimeout),
+		mcp.WithMaxTagPages(*maxTagPages),
+		mcp.WithWorkers(*workers),
+		mcp.WithDBMode(dbMode),
+		mcp.WithRef(*ref),
+		mcp.WithAutoRepair(*autoRepair),
+		mcp.WithCacheTTL(*cacheTTL),
+		mcp.WithIncludeTests(*includeTests),
+		mcp.WithStyle(style),
+		mcp.WithPollInterval(*pollInterval),
+		mcp.WithProfile(*profile),
+		mcp.WithMaxArchiveBytes(*maxArchiveBytes),
+		mcp.WithRateWait(*rateWait),
+		mcp.WithServerInfo(*serverName, *serverVersion),
+	)
+	// SIGINT and SIGTERM cancel Run, which stops running syncs and closes the database before exiting.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	server := mcp.NewServer(*dbPath, *token, *org, *repo)
-	if err := server.Run(context.Background(), os.Stdin, os.Stdout); err != nil {
+	if err := server.Run(ctx, os.Stdin, os.Stdout); err != nil {
 		log.Printf("Server stopped: %v", err)
 	}
 }
+
+// runExport implements "server export": it streams every indexed resource with its attributes
+// to stdout as NDJSON, one resource per line, without modifying the database.
+func runExport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dbPath := fs.String("db", "azurerm-provider.db", "Path to SQLite database file")
+	kind := fs.String("kind", "", "Only export this kind (resource or data_source)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	db, err := database.NewWithMode(*dbPath, database.ModeReadOnly)
+	if err != nil {
+		fmt.Fprintf(stderr, "export: %v\n", err)
+		return 1
+	}
+	defer db.Close()
+
+	out := bufio.NewWriter(stdout)
+	n, err := db.ExportResources(out, *kind)
+	if flushErr := out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "export: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stderr, "Exported %d resources from %s\n", n, *dbPath)
+	return 0
+}
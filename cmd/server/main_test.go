@@ -1,9 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
 )
 
 func TestMainStartsAndExits(t *testing.T) {
@@ -29,3 +33,34 @@ func TestMainStartsAndExits(t *testing.T) {
 
 	main()
 }
+
+func TestRunExport(t *testing.T) {
+	tmpDB := filepath.Join(t.TempDir(), "export.db")
+	db, err := database.New(tmpDB)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5") {
+			t.Skipf("sqlite build without fts5: %v", err)
+		}
+		t.Fatalf("create db: %v", err)
+	}
+	repoID, err := db.InsertRepository(&database.Repository{Name: "terraform-provider-azurerm"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	if _, err := db.InsertProviderResource(&database.ProviderResource{RepositoryID: repoID, Name: "azurerm_resource_group", Kind: "resource"}); err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	db.Close()
+
+	var stdout, stderr bytes.Buffer
+	if code := runExport([]string{"-db", tmpDB}, &stdout, &stderr); code != 0 {
+		t.Fatalf("export exited %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"name":"azurerm_resource_group"`) {
+		t.Fatalf("unexpected export output: %s", stdout.String())
+	}
+
+	if code := runExport([]string{"-db", filepath.Join(t.TempDir(), "missing.db")}, &stdout, &stderr); code == 0 {
+		t.Fatalf("expected failure for missing database")
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// ErrCorrupt reports that the index file is truncated, damaged or not a SQLite database at all.
+var ErrCorrupt = errors.New("database file is corrupt or not a SQLite database")
+
+// isCorruptError recognises SQLITE_CORRUPT and SQLITE_NOTADB, including errors that only carry
+// the message text.
+func isCorruptError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && (sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "file is not a database") || strings.Contains(msg, "database disk image is malformed")
+}
+
+// wrapOpenError tags corruption failures with ErrCorrupt so callers can offer a rebuild.
+func wrapOpenError(dbPath, step string, err error) error {
+	if isCorruptError(err) {
+		return fmt.Errorf("%w: %s: %w", ErrCorrupt, dbPath, err)
+	}
+	return fmt.Errorf("failed to %s: %w", step, err)
+}
+
+// MoveAside renames a corrupt index, and any WAL/SHM side files, to <path>.corrupt-<timestamp>
+// so a fresh database can be created in its place. It returns the new location of the main file.
+func MoveAside(dbPath string) (string, error) {
+	backup := fmt.Sprintf("%s.corrupt-%s", dbPath, time.Now().UTC().Format("20060102T150405Z"))
+	if err := os.Rename(dbPath, backup); err != nil {
+		return "", fmt.Errorf("failed to move corrupt database aside: %w", err)
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(dbPath+suffix, backup+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return backup, fmt.Errorf("failed to move %s aside: %w", dbPath+suffix, err)
+		}
+	}
+	return backup, nil
+}
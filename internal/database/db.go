@@ -3,17 +3,44 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
 type DB struct {
-	conn *sql.DB
+	conn     *sql.DB
+	readOnly bool
+}
+
+// Mode selects how the SQLite index is opened.
+type Mode string
+
+const (
+	// ModeReadWrite opens (or creates) the index and applies schema migrations.
+	ModeReadWrite Mode = "readwrite"
+	// ModeReadOnly opens an existing, pre-built index as immutable and never writes to it.
+	ModeReadOnly Mode = "readonly"
+)
+
+// ParseMode validates a --db-mode value; empty selects ModeReadWrite.
+func ParseMode(value string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ModeReadWrite:
+		return ModeReadWrite, nil
+	case ModeReadOnly:
+		return ModeReadOnly, nil
+	default:
+		return "", fmt.Errorf("unknown database mode %q (expected %q or %q)", value, ModeReadWrite, ModeReadOnly)
+	}
 }
 
 type Repository struct {
@@ -60,8 +87,21 @@ type ProviderResource struct {
 	VersionRemoved     sql.NullString
 	BreakingChanges    sql.NullString
 	APIVersion         sql.NullString
+	RegistrationType   sql.NullString
+	UnresolvedReason   sql.NullString // why no schema was parsed; see the Unresolved* constants
 }
 
+// Reasons recorded in ProviderResource.UnresolvedReason when a registration yields no parsed schema.
+const (
+	UnresolvedTyped           = "typed"            // typed SDK resource; the schema lives in struct methods
+	UnresolvedMissingFunction = "missing_function" // the registered function was not found in the source
+	UnresolvedParseError      = "parse_error"      // the function was found but its schema could not be parsed
+)
+
+// RegistrationRemoved is the ProviderResource.RegistrationType of placeholder rows that keep the
+// availability window of definitions no longer in the source; listings and counts skip them.
+const RegistrationRemoved = "removed"
+
 type ProviderAttribute struct {
 	ID             int64
 	ResourceID     int64
@@ -92,6 +132,39 @@ type ProviderAttribute struct {
 	RequiredWith   sql.NullString
 }
 
+// ElementType returns the primitive element type of a list, set or map attribute in Terraform
+// terms, such as "string" for Elem: &schema.Schema{Type: schema.TypeString}. It is empty for
+// scalars, nested blocks and elements that are themselves collections.
+func (a ProviderAttribute) ElementType() string {
+	if a.NestedBlock {
+		return ""
+	}
+	for _, part := range strings.Split(a.ElemSummary.String, ", ") {
+		if value, ok := strings.CutPrefix(part, "Type="); ok {
+			return primitiveTypeName(value)
+		}
+	}
+	return ""
+}
+
+// primitiveTypeName maps a schema type constant such as "pluginsdk.TypeInt" to its Terraform type
+// ("number"); collection types map to "".
+func primitiveTypeName(schemaType string) string {
+	if i := strings.LastIndex(schemaType, "."); i >= 0 {
+		schemaType = schemaType[i+1:]
+	}
+	switch schemaType {
+	case "TypeString":
+		return "string"
+	case "TypeInt", "TypeFloat":
+		return "number"
+	case "TypeBool":
+		return "bool"
+	default:
+		return ""
+	}
+}
+
 type ProviderResourceSource struct {
 	ID                   int64
 	ResourceID           int64
@@ -103,6 +176,29 @@ type ProviderResourceSource struct {
 	TimeoutsJSON         sql.NullString
 	StateUpgraders       sql.NullString
 	ImporterSnippet      sql.NullString
+	FunctionStartLine    sql.NullInt64
+	FunctionEndLine      sql.NullInt64
+	SchemaStartLine      sql.NullInt64
+	SchemaEndLine        sql.NullInt64
+	SchemaAttributeLines sql.NullString
+	SDKPackages          sql.NullString
+}
+
+// SchemaAttributeLine is the file line on which a top-level schema attribute is declared.
+type SchemaAttributeLine struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+}
+
+// SDKPackage is a go-azure-sdk package imported by a resource's implementation file, such as
+// github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines.
+type SDKPackage struct {
+	Path       string `json:"path"`
+	Alias      string `json:"alias,omitempty"`
+	Plane      string `json:"plane"` // resource-manager or data-plane
+	Service    string `json:"service"`
+	APIVersion string `json:"api_version,omitempty"`
+	Resource   string `json:"resource,omitempty"` // sub-package below the API version, e.g. virtualmachines
 }
 
 type ProviderRelease struct {
@@ -138,6 +234,9 @@ type ParseCacheEntry struct {
 	ParsedAt       time.Time
 	ResourceCount  int
 	AttributeCount int
+	// SymbolCount is how many resource functions and registrations the file declared when it was
+	// last parsed, or -1 when that was not recorded.
+	SymbolCount int
 }
 
 type ProviderAttributeSearchResult struct {
@@ -147,20 +246,43 @@ type ProviderAttributeSearchResult struct {
 	ResourceFilePath sql.NullString
 }
 
+// DeprecatedAttribute is a schema attribute carrying a Deprecated message.
+type DeprecatedAttribute struct {
+	ResourceName string
+	ResourceKind string
+	Name         string
+	Message      string
+}
+
 type AttributeSearchFilters struct {
 	NameContains         string
 	ResourcePrefix       string
+	ServiceName          string
 	Flags                []string
+	NotFlags             []string // flags the attribute must not have
 	ConflictsWith        string
 	DescriptionQuery     string
 	ValidationContains   string
 	DiffSuppressContains string
 	HasValidation        bool
+	MissingValidation    bool // negation of HasValidation: no validation function recorded
 	HasDiffSuppress      bool
+	Types                []string // schema type names such as String or Int, matching pluginsdk.TypeString
+	ConfigurableOnly     bool     // exclude computed-only attributes
 	Limit                int
 }
 
 func New(dbPath string) (*DB, error) {
+	return NewWithMode(dbPath, ModeReadWrite)
+}
+
+// NewWithMode opens the index at dbPath. ModeReadOnly requires an existing file, opens it with
+// mode=ro&immutable=1 and skips schema creation and column migrations.
+func NewWithMode(dbPath string, mode Mode) (*DB, error) {
+	if mode == ModeReadOnly {
+		return openReadOnly(dbPath)
+	}
+
 	dir := filepath.Dir(dbPath)
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
@@ -173,24 +295,219 @@ func New(dbPath string) (*DB, error) {
 
 	if _, err := conn.Exec("PRAGMA foreign_keys = ON"); err != nil {
 		conn.Close()
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
+		return nil, wrapOpenError(dbPath, "enable foreign keys", err)
+	}
+
+	newFTS, err := missingTables(conn, ftsBackfills)
+	if err != nil {
+		conn.Close()
+		return nil, wrapOpenError(dbPath, "inspect schema", err)
+	}
+
+	staleFTS, err := dropLegacyFTSTriggers(conn)
+	if err != nil {
+		conn.Close()
+		return nil, wrapOpenError(dbPath, "migrate schema", err)
 	}
 
 	if _, err := conn.Exec(Schema); err != nil {
 		conn.Close()
-		return nil, fmt.Errorf("failed to initialize schema: %w", err)
+		return nil, wrapOpenError(dbPath, "initialize schema", err)
+	}
+
+	if err := migrateColumns(conn); err != nil {
+		conn.Close()
+		return nil, wrapOpenError(dbPath, "migrate schema", err)
+	}
+
+	if err := rebuildFTS(conn, append(newFTS, staleFTS...)); err != nil {
+		conn.Close()
+		return nil, wrapOpenError(dbPath, "migrate schema", err)
 	}
 
 	return &DB{conn: conn}, nil
 }
 
+func openReadOnly(dbPath string) (*DB, error) {
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, fmt.Errorf("read-only database not found: %w", err)
+	}
+
+	conn, err := sql.Open("sqlite3", "file:"+dbPath+"?mode=ro&immutable=1")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Ping does not read the file; touching sqlite_master surfaces a corrupt header up front.
+	var tables int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master`).Scan(&tables); err != nil {
+		conn.Close()
+		return nil, wrapOpenError(dbPath, "open database", err)
+	}
+
+	return &DB{conn: conn, readOnly: true}, nil
+}
+
+// ReadOnly reports whether the index was opened with ModeReadOnly.
+func (db *DB) ReadOnly() bool {
+	return db.readOnly
+}
+
 func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
+// escapeFTS5 turns free text into an FTS5 query. Every whitespace-separated term is quoted so
+// punctuation cannot break the syntax and the terms are combined with an implicit AND, so
+// "virtual network" matches both words anywhere rather than only the exact phrase. Double-quoted
+// phrases stay together and uppercase AND, OR, NOT and parentheses are honoured as operators.
 func escapeFTS5(query string) string {
-	query = strings.ReplaceAll(query, `"`, `""`)
-	return `"` + query + `"`
+	if expr, ok := fts5BooleanQuery(query); ok {
+		return expr
+	}
+	tokens := fts5Tokens(query)
+	if len(tokens) == 0 {
+		return `""`
+	}
+	terms := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		terms = append(terms, quoteFTS5Term(tok))
+	}
+	return strings.Join(terms, " ")
+}
+
+// anyTermFTS5 is escapeFTS5 with the terms combined by OR, so a descriptive question still matches
+// rows that share only some of its words and bm25 ranks rows sharing more of them first.
+func anyTermFTS5(query string) string {
+	if expr, ok := fts5BooleanQuery(query); ok {
+		return expr
+	}
+	tokens := fts5Tokens(query)
+	if len(tokens) == 0 {
+		return `""`
+	}
+	terms := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		terms = append(terms, quoteFTS5Term(tok))
+	}
+	return strings.Join(terms, " OR ")
+}
+
+func quoteFTS5Term(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+// fts5Operators are passed to FTS5 unquoted when written as standalone uppercase words.
+var fts5Operators = map[string]bool{"AND": true, "OR": true, "NOT": true}
+
+// fts5BooleanQuery turns a query that uses AND, OR, NOT or parentheses into an FTS5 expression,
+// quoting every other term so punctuation such as underscores cannot break the syntax. Quoted
+// phrases in the input are kept together. Operators with a missing operand are dropped rather
+// than surfaced as FTS5 syntax errors, and adjacent operands get an explicit AND because FTS5
+// rejects implicit AND next to a parenthesised group. The second result is false when the query
+// has no operators.
+func fts5BooleanQuery(query string) (string, bool) {
+	tokens := fts5Tokens(query)
+	hasOperator := false
+	for _, tok := range tokens {
+		if fts5Operators[tok] || tok == "(" || tok == ")" {
+			hasOperator = true
+			break
+		}
+	}
+	if !hasOperator {
+		return "", false
+	}
+
+	var out []string
+	// joinOperand inserts AND when the previous token ends an operand: a term or a closing group.
+	joinOperand := func() {
+		if len(out) > 0 && out[len(out)-1] != "(" && !fts5Operators[out[len(out)-1]] {
+			out = append(out, "AND")
+		}
+	}
+	depth := 0
+	for i, tok := range tokens {
+		switch {
+		case tok == "(":
+			joinOperand()
+			depth++
+			out = append(out, tok)
+		case tok == ")":
+			if depth == 0 {
+				continue
+			}
+			for len(out) > 0 && fts5Operators[out[len(out)-1]] {
+				out = out[:len(out)-1]
+			}
+			if len(out) > 0 && out[len(out)-1] == "(" {
+				out = out[:len(out)-1]
+			} else {
+				out = append(out, tok)
+			}
+			depth--
+		case fts5Operators[tok]:
+			if len(out) == 0 || fts5Operators[out[len(out)-1]] || out[len(out)-1] == "(" || i == len(tokens)-1 {
+				continue
+			}
+			out = append(out, tok)
+		default:
+			joinOperand()
+			out = append(out, quoteFTS5Term(tok))
+		}
+	}
+	// Drop dangling operators and groups that were opened but never filled.
+	for len(out) > 0 {
+		last := out[len(out)-1]
+		if last == "(" {
+			depth--
+		} else if !fts5Operators[last] {
+			break
+		}
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return "", false
+	}
+	for ; depth > 0; depth-- {
+		out = append(out, ")")
+	}
+	return strings.Join(out, " "), true
+}
+
+// fts5Tokens splits a query on whitespace, keeping double-quoted phrases (without their quotes)
+// and parentheses as separate tokens.
+func fts5Tokens(query string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	inPhrase := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			if inPhrase {
+				flush()
+			}
+			inPhrase = !inPhrase
+		case inPhrase:
+			current.WriteRune(r)
+		case r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
 }
 
 func (db *DB) InsertRepository(m *Repository) (int64, error) {
@@ -203,6 +520,7 @@ func (db *DB) InsertRepository(m *Repository) (int64, error) {
 			repo_url = excluded.repo_url,
 			last_updated = excluded.last_updated,
 			readme_content = excluded.readme_content,
+			parse_failures = NULL,
 			synced_at = CURRENT_TIMESTAMP
 	`, m.Name, m.FullName, m.Description, m.RepoURL, m.LastUpdated, m.ReadmeContent)
 	if err != nil {
@@ -217,6 +535,73 @@ func (db *DB) InsertRepository(m *Repository) (int64, error) {
 	return id, nil
 }
 
+// SetRepositoryParseFailures records the Go files of a repository that failed to parse during the
+// last sync, replacing any earlier list.
+func (db *DB) SetRepositoryParseFailures(repositoryID int64, paths []string) error {
+	var value sql.NullString
+	if len(paths) > 0 {
+		value = sql.NullString{String: strings.Join(paths, "\n"), Valid: true}
+	}
+	_, err := db.conn.Exec(`UPDATE repositories SET parse_failures = ? WHERE id = ?`, value, repositoryID)
+	return err
+}
+
+// ListParseFailures returns the Go files that failed to parse in the last sync of each repository,
+// ordered by repository and path. Paths are prefixed with the repository name when more than one
+// repository is indexed.
+func (db *DB) ListParseFailures() ([]string, error) {
+	rows, err := db.conn.Query(`SELECT name, parse_failures FROM repositories ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type repoFailures struct {
+		name  string
+		paths []string
+	}
+	var repos []repoFailures
+	for rows.Next() {
+		var name string
+		var failures sql.NullString
+		if err := rows.Scan(&name, &failures); err != nil {
+			return nil, err
+		}
+		entry := repoFailures{name: name}
+		if failures.String != "" {
+			entry.paths = strings.Split(failures.String, "\n")
+		}
+		repos = append(repos, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, repo := range repos {
+		for _, path := range repo.paths {
+			if len(repos) > 1 {
+				path = repo.name + ": " + path
+			}
+			out = append(out, path)
+		}
+	}
+	return out, nil
+}
+
+// GetRepositoryParseFailures returns the Go files of the named repository that failed to parse in
+// its last sync.
+func (db *DB) GetRepositoryParseFailures(name string) ([]string, error) {
+	var failures sql.NullString
+	if err := db.conn.QueryRow(`SELECT parse_failures FROM repositories WHERE name = ?`, name).Scan(&failures); err != nil {
+		return nil, err
+	}
+	if failures.String == "" {
+		return nil, nil
+	}
+	return strings.Split(failures.String, "\n"), nil
+}
+
 func (db *DB) GetRepository(name string) (*Repository, error) {
 	var m Repository
 	err := db.conn.QueryRow(`
@@ -263,6 +648,40 @@ func (db *DB) ListRepositories() ([]Repository, error) {
 	return repositories, rows.Err()
 }
 
+// RepositoryResourceCounts holds the number of indexed definitions of one repository by kind.
+type RepositoryResourceCounts struct {
+	Resources   int
+	DataSources int
+}
+
+// CountResourcesByRepository returns live resource and data source counts keyed by repository ID.
+// Repositories without any indexed definitions are absent from the map.
+func (db *DB) CountResourcesByRepository() (map[int64]RepositoryResourceCounts, error) {
+	rows, err := db.conn.Query(`
+		SELECT repository_id,
+			SUM(CASE WHEN kind = 'resource' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN kind = 'data_source' THEN 1 ELSE 0 END)
+		FROM provider_resources
+		WHERE ` + liveResourceFilter("") + `
+		GROUP BY repository_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]RepositoryResourceCounts)
+	for rows.Next() {
+		var id int64
+		var c RepositoryResourceCounts
+		if err := rows.Scan(&id, &c.Resources, &c.DataSources); err != nil {
+			return nil, err
+		}
+		counts[id] = c
+	}
+	return counts, rows.Err()
+}
+
 func (db *DB) SearchRepositories(query string, limit int) ([]Repository, error) {
 	rows, err := db.conn.Query(`
 		SELECT m.id, m.name, m.full_name, m.description, m.repo_url, m.last_updated, m.synced_at, m.readme_content
@@ -325,6 +744,57 @@ func (db *DB) GetRepositoryFiles(repositoryID int64) ([]RepositoryFile, error) {
 	return files, rows.Err()
 }
 
+// ListRepositoryFilesUnder returns the files of a repository whose path starts with dir, ordered by path.
+func (db *DB) ListRepositoryFilesUnder(repositoryID int64, dir string) ([]RepositoryFile, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, repository_id, file_name, file_path, file_type, content, size_bytes
+		FROM repository_files
+		WHERE repository_id = ? AND substr(file_path, 1, length(?)) = ?
+		ORDER BY file_path
+	`, repositoryID, dir, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []RepositoryFile
+	for rows.Next() {
+		var f RepositoryFile
+		if err := rows.Scan(&f.ID, &f.RepositoryID, &f.FileName, &f.FilePath, &f.FileType, &f.Content, &f.SizeBytes); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	return files, rows.Err()
+}
+
+// ListRepositoryFileEntriesUnder is ListRepositoryFilesUnder without the file content, for listings
+// that only need paths and sizes.
+func (db *DB) ListRepositoryFileEntriesUnder(repositoryID int64, dir string) ([]RepositoryFile, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, repository_id, file_name, file_path, file_type, size_bytes
+		FROM repository_files
+		WHERE repository_id = ? AND substr(file_path, 1, length(?)) = ?
+		ORDER BY file_path
+	`, repositoryID, dir, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []RepositoryFile
+	for rows.Next() {
+		var f RepositoryFile
+		if err := rows.Scan(&f.ID, &f.RepositoryID, &f.FileName, &f.FilePath, &f.FileType, &f.SizeBytes); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	return files, rows.Err()
+}
+
 func (db *DB) SearchFiles(query string, limit int) ([]RepositoryFile, error) {
 	rows, err := db.conn.Query(`
 		SELECT mf.id, mf.repository_id, mf.file_name, mf.file_path, mf.file_type, mf.content, mf.size_bytes
@@ -441,6 +911,10 @@ func (db *DB) ClearRepositoryData(repositoryID int64) error {
 		return fmt.Errorf("failed to rebuild provider_resources_fts: %w", err)
 	}
 
+	if _, err := tx.Exec(`INSERT INTO provider_attributes_fts(provider_attributes_fts) VALUES('rebuild')`); err != nil {
+		return fmt.Errorf("failed to rebuild provider_attributes_fts: %w", err)
+	}
+
 	return tx.Commit()
 }
 
@@ -469,21 +943,39 @@ func (db *DB) InsertProviderService(s *ProviderService) (int64, error) {
 	return id, nil
 }
 
+// GetProviderServiceByID returns the service registration a definition belongs to.
+func (db *DB) GetProviderServiceByID(id int64) (*ProviderService, error) {
+	var svc ProviderService
+	err := db.conn.QueryRow(`
+		SELECT id, repository_id, name, file_path, website_categories, github_label
+		FROM provider_services WHERE id = ?
+	`, id).Scan(&svc.ID, &svc.RepositoryID, &svc.Name, &svc.FilePath, &svc.WebsiteCategories, &svc.GitHubLabel)
+	if err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
+// InsertProviderResource inserts or updates a resource and returns its ID. The provider_resources_fts
+// triggers index the new values in the same statement, so search needs no rebuild afterwards.
 func (db *DB) InsertProviderResource(r *ProviderResource) (int64, error) {
 	_, err := db.conn.Exec(`
-		INSERT INTO provider_resources (repository_id, service_id, name, display_name, kind, file_path, description, deprecation_message, version_added, version_removed, breaking_changes, api_version)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO provider_resources (repository_id, service_id, name, display_name, kind, file_path, description, deprecation_message, version_added, version_removed, breaking_changes, api_version, registration_type, unresolved_reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(repository_id, name, kind) DO UPDATE SET
 			service_id = excluded.service_id,
 			display_name = excluded.display_name,
 			file_path = excluded.file_path,
 			description = excluded.description,
 			deprecation_message = excluded.deprecation_message,
-			version_added = excluded.version_added,
-			version_removed = excluded.version_removed,
+			version_added = COALESCE(excluded.version_added, version_added),
+			version_removed = COALESCE(excluded.version_removed, version_removed),
 			breaking_changes = excluded.breaking_changes,
-			api_version = excluded.api_version
-	`, r.RepositoryID, r.ServiceID, r.Name, r.DisplayName, r.Kind, r.FilePath, r.Description, r.DeprecationMessage, r.VersionAdded, r.VersionRemoved, r.BreakingChanges, r.APIVersion)
+			api_version = excluded.api_version,
+			registration_type = excluded.registration_type,
+			unresolved_reason = excluded.unresolved_reason
+	`, r.RepositoryID, r.ServiceID, r.Name, r.DisplayName, r.Kind, r.FilePath, r.Description, r.DeprecationMessage, r.VersionAdded, r.VersionRemoved, r.BreakingChanges, r.APIVersion, r.RegistrationType,
+		r.UnresolvedReason)
 	if err != nil {
 		return 0, err
 	}
@@ -553,67 +1045,268 @@ func (db *DB) UpsertProviderResourceSource(resourceID int64, functionName, fileP
 	return err
 }
 
+// SetProviderResourceSourceLines records where the function and schema literal of a stored source
+// snippet live in their file. Zero values are stored as NULL.
+func (db *DB) SetProviderResourceSourceLines(resourceID int64, functionStart, functionEnd, schemaStart, schemaEnd int) error {
+	_, err := db.conn.Exec(`
+		UPDATE provider_resource_sources
+		SET function_start_line = ?, function_end_line = ?, schema_start_line = ?, schema_end_line = ?
+		WHERE resource_id = ?
+	`, nullIfZero(functionStart), nullIfZero(functionEnd), nullIfZero(schemaStart), nullIfZero(schemaEnd), resourceID)
+	return err
+}
+
+// SetProviderResourceSourceAttributeLines records the declaration line of each top-level schema
+// attribute. An empty slice clears the stored lines.
+func (db *DB) SetProviderResourceSourceAttributeLines(resourceID int64, lines []SchemaAttributeLine) error {
+	var value any
+	if len(lines) > 0 {
+		data, err := json.Marshal(lines)
+		if err != nil {
+			return err
+		}
+		value = string(data)
+	}
+	_, err := db.conn.Exec(`
+		UPDATE provider_resource_sources SET schema_attribute_lines = ? WHERE resource_id = ?
+	`, value, resourceID)
+	return err
+}
+
+// AttributeLines decodes the stored attribute declaration lines. It returns nil when none were
+// recorded for the source.
+func (src *ProviderResourceSource) AttributeLines() ([]SchemaAttributeLine, error) {
+	if !src.SchemaAttributeLines.Valid || src.SchemaAttributeLines.String == "" {
+		return nil, nil
+	}
+	var lines []SchemaAttributeLine
+	if err := json.Unmarshal([]byte(src.SchemaAttributeLines.String), &lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// SetProviderResourceSourceSDKPackages records the go-azure-sdk packages imported by a stored
+// source's file. An empty slice is stored as an empty list, so NULL keeps meaning "not recorded".
+func (db *DB) SetProviderResourceSourceSDKPackages(resourceID int64, packages []SDKPackage) error {
+	if packages == nil {
+		packages = []SDKPackage{}
+	}
+	data, err := json.Marshal(packages)
+	if err != nil {
+		return err
+	}
+	_, err = db.conn.Exec(`
+		UPDATE provider_resource_sources SET sdk_packages = ? WHERE resource_id = ?
+	`, string(data), resourceID)
+	return err
+}
+
+// ImportedSDKPackages decodes the stored go-azure-sdk packages. recorded is false when the source
+// predates SDK package tracking and the resource needs a re-sync.
+func (src *ProviderResourceSource) ImportedSDKPackages() (packages []SDKPackage, recorded bool, err error) {
+	if !src.SDKPackages.Valid || src.SDKPackages.String == "" {
+		return nil, false, nil
+	}
+	if err := json.Unmarshal([]byte(src.SDKPackages.String), &packages); err != nil {
+		return nil, true, err
+	}
+	return packages, true, nil
+}
+
 func (db *DB) GetProviderResourceSource(resourceID int64) (*ProviderResourceSource, error) {
 	var src ProviderResourceSource
 	err := db.conn.QueryRow(`
 		SELECT id, resource_id, function_name, file_path, function_snippet, schema_snippet,
-			customize_diff_snippet, timeouts_json, state_upgraders, importer_snippet
+			customize_diff_snippet, timeouts_json, state_upgraders, importer_snippet,
+			function_start_line, function_end_line, schema_start_line, schema_end_line, schema_attribute_lines, sdk_packages
 		FROM provider_resource_sources
 		WHERE resource_id = ?
 	`, resourceID).Scan(&src.ID, &src.ResourceID, &src.FunctionName, &src.FilePath, &src.FunctionSnippet, &src.SchemaSnippet,
-		&src.CustomizeDiffSnippet, &src.TimeoutsJSON, &src.StateUpgraders, &src.ImporterSnippet)
+		&src.CustomizeDiffSnippet, &src.TimeoutsJSON, &src.StateUpgraders, &src.ImporterSnippet,
+		&src.FunctionStartLine, &src.FunctionEndLine, &src.SchemaStartLine, &src.SchemaEndLine, &src.SchemaAttributeLines, &src.SDKPackages)
 	if err != nil {
 		return nil, err
 	}
 	return &src, nil
 }
 
-func (db *DB) UpsertProviderRelease(r *ProviderRelease) (int64, error) {
-	_, err := db.conn.Exec(`
-		INSERT INTO provider_releases (
-			repository_id, version, tag, previous_version, previous_tag,
-			commit_sha, previous_commit_sha, release_date, comparison_url
-		)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(repository_id, version) DO UPDATE SET
-			tag = excluded.tag,
-			previous_version = excluded.previous_version,
-			previous_tag = excluded.previous_tag,
-			commit_sha = excluded.commit_sha,
-			previous_commit_sha = excluded.previous_commit_sha,
-			release_date = excluded.release_date,
-			comparison_url = excluded.comparison_url
-	`, r.RepositoryID, r.Version, r.Tag, r.PreviousVersion, r.PreviousTag, r.CommitSHA, r.PreviousCommitSHA, r.ReleaseDate, r.ComparisonURL)
-	if err != nil {
-		return 0, err
+// ListResourcesWithCustomizeDiff returns definitions whose stored source declares a CustomizeDiff
+// function, optionally restricted to names starting with resourcePrefix.
+func (db *DB) ListResourcesWithCustomizeDiff(resourcePrefix string) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		JOIN provider_resource_sources s ON s.resource_id = r.id
+		WHERE s.customize_diff_snippet IS NOT NULL`
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
 	}
+	query += " ORDER BY r.name"
 
-	var id int64
-	if err := db.conn.QueryRow(`
-		SELECT id FROM provider_releases WHERE repository_id = ? AND version = ?
-	`, r.RepositoryID, r.Version).Scan(&id); err != nil {
-		return 0, err
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return id, nil
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
 }
 
-func (db *DB) ReplaceReleaseEntries(releaseID int64, entries []ProviderReleaseEntry) error {
-	tx, err := db.conn.Begin()
-	if err != nil {
-		return err
+// ListNonImportableResources returns resources whose stored source has no Importer, optionally
+// restricted to names starting with resourcePrefix. Typed resources always wire an importer
+// through the SDK, and definitions without stored source are unknown, so neither is returned.
+func (db *DB) ListNonImportableResources(resourcePrefix string) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		JOIN provider_resource_sources s ON s.resource_id = r.id
+		WHERE r.kind = 'resource'
+			AND s.importer_snippet IS NULL
+			AND COALESCE(r.registration_type, '') != 'typed'`
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
 	}
-	defer tx.Rollback()
+	query += " ORDER BY r.name"
 
-	if _, err := tx.Exec(`DELETE FROM provider_release_entries WHERE release_id = ?`, releaseID); err != nil {
-		return err
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	stmt, err := tx.Prepare(`
-		INSERT INTO provider_release_entries (
-			release_id, section, entry_key, title, details,
-			resource_name, identifier, change_type, order_index
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListDeprecatedResources returns definitions with a deprecation message, optionally restricted to
+// names starting with resourcePrefix.
+func (db *DB) ListDeprecatedResources(resourcePrefix string) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("") + `
+		FROM provider_resources
+		WHERE deprecation_message IS NOT NULL AND deprecation_message <> ''`
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY name, kind"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListDeprecatedAttributes returns attributes with a Deprecated message on definitions whose name
+// starts with resourcePrefix (all definitions when empty).
+func (db *DB) ListDeprecatedAttributes(resourcePrefix string) ([]DeprecatedAttribute, error) {
+	query := `
+		SELECT r.name, r.kind, a.name, a.deprecated
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id
+		WHERE a.deprecated IS NOT NULL AND a.deprecated <> ''`
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name, r.kind, a.name"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attrs []DeprecatedAttribute
+	for rows.Next() {
+		var a DeprecatedAttribute
+		if err := rows.Scan(&a.ResourceName, &a.ResourceKind, &a.Name, &a.Message); err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs, rows.Err()
+}
+
+func (db *DB) UpsertProviderRelease(r *ProviderRelease) (int64, error) {
+	_, err := db.conn.Exec(`
+		INSERT INTO provider_releases (
+			repository_id, version, tag, previous_version, previous_tag,
+			commit_sha, previous_commit_sha, release_date, comparison_url
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repository_id, version) DO UPDATE SET
+			tag = excluded.tag,
+			previous_version = excluded.previous_version,
+			previous_tag = excluded.previous_tag,
+			commit_sha = excluded.commit_sha,
+			previous_commit_sha = excluded.previous_commit_sha,
+			release_date = excluded.release_date,
+			comparison_url = excluded.comparison_url
+	`, r.RepositoryID, r.Version, r.Tag, r.PreviousVersion, r.PreviousTag, r.CommitSHA, r.PreviousCommitSHA, r.ReleaseDate, r.ComparisonURL)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := db.conn.QueryRow(`
+		SELECT id FROM provider_releases WHERE repository_id = ? AND version = ?
+	`, r.RepositoryID, r.Version).Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (db *DB) ReplaceReleaseEntries(releaseID int64, entries []ProviderReleaseEntry) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM provider_release_entries WHERE release_id = ?`, releaseID); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO provider_release_entries (
+			release_id, section, entry_key, title, details,
+			resource_name, identifier, change_type, order_index
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -661,6 +1354,72 @@ func (db *DB) GetLatestProviderRelease(repositoryID int64) (*ProviderRelease, er
 	return &r, nil
 }
 
+// ListProviderReleases returns the most recent releases first; limit <= 0 returns all of them.
+func (db *DB) ListProviderReleases(repositoryID int64, limit int) ([]ProviderRelease, error) {
+	query := `
+		SELECT id, repository_id, version, tag, previous_version, previous_tag,
+			commit_sha, previous_commit_sha, release_date, comparison_url, created_at
+		FROM provider_releases
+		WHERE repository_id = ?
+		ORDER BY
+			CASE WHEN release_date IS NULL OR release_date = '' THEN 1 ELSE 0 END,
+			release_date DESC,
+			created_at DESC`
+	args := []any{repositoryID}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []ProviderRelease
+	for rows.Next() {
+		var r ProviderRelease
+		if err := rows.Scan(&r.ID, &r.RepositoryID, &r.Version, &r.Tag, &r.PreviousVersion, &r.PreviousTag, &r.CommitSHA, &r.PreviousCommitSHA, &r.ReleaseDate, &r.ComparisonURL, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return releases, rows.Err()
+}
+
+// ListReleasesMentioning returns the releases, most recent first, with an entry that names
+// resourceName as its parsed resource or anywhere in its title.
+func (db *DB) ListReleasesMentioning(repositoryID int64, resourceName string) ([]ProviderRelease, error) {
+	rows, err := db.conn.Query(`
+		SELECT r.id, r.repository_id, r.version, r.tag, r.previous_version, r.previous_tag,
+			r.commit_sha, r.previous_commit_sha, r.release_date, r.comparison_url, r.created_at
+		FROM provider_releases r
+		WHERE r.repository_id = ?
+			AND EXISTS (
+				SELECT 1 FROM provider_release_entries e
+				WHERE e.release_id = r.id AND (e.resource_name = ? OR instr(e.title, ?) > 0)
+			)
+		ORDER BY
+			CASE WHEN r.release_date IS NULL OR r.release_date = '' THEN 1 ELSE 0 END,
+			r.release_date DESC,
+			r.created_at DESC`, repositoryID, resourceName, resourceName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []ProviderRelease
+	for rows.Next() {
+		var r ProviderRelease
+		if err := rows.Scan(&r.ID, &r.RepositoryID, &r.Version, &r.Tag, &r.PreviousVersion, &r.PreviousTag, &r.CommitSHA, &r.PreviousCommitSHA, &r.ReleaseDate, &r.ComparisonURL, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return releases, rows.Err()
+}
+
 func (db *DB) GetProviderReleaseByVersion(repositoryID int64, version string) (*ProviderRelease, error) {
 	var r ProviderRelease
 	err := db.conn.QueryRow(`
@@ -763,13 +1522,16 @@ func (db *DB) GetReleaseWithEntriesByTag(repositoryID int64, tag string) (*Provi
 	return release, entries, nil
 }
 
+// ListProviderResources returns indexed definitions by name, optionally of one kind. Placeholder
+// rows that only record when a removed definition existed are left out.
 func (db *DB) ListProviderResources(kind string, limit int) ([]ProviderResource, error) {
 	query := `
-		SELECT id, repository_id, service_id, name, display_name, kind, file_path, description, deprecation_message, version_added, version_removed, breaking_changes, api_version
-		FROM provider_resources`
+		SELECT ` + providerResourceColumns("") + `
+		FROM provider_resources
+		WHERE ` + liveResourceFilter("")
 	var args []any
 	if kind != "" {
-		query += " WHERE kind = ?"
+		query += " AND kind = ?"
 		args = append(args, kind)
 	}
 	query += " ORDER BY name"
@@ -786,7 +1548,44 @@ func (db *DB) ListProviderResources(kind string, limit int) ([]ProviderResource,
 	var resources []ProviderResource
 	for rows.Next() {
 		var r ProviderResource
-		if err := rows.Scan(&r.ID, &r.RepositoryID, &r.ServiceID, &r.Name, &r.DisplayName, &r.Kind, &r.FilePath, &r.Description, &r.DeprecationMessage, &r.VersionAdded, &r.VersionRemoved, &r.BreakingChanges, &r.APIVersion); err != nil {
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListProviderResourcesByCategory returns definitions whose service lists category among its
+// comma-separated website categories (case-insensitive), optionally filtered by kind.
+func (db *DB) ListProviderResourcesByCategory(category, kind string, limit int) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		JOIN provider_services s ON s.id = r.service_id
+		WHERE ',' || LOWER(REPLACE(COALESCE(s.website_categories, ''), ', ', ',')) || ',' LIKE ?
+			AND ` + liveResourceFilter("r")
+	args := []any{"%," + strings.ToLower(strings.TrimSpace(category)) + ",%"}
+	if kind != "" {
+		query += " AND r.kind = ?"
+		args = append(args, kind)
+	}
+	query += " ORDER BY r.name"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
 			return nil, err
 		}
 		resources = append(resources, r)
@@ -794,15 +1593,77 @@ func (db *DB) ListProviderResources(kind string, limit int) ([]ProviderResource,
 	return resources, rows.Err()
 }
 
-func (db *DB) SearchProviderResources(query string, limit int) ([]ProviderResource, error) {
+// ListWebsiteCategories returns the distinct website categories declared by indexed services, sorted.
+func (db *DB) ListWebsiteCategories() ([]string, error) {
 	rows, err := db.conn.Query(`
-		SELECT pr.id, pr.repository_id, pr.service_id, pr.name, pr.display_name, pr.kind, pr.file_path, pr.description, pr.deprecation_message, pr.version_added, pr.version_removed, pr.breaking_changes, pr.api_version
+		SELECT website_categories FROM provider_services
+		WHERE website_categories IS NOT NULL AND website_categories <> ''
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	var categories []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		for _, category := range strings.Split(value, ",") {
+			category = strings.TrimSpace(category)
+			if category != "" && !seen[category] {
+				seen[category] = true
+				categories = append(categories, category)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(categories)
+	return categories, nil
+}
+
+// CountProviderResources returns how many resources match the full-text query, optionally
+// restricted to one kind (resource or data_source).
+func (db *DB) CountProviderResources(query, kind string) (int, error) {
+	sqlQuery := `
+		SELECT COUNT(*)
 		FROM provider_resources pr
 		JOIN provider_resources_fts ON provider_resources_fts.rowid = pr.id
 		WHERE provider_resources_fts MATCH ?
-		ORDER BY rank
-		LIMIT ?
-	`, escapeFTS5(query), limit)
+			AND ` + liveResourceFilter("pr")
+	args := []any{escapeFTS5(query)}
+	if kind != "" {
+		sqlQuery += " AND pr.kind = ?"
+		args = append(args, kind)
+	}
+
+	var count int
+	err := db.conn.QueryRow(sqlQuery, args...).Scan(&count)
+	return count, err
+}
+
+// SearchProviderResources ranks definitions against the full-text query. A non-empty kind keeps
+// only resources or only data sources; placeholders for removed definitions never match.
+func (db *DB) SearchProviderResources(query, kind string, limit int) ([]ProviderResource, error) {
+	sqlQuery := `
+		SELECT ` + providerResourceColumns("pr") + `
+		FROM provider_resources pr
+		JOIN provider_resources_fts ON provider_resources_fts.rowid = pr.id
+		WHERE provider_resources_fts MATCH ?
+			AND ` + liveResourceFilter("pr")
+	args := []any{escapeFTS5(query)}
+	if kind != "" {
+		sqlQuery += " AND pr.kind = ?"
+		args = append(args, kind)
+	}
+	sqlQuery += " ORDER BY rank LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.conn.Query(sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -811,7 +1672,7 @@ func (db *DB) SearchProviderResources(query string, limit int) ([]ProviderResour
 	var resources []ProviderResource
 	for rows.Next() {
 		var r ProviderResource
-		if err := rows.Scan(&r.ID, &r.RepositoryID, &r.ServiceID, &r.Name, &r.DisplayName, &r.Kind, &r.FilePath, &r.Description, &r.DeprecationMessage, &r.VersionAdded, &r.VersionRemoved, &r.BreakingChanges, &r.APIVersion); err != nil {
+		if err := scanProviderResource(rows, &r); err != nil {
 			return nil, err
 		}
 		resources = append(resources, r)
@@ -819,122 +1680,366 @@ func (db *DB) SearchProviderResources(query string, limit int) ([]ProviderResour
 	return resources, rows.Err()
 }
 
+// GetProviderResource resolves a definition by exact name, then by the name with the provider
+// prefix prepended (so "virtual_network" finds azurerm_virtual_network), then by alias.
 func (db *DB) GetProviderResource(name string) (*ProviderResource, error) {
+	r, err := db.getProviderResourceByName(name)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return r, err
+	}
+
+	if key := resourceAliasKey(name); key != "" {
+		prefix, err := db.providerPrefix()
+		if err != nil {
+			return nil, err
+		}
+		if prefix != "" && !strings.HasPrefix(key, prefix) {
+			r, err := db.getProviderResourceByName(prefix + key)
+			if !errors.Is(err, sql.ErrNoRows) {
+				return r, err
+			}
+		}
+	}
+
+	return db.resolveProviderResourceAlias(name)
+}
+
+func (db *DB) getProviderResourceByName(name string) (*ProviderResource, error) {
 	var r ProviderResource
 	// When a name exists as both resource and data_source, prefer the resource
-	err := db.conn.QueryRow(`
-		SELECT id, repository_id, service_id, name, display_name, kind, file_path, description, deprecation_message, version_added, version_removed, breaking_changes, api_version
+	err := scanProviderResource(db.conn.QueryRow(`
+		SELECT `+providerResourceColumns("")+`
 		FROM provider_resources
 		WHERE name = ?
 		ORDER BY CASE kind WHEN 'resource' THEN 0 WHEN 'data_source' THEN 1 ELSE 2 END
 		LIMIT 1
-	`, name).Scan(&r.ID, &r.RepositoryID, &r.ServiceID, &r.Name, &r.DisplayName, &r.Kind, &r.FilePath, &r.Description, &r.DeprecationMessage, &r.VersionAdded, &r.VersionRemoved, &r.BreakingChanges, &r.APIVersion)
+	`, name), &r)
 	if err != nil {
 		return nil, err
 	}
 	return &r, nil
 }
 
-func (db *DB) GetProviderResourceAttributes(resourceID int64) ([]ProviderAttribute, error) {
-	rows, err := db.conn.Query(`
-		SELECT id, resource_id, name, type, required, optional, computed, force_new, sensitive, deprecated, description,
-			conflicts_with, exactly_one_of, at_least_one_of, max_items, min_items, elem_type, elem_summary,
-			nested_block, validation, diff_suppress, default_value, state_func, set_func, elem_schema_json,
-			type_details, required_with
-		FROM provider_resource_attributes
-		WHERE resource_id = ?
-		ORDER BY name
-	`, resourceID)
+// GetProviderResourceOfKind returns the definition with exactly name and kind, such as the data
+// source sharing a resource's name.
+func (db *DB) GetProviderResourceOfKind(name, kind string) (*ProviderResource, error) {
+	var r ProviderResource
+	err := scanProviderResource(db.conn.QueryRow(`
+		SELECT `+providerResourceColumns("")+`
+		FROM provider_resources
+		WHERE name = ? AND kind = ?
+		LIMIT 1
+	`, name, kind), &r)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return &r, nil
+}
 
-	var attrs []ProviderAttribute
-	for rows.Next() {
-		var a ProviderAttribute
-		if err := rows.Scan(&a.ID, &a.ResourceID, &a.Name, &a.Type, &a.Required, &a.Optional, &a.Computed, &a.ForceNew, &a.Sensitive, &a.Deprecated,
-			&a.Description, &a.ConflictsWith, &a.ExactlyOneOf, &a.AtLeastOneOf, &a.MaxItems, &a.MinItems, &a.ElemType, &a.ElemSummary,
-			&a.NestedBlock, &a.Validation, &a.DiffSuppress, &a.DefaultValue, &a.StateFunc, &a.SetFunc, &a.ElemSchemaJSON,
-			&a.TypeDetails, &a.RequiredWith); err != nil {
-			return nil, err
-		}
-		attrs = append(attrs, a)
+// providerPrefix returns the most common name prefix among indexed definitions, such as
+// "azurerm_", so lookups follow whichever provider was synced. It is empty for an empty index.
+func (db *DB) providerPrefix() (string, error) {
+	var prefix string
+	err := db.conn.QueryRow(`
+		SELECT substr(name, 1, instr(name, '_'))
+		FROM provider_resources
+		WHERE instr(name, '_') > 0 AND ` + liveResourceFilter("") + `
+		GROUP BY 1
+		ORDER BY COUNT(*) DESC
+		LIMIT 1
+	`).Scan(&prefix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
 	}
-	return attrs, rows.Err()
+	return prefix, err
 }
 
-func (db *DB) SearchProviderAttributes(filters AttributeSearchFilters) ([]ProviderAttributeSearchResult, error) {
-	if filters.Limit <= 0 {
-		filters.Limit = 20
+// resolveProviderResourceAlias matches human-friendly input such as "Virtual Network" or
+// "virtual_network" against the display name or the name without its provider prefix.
+func (db *DB) resolveProviderResourceAlias(name string) (*ProviderResource, error) {
+	key := resourceAliasKey(name)
+	if key == "" {
+		return nil, sql.ErrNoRows
 	}
 
-	var builder strings.Builder
-	builder.WriteString(`
-		SELECT
-			a.id, a.resource_id, a.name, a.type, a.required, a.optional, a.computed, a.force_new, a.sensitive,
-			a.deprecated, a.description, a.conflicts_with, a.exactly_one_of, a.at_least_one_of, a.max_items,
-			a.min_items, a.elem_type, a.elem_summary, a.nested_block, a.validation, a.diff_suppress,
-			a.default_value, a.state_func, a.set_func, a.elem_schema_json, a.type_details, a.required_with,
-			r.name, r.kind, r.file_path
-		FROM provider_resource_attributes a
-		JOIN provider_resources r ON r.id = a.resource_id
-		WHERE 1=1
-	`)
-
-	var args []any
-	lowerLike := func(val string) string {
-		return "%" + strings.ToLower(val) + "%"
+	var r ProviderResource
+	err := scanProviderResource(db.conn.QueryRow(`
+		SELECT `+providerResourceColumns("")+`
+		FROM provider_resources
+		WHERE LOWER(name) = ?
+			OR substr(name, instr(name, '_') + 1) = ?
+			OR REPLACE(REPLACE(LOWER(TRIM(COALESCE(display_name, ''))), ' ', '_'), '-', '_') = ?
+		ORDER BY
+			CASE WHEN `+liveResourceFilter("")+` THEN 0 ELSE 1 END,
+			CASE kind WHEN 'resource' THEN 0 WHEN 'data_source' THEN 1 ELSE 2 END,
+			name
+		LIMIT 1
+	`, key, key, key), &r)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// resourceAliasKey lowercases input and folds runs of spaces, hyphens and underscores into one underscore.
+func resourceAliasKey(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '\t'
+	})
+	return strings.Join(fields, "_")
+}
+
+var providerResourceColumnNames = []string{
+	"id", "repository_id", "service_id", "name", "display_name", "kind", "file_path", "description", "deprecation_message",
+	"version_added", "version_removed", "breaking_changes", "api_version", "registration_type", "unresolved_reason",
+}
+
+// providerResourceColumns returns the column list matching scanProviderResource, optionally qualified by a table alias.
+// liveResourceFilter is the SQL predicate that keeps definitions present in the indexed source and
+// drops the placeholder rows recorded for removed ones (registration type RegistrationRemoved).
+// alias qualifies the column as in providerResourceColumns.
+func liveResourceFilter(alias string) string {
+	column := "registration_type"
+	if alias != "" {
+		column = alias + "." + column
+	}
+	return "COALESCE(" + column + ", '') != '" + RegistrationRemoved + "'"
+}
+
+func providerResourceColumns(alias string) string {
+	if alias == "" {
+		return strings.Join(providerResourceColumnNames, ", ")
+	}
+	qualified := make([]string, len(providerResourceColumnNames))
+	for i, name := range providerResourceColumnNames {
+		qualified[i] = alias + "." + name
+	}
+	return strings.Join(qualified, ", ")
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProviderResource(row rowScanner, r *ProviderResource) error {
+	return row.Scan(&r.ID, &r.RepositoryID, &r.ServiceID, &r.Name, &r.DisplayName, &r.Kind, &r.FilePath, &r.Description, &r.DeprecationMessage,
+		&r.VersionAdded, &r.VersionRemoved, &r.BreakingChanges, &r.APIVersion, &r.RegistrationType, &r.UnresolvedReason)
+}
+
+// extraColumnsScanner appends destinations for columns selected after the provider resource
+// columns, so scanProviderResource can be reused by queries that select more.
+type extraColumnsScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraColumnsScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+// AttributeFingerprint identifies the contents of provider_resource_attributes cheaply. Syncs
+// delete and re-insert attributes with fresh AUTOINCREMENT ids, so any change alters it.
+type AttributeFingerprint struct {
+	Count int64
+	MaxID int64
+}
+
+// GetAttributeFingerprint returns the current row count and highest id of the attribute table.
+func (db *DB) GetAttributeFingerprint() (AttributeFingerprint, error) {
+	var fp AttributeFingerprint
+	err := db.conn.QueryRow(`
+		SELECT COUNT(*), COALESCE(MAX(id), 0) FROM provider_resource_attributes
+	`).Scan(&fp.Count, &fp.MaxID)
+	return fp, err
+}
+
+// ListAttributeNamesByResource returns the top-level attribute names of every definition of kind
+// (all kinds when empty), keyed by resource id, in a single query.
+func (db *DB) ListAttributeNamesByResource(kind string) (map[int64][]string, error) {
+	query := `
+		SELECT a.resource_id, a.name
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id`
+	var args []any
+	if kind != "" {
+		query += " WHERE r.kind = ?"
+		args = append(args, kind)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int64][]string)
+	for rows.Next() {
+		var resourceID int64
+		var name string
+		if err := rows.Scan(&resourceID, &name); err != nil {
+			return nil, err
+		}
+		names[resourceID] = append(names[resourceID], name)
+	}
+	return names, rows.Err()
+}
+
+func (db *DB) GetProviderResourceAttributes(resourceID int64) ([]ProviderAttribute, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, resource_id, name, type, required, optional, computed, force_new, sensitive, deprecated, description,
+			conflicts_with, exactly_one_of, at_least_one_of, max_items, min_items, elem_type, elem_summary,
+			nested_block, validation, diff_suppress, default_value, state_func, set_func, elem_schema_json,
+			type_details, required_with
+		FROM provider_resource_attributes
+		WHERE resource_id = ?
+		ORDER BY name
+	`, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attrs []ProviderAttribute
+	for rows.Next() {
+		var a ProviderAttribute
+		if err := rows.Scan(&a.ID, &a.ResourceID, &a.Name, &a.Type, &a.Required, &a.Optional, &a.Computed, &a.ForceNew, &a.Sensitive, &a.Deprecated,
+			&a.Description, &a.ConflictsWith, &a.ExactlyOneOf, &a.AtLeastOneOf, &a.MaxItems, &a.MinItems, &a.ElemType, &a.ElemSummary,
+			&a.NestedBlock, &a.Validation, &a.DiffSuppress, &a.DefaultValue, &a.StateFunc, &a.SetFunc, &a.ElemSchemaJSON,
+			&a.TypeDetails, &a.RequiredWith); err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, a)
+	}
+	return attrs, rows.Err()
+}
+
+// attributeSearchWhere renders the AND-ed filter clauses shared by attribute search and count queries.
+func attributeSearchWhere(filters AttributeSearchFilters) (string, []any) {
+	var where strings.Builder
+	var args []any
+	lowerLike := func(val string) string {
+		return "%" + strings.ToLower(val) + "%"
 	}
 
 	if filters.NameContains != "" {
-		builder.WriteString(" AND LOWER(a.name) LIKE ?")
+		where.WriteString(" AND LOWER(a.name) LIKE ?")
 		args = append(args, lowerLike(filters.NameContains))
 	}
 	if filters.ResourcePrefix != "" {
-		builder.WriteString(" AND r.name LIKE ?")
+		where.WriteString(" AND r.name LIKE ?")
 		args = append(args, filters.ResourcePrefix+"%")
 	}
+	if filters.ServiceName != "" {
+		where.WriteString(" AND r.service_id IN (SELECT id FROM provider_services WHERE LOWER(name) = LOWER(?))")
+		args = append(args, filters.ServiceName)
+	}
 	for _, flag := range filters.Flags {
-		switch strings.ToLower(flag) {
-		case "required":
-			builder.WriteString(" AND a.required = 1")
-		case "optional":
-			builder.WriteString(" AND a.optional = 1")
-		case "computed":
-			builder.WriteString(" AND a.computed = 1")
-		case "force_new":
-			builder.WriteString(" AND a.force_new = 1")
-		case "sensitive":
-			builder.WriteString(" AND a.sensitive = 1")
-		case "deprecated":
-			builder.WriteString(" AND a.deprecated IS NOT NULL AND a.deprecated <> ''")
-		case "nested":
-			builder.WriteString(" AND a.nested_block = 1")
+		if clause := attributeFlagClause(flag, false); clause != "" {
+			where.WriteString(" AND " + clause)
+		}
+	}
+	for _, flag := range filters.NotFlags {
+		if clause := attributeFlagClause(flag, true); clause != "" {
+			where.WriteString(" AND " + clause)
 		}
 	}
 	if filters.ConflictsWith != "" {
-		builder.WriteString(" AND LOWER(COALESCE(a.conflicts_with, '')) LIKE ?")
+		where.WriteString(" AND LOWER(COALESCE(a.conflicts_with, '')) LIKE ?")
 		args = append(args, lowerLike(filters.ConflictsWith))
 	}
 	if filters.DescriptionQuery != "" {
-		builder.WriteString(" AND LOWER(COALESCE(a.description, a.elem_summary, '')) LIKE ?")
+		where.WriteString(" AND LOWER(COALESCE(a.description, a.elem_summary, '')) LIKE ?")
 		args = append(args, lowerLike(filters.DescriptionQuery))
 	}
 	if filters.HasValidation {
-		builder.WriteString(" AND a.validation IS NOT NULL AND a.validation <> ''")
+		where.WriteString(" AND a.validation IS NOT NULL AND a.validation <> ''")
+	}
+	if filters.MissingValidation {
+		where.WriteString(" AND (a.validation IS NULL OR a.validation = '')")
+	}
+	if len(filters.Types) > 0 {
+		clauses := make([]string, 0, len(filters.Types))
+		for _, typ := range filters.Types {
+			clauses = append(clauses, "a.type LIKE ?")
+			args = append(args, "%Type"+strings.TrimPrefix(typ, "Type"))
+		}
+		where.WriteString(" AND (" + strings.Join(clauses, " OR ") + ")")
+	}
+	if filters.ConfigurableOnly {
+		where.WriteString(" AND (a.required = 1 OR a.optional = 1)")
 	}
 	if filters.ValidationContains != "" {
-		builder.WriteString(" AND LOWER(COALESCE(a.validation, '')) LIKE ?")
+		where.WriteString(" AND LOWER(COALESCE(a.validation, '')) LIKE ?")
 		args = append(args, lowerLike(filters.ValidationContains))
 	}
 	if filters.HasDiffSuppress {
-		builder.WriteString(" AND a.diff_suppress IS NOT NULL AND a.diff_suppress <> ''")
+		where.WriteString(" AND a.diff_suppress IS NOT NULL AND a.diff_suppress <> ''")
 	}
 	if filters.DiffSuppressContains != "" {
-		builder.WriteString(" AND LOWER(COALESCE(a.diff_suppress, '')) LIKE ?")
+		where.WriteString(" AND LOWER(COALESCE(a.diff_suppress, '')) LIKE ?")
 		args = append(args, lowerLike(filters.DiffSuppressContains))
 	}
+	return where.String(), args
+}
+
+// attributeFlagClause returns the SQL condition requiring (or, when negated, excluding) a schema
+// flag; unknown flags yield "".
+func attributeFlagClause(flag string, negate bool) string {
+	var column string
+	switch strings.ToLower(flag) {
+	case "required":
+		column = "a.required"
+	case "optional":
+		column = "a.optional"
+	case "computed":
+		column = "a.computed"
+	case "force_new":
+		column = "a.force_new"
+	case "sensitive":
+		column = "a.sensitive"
+	case "nested":
+		column = "a.nested_block"
+	case "deprecated":
+		if negate {
+			return "(a.deprecated IS NULL OR a.deprecated = '')"
+		}
+		return "a.deprecated IS NOT NULL AND a.deprecated <> ''"
+	default:
+		return ""
+	}
+	if negate {
+		return column + " = 0"
+	}
+	return column + " = 1"
+}
+
+// CountProviderAttributes returns how many attributes match the filters, ignoring Limit.
+func (db *DB) CountProviderAttributes(filters AttributeSearchFilters) (int, error) {
+	where, args := attributeSearchWhere(filters)
+	var count int
+	err := db.conn.QueryRow(`
+		SELECT COUNT(*)
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id
+		WHERE 1=1`+where, args...).Scan(&count)
+	return count, err
+}
+
+func (db *DB) SearchProviderAttributes(filters AttributeSearchFilters) ([]ProviderAttributeSearchResult, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = 20
+	}
+
+	var builder strings.Builder
+	builder.WriteString(`
+		SELECT ` + attributeSearchColumns + `
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id
+		WHERE 1=1
+	`)
+
+	where, args := attributeSearchWhere(filters)
+	builder.WriteString(where)
 
 	builder.WriteString(" ORDER BY r.name, a.name LIMIT ?")
 	args = append(args, filters.Limit)
@@ -945,6 +2050,47 @@ func (db *DB) SearchProviderAttributes(filters AttributeSearchFilters) ([]Provid
 	}
 	defer rows.Close()
 
+	return scanAttributeSearchResults(rows)
+}
+
+// SearchProviderAttributeDescriptions ranks attributes by how well their name and description
+// match the full-text query. A non-empty resourcePrefix limits the search to matching resources;
+// a limit of 0 or less returns every match.
+func (db *DB) SearchProviderAttributeDescriptions(query, resourcePrefix string, limit int) ([]ProviderAttributeSearchResult, error) {
+	sqlQuery := `
+		SELECT ` + attributeSearchColumns + `
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id
+		JOIN provider_attributes_fts ON provider_attributes_fts.rowid = a.id
+		WHERE provider_attributes_fts MATCH ?`
+	args := []any{anyTermFTS5(query)}
+	if resourcePrefix != "" {
+		sqlQuery += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	sqlQuery += " ORDER BY rank, r.name, a.name LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := db.conn.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAttributeSearchResults(rows)
+}
+
+const attributeSearchColumns = `
+			a.id, a.resource_id, a.name, a.type, a.required, a.optional, a.computed, a.force_new, a.sensitive,
+			a.deprecated, a.description, a.conflicts_with, a.exactly_one_of, a.at_least_one_of, a.max_items,
+			a.min_items, a.elem_type, a.elem_summary, a.nested_block, a.validation, a.diff_suppress,
+			a.default_value, a.state_func, a.set_func, a.elem_schema_json, a.type_details, a.required_with,
+			r.name, r.kind, r.file_path`
+
+func scanAttributeSearchResults(rows *sql.Rows) ([]ProviderAttributeSearchResult, error) {
 	var out []ProviderAttributeSearchResult
 	for rows.Next() {
 		var res ProviderAttributeSearchResult
@@ -994,13 +2140,20 @@ func nullIfEmpty(s string) any {
 	return s
 }
 
+func nullIfZero(n int) any {
+	if n <= 0 {
+		return nil
+	}
+	return n
+}
+
 func (db *DB) GetParseCacheEntry(filePath string) (*ParseCacheEntry, error) {
 	var entry ParseCacheEntry
 	err := db.conn.QueryRow(`
-		SELECT file_path, content_hash, parsed_at, resource_count, attribute_count
+		SELECT file_path, content_hash, parsed_at, resource_count, attribute_count, COALESCE(symbol_count, -1)
 		FROM parse_cache
 		WHERE file_path = ?
-	`, filePath).Scan(&entry.FilePath, &entry.ContentHash, &entry.ParsedAt, &entry.ResourceCount, &entry.AttributeCount)
+	`, filePath).Scan(&entry.FilePath, &entry.ContentHash, &entry.ParsedAt, &entry.ResourceCount, &entry.AttributeCount, &entry.SymbolCount)
 	if err != nil {
 		return nil, err
 	}
@@ -1009,13 +2162,467 @@ func (db *DB) GetParseCacheEntry(filePath string) (*ParseCacheEntry, error) {
 
 func (db *DB) UpsertParseCacheEntry(entry *ParseCacheEntry) error {
 	_, err := db.conn.Exec(`
-		INSERT INTO parse_cache (file_path, content_hash, resource_count, attribute_count)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO parse_cache (file_path, content_hash, resource_count, attribute_count, symbol_count)
+		VALUES (?, ?, ?, ?, ?)
 		ON CONFLICT(file_path) DO UPDATE SET
 			content_hash = excluded.content_hash,
 			parsed_at = CURRENT_TIMESTAMP,
 			resource_count = excluded.resource_count,
-			attribute_count = excluded.attribute_count
-	`, entry.FilePath, entry.ContentHash, entry.ResourceCount, entry.AttributeCount)
+			attribute_count = excluded.attribute_count,
+			symbol_count = excluded.symbol_count
+	`, entry.FilePath, entry.ContentHash, entry.ResourceCount, entry.AttributeCount, entry.SymbolCount)
 	return err
 }
+
+type ProviderOverview struct {
+	TotalDefinitions     int
+	Kinds                map[string]int
+	RegistrationTypes    map[string]int
+	Services             int
+	ResourcesWithService int
+	WithoutAttributes    int
+	ParseFailures        []string // Go files skipped in the last sync because they failed to parse
+}
+
+func (db *DB) GetProviderOverview() (*ProviderOverview, error) {
+	overview := &ProviderOverview{
+		Kinds:             make(map[string]int),
+		RegistrationTypes: make(map[string]int),
+	}
+
+	countBy := func(column string, into map[string]int) error {
+		rows, err := db.conn.Query(fmt.Sprintf(`SELECT COALESCE(%s, 'unknown'), COUNT(*) FROM provider_resources WHERE %s GROUP BY 1`, column, liveResourceFilter("")))
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var key string
+			var count int
+			if err := rows.Scan(&key, &count); err != nil {
+				return err
+			}
+			into[key] = count
+		}
+		return rows.Err()
+	}
+
+	if err := countBy("kind", overview.Kinds); err != nil {
+		return nil, err
+	}
+	if err := countBy("registration_type", overview.RegistrationTypes); err != nil {
+		return nil, err
+	}
+
+	err := db.conn.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM provider_resources WHERE `+liveResourceFilter("")+`),
+			(SELECT COUNT(*) FROM provider_services),
+			(SELECT COUNT(*) FROM provider_resources WHERE service_id IS NOT NULL),
+			(SELECT COUNT(*) FROM provider_resources r WHERE `+liveResourceFilter("r")+` AND NOT EXISTS (SELECT 1 FROM provider_resource_attributes a WHERE a.resource_id = r.id))
+	`).Scan(&overview.TotalDefinitions, &overview.Services, &overview.ResourcesWithService, &overview.WithoutAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	if overview.ParseFailures, err = db.ListParseFailures(); err != nil {
+		return nil, err
+	}
+	return overview, nil
+}
+
+func (db *DB) ListProviderResourcesWithoutAttributes(limit int) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		WHERE NOT EXISTS (SELECT 1 FROM provider_resource_attributes a WHERE a.resource_id = r.id)
+			AND ` + liveResourceFilter("r") + `
+		ORDER BY r.name, r.kind`
+	var args []any
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListUnresolvedResources returns current definitions without parsed attributes whose name starts
+// with resourcePrefix (all when empty), ordered by name and kind.
+func (db *DB) ListUnresolvedResources(resourcePrefix string) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		WHERE NOT EXISTS (SELECT 1 FROM provider_resource_attributes a WHERE a.resource_id = r.id)
+			AND ` + liveResourceFilter("r")
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name, r.kind"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListResourcesWithoutTimeouts returns definitions whose stored source declares no Timeouts block.
+// Definitions without a stored source, such as typed resources, are not included.
+func (db *DB) ListResourcesWithoutTimeouts(resourcePrefix string) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		JOIN provider_resource_sources src ON src.resource_id = r.id
+		WHERE src.timeouts_json IS NULL
+			AND ` + liveResourceFilter("r")
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name, r.kind"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ListResourcesWithAttributes returns current definitions whose top-level attributes include every
+// one of names, optionally filtered by kind and name prefix.
+func (db *DB) ListResourcesWithAttributes(names []string, kind, resourcePrefix string) ([]ProviderResource, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	args := make([]any, 0, len(names)+3)
+	for _, name := range names {
+		args = append(args, name)
+	}
+	args = append(args, len(names))
+
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		JOIN (
+			SELECT resource_id
+			FROM provider_resource_attributes
+			WHERE name IN (?` + strings.Repeat(", ?", len(names)-1) + `)
+			GROUP BY resource_id
+			HAVING COUNT(DISTINCT name) = ?
+		) matched ON matched.resource_id = r.id
+		WHERE ` + liveResourceFilter("r")
+	if kind != "" {
+		query += " AND r.kind = ?"
+		args = append(args, kind)
+	}
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name, r.kind"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// ResourceArgumentCoverage describes which of a set of expected top-level arguments a definition
+// declares, with the context needed to judge whether a missing one is a parser gap.
+type ResourceArgumentCoverage struct {
+	Resource       ProviderResource
+	AttributeCount int
+	Present        []string // expected arguments the definition declares
+	ParentIDs      []string // required arguments ending in _id, typical of child resources
+	SchemaSnippet  string
+}
+
+// ListResourcesMissingArguments returns current definitions of kind (all kinds when empty) whose
+// top-level attributes lack at least one of names, optionally filtered by name prefix.
+func (db *DB) ListResourcesMissingArguments(names []string, kind, resourcePrefix string) ([]ResourceArgumentCoverage, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	placeholders := "?" + strings.Repeat(", ?", len(names)-1)
+	args := make([]any, 0, 2*len(names)+3)
+	for _, name := range names {
+		args = append(args, name)
+	}
+
+	query := `
+		SELECT ` + providerResourceColumns("r") + `,
+			COUNT(a.id),
+			COALESCE(GROUP_CONCAT(CASE WHEN a.name IN (` + placeholders + `) THEN a.name END), ''),
+			COALESCE(GROUP_CONCAT(CASE WHEN a.required AND a.name LIKE '%\_id' ESCAPE '\' THEN a.name END), ''),
+			COALESCE(MAX(src.schema_snippet), '')
+		FROM provider_resources r
+		LEFT JOIN provider_resource_attributes a ON a.resource_id = r.id
+		LEFT JOIN provider_resource_sources src ON src.resource_id = r.id
+		WHERE ` + liveResourceFilter("r")
+	if kind != "" {
+		query += " AND r.kind = ?"
+		args = append(args, kind)
+	}
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += `
+		GROUP BY r.id
+		HAVING COUNT(DISTINCT CASE WHEN a.name IN (` + placeholders + `) THEN a.name END) < ?
+		ORDER BY r.name, r.kind`
+	for _, name := range names {
+		args = append(args, name)
+	}
+	args = append(args, len(names))
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coverage []ResourceArgumentCoverage
+	for rows.Next() {
+		var c ResourceArgumentCoverage
+		var present, parentIDs string
+		scanner := extraColumnsScanner{row: rows, extra: []any{&c.AttributeCount, &present, &parentIDs, &c.SchemaSnippet}}
+		if err := scanProviderResource(scanner, &c.Resource); err != nil {
+			return nil, err
+		}
+		if present != "" {
+			c.Present = strings.Split(present, ",")
+		}
+		if parentIDs != "" {
+			c.ParentIDs = strings.Split(parentIDs, ",")
+			sort.Strings(c.ParentIDs)
+		}
+		coverage = append(coverage, c)
+	}
+	return coverage, rows.Err()
+}
+
+// ResourceLocationPlacement captures how a resource declares its top-level location attribute.
+type ResourceLocationPlacement struct {
+	Name        string
+	HasLocation bool
+	Required    bool
+	Optional    bool
+	Computed    bool
+}
+
+// ListResourceLocationPlacements reports the location attribute flags for every parsed resource
+// (data sources and definitions without attributes are skipped).
+func (db *DB) ListResourceLocationPlacements(resourcePrefix string) ([]ResourceLocationPlacement, error) {
+	query := `
+		SELECT r.name,
+			a.id IS NOT NULL,
+			COALESCE(a.required, 0),
+			COALESCE(a.optional, 0),
+			COALESCE(a.computed, 0)
+		FROM provider_resources r
+		LEFT JOIN provider_resource_attributes a ON a.resource_id = r.id AND a.name = 'location'
+		WHERE r.kind = 'resource'
+			AND EXISTS (SELECT 1 FROM provider_resource_attributes x WHERE x.resource_id = r.id)`
+	var args []any
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var placements []ResourceLocationPlacement
+	for rows.Next() {
+		var p ResourceLocationPlacement
+		if err := rows.Scan(&p.Name, &p.HasLocation, &p.Required, &p.Optional, &p.Computed); err != nil {
+			return nil, err
+		}
+		placements = append(placements, p)
+	}
+	return placements, rows.Err()
+}
+
+// AttributeUsage counts the definitions that declare a top-level attribute name.
+type AttributeUsage struct {
+	Name        string
+	Resources   int
+	DataSources int
+}
+
+// ListAttributeUsage groups top-level attributes by name, matching name exactly or as a
+// case-insensitive substring, most widely used first. limit <= 0 returns every group.
+func (db *DB) ListAttributeUsage(name string, exact bool, limit int) ([]AttributeUsage, error) {
+	query := `
+		SELECT a.name,
+			SUM(CASE WHEN r.kind = 'resource' THEN 1 ELSE 0 END),
+			SUM(CASE WHEN r.kind = 'data_source' THEN 1 ELSE 0 END)
+		FROM provider_resource_attributes a
+		JOIN provider_resources r ON r.id = a.resource_id`
+	var args []any
+	if exact {
+		query += " WHERE a.name = ?"
+		args = append(args, name)
+	} else {
+		query += " WHERE LOWER(a.name) LIKE ?"
+		args = append(args, "%"+strings.ToLower(name)+"%")
+	}
+	query += " GROUP BY a.name ORDER BY COUNT(*) DESC, a.name"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var usages []AttributeUsage
+	for rows.Next() {
+		var u AttributeUsage
+		if err := rows.Scan(&u.Name, &u.Resources, &u.DataSources); err != nil {
+			return nil, err
+		}
+		usages = append(usages, u)
+	}
+	return usages, rows.Err()
+}
+
+// ListResourcesWithAttribute returns the definitions declaring the exact top-level attribute name.
+func (db *DB) ListResourcesWithAttribute(name string, limit int) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		WHERE EXISTS (SELECT 1 FROM provider_resource_attributes a WHERE a.resource_id = r.id AND a.name = ?)
+		ORDER BY r.name, r.kind`
+	args := []any{name}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
+
+// UpdateProviderResourceLifecycle records the changelog versions in which a definition was added
+// or removed. Empty versions leave the stored value untouched; it reports whether a row matched.
+func (db *DB) UpdateProviderResourceLifecycle(repositoryID int64, name, kind, added, removed string) (bool, error) {
+	result, err := db.conn.Exec(`
+		UPDATE provider_resources
+		SET version_added = COALESCE(?, version_added),
+			version_removed = COALESCE(?, version_removed)
+		WHERE repository_id = ? AND name = ? AND kind = ?`,
+		nullIfEmpty(added), nullIfEmpty(removed), repositoryID, name, kind)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ListProviderResourceLifecycle returns definitions with a recorded added or removed version.
+func (db *DB) ListProviderResourceLifecycle(resourcePrefix string, removedOnly bool) ([]ProviderResource, error) {
+	query := `
+		SELECT ` + providerResourceColumns("r") + `
+		FROM provider_resources r
+		WHERE (r.version_added IS NOT NULL OR r.version_removed IS NOT NULL)`
+	var args []any
+	if removedOnly {
+		query += " AND r.version_removed IS NOT NULL"
+	}
+	if resourcePrefix != "" {
+		query += " AND r.name LIKE ?"
+		args = append(args, resourcePrefix+"%")
+	}
+	query += " ORDER BY r.name, r.kind"
+
+	rows, err := db.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []ProviderResource
+	for rows.Next() {
+		var r ProviderResource
+		if err := scanProviderResource(rows, &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, rows.Err()
+}
@@ -2,7 +2,11 @@ package database
 
 import (
 	"database/sql"
+	"errors"
+	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 )
@@ -44,6 +48,85 @@ func TestInsertAndFetchRepository(t *testing.T) {
 	}
 }
 
+func TestNewWithModeReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "prebuilt.db")
+	db, err := New(dbPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5") {
+			t.Skipf("sqlite build without fts5: %v", err)
+		}
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if _, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"}); err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("close db: %v", err)
+	}
+
+	ro, err := NewWithMode(dbPath, ModeReadOnly)
+	if err != nil {
+		t.Fatalf("open read-only: %v", err)
+	}
+	t.Cleanup(func() { _ = ro.Close() })
+
+	if !ro.ReadOnly() {
+		t.Fatalf("expected read-only database")
+	}
+	repo, err := ro.GetRepository("terraform-provider-azurerm")
+	if err != nil || repo.Name != "terraform-provider-azurerm" {
+		t.Fatalf("query read-only db: %v %+v", err, repo)
+	}
+	if _, err := ro.InsertRepository(&Repository{Name: "other"}); err == nil {
+		t.Fatalf("expected write to read-only db to fail")
+	}
+
+	if _, err := NewWithMode(filepath.Join(t.TempDir(), "missing.db"), ModeReadOnly); err == nil {
+		t.Fatalf("expected error opening missing read-only db")
+	}
+}
+
+func TestNewDetectsCorruptFile(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "corrupt.db")
+	if err := os.WriteFile(dbPath, []byte(strings.Repeat("not a sqlite database ", 256)), 0o644); err != nil {
+		t.Fatalf("write garbage: %v", err)
+	}
+
+	for _, mode := range []Mode{ModeReadWrite, ModeReadOnly} {
+		db, err := NewWithMode(dbPath, mode)
+		if err == nil {
+			_ = db.Close()
+			t.Fatalf("%s: expected error opening corrupt db", mode)
+		}
+		if !errors.Is(err, ErrCorrupt) || !strings.Contains(err.Error(), dbPath) {
+			t.Fatalf("%s: expected ErrCorrupt naming the file, got %v", mode, err)
+		}
+	}
+
+	backup, err := MoveAside(dbPath)
+	if err != nil {
+		t.Fatalf("move aside: %v", err)
+	}
+	if _, err := os.Stat(backup); err != nil || !strings.HasPrefix(backup, dbPath+".corrupt-") {
+		t.Fatalf("expected backup at %s: %v", backup, err)
+	}
+	if _, err := os.Stat(dbPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected original path to be free, got %v", err)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for input, want := range map[string]Mode{"": ModeReadWrite, "readwrite": ModeReadWrite, "ReadOnly": ModeReadOnly} {
+		got, err := ParseMode(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseMode("ro"); err == nil {
+		t.Fatalf("expected error for unknown mode")
+	}
+}
+
 func TestInsertResourceAndAttributes(t *testing.T) {
 	db := newTestDB(t)
 	repo := &Repository{Name: "terraform-provider-azurerm"}
@@ -102,6 +185,61 @@ func TestUpsertReleaseAndEntries(t *testing.T) {
 	}
 }
 
+func TestGetProviderResourceResolvesDisplayName(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	if _, err := db.InsertProviderResource(&ProviderResource{
+		RepositoryID: repoID,
+		Name:         "azurerm_virtual_network",
+		Kind:         "resource",
+		DisplayName:  sql.NullString{String: "Virtual Network", Valid: true},
+	}); err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+
+	for _, input := range []string{"azurerm_virtual_network", "virtual network", "Virtual Network", "virtual_network", "Virtual-Network", "AZURERM_VIRTUAL_NETWORK"} {
+		res, err := db.GetProviderResource(input)
+		if err != nil || res.Name != "azurerm_virtual_network" {
+			t.Fatalf("GetProviderResource(%q) = %+v, %v", input, res, err)
+		}
+	}
+
+	if _, err := db.GetProviderResource("virtual networks"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected no match for unrelated input, got %v", err)
+	}
+}
+
+func TestGetProviderResourcePrependsProviderPrefix(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	insert := func(name, displayName string) {
+		t.Helper()
+		if _, err := db.InsertProviderResource(&ProviderResource{
+			RepositoryID: repoID,
+			Name:         name,
+			Kind:         "resource",
+			DisplayName:  sql.NullString{String: displayName, Valid: displayName != ""},
+		}); err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+	}
+	// The display name of azurerm_network_manager would win the alias match on name order alone.
+	insert("azurerm_network_manager", "Virtual Network")
+	insert("azurerm_virtual_network", "")
+
+	for _, input := range []string{"virtual_network", "Virtual_Network", "azurerm_virtual_network"} {
+		res, err := db.GetProviderResource(input)
+		if err != nil || res.Name != "azurerm_virtual_network" {
+			t.Fatalf("GetProviderResource(%q) = %+v, %v", input, res, err)
+		}
+	}
+
+	prefix, err := db.providerPrefix()
+	if err != nil || prefix != "azurerm_" {
+		t.Fatalf("expected azurerm_ prefix, got %q, %v", prefix, err)
+	}
+}
+
 func TestSearchProviderResourcesFTS(t *testing.T) {
 	db := newTestDB(t)
 	repo := &Repository{Name: "terraform-provider-azurerm"}
@@ -116,7 +254,7 @@ func TestSearchProviderResourcesFTS(t *testing.T) {
 		t.Fatalf("insert resource: %v", err)
 	}
 
-	results, err := db.SearchProviderResources("example", 5)
+	results, err := db.SearchProviderResources("example", "", 5)
 	if err != nil {
 		if strings.Contains(err.Error(), "fts") {
 			t.Skipf("sqlite build without FTS support: %v", err)
@@ -127,10 +265,232 @@ func TestSearchProviderResourcesFTS(t *testing.T) {
 		t.Fatalf("expected one resource match, got %+v", results)
 	}
 
+	if count, err := db.CountProviderResources("example", ""); err != nil || count != 1 {
+		t.Fatalf("expected count 1, got %d err=%v", count, err)
+	}
+
 	// Test that escapeFTS5 handles special characters gracefully
-	if _, err := db.SearchProviderResources("\"", 5); err != nil {
+	if _, err := db.SearchProviderResources("\"", "", 5); err != nil {
 		t.Fatalf("escapeFTS5 should handle quotes: %v", err)
 	}
+
+	dataSource := &ProviderResource{
+		RepositoryID: repoID,
+		Name:         "azurerm_example",
+		Kind:         "data_source",
+		Description:  sql.NullString{Valid: true, String: "example data source"},
+	}
+	if _, err := db.InsertProviderResource(dataSource); err != nil {
+		t.Fatalf("insert data source: %v", err)
+	}
+
+	results, err = db.SearchProviderResources("example", "data_source", 5)
+	if err != nil || len(results) != 1 || results[0].Kind != "data_source" {
+		t.Fatalf("expected only the data source, got %+v err=%v", results, err)
+	}
+	if count, err := db.CountProviderResources("example", "resource"); err != nil || count != 1 {
+		t.Fatalf("expected resource count 1, got %d err=%v", count, err)
+	}
+	if count, err := db.CountProviderResources("example", ""); err != nil || count != 2 {
+		t.Fatalf("expected unfiltered count 2, got %d err=%v", count, err)
+	}
+}
+
+func TestInsertProviderResourceKeepsFTSInSync(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+
+	res := &ProviderResource{
+		RepositoryID: repoID,
+		Name:         "azurerm_widget",
+		Kind:         "resource",
+		Description:  sql.NullString{Valid: true, String: "manages a gizmo"},
+	}
+	if _, err := db.InsertProviderResource(res); err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	if results, err := db.SearchProviderResources("gizmo", "", 5); err != nil || len(results) != 1 {
+		t.Fatalf("expected the new resource to be searchable, got %+v err=%v", results, err)
+	}
+
+	// An incremental parse upserts the same row; the old description must stop matching.
+	res.Description = sql.NullString{Valid: true, String: "manages a sprocket"}
+	res.BreakingChanges = sql.NullString{Valid: true, String: "renamed gadget_id"}
+	if _, err := db.InsertProviderResource(res); err != nil {
+		t.Fatalf("upsert resource: %v", err)
+	}
+	for query, want := range map[string]int{"gizmo": 0, "sprocket": 1, "gadget_id": 1} {
+		if count, err := db.CountProviderResources(query, ""); err != nil || count != want {
+			t.Fatalf("search %q: expected %d matches, got %d err=%v", query, want, count, err)
+		}
+	}
+
+	if _, err := db.conn.Exec(`INSERT INTO provider_resources_fts(provider_resources_fts, rank) VALUES('integrity-check', 1)`); err != nil {
+		t.Fatalf("provider_resources_fts drifted from its content table: %v", err)
+	}
+}
+
+func TestSearchProviderResourcesBooleanOperators(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	for name, description := range map[string]string{
+		"azurerm_virtual_network": "Manages a virtual network",
+		"azurerm_subnet":          "Manages a subnet within a virtual network",
+		"azurerm_storage_account": "Manages an Azure Storage Account",
+	} {
+		if _, err := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: name, Kind: "resource", Description: sql.NullString{String: description, Valid: true}}); err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+	}
+
+	names := func(query string) []string {
+		t.Helper()
+		results, err := db.SearchProviderResources(query, "", 10)
+		if err != nil {
+			t.Fatalf("search %q: %v", query, err)
+		}
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Name)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	if got := names("network OR storage"); strings.Join(got, ",") != "azurerm_storage_account,azurerm_subnet,azurerm_virtual_network" {
+		t.Fatalf("expected OR to match all three, got %v", got)
+	}
+	if got := names("network NOT subnet"); strings.Join(got, ",") != "azurerm_virtual_network" {
+		t.Fatalf("expected NOT to exclude subnet, got %v", got)
+	}
+	if got := names("(subnet OR storage) AND manages"); len(got) != 2 {
+		t.Fatalf("expected grouped expression to match two resources, got %v", got)
+	}
+	if count, err := db.CountProviderResources("subnet OR storage", ""); err != nil || count != 2 {
+		t.Fatalf("expected count 2, got %d err=%v", count, err)
+	}
+	for _, query := range []string{"OR", "network OR", "NOT network", "(network", "a) AND"} {
+		if _, err := db.SearchProviderResources(query, "", 10); err != nil {
+			t.Fatalf("malformed query %q should not error: %v", query, err)
+		}
+	}
+}
+
+func TestEscapeFTS5(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"network", `"network"`},
+		{"virtual  network", `"virtual" "network"`},
+		{`"virtual network" peering`, `"virtual network" "peering"`},
+		{"azurerm_subnet:id*", `"azurerm_subnet:id*"`},
+		{`say "hi`, `"say" "hi"`},
+		{"network OR subnet", `"network" OR "subnet"`},
+		{"   ", `""`},
+	}
+	for _, tt := range tests {
+		if got := escapeFTS5(tt.query); got != tt.want {
+			t.Errorf("escapeFTS5(%q) = %q; want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestProviderAttributeElementType(t *testing.T) {
+	tests := []struct {
+		name string
+		attr ProviderAttribute
+		want string
+	}{
+		{"string list", ProviderAttribute{ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeString, ValidateFunc=validation.StringIsNotEmpty", Valid: true}}, "string"},
+		{"int set", ProviderAttribute{ElemSummary: sql.NullString{String: "Type=schema.TypeInt", Valid: true}}, "number"},
+		{"bool map", ProviderAttribute{ElemSummary: sql.NullString{String: "ValidateFunc=x, Type=pluginsdk.TypeBool", Valid: true}}, "bool"},
+		{"list of lists", ProviderAttribute{ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeList", Valid: true}}, ""},
+		{"nested block", ProviderAttribute{NestedBlock: true, ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeString", Valid: true}}, ""},
+		{"scalar", ProviderAttribute{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.attr.ElementType(); got != tt.want {
+			t.Errorf("%s: ElementType() = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFTSMultiWordQueries(t *testing.T) {
+	db := newTestDB(t)
+	repoID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm", Description: "Azure Resource Manager Provider"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	if _, err := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_virtual_network", Kind: "resource", Description: sql.NullString{String: "Manages a virtual network including any configured subnets", Valid: true}}); err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	if err := db.InsertFile(&RepositoryFile{RepositoryID: repoID, FileName: "subnet.go", FilePath: "internal/services/network/subnet.go", FileType: "go", Content: "func subnetDelegation() {}\n// delegation for the service endpoint"}); err != nil {
+		t.Fatalf("insert file: %v", err)
+	}
+
+	// Terms are matched independently of order and adjacency, which a phrase search would miss.
+	if results, err := db.SearchProviderResources("subnets virtual", "", 5); err != nil || len(results) != 1 {
+		t.Fatalf("expected resource match for out-of-order terms, got %v err=%v", results, err)
+	}
+	if count, err := db.CountProviderResources("network manages", ""); err != nil || count != 1 {
+		t.Fatalf("expected count 1, got %d err=%v", count, err)
+	}
+	if results, err := db.SearchProviderResources(`"network manages"`, "", 5); err != nil || len(results) != 0 {
+		t.Fatalf("expected explicit phrase to stay a phrase, got %v err=%v", results, err)
+	}
+	if files, err := db.SearchFiles("endpoint delegation", 5); err != nil || len(files) != 1 {
+		t.Fatalf("expected file match for multi-word query, got %d err=%v", len(files), err)
+	}
+	if repos, err := db.SearchRepositories("manager azure", 5); err != nil || len(repos) != 1 {
+		t.Fatalf("expected repository match for multi-word query, got %d err=%v", len(repos), err)
+	}
+
+	for _, query := range []string{`"`, "network*", "a:b", "-subnet", "NEAR(", "^start", "virtual + network"} {
+		if _, err := db.SearchProviderResources(query, "", 5); err != nil {
+			t.Fatalf("special-character query %q should not error: %v", query, err)
+		}
+		if _, err := db.SearchFiles(query, 5); err != nil {
+			t.Fatalf("special-character file query %q should not error: %v", query, err)
+		}
+	}
+}
+
+func TestFTS5BooleanQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+		ok    bool
+	}{
+		{"virtual network", "", false},
+		{"network OR subnet", `"network" OR "subnet"`, true},
+		{"azurerm_subnet NOT delegation", `"azurerm_subnet" NOT "delegation"`, true},
+		{`("virtual network" OR subnet) AND nsg`, `( "virtual network" OR "subnet" ) AND "nsg"`, true},
+		{"OR network AND", `"network"`, true},
+		{"(network OR", `( "network" )`, true},
+		{"AND OR", "", false},
+		{"kubernetes (cluster OR pool)", `"kubernetes" AND ( "cluster" OR "pool" )`, true},
+		{"( a ) ( b )", `( "a" ) AND ( "b" )`, true},
+		{"x(y)z", `"x" AND ( "y" ) AND "z"`, true},
+		{"NEAR(a b)", `"NEAR" AND ( "a" AND "b" )`, true},
+		{"a () b", `"a" AND "b"`, true},
+		{"a (", `"a"`, true},
+		{"(a OR b) NOT c", `( "a" OR "b" ) NOT "c"`, true},
+	}
+	db := newTestDB(t)
+	for _, tt := range tests {
+		got, ok := fts5BooleanQuery(tt.query)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("fts5BooleanQuery(%q) = %q, %v; want %q, %v", tt.query, got, ok, tt.want, tt.ok)
+		}
+		if !ok {
+			continue
+		}
+		var count int
+		if err := db.conn.QueryRow(`SELECT COUNT(*) FROM provider_resources_fts WHERE provider_resources_fts MATCH ?`, got).Scan(&count); err != nil {
+			t.Errorf("MATCH %q (from %q) failed: %v", got, tt.query, err)
+		}
+	}
 }
 
 func TestSearchProviderAttributesFilters(t *testing.T) {
@@ -168,6 +528,60 @@ func TestSearchProviderAttributesFilters(t *testing.T) {
 	if err != nil || len(results) != 1 || results[0].Attribute.Name != "opt" {
 		t.Fatalf("expected filtered optional attribute, got %+v err=%v", results, err)
 	}
+
+	count, err := db.CountProviderAttributes(AttributeSearchFilters{ResourcePrefix: "azurerm_", Limit: 1})
+	if err != nil || count != 2 {
+		t.Fatalf("expected count of 2 ignoring limit, got %d err=%v", count, err)
+	}
+}
+
+func TestSearchProviderAttributeDescriptions(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	storageID, _ := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_storage_account", Kind: "resource"})
+	appID, _ := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_linux_web_app", Kind: "resource"})
+	for _, attr := range []*ProviderAttribute{
+		{ResourceID: storageID, Name: "min_tls_version", Description: sql.NullString{String: "The minimum supported TLS version for the storage account.", Valid: true}},
+		{ResourceID: storageID, Name: "https_traffic_only_enabled", Description: sql.NullString{String: "Boolean flag which forces HTTPS if enabled.", Valid: true}},
+		{ResourceID: appID, Name: "minimum_tls_version", Description: sql.NullString{String: "The configures the minimum version of TLS required for SSL requests.", Valid: true}},
+		{ResourceID: appID, Name: "client_affinity_enabled", Description: sql.NullString{String: "Should Client Affinity be enabled?", Valid: true}},
+	} {
+		if err := db.InsertProviderAttribute(attr); err != nil {
+			t.Fatalf("insert attribute: %v", err)
+		}
+	}
+
+	names := func(query, prefix string) []string {
+		t.Helper()
+		results, err := db.SearchProviderAttributeDescriptions(query, prefix, 0)
+		if err != nil {
+			t.Fatalf("search %q: %v", query, err)
+		}
+		var out []string
+		for _, res := range results {
+			out = append(out, res.ResourceName+"."+res.Attribute.Name)
+		}
+		return out
+	}
+
+	got := names("the field that sets the TLS minimum version", "")
+	if len(got) < 2 || !strings.HasSuffix(got[0], "tls_version") || !strings.HasSuffix(got[1], "tls_version") {
+		t.Fatalf("expected TLS attributes ranked first, got %v", got)
+	}
+	if got := names("TLS version", "azurerm_linux_"); len(got) != 1 || got[0] != "azurerm_linux_web_app.minimum_tls_version" {
+		t.Fatalf("expected prefix to limit results, got %v", got)
+	}
+
+	// Upserts replace the indexed description rather than adding to it.
+	if err := db.InsertProviderAttribute(&ProviderAttribute{ResourceID: appID, Name: "client_affinity_enabled", Description: sql.NullString{String: "Sticky sessions for the app.", Valid: true}}); err != nil {
+		t.Fatalf("update attribute: %v", err)
+	}
+	if got := names("affinity", ""); len(got) != 1 || got[0] != "azurerm_linux_web_app.client_affinity_enabled" {
+		t.Fatalf("expected name match to survive the update, got %v", got)
+	}
+	if got := names("should", ""); len(got) != 0 {
+		t.Fatalf("expected replaced description to be dropped from the index, got %v", got)
+	}
 }
 
 func TestSearchFilesAndGetFile(t *testing.T) {
@@ -436,6 +850,26 @@ func TestGetProviderReleaseByTag(t *testing.T) {
 	}
 }
 
+func TestListReleasesMentioning(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+
+	older, _ := db.UpsertProviderRelease(&ProviderRelease{RepositoryID: repoID, Version: "1.0.0", Tag: "v1.0.0", ReleaseDate: sql.NullString{String: "2024-01-01", Valid: true}})
+	newer, _ := db.UpsertProviderRelease(&ProviderRelease{RepositoryID: repoID, Version: "1.1.0", Tag: "v1.1.0", ReleaseDate: sql.NullString{String: "2024-02-01", Valid: true}})
+	other, _ := db.UpsertProviderRelease(&ProviderRelease{RepositoryID: repoID, Version: "1.2.0", Tag: "v1.2.0", ReleaseDate: sql.NullString{String: "2024-03-01", Valid: true}})
+	_ = db.ReplaceReleaseEntries(older, []ProviderReleaseEntry{{Section: "FEATURES", EntryKey: "a", Title: "New Resource: azurerm_foo", ResourceName: sql.NullString{String: "azurerm_foo", Valid: true}}})
+	_ = db.ReplaceReleaseEntries(newer, []ProviderReleaseEntry{{Section: "BUG FIXES", EntryKey: "a", Title: "`azurerm_foo` - fix a crash"}})
+	_ = db.ReplaceReleaseEntries(other, []ProviderReleaseEntry{{Section: "BUG FIXES", EntryKey: "a", Title: "`azurerm_bar` - fix a crash"}})
+
+	releases, err := db.ListReleasesMentioning(repoID, "azurerm_foo")
+	if err != nil {
+		t.Fatalf("list releases: %v", err)
+	}
+	if len(releases) != 2 || releases[0].Version != "1.1.0" || releases[1].Version != "1.0.0" {
+		t.Fatalf("expected 1.1.0 then 1.0.0, got %+v", releases)
+	}
+}
+
 func TestGetReleaseWithEntriesByTag(t *testing.T) {
 	db := newTestDB(t)
 	repo := &Repository{Name: "terraform-provider-azurerm"}
@@ -544,6 +978,44 @@ func TestListProviderResourcesFilters(t *testing.T) {
 	}
 }
 
+func TestRemovedPlaceholdersSkippedByCounts(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	for _, r := range []*ProviderResource{
+		{RepositoryID: repoID, Name: "azurerm_mssql_server", Kind: "resource", RegistrationType: sql.NullString{String: "untyped", Valid: true}},
+		{RepositoryID: repoID, Name: "azurerm_mssql_server", Kind: "data_source", RegistrationType: sql.NullString{String: "untyped", Valid: true}},
+		{RepositoryID: repoID, Name: "azurerm_sql_server", Kind: "resource", VersionRemoved: sql.NullString{String: "4.0.0", Valid: true}, RegistrationType: sql.NullString{String: RegistrationRemoved, Valid: true}},
+	} {
+		if _, err := db.InsertProviderResource(r); err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+	}
+
+	overview, err := db.GetProviderOverview()
+	if err != nil {
+		t.Fatalf("overview: %v", err)
+	}
+	if overview.TotalDefinitions != 2 || overview.Kinds["resource"] != 1 || overview.WithoutAttributes != 2 {
+		t.Fatalf("expected placeholders left out of the overview, got %+v", overview)
+	}
+	if _, ok := overview.RegistrationTypes[RegistrationRemoved]; ok {
+		t.Fatalf("expected no removed registration bucket, got %+v", overview.RegistrationTypes)
+	}
+
+	counts, err := db.CountResourcesByRepository()
+	if err != nil || counts[repoID] != (RepositoryResourceCounts{Resources: 1, DataSources: 1}) {
+		t.Fatalf("expected live counts only, got %+v err=%v", counts, err)
+	}
+	if unresolved, err := db.ListUnresolvedResources(""); err != nil || len(unresolved) != 2 {
+		t.Fatalf("expected placeholders left out of unresolved definitions, got %+v err=%v", unresolved, err)
+	}
+
+	// Lookups by name still reach the placeholder so tools can report when it was removed.
+	if r, err := db.GetProviderResource("azurerm_sql_server"); err != nil || r.VersionRemoved.String != "4.0.0" {
+		t.Fatalf("expected placeholder lookup by name, got %+v err=%v", r, err)
+	}
+}
+
 func TestSearchProviderAttributesAdvancedFilters(t *testing.T) {
 	db := newTestDB(t)
 	repo := &Repository{Name: "terraform-provider-azurerm"}
@@ -555,12 +1027,14 @@ func TestSearchProviderAttributesAdvancedFilters(t *testing.T) {
 	attrs := []*ProviderAttribute{
 		{ResourceID: resID, Name: "sensitive_field", Sensitive: true},
 		{ResourceID: resID, Name: "force_new_field", ForceNew: true},
-		{ResourceID: resID, Name: "computed_field", Computed: true},
+		{ResourceID: resID, Name: "computed_field", Computed: true, Type: sql.NullString{Valid: true, String: "pluginsdk.TypeString"}},
 		{ResourceID: resID, Name: "deprecated_field", Deprecated: sql.NullString{Valid: true, String: "Use new_field instead"}},
 		{ResourceID: resID, Name: "nested_field", NestedBlock: true},
 		{ResourceID: resID, Name: "validated_field", Validation: sql.NullString{Valid: true, String: "StringLenBetween(1,255)"}},
 		{ResourceID: resID, Name: "diff_suppress_field", DiffSuppress: sql.NullString{Valid: true, String: "suppress.CaseDifference"}},
 		{ResourceID: resID, Name: "conflict_field", ConflictsWith: sql.NullString{Valid: true, String: "other_field"}},
+		{ResourceID: resID, Name: "string_field", Optional: true, Type: sql.NullString{Valid: true, String: "pluginsdk.TypeString"}},
+		{ResourceID: resID, Name: "int_field", Required: true, Type: sql.NullString{Valid: true, String: "schema.TypeInt"}},
 	}
 	for _, a := range attrs {
 		if err := db.InsertProviderAttribute(a); err != nil {
@@ -583,6 +1057,12 @@ func TestSearchProviderAttributesAdvancedFilters(t *testing.T) {
 		{"has diff suppress", AttributeSearchFilters{HasDiffSuppress: true, Limit: 10}, 1},
 		{"diff suppress contains", AttributeSearchFilters{DiffSuppressContains: "CaseDifference", Limit: 10}, 1},
 		{"conflicts with", AttributeSearchFilters{ConflictsWith: "other", Limit: 10}, 1},
+		{"missing validation", AttributeSearchFilters{MissingValidation: true, Limit: 20}, 9},
+		{"types", AttributeSearchFilters{Types: []string{"String", "Int"}, Limit: 10}, 3},
+		{"not computed", AttributeSearchFilters{NotFlags: []string{"computed"}, Limit: 20}, 9},
+		{"not deprecated", AttributeSearchFilters{NotFlags: []string{"deprecated"}, Limit: 20}, 9},
+		{"optional not computed", AttributeSearchFilters{Flags: []string{"optional"}, NotFlags: []string{"computed"}, Limit: 10}, 1},
+		{"configurable unvalidated", AttributeSearchFilters{MissingValidation: true, Types: []string{"String", "Int"}, ConfigurableOnly: true, Limit: 10}, 2},
 	}
 
 	for _, tt := range tests {
@@ -880,3 +1360,192 @@ func TestIntegrationFullWorkflow(t *testing.T) {
 		t.Error("repository should still exist after clear")
 	}
 }
+
+func TestNewMigratesMissingColumns(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	conn, err := sql.Open("sqlite3", dbPath)
+	if err != nil {
+		t.Fatalf("open legacy db: %v", err)
+	}
+	if _, err := conn.Exec(`CREATE TABLE provider_resources (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_id INTEGER NOT NULL,
+		service_id INTEGER,
+		name TEXT NOT NULL,
+		display_name TEXT,
+		kind TEXT NOT NULL,
+		file_path TEXT,
+		description TEXT,
+		deprecation_message TEXT,
+		version_added TEXT,
+		version_removed TEXT,
+		breaking_changes TEXT,
+		api_version TEXT,
+		UNIQUE(repository_id, name, kind)
+	)`); err != nil {
+		t.Fatalf("create legacy table: %v", err)
+	}
+	conn.Close()
+
+	db, err := New(dbPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5") {
+			t.Skipf("sqlite build without fts5: %v", err)
+		}
+		t.Fatalf("open with migrations: %v", err)
+	}
+	defer db.Close()
+
+	repoID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	if _, err := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_example", Kind: "resource", RegistrationType: sql.NullString{String: "typed", Valid: true}}); err != nil {
+		t.Fatalf("insert resource after migration: %v", err)
+	}
+	res, err := db.GetProviderResource("azurerm_example")
+	if err != nil || res.RegistrationType.String != "typed" {
+		t.Fatalf("expected migrated registration_type column, got %+v err=%v", res, err)
+	}
+}
+
+func TestNewBackfillsAttributeFTS(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	db, err := New(dbPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5") {
+			t.Skipf("sqlite build without fts5: %v", err)
+		}
+		t.Fatalf("open db: %v", err)
+	}
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	resID, _ := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_example", Kind: "resource"})
+	if err := db.InsertProviderAttribute(&ProviderAttribute{ResourceID: resID, Name: "sku", Description: sql.NullString{String: "The pricing tier.", Valid: true}}); err != nil {
+		t.Fatalf("insert attribute: %v", err)
+	}
+	// Simulate an index created before provider_attributes_fts existed.
+	if _, err := db.conn.Exec(`DROP TABLE provider_attributes_fts`); err != nil {
+		t.Fatalf("drop fts table: %v", err)
+	}
+	db.Close()
+
+	db, err = New(dbPath)
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	results, err := db.SearchProviderAttributeDescriptions("pricing", "", 10)
+	if err != nil || len(results) != 1 || results[0].Attribute.Name != "sku" {
+		t.Fatalf("expected backfilled attribute, got %+v err=%v", results, err)
+	}
+}
+
+func TestInsertRepositoryUpdatesFTS(t *testing.T) {
+	db := newTestDB(t)
+	repo := &Repository{Name: "terraform-provider-azurerm", FullName: "hashicorp/terraform-provider-azurerm", RepoURL: "https://github.com/hashicorp/terraform-provider-azurerm"}
+	if _, err := db.InsertRepository(repo); err != nil {
+		t.Fatalf("insert repository: %v", err)
+	}
+	repo.ReadmeContent = "Terraform provider for Azure Resource Manager"
+	if _, err := db.InsertRepository(repo); err != nil {
+		t.Fatalf("update repository: %v", err)
+	}
+
+	got, err := db.GetRepository(repo.Name)
+	if err != nil || got.ReadmeContent != repo.ReadmeContent {
+		t.Fatalf("expected README stored, got %+v err=%v", got, err)
+	}
+	results, err := db.SearchRepositories("Manager", 10)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("expected README indexed, got %+v err=%v", results, err)
+	}
+}
+
+func TestNewUpgradesLegacyFTSTriggers(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "legacy.db")
+	db, err := New(dbPath)
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5") {
+			t.Skipf("sqlite build without fts5: %v", err)
+		}
+		t.Fatalf("open db: %v", err)
+	}
+	// Simulate an index created with the plain UPDATE/DELETE triggers.
+	if _, err := db.conn.Exec(`
+		DROP TRIGGER repositories_fts_update;
+		DROP TRIGGER repositories_fts_delete;
+		CREATE TRIGGER repositories_fts_update AFTER UPDATE ON repositories BEGIN
+			UPDATE repositories_fts SET readme_content = new.readme_content WHERE rowid = new.id;
+		END;
+		CREATE TRIGGER repositories_fts_delete AFTER DELETE ON repositories BEGIN
+			DELETE FROM repositories_fts WHERE rowid = old.id;
+		END;
+	`); err != nil {
+		t.Fatalf("install legacy triggers: %v", err)
+	}
+	db.Close()
+
+	db, err = New(dbPath)
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	for _, trigger := range []string{"repositories_fts_update", "repositories_fts_delete"} {
+		var definition string
+		if err := db.conn.QueryRow(`SELECT sql FROM sqlite_master WHERE name = ?`, trigger).Scan(&definition); err != nil {
+			t.Fatalf("load %s: %v", trigger, err)
+		}
+		if !strings.Contains(definition, "'delete'") {
+			t.Fatalf("expected %s to be recreated, got %s", trigger, definition)
+		}
+	}
+}
+
+func TestListResourcesWithAttributes(t *testing.T) {
+	db := newTestDB(t)
+	repoID, _ := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	insert := func(name, kind string, attrs ...string) {
+		t.Helper()
+		id, err := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: name, Kind: kind})
+		if err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+		for _, attr := range attrs {
+			if err := db.InsertProviderAttribute(&ProviderAttribute{ResourceID: id, Name: attr}); err != nil {
+				t.Fatalf("insert attribute: %v", err)
+			}
+		}
+	}
+	insert("azurerm_storage_account", "resource", "name", "tags", "identity")
+	insert("azurerm_storage_account", "data_source", "name", "tags", "identity")
+	insert("azurerm_key_vault", "resource", "name", "tags")
+	insert("azurerm_user_assigned_identity", "resource", "name", "identity")
+
+	names := func(attrs []string, kind, prefix string) []string {
+		t.Helper()
+		resources, err := db.ListResourcesWithAttributes(attrs, kind, prefix)
+		if err != nil {
+			t.Fatalf("list %v: %v", attrs, err)
+		}
+		var out []string
+		for _, r := range resources {
+			out = append(out, r.Name+"/"+r.Kind)
+		}
+		return out
+	}
+
+	if got := names([]string{"tags", "identity"}, "", ""); !slices.Equal(got, []string{"azurerm_storage_account/data_source", "azurerm_storage_account/resource"}) {
+		t.Fatalf("unexpected matches: %v", got)
+	}
+	if got := names([]string{"tags", "identity"}, "resource", ""); !slices.Equal(got, []string{"azurerm_storage_account/resource"}) {
+		t.Fatalf("unexpected kind-filtered matches: %v", got)
+	}
+	if got := names([]string{"name"}, "resource", "azurerm_key"); !slices.Equal(got, []string{"azurerm_key_vault/resource"}) {
+		t.Fatalf("unexpected prefix-filtered matches: %v", got)
+	}
+	if got := names([]string{"tags", "missing"}, "", ""); len(got) != 0 {
+		t.Fatalf("expected no matches when one attribute is absent, got %v", got)
+	}
+}
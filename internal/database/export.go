@@ -0,0 +1,65 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportedResource is the NDJSON shape written by ExportResources, one line per definition.
+type ExportedResource struct {
+	Name               string            `json:"name"`
+	Kind               string            `json:"kind"`
+	DisplayName        string            `json:"display_name,omitempty"`
+	FilePath           string            `json:"file_path,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	DeprecationMessage string            `json:"deprecation_message,omitempty"`
+	VersionAdded       string            `json:"version_added,omitempty"`
+	VersionRemoved     string            `json:"version_removed,omitempty"`
+	APIVersion         string            `json:"api_version,omitempty"`
+	RegistrationType   string            `json:"registration_type,omitempty"`
+	Attributes         []NestedAttribute `json:"attributes"`
+}
+
+// ExportResources streams every indexed resource (optionally filtered by kind) with its attributes
+// to w as newline-delimited JSON and returns the number of lines written.
+func (db *DB) ExportResources(w io.Writer, kind string) (int, error) {
+	resources, err := db.ListProviderResources(kind, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list resources: %w", err)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	written := 0
+	for _, r := range resources {
+		attrs, err := db.GetProviderResourceAttributes(r.ID)
+		if err != nil {
+			return written, fmt.Errorf("failed to load attributes for %s: %w", r.Name, err)
+		}
+
+		exported := ExportedResource{
+			Name:               r.Name,
+			Kind:               r.Kind,
+			DisplayName:        r.DisplayName.String,
+			FilePath:           r.FilePath.String,
+			Description:        r.Description.String,
+			DeprecationMessage: r.DeprecationMessage.String,
+			VersionAdded:       r.VersionAdded.String,
+			VersionRemoved:     r.VersionRemoved.String,
+			APIVersion:         r.APIVersion.String,
+			RegistrationType:   r.RegistrationType.String,
+			Attributes:         make([]NestedAttribute, 0, len(attrs)),
+		}
+		for _, attr := range attrs {
+			exported.Attributes = append(exported.Attributes, NestedAttributeFromProvider(attr))
+		}
+
+		if err := enc.Encode(exported); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", r.Name, err)
+		}
+		written++
+	}
+	return written, nil
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportResources(t *testing.T) {
+	db := newTestDB(t)
+	repoID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	for _, r := range []*ProviderResource{
+		{RepositoryID: repoID, Name: "azurerm_storage_account", Kind: "resource"},
+		{RepositoryID: repoID, Name: "azurerm_storage_account", Kind: "data_source"},
+	} {
+		id, err := db.InsertProviderResource(r)
+		if err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+		if err := db.InsertProviderAttribute(&ProviderAttribute{
+			ResourceID:  id,
+			Name:        "name",
+			Required:    true,
+			Description: sql.NullString{String: "The <name> of the account.", Valid: true},
+		}); err != nil {
+			t.Fatalf("insert attribute: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := db.ExportResources(&buf, "resource")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if n != 1 || len(lines) != 1 {
+		t.Fatalf("expected one exported resource, got n=%d lines=%q", n, lines)
+	}
+	if !strings.Contains(lines[0], "The <name> of the account.") {
+		t.Fatalf("expected unescaped description, got %s", lines[0])
+	}
+
+	var exported ExportedResource
+	if err := json.Unmarshal([]byte(lines[0]), &exported); err != nil {
+		t.Fatalf("decode line: %v", err)
+	}
+	if exported.Name != "azurerm_storage_account" || exported.Kind != "resource" || len(exported.Attributes) != 1 || !exported.Attributes[0].Required {
+		t.Fatalf("unexpected export: %+v", exported)
+	}
+
+	buf.Reset()
+	if n, err := db.ExportResources(&buf, ""); err != nil || n != 2 {
+		t.Fatalf("expected both kinds exported, got n=%d err=%v", n, err)
+	}
+}
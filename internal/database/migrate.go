@@ -0,0 +1,99 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func migrateColumns(conn *sql.DB) error {
+	for _, m := range columnMigrations {
+		exists, err := columnExists(conn, m.table, m.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := conn.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.definition)); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", m.table, m.column, err)
+		}
+	}
+	return nil
+}
+
+// missingTables returns the tables among names that do not exist yet.
+func missingTables(conn *sql.DB, names []string) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		var count int
+		if err := conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = ?`, name).Scan(&count); err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// dropLegacyFTSTriggers drops the update and delete triggers of the ftsTriggerUpgrades tables that
+// predate 'delete' commands, so Schema recreates them. It returns the tables whose index must be
+// rebuilt because the old triggers may have left it inconsistent.
+func dropLegacyFTSTriggers(conn *sql.DB) ([]string, error) {
+	var stale []string
+	for _, table := range ftsTriggerUpgrades {
+		var definition string
+		err := conn.QueryRow(`SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = ?`, table+"_delete").Scan(&definition)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && strings.Contains(definition, "'delete'")) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, trigger := range []string{table + "_update", table + "_delete"} {
+			if _, err := conn.Exec("DROP TRIGGER IF EXISTS " + trigger); err != nil {
+				return nil, fmt.Errorf("failed to drop %s: %w", trigger, err)
+			}
+		}
+		stale = append(stale, table)
+	}
+	return stale, nil
+}
+
+// rebuildFTS repopulates external-content FTS tables from their content tables.
+func rebuildFTS(conn *sql.DB, tables []string) error {
+	for _, table := range tables {
+		if _, err := conn.Exec(fmt.Sprintf("INSERT INTO %s(%s) VALUES('rebuild')", table, table)); err != nil {
+			return fmt.Errorf("failed to rebuild %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func columnExists(conn *sql.DB, table, column string) (bool, error) {
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			colType    string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
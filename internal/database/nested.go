@@ -0,0 +1,110 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+)
+
+// NestedAttribute is the JSON shape persisted in elem_schema_json for the children of a nested block.
+type NestedAttribute struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type,omitempty"`
+	Required      bool              `json:"required,omitempty"`
+	Optional      bool              `json:"optional,omitempty"`
+	Computed      bool              `json:"computed,omitempty"`
+	ForceNew      bool              `json:"force_new,omitempty"`
+	Sensitive     bool              `json:"sensitive,omitempty"`
+	Deprecated    string            `json:"deprecated,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	ConflictsWith string            `json:"conflicts_with,omitempty"`
+	ExactlyOneOf  string            `json:"exactly_one_of,omitempty"`
+	AtLeastOneOf  string            `json:"at_least_one_of,omitempty"`
+	MaxItems      int64             `json:"max_items,omitempty"`
+	MinItems      int64             `json:"min_items,omitempty"`
+	Validation    string            `json:"validation,omitempty"`
+	NestedBlock   bool              `json:"nested_block,omitempty"`
+	Children      []NestedAttribute `json:"children,omitempty"`
+}
+
+// NestedAttributeFromProvider converts a stored attribute (and its nested schema, if any) into a NestedAttribute tree.
+func NestedAttributeFromProvider(a ProviderAttribute) NestedAttribute {
+	return NestedAttribute{
+		Name:          a.Name,
+		Type:          a.Type.String,
+		Required:      a.Required,
+		Optional:      a.Optional,
+		Computed:      a.Computed,
+		ForceNew:      a.ForceNew,
+		Sensitive:     a.Sensitive,
+		Deprecated:    a.Deprecated.String,
+		Description:   a.Description.String,
+		ConflictsWith: a.ConflictsWith.String,
+		ExactlyOneOf:  a.ExactlyOneOf.String,
+		AtLeastOneOf:  a.AtLeastOneOf.String,
+		MaxItems:      a.MaxItems.Int64,
+		MinItems:      a.MinItems.Int64,
+		Validation:    a.Validation.String,
+		NestedBlock:   a.NestedBlock,
+		Children:      DecodeNestedSchema(a.ElemSchemaJSON.String),
+	}
+}
+
+// ProviderAttributeFromNested is the inverse of NestedAttributeFromProvider; children are
+// re-encoded into ElemSchemaJSON.
+func ProviderAttributeFromNested(n NestedAttribute) ProviderAttribute {
+	attr := ProviderAttribute{
+		Name:          n.Name,
+		Type:          nullString(n.Type),
+		Required:      n.Required,
+		Optional:      n.Optional,
+		Computed:      n.Computed,
+		ForceNew:      n.ForceNew,
+		Sensitive:     n.Sensitive,
+		Deprecated:    nullString(n.Deprecated),
+		Description:   nullString(n.Description),
+		ConflictsWith: nullString(n.ConflictsWith),
+		ExactlyOneOf:  nullString(n.ExactlyOneOf),
+		AtLeastOneOf:  nullString(n.AtLeastOneOf),
+		Validation:    nullString(n.Validation),
+		NestedBlock:   n.NestedBlock,
+	}
+	if n.MaxItems > 0 {
+		attr.MaxItems = sql.NullInt64{Int64: n.MaxItems, Valid: true}
+	}
+	if n.MinItems > 0 {
+		attr.MinItems = sql.NullInt64{Int64: n.MinItems, Valid: true}
+	}
+	if encoded := EncodeNestedSchema(n.Children); encoded != "" {
+		attr.ElemSchemaJSON = sql.NullString{String: encoded, Valid: true}
+	}
+	return attr
+}
+
+// EncodeNestedSchema serialises nested block children for storage; it returns "" when there is nothing to store.
+func EncodeNestedSchema(children []NestedAttribute) string {
+	if len(children) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(children)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// DecodeNestedSchema parses elem_schema_json, returning nil for empty or malformed input.
+func DecodeNestedSchema(raw string) []NestedAttribute {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	var children []NestedAttribute
+	if err := json.Unmarshal([]byte(raw), &children); err != nil {
+		return nil
+	}
+	return children
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// ContentHash returns the hash stored in parse_cache for a file's content.
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseCacheSummary aggregates the parse_cache table.
+type ParseCacheSummary struct {
+	Files          int
+	Resources      int
+	Attributes     int
+	LastParsedAt   time.Time // zero when the cache is empty
+	IndexedGoFiles int
+}
+
+// StaleParseCacheEntry is a cached file whose stored content no longer matches the cached hash,
+// or which is no longer part of the index at all.
+type StaleParseCacheEntry struct {
+	Entry   ParseCacheEntry
+	Missing bool
+}
+
+// ListParseCacheEntries returns every parse cache entry keyed by file path.
+func (db *DB) ListParseCacheEntries() (map[string]ParseCacheEntry, error) {
+	rows, err := db.conn.Query(`
+		SELECT file_path, content_hash, parsed_at, COALESCE(resource_count, 0), COALESCE(attribute_count, 0),
+			COALESCE(symbol_count, -1)
+		FROM parse_cache
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make(map[string]ParseCacheEntry)
+	for rows.Next() {
+		var entry ParseCacheEntry
+		if err := rows.Scan(&entry.FilePath, &entry.ContentHash, &entry.ParsedAt,
+			&entry.ResourceCount, &entry.AttributeCount, &entry.SymbolCount); err != nil {
+			return nil, err
+		}
+		entries[entry.FilePath] = entry
+	}
+	return entries, rows.Err()
+}
+
+// GetParseCacheSummary reports how many files are cached, the resource and attribute counts they
+// produced, and when parsing last happened.
+func (db *DB) GetParseCacheSummary() (*ParseCacheSummary, error) {
+	var summary ParseCacheSummary
+	if err := db.conn.QueryRow(`
+		SELECT COUNT(*), COALESCE(SUM(resource_count), 0), COALESCE(SUM(attribute_count), 0)
+		FROM parse_cache
+	`).Scan(&summary.Files, &summary.Resources, &summary.Attributes); err != nil {
+		return nil, err
+	}
+
+	if err := db.conn.QueryRow(`
+		SELECT COUNT(*) FROM repository_files WHERE file_type = 'go'
+	`).Scan(&summary.IndexedGoFiles); err != nil {
+		return nil, err
+	}
+
+	if summary.Files == 0 {
+		return &summary, nil
+	}
+
+	// MAX() loses the DATETIME column type, so the latest row is read directly.
+	if err := db.conn.QueryRow(`
+		SELECT parsed_at FROM parse_cache ORDER BY parsed_at DESC LIMIT 1
+	`).Scan(&summary.LastParsedAt); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// ListStaleParseCacheEntries compares every cached hash with the hash of the file content currently
+// stored in repository_files and returns the entries that differ or whose file is gone.
+func (db *DB) ListStaleParseCacheEntries() ([]StaleParseCacheEntry, error) {
+	rows, err := db.conn.Query(`
+		SELECT pc.file_path, pc.content_hash, pc.parsed_at,
+			COALESCE(pc.resource_count, 0), COALESCE(pc.attribute_count, 0), f.content
+		FROM parse_cache pc
+		LEFT JOIN repository_files f ON f.file_path = pc.file_path
+		ORDER BY pc.file_path
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stale []StaleParseCacheEntry
+	for rows.Next() {
+		var entry ParseCacheEntry
+		var content sql.NullString
+		if err := rows.Scan(&entry.FilePath, &entry.ContentHash, &entry.ParsedAt,
+			&entry.ResourceCount, &entry.AttributeCount, &content); err != nil {
+			return nil, err
+		}
+		switch {
+		case !content.Valid:
+			stale = append(stale, StaleParseCacheEntry{Entry: entry, Missing: true})
+		case ContentHash(content.String) != entry.ContentHash:
+			stale = append(stale, StaleParseCacheEntry{Entry: entry})
+		}
+	}
+	return stale, rows.Err()
+}
@@ -0,0 +1,65 @@
+package database
+
+import "testing"
+
+func TestParseCacheSummaryAndStaleEntries(t *testing.T) {
+	db := newTestDB(t)
+
+	summary, err := db.GetParseCacheSummary()
+	if err != nil {
+		t.Fatalf("empty summary: %v", err)
+	}
+	if summary.Files != 0 || !summary.LastParsedAt.IsZero() {
+		t.Fatalf("expected empty summary, got %+v", summary)
+	}
+
+	repoID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	files := map[string]string{
+		"internal/services/network/virtual_network_resource.go": "package network",
+		"internal/services/storage/storage_account_resource.go": "package storage // edited",
+	}
+	for path, content := range files {
+		if err := db.InsertFile(&RepositoryFile{RepositoryID: repoID, FileName: path, FilePath: path, FileType: "go", Content: content}); err != nil {
+			t.Fatalf("insert file: %v", err)
+		}
+	}
+
+	entries := []ParseCacheEntry{
+		{FilePath: "internal/services/network/virtual_network_resource.go", ContentHash: ContentHash("package network"), ResourceCount: 1, AttributeCount: 10},
+		{FilePath: "internal/services/storage/storage_account_resource.go", ContentHash: ContentHash("package storage"), ResourceCount: 2, AttributeCount: 30},
+		{FilePath: "internal/services/legacy/removed_resource.go", ContentHash: ContentHash("package legacy"), ResourceCount: 1, AttributeCount: 5},
+	}
+	for i := range entries {
+		if err := db.UpsertParseCacheEntry(&entries[i]); err != nil {
+			t.Fatalf("upsert cache: %v", err)
+		}
+	}
+
+	summary, err = db.GetParseCacheSummary()
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if summary.Files != 3 || summary.Resources != 4 || summary.Attributes != 45 || summary.IndexedGoFiles != 2 {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if summary.LastParsedAt.IsZero() {
+		t.Fatalf("expected last parse time")
+	}
+
+	stale, err := db.ListStaleParseCacheEntries()
+	if err != nil {
+		t.Fatalf("stale entries: %v", err)
+	}
+	if len(stale) != 2 {
+		t.Fatalf("expected 2 stale entries, got %+v", stale)
+	}
+	if stale[0].Entry.FilePath != "internal/services/legacy/removed_resource.go" || !stale[0].Missing {
+		t.Fatalf("expected removed file reported missing, got %+v", stale[0])
+	}
+	if stale[1].Entry.FilePath != "internal/services/storage/storage_account_resource.go" || stale[1].Missing {
+		t.Fatalf("expected edited file reported as changed, got %+v", stale[1])
+	}
+}
@@ -9,7 +9,8 @@ CREATE TABLE IF NOT EXISTS repositories (
     repo_url TEXT NOT NULL,
     last_updated TEXT,
     synced_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-    readme_content TEXT
+    readme_content TEXT,
+    parse_failures TEXT
 );
 
 CREATE TABLE IF NOT EXISTS repository_files (
@@ -53,15 +54,15 @@ CREATE TRIGGER IF NOT EXISTS repositories_fts_insert AFTER INSERT ON repositorie
 END;
 
 CREATE TRIGGER IF NOT EXISTS repositories_fts_update AFTER UPDATE ON repositories BEGIN
-    UPDATE repositories_fts
-    SET name = new.name,
-        description = new.description,
-        readme_content = new.readme_content
-    WHERE rowid = new.id;
+    INSERT INTO repositories_fts(repositories_fts, rowid, name, description, readme_content)
+    VALUES ('delete', old.id, old.name, old.description, old.readme_content);
+    INSERT INTO repositories_fts(rowid, name, description, readme_content)
+    VALUES (new.id, new.name, new.description, new.readme_content);
 END;
 
 CREATE TRIGGER IF NOT EXISTS repositories_fts_delete AFTER DELETE ON repositories BEGIN
-    DELETE FROM repositories_fts WHERE rowid = old.id;
+    INSERT INTO repositories_fts(repositories_fts, rowid, name, description, readme_content)
+    VALUES ('delete', old.id, old.name, old.description, old.readme_content);
 END;
 
 -- Triggers to keep file FTS in sync
@@ -71,15 +72,15 @@ CREATE TRIGGER IF NOT EXISTS repository_files_fts_insert AFTER INSERT ON reposit
 END;
 
 CREATE TRIGGER IF NOT EXISTS repository_files_fts_update AFTER UPDATE ON repository_files BEGIN
-    UPDATE repository_files_fts
-    SET file_name = new.file_name,
-        file_path = new.file_path,
-        content = new.content
-    WHERE rowid = new.id;
+    INSERT INTO repository_files_fts(repository_files_fts, rowid, file_name, file_path, content)
+    VALUES ('delete', old.id, old.file_name, old.file_path, old.content);
+    INSERT INTO repository_files_fts(rowid, file_name, file_path, content)
+    VALUES (new.id, new.file_name, new.file_path, new.content);
 END;
 
 CREATE TRIGGER IF NOT EXISTS repository_files_fts_delete AFTER DELETE ON repository_files BEGIN
-    DELETE FROM repository_files_fts WHERE rowid = old.id;
+    INSERT INTO repository_files_fts(repository_files_fts, rowid, file_name, file_path, content)
+    VALUES ('delete', old.id, old.file_name, old.file_path, old.content);
 END;
 
 CREATE TABLE IF NOT EXISTS provider_services (
@@ -110,6 +111,8 @@ CREATE TABLE IF NOT EXISTS provider_resources (
     version_removed TEXT,
     breaking_changes TEXT,
     api_version TEXT,
+    registration_type TEXT,
+    unresolved_reason TEXT,
     FOREIGN KEY (repository_id) REFERENCES repositories(id) ON DELETE CASCADE,
     FOREIGN KEY (service_id) REFERENCES provider_services(id) ON DELETE SET NULL,
     UNIQUE(repository_id, name, kind)
@@ -132,15 +135,15 @@ CREATE TRIGGER IF NOT EXISTS provider_resources_fts_insert AFTER INSERT ON provi
 END;
 
 CREATE TRIGGER IF NOT EXISTS provider_resources_fts_update AFTER UPDATE ON provider_resources BEGIN
-    UPDATE provider_resources_fts
-    SET name = new.name,
-        description = new.description,
-        breaking_changes = new.breaking_changes
-    WHERE rowid = new.id;
+    INSERT INTO provider_resources_fts(provider_resources_fts, rowid, name, description, breaking_changes)
+    VALUES ('delete', old.id, old.name, old.description, old.breaking_changes);
+    INSERT INTO provider_resources_fts(rowid, name, description, breaking_changes)
+    VALUES (new.id, new.name, new.description, new.breaking_changes);
 END;
 
 CREATE TRIGGER IF NOT EXISTS provider_resources_fts_delete AFTER DELETE ON provider_resources BEGIN
-    DELETE FROM provider_resources_fts WHERE rowid = old.id;
+    INSERT INTO provider_resources_fts(provider_resources_fts, rowid, name, description, breaking_changes)
+    VALUES ('delete', old.id, old.name, old.description, old.breaking_changes);
 END;
 
 CREATE TABLE IF NOT EXISTS provider_resource_attributes (
@@ -175,6 +178,31 @@ CREATE TABLE IF NOT EXISTS provider_resource_attributes (
     UNIQUE(resource_id, name)
 );
 
+CREATE VIRTUAL TABLE IF NOT EXISTS provider_attributes_fts USING fts5(
+    name,
+    description,
+    content='provider_resource_attributes',
+    content_rowid='id'
+);
+
+-- External-content FTS5 tables need the old values to drop index entries, hence the 'delete' commands.
+CREATE TRIGGER IF NOT EXISTS provider_attributes_fts_insert AFTER INSERT ON provider_resource_attributes BEGIN
+    INSERT INTO provider_attributes_fts(rowid, name, description)
+    VALUES (new.id, new.name, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS provider_attributes_fts_update AFTER UPDATE ON provider_resource_attributes BEGIN
+    INSERT INTO provider_attributes_fts(provider_attributes_fts, rowid, name, description)
+    VALUES ('delete', old.id, old.name, old.description);
+    INSERT INTO provider_attributes_fts(rowid, name, description)
+    VALUES (new.id, new.name, new.description);
+END;
+
+CREATE TRIGGER IF NOT EXISTS provider_attributes_fts_delete AFTER DELETE ON provider_resource_attributes BEGIN
+    INSERT INTO provider_attributes_fts(provider_attributes_fts, rowid, name, description)
+    VALUES ('delete', old.id, old.name, old.description);
+END;
+
 CREATE INDEX IF NOT EXISTS idx_provider_attr_resource ON provider_resource_attributes(resource_id);
 CREATE INDEX IF NOT EXISTS idx_provider_attr_name_lower ON provider_resource_attributes(LOWER(name));
 CREATE INDEX IF NOT EXISTS idx_provider_attr_force_new ON provider_resource_attributes(force_new) WHERE force_new = 1;
@@ -192,6 +220,12 @@ CREATE TABLE IF NOT EXISTS provider_resource_sources (
     timeouts_json TEXT,
     state_upgraders TEXT,
     importer_snippet TEXT,
+    function_start_line INTEGER,
+    function_end_line INTEGER,
+    schema_start_line INTEGER,
+    schema_end_line INTEGER,
+    schema_attribute_lines TEXT,
+    sdk_packages TEXT,
     FOREIGN KEY (resource_id) REFERENCES provider_resources(id) ON DELETE CASCADE
 );
 
@@ -234,12 +268,59 @@ CREATE TABLE IF NOT EXISTS provider_release_entries (
 CREATE INDEX IF NOT EXISTS idx_release_entries_release ON provider_release_entries(release_id);
 CREATE INDEX IF NOT EXISTS idx_release_entries_identifier ON provider_release_entries(identifier);
 
+-- Per-release schema snapshots recorded by versioned (--ref) syncs; kept across resyncs
+CREATE TABLE IF NOT EXISTS provider_schema_snapshots (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL,
+    version TEXT NOT NULL,
+    resource_name TEXT NOT NULL,
+    resource_kind TEXT NOT NULL,
+    attributes_json TEXT NOT NULL,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (repository_id) REFERENCES repositories(id) ON DELETE CASCADE,
+    UNIQUE(repository_id, version, resource_name, resource_kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_schema_snapshots_resource ON provider_schema_snapshots(resource_name, version);
+
 -- Parse cache for incremental parsing
 CREATE TABLE IF NOT EXISTS parse_cache (
     file_path TEXT PRIMARY KEY,
     content_hash TEXT NOT NULL,
     parsed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
     resource_count INTEGER,
-    attribute_count INTEGER
+    attribute_count INTEGER,
+    symbol_count INTEGER
 );
 `
+
+type columnMigration struct {
+	table      string
+	column     string
+	definition string
+}
+
+// columnMigrations lists columns added after a table was first created. CREATE TABLE IF NOT EXISTS
+// leaves existing databases untouched, so these are applied with ALTER TABLE when missing.
+var columnMigrations = []columnMigration{
+	{table: "provider_resources", column: "registration_type", definition: "TEXT"},
+	{table: "provider_resource_sources", column: "function_start_line", definition: "INTEGER"},
+	{table: "provider_resource_sources", column: "function_end_line", definition: "INTEGER"},
+	{table: "provider_resource_sources", column: "schema_start_line", definition: "INTEGER"},
+	{table: "provider_resource_sources", column: "schema_end_line", definition: "INTEGER"},
+	{table: "parse_cache", column: "symbol_count", definition: "INTEGER"},
+	{table: "provider_resource_sources", column: "schema_attribute_lines", definition: "TEXT"},
+	{table: "provider_resources", column: "unresolved_reason", definition: "TEXT"},
+	{table: "repositories", column: "parse_failures", definition: "TEXT"},
+	{table: "provider_resource_sources", column: "sdk_packages", definition: "TEXT"},
+}
+
+// ftsBackfills lists FTS tables added after their content table. A database that predates one gets
+// the empty table from Schema, so it is rebuilt from the content table once, right after creation.
+var ftsBackfills = []string{"provider_attributes_fts"}
+
+// ftsTriggerUpgrades lists FTS tables whose update and delete triggers once issued plain UPDATE and
+// DELETE statements. External-content FTS5 tables cannot drop index entries that way, which left
+// stale tokens or failed with "database disk image is malformed"; the triggers now pass the old
+// values through 'delete' commands.
+var ftsTriggerUpgrades = []string{"repositories_fts", "repository_files_fts", "provider_resources_fts"}
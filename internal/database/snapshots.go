@@ -0,0 +1,118 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// SnapshotVersion summarises the schema snapshot recorded for one release.
+type SnapshotVersion struct {
+	Version   string
+	Resources int
+}
+
+// ReplaceSchemaSnapshot stores the attribute set of a definition as parsed at version, replacing
+// any earlier snapshot for the same version.
+func (db *DB) ReplaceSchemaSnapshot(repositoryID int64, version, name, kind string, attrs []ProviderAttribute) error {
+	nodes := make([]NestedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		nodes = append(nodes, NestedAttributeFromProvider(attr))
+	}
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot for %s: %w", name, err)
+	}
+
+	_, err = db.conn.Exec(`
+		INSERT INTO provider_schema_snapshots (repository_id, version, resource_name, resource_kind, attributes_json)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(repository_id, version, resource_name, resource_kind) DO UPDATE SET
+			attributes_json = excluded.attributes_json,
+			created_at = CURRENT_TIMESTAMP
+	`, repositoryID, version, name, kind, string(data))
+	return err
+}
+
+// GetSnapshotAttributes returns the attributes of a repository's definition recorded at version,
+// or sql.ErrNoRows when that version was never snapshotted.
+func (db *DB) GetSnapshotAttributes(repositoryID int64, name, kind, version string) ([]ProviderAttribute, error) {
+	var raw string
+	err := db.conn.QueryRow(`
+		SELECT attributes_json
+		FROM provider_schema_snapshots
+		WHERE repository_id = ? AND resource_name = ? AND resource_kind = ? AND version = ?
+	`, repositoryID, name, kind, version).Scan(&raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []NestedAttribute
+	if err := json.Unmarshal([]byte(raw), &nodes); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot for %s@%s: %w", name, version, err)
+	}
+	attrs := make([]ProviderAttribute, 0, len(nodes))
+	for _, node := range nodes {
+		attrs = append(attrs, ProviderAttributeFromNested(node))
+	}
+	return attrs, nil
+}
+
+// GetResourceAttributesAtVersion returns the snapshotted attributes of the indexed definition
+// resourceID as they were at version.
+func (db *DB) GetResourceAttributesAtVersion(resourceID int64, version string) ([]ProviderAttribute, error) {
+	var repositoryID int64
+	var name, kind string
+	if err := db.conn.QueryRow(`SELECT repository_id, name, kind FROM provider_resources WHERE id = ?`, resourceID).Scan(&repositoryID, &name, &kind); err != nil {
+		return nil, err
+	}
+	return db.GetSnapshotAttributes(repositoryID, name, kind, version)
+}
+
+// ListSnapshotVersions lists the versions with recorded schema snapshots, newest first.
+func (db *DB) ListSnapshotVersions(repositoryID int64) ([]SnapshotVersion, error) {
+	rows, err := db.conn.Query(`
+		SELECT version, COUNT(*)
+		FROM provider_schema_snapshots
+		WHERE repository_id = ?
+		GROUP BY version
+		ORDER BY MAX(created_at) DESC, version DESC
+	`, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []SnapshotVersion
+	for rows.Next() {
+		var v SnapshotVersion
+		if err := rows.Scan(&v.Version, &v.Resources); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// ListResourceSnapshotVersions lists the versions at which the repository's named definition was
+// snapshotted. Versions are returned in storage order; callers sort them semantically.
+func (db *DB) ListResourceSnapshotVersions(repositoryID int64, name, kind string) ([]string, error) {
+	rows, err := db.conn.Query(`
+		SELECT version
+		FROM provider_schema_snapshots
+		WHERE repository_id = ? AND resource_name = ? AND resource_kind = ?
+	`, repositoryID, name, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, rows.Err()
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestSchemaSnapshots(t *testing.T) {
+	db := newTestDB(t)
+	repoID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azurerm"})
+	if err != nil {
+		t.Fatalf("insert repo: %v", err)
+	}
+	resourceID, err := db.InsertProviderResource(&ProviderResource{RepositoryID: repoID, Name: "azurerm_storage_account", Kind: "resource"})
+	if err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+
+	old := []ProviderAttribute{
+		{Name: "name", Required: true, ForceNew: true, Type: sql.NullString{String: "pluginsdk.TypeString", Valid: true}},
+		{
+			Name:           "network_rules",
+			Optional:       true,
+			NestedBlock:    true,
+			MaxItems:       sql.NullInt64{Int64: 1, Valid: true},
+			ElemSchemaJSON: sql.NullString{String: EncodeNestedSchema([]NestedAttribute{{Name: "default_action", Required: true}}), Valid: true},
+		},
+	}
+	if err := db.ReplaceSchemaSnapshot(repoID, "3.0.0", "azurerm_storage_account", "resource", old); err != nil {
+		t.Fatalf("record snapshot: %v", err)
+	}
+	if err := db.ReplaceSchemaSnapshot(repoID, "4.0.0", "azurerm_storage_account", "resource", old[:1]); err != nil {
+		t.Fatalf("record snapshot: %v", err)
+	}
+	// Re-recording a version replaces the earlier snapshot instead of duplicating it.
+	if err := db.ReplaceSchemaSnapshot(repoID, "3.0.0", "azurerm_storage_account", "resource", old); err != nil {
+		t.Fatalf("re-record snapshot: %v", err)
+	}
+
+	attrs, err := db.GetResourceAttributesAtVersion(resourceID, "3.0.0")
+	if err != nil {
+		t.Fatalf("get snapshot: %v", err)
+	}
+	if len(attrs) != 2 || attrs[0].Name != "name" || !attrs[0].ForceNew || attrs[0].Type.String != "pluginsdk.TypeString" {
+		t.Fatalf("unexpected snapshot attributes: %+v", attrs)
+	}
+	block := attrs[1]
+	if !block.NestedBlock || block.MaxItems.Int64 != 1 {
+		t.Fatalf("expected nested block with max_items 1, got %+v", block)
+	}
+	if children := DecodeNestedSchema(block.ElemSchemaJSON.String); len(children) != 1 || children[0].Name != "default_action" {
+		t.Fatalf("expected nested children to round-trip, got %+v", children)
+	}
+
+	if attrs, err := db.GetResourceAttributesAtVersion(resourceID, "4.0.0"); err != nil || len(attrs) != 1 {
+		t.Fatalf("expected single attribute at 4.0.0: %v %+v", err, attrs)
+	}
+	if _, err := db.GetResourceAttributesAtVersion(resourceID, "2.0.0"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for unknown version, got %v", err)
+	}
+
+	versions, err := db.ListSnapshotVersions(repoID)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("list versions: %v %+v", err, versions)
+	}
+	for _, v := range versions {
+		if v.Resources != 1 {
+			t.Fatalf("expected one resource per version, got %+v", v)
+		}
+	}
+
+	if err := db.ClearRepositoryData(repoID); err != nil {
+		t.Fatalf("clear repository data: %v", err)
+	}
+	if attrs, err := db.GetSnapshotAttributes(repoID, "azurerm_storage_account", "resource", "3.0.0"); err != nil || len(attrs) != 2 {
+		t.Fatalf("expected snapshots to survive a resync: %v %+v", err, attrs)
+	}
+
+	otherID, err := db.InsertRepository(&Repository{Name: "terraform-provider-azuread"})
+	if err != nil {
+		t.Fatalf("insert other repository: %v", err)
+	}
+	if err := db.ReplaceSchemaSnapshot(otherID, "5.0.0", "azurerm_storage_account", "resource", nil); err != nil {
+		t.Fatalf("replace other snapshot: %v", err)
+	}
+	if _, err := db.GetSnapshotAttributes(repoID, "azurerm_storage_account", "resource", "5.0.0"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected another repository's snapshot to stay hidden, got %v", err)
+	}
+	if versions, err := db.ListResourceSnapshotVersions(repoID, "azurerm_storage_account", "resource"); err != nil || len(versions) != 2 {
+		t.Fatalf("expected only this repository's snapshot versions, got %v %v", versions, err)
+	}
+}
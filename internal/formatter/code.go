@@ -2,15 +2,16 @@
 package formatter
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
 
 	"github.com/dkooll/aztfmcp/internal/database"
 )
 
-func CodeSearchResults(query string, files []database.RepositoryFile, getRepositoryName func(int64) string) string {
+func CodeSearchResults(st Style, query string, files []database.RepositoryFile, getRepositoryName func(int64) string) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Code Search Results for '%s' (%d matches)\n\n", query, len(files))
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Code Search Results for '%s' (%d matches)", query, len(files))))
 
 	if len(files) == 0 {
 		text.WriteString("No code matches found.\n")
@@ -19,10 +20,9 @@ func CodeSearchResults(query string, files []database.RepositoryFile, getReposit
 
 	for _, file := range files {
 		repositoryName := getRepositoryName(file.RepositoryID)
-		fmt.Fprintf(&text, "## %s / %s\n", repositoryName, file.FilePath)
-		text.WriteString("```\n")
-		text.WriteString(ExtractCodeContext(file.Content, query))
-		text.WriteString("```\n\n")
+		fmt.Fprintf(&text, "%s\n", st.heading(2, repositoryName+" / "+file.FilePath))
+		text.WriteString(st.codeFence(fenceLanguage(file.FileType), ExtractCodeContext(file.Content, query)))
+		text.WriteString("\n")
 	}
 
 	return text.String()
@@ -53,38 +53,97 @@ func ExtractCodeContext(content, query string) string {
 	return text.String()
 }
 
-func FileContent(repositoryName, filePath, fileType string, sizeBytes int64, content string, startLine, endLine, totalLines int, includeContent bool) string {
+func FileContent(st Style, repositoryName, filePath, fileType string, sizeBytes int64, content string, startLine, endLine, totalLines int, includeContent bool) string {
+	return fileContent(st, repositoryName, filePath, fileType, sizeBytes, content, startLine, endLine, totalLines, includeContent, "")
+}
+
+// FileContentPage renders one page of a file walked with page/page_size, noting the page
+// position and how to fetch the next one.
+func FileContentPage(st Style, repositoryName, filePath, fileType string, sizeBytes int64, content string, startLine, endLine, totalLines, page, pages, pageSize int, includeContent bool) string {
+	pageInfo := fmt.Sprintf("%s %d of %d (%d lines per page)", st.bold("Page:"), page, pages, pageSize)
+	if page < pages {
+		pageInfo += fmt.Sprintf(". Next: page %d", page+1)
+	}
+	return fileContent(st, repositoryName, filePath, fileType, sizeBytes, content, startLine, endLine, totalLines, includeContent, pageInfo)
+}
+
+func fileContent(st Style, repositoryName, filePath, fileType string, sizeBytes int64, content string, startLine, endLine, totalLines int, includeContent bool, pageInfo string) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# %s / %s\n\n", repositoryName, filePath)
-	fmt.Fprintf(&text, "**Size:** %d bytes\n", sizeBytes)
-	fmt.Fprintf(&text, "**Type:** %s\n\n", fileType)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, repositoryName+" / "+filePath))
+	fmt.Fprintf(&text, "%s %d bytes\n", st.bold("Size:"), sizeBytes)
+	fmt.Fprintf(&text, "%s %s\n\n", st.bold("Type:"), fileType)
 	if startLine > 0 {
 		if endLine == 0 {
 			endLine = totalLines
 		}
-		fmt.Fprintf(&text, "**Lines:** %d-%d of %d\n\n", startLine, endLine, totalLines)
+		fmt.Fprintf(&text, "%s %d-%d of %d\n", st.bold("Lines:"), startLine, endLine, totalLines)
+		if pageInfo != "" {
+			fmt.Fprintf(&text, "%s\n", pageInfo)
+		}
+		text.WriteString("\n")
 	}
-	lang := ""
+	if !includeContent {
+		content = ""
+	}
+	text.WriteString(st.codeFence(fenceLanguage(fileType), content))
+	return text.String()
+}
+
+// fenceLanguage maps an indexed file type to the info string of a markdown code fence, so
+// rendering clients highlight the content. Unknown types get a bare fence.
+func fenceLanguage(fileType string) string {
 	switch fileType {
 	case "terraform":
-		lang = "hcl"
-	case "go":
-		lang = "go"
-	case "yaml":
-		lang = "yaml"
-	case "json":
-		lang = "json"
-	case "markdown":
-		lang = "markdown"
+		return "hcl"
+	case "go", "yaml", "json", "markdown":
+		return fileType
+	default:
+		return ""
 	}
-	if lang == "" {
-		text.WriteString("```\n")
-	} else {
-		text.WriteString("```" + lang + "\n")
+}
+
+// DirectoryEntry is one row of a directory listing: a file, or a subdirectory collapsed at the
+// listing depth with the number and total size of the files below it.
+type DirectoryEntry struct {
+	Path      string
+	Dir       bool
+	Files     int
+	SizeBytes int64
+}
+
+// DirectoryListing renders the files and collapsed subdirectories under dir. totalFiles and
+// totalBytes cover the whole subtree; limit is the number of rows shown (0 for all).
+func DirectoryListing(st Style, repositoryName, dir string, entries []DirectoryEntry, depth, totalFiles int, totalBytes int64, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, repositoryName+" / "+cmp.Or(dir, "(root)")))
+
+	if totalFiles == 0 {
+		fmt.Fprintf(&text, "No indexed files under '%s'. Check the path, or run sync_provider first.\n", dir)
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s %d (%s)\n", st.bold("Files:"), totalFiles, formatBytes(int(totalBytes)))
+	if depth > 0 {
+		fmt.Fprintf(&text, "%s %d\n", st.bold("Depth:"), depth)
+	}
+	text.WriteString("\n")
+
+	shown := entries
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	rows := st.table("Path", "Files", "Size")
+	for _, entry := range shown {
+		if entry.Dir {
+			rows.row(entry.Path+"/", fmt.Sprint(entry.Files), formatBytes(int(entry.SizeBytes)))
+			continue
+		}
+		rows.row(entry.Path, "1", formatBytes(int(entry.SizeBytes)))
 	}
-	if includeContent {
-		text.WriteString(content)
+	text.WriteString(rows.String())
+	if len(shown) < len(entries) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d entries. Narrow path_prefix or depth, or raise limit.", len(shown), len(entries))))
 	}
-	text.WriteString("\n```\n")
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Open a directory with list_directory or a file with get_file_content."))
 	return text.String()
 }
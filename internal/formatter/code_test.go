@@ -17,14 +17,14 @@ func TestCodeSearchResultsAndExtractContext(t *testing.T) {
 	files := []database.RepositoryFile{
 		{RepositoryID: 1, FilePath: "path.go", Content: content},
 	}
-	out := CodeSearchResults("search", files, func(id int64) string { return "repo" })
+	out := CodeSearchResults(Style{}, "search", files, func(id int64) string { return "repo" })
 	if out == "" || !containsStr(out, "path.go") {
 		t.Fatalf("expected search results output, got %s", out)
 	}
 }
 
 func TestFileContentSummary(t *testing.T) {
-	out := FileContent("repo", "path/file.txt", "go", 10, "code", 0, 0, 0, false)
+	out := FileContent(Style{}, "repo", "path/file.txt", "go", 10, "code", 0, 0, 0, false)
 	if !containsStr(out, "path/file.txt") || containsStr(out, "code") {
 		t.Fatalf("expected metadata without content when includeContent=false, got %s", out)
 	}
@@ -33,3 +33,22 @@ func TestFileContentSummary(t *testing.T) {
 func containsStr(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestFileContentFenceLanguage(t *testing.T) {
+	tests := []struct {
+		fileType string
+		fence    string
+	}{
+		{"terraform", "```hcl\n"},
+		{"go", "```go\n"},
+		{"json", "```json\n"},
+		{"markdown", "```markdown\n"},
+		{"other", "```\n"},
+	}
+	for _, tt := range tests {
+		out := FileContent(Style{}, "repo", "f", tt.fileType, 3, "abc", 1, 1, 1, true)
+		if !strings.Contains(out, tt.fence+"abc\n```\n") {
+			t.Errorf("%s: expected %q fence, got %q", tt.fileType, tt.fence, out)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package formatter
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// DocsStub renders a website/docs style Markdown skeleton (arguments and attributes reference) for a resource or data source.
+// The skeleton is markdown content in its own right, so it does not follow the output style.
+func DocsStub(resource *database.ProviderResource, attrs []database.ProviderAttribute) string {
+	tree := make([]database.NestedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		tree = append(tree, database.NestedAttributeFromProvider(attr))
+	}
+
+	friendly := docsFriendlyName(resource)
+	dataSource := resource.Kind == "data_source"
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "# %s\n\n", resource.Name)
+	if dataSource {
+		fmt.Fprintf(&text, "Use this data source to access information about an existing %s.\n\n", friendly)
+	} else {
+		fmt.Fprintf(&text, "Manages a %s.\n\n", friendly)
+	}
+
+	text.WriteString("## Arguments Reference\n\n")
+	text.WriteString("The following arguments are supported:\n\n")
+
+	var blocks []database.NestedAttribute
+	arguments := docsArguments(tree)
+	if len(arguments) == 0 {
+		text.WriteString("_No arguments parsed from the schema._\n\n")
+	}
+	for _, attr := range arguments {
+		writeDocsArgument(&text, attr, friendly, dataSource)
+		if attr.NestedBlock && len(attr.Children) > 0 {
+			blocks = append(blocks, attr)
+		}
+	}
+
+	// Nested blocks are documented breadth-first after the top-level arguments.
+	for i := 0; i < len(blocks); i++ {
+		block := blocks[i]
+		text.WriteString("---\n\n")
+		fmt.Fprintf(&text, "A `%s` block supports the following:\n\n", block.Name)
+		for _, child := range docsArguments(block.Children) {
+			writeDocsArgument(&text, child, friendly, dataSource)
+			if child.NestedBlock && len(child.Children) > 0 {
+				blocks = append(blocks, child)
+			}
+		}
+	}
+
+	text.WriteString("## Attributes Reference\n\n")
+	text.WriteString("In addition to the Arguments listed above - the following Attributes are exported:\n\n")
+	fmt.Fprintf(&text, "* `id` - The ID of the %s.\n\n", friendly)
+
+	var exportedBlocks []database.NestedAttribute
+	for _, attr := range docsComputed(tree) {
+		writeDocsAttribute(&text, attr)
+		if attr.NestedBlock && len(attr.Children) > 0 {
+			exportedBlocks = append(exportedBlocks, attr)
+		}
+	}
+
+	for i := 0; i < len(exportedBlocks); i++ {
+		block := exportedBlocks[i]
+		text.WriteString("---\n\n")
+		fmt.Fprintf(&text, "A `%s` block exports the following:\n\n", block.Name)
+		for _, child := range sortedDocsAttributes(block.Children) {
+			writeDocsAttribute(&text, child)
+			if child.NestedBlock && len(child.Children) > 0 {
+				exportedBlocks = append(exportedBlocks, child)
+			}
+		}
+	}
+
+	return strings.TrimRight(text.String(), "\n") + "\n"
+}
+
+// docsArguments returns user-settable attributes, required first, each group sorted by name.
+func docsArguments(attrs []database.NestedAttribute) []database.NestedAttribute {
+	var required, optional []database.NestedAttribute
+	for _, attr := range attrs {
+		switch {
+		case attr.Name == "id":
+			continue
+		case attr.Required:
+			required = append(required, attr)
+		case attr.Optional:
+			optional = append(optional, attr)
+		}
+	}
+	return append(sortedDocsAttributes(required), sortedDocsAttributes(optional)...)
+}
+
+// docsComputed returns attributes that are only exported (computed and not settable).
+func docsComputed(attrs []database.NestedAttribute) []database.NestedAttribute {
+	var computed []database.NestedAttribute
+	for _, attr := range attrs {
+		if attr.Name == "id" || attr.Required || attr.Optional || !attr.Computed {
+			continue
+		}
+		computed = append(computed, attr)
+	}
+	return sortedDocsAttributes(computed)
+}
+
+func sortedDocsAttributes(attrs []database.NestedAttribute) []database.NestedAttribute {
+	sorted := append([]database.NestedAttribute(nil), attrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func writeDocsArgument(text *strings.Builder, attr database.NestedAttribute, friendly string, dataSource bool) {
+	requirement := "Optional"
+	if attr.Required {
+		requirement = "Required"
+	}
+
+	description := docsDescription(attr)
+	if attr.ForceNew && !dataSource {
+		description += fmt.Sprintf(" Changing this forces a new %s to be created.", friendly)
+	}
+	fmt.Fprintf(text, "* `%s` - (%s) %s\n\n", attr.Name, requirement, description)
+}
+
+func writeDocsAttribute(text *strings.Builder, attr database.NestedAttribute) {
+	fmt.Fprintf(text, "* `%s` - %s\n\n", attr.Name, docsDescription(attr))
+}
+
+func docsDescription(attr database.NestedAttribute) string {
+	if attr.NestedBlock && len(attr.Children) > 0 {
+		if attr.MaxItems == 1 {
+			return fmt.Sprintf("A `%s` block as defined below.", attr.Name)
+		}
+		return fmt.Sprintf("One or more `%s` blocks as defined below.", attr.Name)
+	}
+	desc := strings.TrimSpace(attr.Description)
+	if desc == "" {
+		return fmt.Sprintf("TODO: describe `%s`.", attr.Name)
+	}
+	if !strings.HasSuffix(desc, ".") {
+		desc += "."
+	}
+	return desc
+}
+
+func docsFriendlyName(resource *database.ProviderResource) string {
+	if resource.DisplayName.Valid && strings.TrimSpace(resource.DisplayName.String) != "" {
+		return strings.TrimSpace(resource.DisplayName.String)
+	}
+	name := resource.Name
+	if _, rest, ok := strings.Cut(name, "_"); ok {
+		name = rest
+	}
+	words := strings.Split(name, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// ProviderReadme renders the stored repository README, cut at a line boundary after maxChars
+// characters. maxChars <= 0 returns it in full.
+func ProviderReadme(st Style, repoName, content string, maxChars int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "README: "+repoName))
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		text.WriteString("No README is stored for this repository. Run sync_provider to fetch it.\n")
+		return text.String()
+	}
+
+	shown := content
+	if maxChars > 0 && len(content) > maxChars {
+		shown = content[:maxChars]
+		if idx := strings.LastIndex(shown, "\n"); idx > 0 {
+			shown = shown[:idx]
+		}
+		shown = strings.ToValidUTF8(strings.TrimRight(shown, " \n"), "")
+	}
+	text.WriteString(shown)
+	text.WriteString("\n")
+
+	if len(shown) < len(content) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Truncated to %d of %d characters. Fetch README.md with get_file_content, or pass max_chars -1, for the full text.", len(shown), len(content))))
+	}
+	return text.String()
+}
+
+// DocGuide is a long-form guide page with the title and description from its front matter.
+type DocGuide struct {
+	Path        string
+	Title       string
+	Description string
+}
+
+// DocGuides lists the guide pages found under dir.
+func DocGuides(st Style, dir string, guides []DocGuide) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Documentation Guides"))
+
+	if len(guides) == 0 {
+		fmt.Fprintf(&text, "No guides are indexed under %s. Run sync_provider first.\n", dir)
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Guides"), len(guides))
+	rows := st.table("Title", "Description", "File")
+	for _, guide := range guides {
+		title := cmp.Or(guide.Title, strings.TrimSuffix(path.Base(guide.Path), ".html.markdown"))
+		rows.row(orDash(title), orDash(guide.Description), guide.Path)
+	}
+	text.WriteString(rows.String())
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Open a guide with get_file_content."))
+	return text.String()
+}
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+func TestDocsStub(t *testing.T) {
+	resource := &database.ProviderResource{Name: "azurerm_storage_account", Kind: "resource"}
+	attrs := []database.ProviderAttribute{
+		{Name: "name", Required: true, ForceNew: true, Description: sql.NullString{Valid: true, String: "Specifies the name of the storage account"}},
+		{Name: "account_tier", Required: true},
+		{Name: "min_tls_version", Optional: true, Computed: true},
+		{
+			Name:        "network_rules",
+			Optional:    true,
+			NestedBlock: true,
+			MaxItems:    sql.NullInt64{Valid: true, Int64: 1},
+			ElemSchemaJSON: sql.NullString{Valid: true, String: database.EncodeNestedSchema([]database.NestedAttribute{
+				{Name: "default_action", Required: true},
+				{Name: "bypass", Optional: true},
+			})},
+		},
+		{Name: "primary_blob_endpoint", Computed: true, Description: sql.NullString{Valid: true, String: "The endpoint URL for blob storage in the primary location."}},
+		{Name: "id", Computed: true},
+	}
+
+	stub := DocsStub(resource, attrs)
+
+	for _, want := range []string{
+		"# azurerm_storage_account",
+		"Manages a Storage Account.",
+		"## Arguments Reference",
+		"* `name` - (Required) Specifies the name of the storage account. Changing this forces a new Storage Account to be created.",
+		"* `account_tier` - (Required) TODO: describe `account_tier`.",
+		"* `min_tls_version` - (Optional)",
+		"* `network_rules` - (Optional) A `network_rules` block as defined below.",
+		"A `network_rules` block supports the following:",
+		"* `default_action` - (Required)",
+		"## Attributes Reference",
+		"* `id` - The ID of the Storage Account.",
+		"* `primary_blob_endpoint` - The endpoint URL for blob storage in the primary location.",
+	} {
+		if !strings.Contains(stub, want) {
+			t.Errorf("expected %q in stub:\n%s", want, stub)
+		}
+	}
+
+	args := stub[strings.Index(stub, "## Arguments Reference"):strings.Index(stub, "## Attributes Reference")]
+	if strings.Contains(args, "primary_blob_endpoint") {
+		t.Errorf("computed-only attributes should not be listed as arguments")
+	}
+	if strings.Index(args, "`account_tier`") > strings.Index(args, "`min_tls_version`") {
+		t.Errorf("expected required arguments before optional ones")
+	}
+}
+
+func TestDocsStubDataSource(t *testing.T) {
+	resource := &database.ProviderResource{Name: "azurerm_resource_group", Kind: "data_source"}
+	attrs := []database.ProviderAttribute{
+		{Name: "name", Required: true, ForceNew: true},
+		{Name: "location", Computed: true},
+	}
+
+	stub := DocsStub(resource, attrs)
+	if !strings.Contains(stub, "Use this data source to access information about an existing Resource Group.") {
+		t.Errorf("expected data source intro, got:\n%s", stub)
+	}
+	if strings.Contains(stub, "forces a new") {
+		t.Errorf("data source arguments should not mention ForceNew, got:\n%s", stub)
+	}
+	if !strings.Contains(stub, "* `location` - TODO: describe `location`.") {
+		t.Errorf("expected computed attribute placeholder, got:\n%s", stub)
+	}
+}
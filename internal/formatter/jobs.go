@@ -6,9 +6,9 @@ import (
 	"time"
 )
 
-func IncrementalSyncProgress(totalRepos, synced, skipped int, updatedRepos, errors []string) string {
+func IncrementalSyncProgress(st Style, totalRepos, synced, skipped int, updatedRepos, errors []string) string {
 	var text strings.Builder
-	text.WriteString("# Incremental Sync Completed\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Incremental Sync Completed"))
 
 	fmt.Fprintf(&text, "Checked %d repositories\n", totalRepos)
 	fmt.Fprintf(&text, "Updated repositories: %d\n", synced)
@@ -36,9 +36,14 @@ func IncrementalSyncProgress(totalRepos, synced, skipped int, updatedRepos, erro
 	return text.String()
 }
 
-func JobDetails(jobID, jobType, status string, startedAt time.Time, completedAt *time.Time, errorMsg string, progressText string) string {
+// SyncStarted acknowledges a full sync queued as a background job.
+func SyncStarted(st Style, jobID string) string {
+	return fmt.Sprintf("Full sync started.\nJob ID: %s\nUse %s with this job ID to monitor progress.", jobID, st.code("sync_status"))
+}
+
+func JobDetails(st Style, jobID, jobType, status string, startedAt time.Time, completedAt *time.Time, errorMsg string, progressText string) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Sync Job %s (%s)\n\n", jobID, jobType)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Sync Job %s (%s)", jobID, jobType)))
 	fmt.Fprintf(&text, "Status: %s\n", strings.ToUpper(status))
 	fmt.Fprintf(&text, "Started: %s\n", startedAt.Format(time.RFC3339))
 	if completedAt != nil {
@@ -60,23 +65,38 @@ func JobDetails(jobID, jobType, status string, startedAt time.Time, completedAt
 	return text.String()
 }
 
-func JobList(jobs []JobInfo) string {
+// PollGuidance tells the agent when to call sync_status again for a running job. typical is how long
+// the last completed job of the same type took, or zero when none has finished in this session.
+func PollGuidance(typical, interval time.Duration) string {
+	var text strings.Builder
+	if typical > 0 {
+		fmt.Fprintf(&text, "The previous sync of this type took %s.\n", typical.Round(time.Second))
+	} else {
+		text.WriteString("A full sync usually finishes within a minute or two, depending on GitHub rate limits.\n")
+	}
+	fmt.Fprintf(&text, "Check again in %d seconds.\n", int(interval.Round(time.Second).Seconds()))
+	return text.String()
+}
+
+func JobList(st Style, jobs []JobInfo) string {
 	if len(jobs) == 0 {
 		return "No sync jobs have been scheduled yet."
 	}
 
 	var text strings.Builder
-	text.WriteString("# Sync Jobs\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Sync Jobs"))
 	for _, job := range jobs {
 		fmt.Fprintf(&text, "- %s (%s) — %s", job.ID, job.Type, strings.ToUpper(job.Status))
 		if job.CompletedAt != nil {
 			duration := job.CompletedAt.Sub(job.StartedAt)
 			fmt.Fprintf(&text, " in %s", duration.Round(time.Second))
+		} else if job.TotalRepos > 0 {
+			fmt.Fprintf(&text, " (%d/%d repositories)", job.ProcessedRepos, job.TotalRepos)
 		}
 		text.WriteString("\n")
 	}
 
-	text.WriteString("\nUse `sync_status` with a job_id for detailed information.\n")
+	fmt.Fprintf(&text, "\nUse %s with a job_id for detailed information.\n", st.code("sync_status"))
 	return text.String()
 }
 
@@ -86,4 +106,8 @@ type JobInfo struct {
 	Status      string
 	StartedAt   time.Time
 	CompletedAt *time.Time
+
+	// Repository counts of a running job; zero while it is still listing repositories.
+	ProcessedRepos int
+	TotalRepos     int
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func TestIncrementalSyncProgress(t *testing.T) {
-	out := IncrementalSyncProgress(3, 2, 1, []string{"a", "b"}, []string{"err1", "err2", "err3", "err4", "err5", "err6", "err7", "err8", "err9", "err10", "err11"})
+	out := IncrementalSyncProgress(Style{}, 3, 2, 1, []string{"a", "b"}, []string{"err1", "err2", "err3", "err4", "err5", "err6", "err7", "err8", "err9", "err10", "err11"})
 	if !strings.Contains(out, "Updated repositories: 2") {
 		t.Fatalf("expected updated count, got: %s", out)
 	}
@@ -20,12 +20,12 @@ func TestJobDetailsAndList(t *testing.T) {
 	start := time.Now().Add(-2 * time.Minute)
 	end := time.Now()
 
-	detail := JobDetails("id1", "full", "completed", start, &end, "oops", "progress text")
+	detail := JobDetails(Style{}, "id1", "full", "completed", start, &end, "oops", "progress text")
 	if !strings.Contains(detail, "oops") || !strings.Contains(detail, "progress text") {
 		t.Fatalf("expected error and progress in detail: %s", detail)
 	}
 
-	list := JobList([]JobInfo{
+	list := JobList(Style{}, []JobInfo{
 		{ID: "id1", Type: "full", Status: "completed", StartedAt: start, CompletedAt: &end},
 	})
 	if !strings.Contains(list, "id1 (full)") || !strings.Contains(list, "COMPLETED") {
@@ -33,8 +33,30 @@ func TestJobDetailsAndList(t *testing.T) {
 	}
 }
 
+func TestPollGuidance(t *testing.T) {
+	if got := PollGuidance(0, 10*time.Second); !strings.Contains(got, "within a minute or two") || !strings.Contains(got, "Check again in 10 seconds.") {
+		t.Fatalf("expected generic estimate and hint, got: %s", got)
+	}
+	if got := PollGuidance(3*time.Minute, 30*time.Second); !strings.Contains(got, "took 3m0s") || !strings.Contains(got, "Check again in 30 seconds.") {
+		t.Fatalf("expected previous duration and hint, got: %s", got)
+	}
+}
+
+func TestJobListShowsRunningProgress(t *testing.T) {
+	list := JobList(Style{}, []JobInfo{
+		{ID: "id2", Type: "full_sync", Status: "running", StartedAt: time.Now(), ProcessedRepos: 2, TotalRepos: 5},
+		{ID: "id3", Type: "full_sync", Status: "running", StartedAt: time.Now()},
+	})
+	if !strings.Contains(list, "RUNNING (2/5 repositories)") {
+		t.Fatalf("expected running counts in list: %s", list)
+	}
+	if strings.Contains(list, "(0/0") {
+		t.Fatalf("expected no counts before totals are known: %s", list)
+	}
+}
+
 func TestJobListEmpty(t *testing.T) {
-	if got := JobList(nil); !strings.Contains(got, "No sync jobs") {
+	if got := JobList(Style{}, nil); !strings.Contains(got, "No sync jobs") {
 		t.Fatalf("expected empty message, got: %s", got)
 	}
 }
@@ -0,0 +1,572 @@
+package formatter
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// ProviderOverview renders registration counts and parse coverage for the indexed provider.
+func ProviderOverview(st Style, overview *database.ProviderOverview, unresolved []database.ProviderResource) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Provider Overview"))
+
+	if overview == nil || overview.TotalDefinitions == 0 {
+		text.WriteString("No provider definitions indexed yet. Run sync_provider first.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Definitions"), overview.TotalDefinitions)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Services"), overview.Services)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Linked to a Service"), overview.ResourcesWithService)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Without Parsed Attributes"), overview.WithoutAttributes)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Go Files That Failed To Parse"), len(overview.ParseFailures))
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "By Kind"))
+	writeCounts(&text, overview.Kinds)
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "By Registration"))
+	writeCounts(&text, overview.RegistrationTypes)
+
+	if len(unresolved) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Unresolved Schemas"))
+		rows := st.table("Name", "Kind", "Registration")
+		for _, r := range unresolved {
+			registration := r.RegistrationType.String
+			if registration == "" {
+				registration = "unknown"
+			}
+			rows.row(r.Name, r.Kind, registration)
+		}
+		text.WriteString(rows.String())
+		if len(unresolved) < overview.WithoutAttributes {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(unresolved), overview.WithoutAttributes)))
+		}
+		text.WriteString("\n")
+	}
+
+	if len(overview.ParseFailures) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Parse Failures"))
+		text.WriteString("These files were skipped in the last sync; resources defined in them may be missing.\n\n")
+		for _, path := range overview.ParseFailures {
+			fmt.Fprintf(&text, "- %s\n", path)
+		}
+		text.WriteString("\n")
+	}
+
+	return text.String()
+}
+
+func writeCounts(text *strings.Builder, counts map[string]int) {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(text, "- %s: %d\n", key, counts[key])
+	}
+	text.WriteString("\n")
+}
+
+// GlobalResources renders resources that lack a required location attribute, split into those
+// with no location at all and those where location is optional or computed.
+func GlobalResources(st Style, scope string, regional int, withoutLocation, optionalLocation []database.ResourceLocationPlacement, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Global Resource Candidates (%s)", scope)))
+
+	total := regional + len(withoutLocation) + len(optionalLocation)
+	if total == 0 {
+		text.WriteString("No parsed resources matched. Run sync_provider first or adjust resource_prefix.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d resources\n", st.bold("Scanned"), total)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Regional (required location)"), regional)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("No Location Attribute"), len(withoutLocation))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Optional/Computed Location"), len(optionalLocation))
+	fmt.Fprintf(&text, "%s\n\n", st.italic(fmt.Sprintf("Heuristic: resources without a required %s are usually global, subscription-scoped, or inherit placement from a parent resource.", st.code("location"))))
+
+	if len(withoutLocation) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "No Location Attribute"))
+		shown := withoutLocation
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		for _, p := range shown {
+			fmt.Fprintf(&text, "- %s\n", p.Name)
+		}
+		if len(shown) < len(withoutLocation) {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(withoutLocation))))
+		}
+		text.WriteString("\n")
+	}
+
+	if len(optionalLocation) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Optional or Computed Location"))
+		rows := st.table("Resource", "Location Flags")
+		shown := optionalLocation
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		for _, p := range shown {
+			var flags []string
+			if p.Optional {
+				flags = append(flags, "optional")
+			}
+			if p.Computed {
+				flags = append(flags, "computed")
+			}
+			rows.row(p.Name, orDash(strings.Join(flags, ", ")))
+		}
+		text.WriteString(rows.String())
+		if len(shown) < len(optionalLocation) {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(optionalLocation))))
+		}
+		text.WriteString("\n")
+	}
+
+	return text.String()
+}
+
+// ResourcesWithCustomDiff lists definitions that declare a CustomizeDiff function.
+func ResourcesWithCustomDiff(st Style, scope string, resources []database.ProviderResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resources with CustomizeDiff (%s)", scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("No resources with a CustomizeDiff function found. Run sync_provider first or adjust resource_prefix.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	fmt.Fprintf(&text, "%s\n\n", st.italic("CustomizeDiff can force replacement or rewrite planned values; review these resources closely during upgrades."))
+	rows := st.table("Resource", "Kind", "File")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Name, r.Kind, orDash(r.FilePath.String))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d. Use get_schema_source or get_resource_behaviors to inspect the logic.", len(shown), len(resources))))
+	}
+
+	return text.String()
+}
+
+// NonImportableResources lists resources whose schema declares no Importer.
+func NonImportableResources(st Style, scope string, resources []database.ProviderResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Non-Importable Resources (%s)", scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("Every resource with stored source declares an Importer. Run sync_provider first or adjust resource_prefix.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	fmt.Fprintf(&text, "%s\n\n", st.italic(fmt.Sprintf("These resources cannot be brought under management with %s or %s blocks; existing infrastructure must be recreated.", st.code("terraform import"), st.code("import"))))
+	rows := st.table("Resource", "File")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Name, orDash(r.FilePath.String))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+	}
+
+	return text.String()
+}
+
+// Deprecations renders deprecated definitions and attributes with their replacement guidance as
+// an upgrade checklist. limit caps each list separately; 0 shows everything.
+func Deprecations(st Style, scope string, resources []database.ProviderResource, attrs []database.DeprecatedAttribute, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Deprecations (%s)", scope)))
+
+	if len(resources) == 0 && len(attrs) == 0 {
+		text.WriteString("No deprecated resources or attributes found. Run sync_provider first or adjust the scope.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Deprecated Resources"), len(resources))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Deprecated Attributes"), len(attrs))
+
+	if len(resources) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Resources"))
+		rows := st.table("Resource", "Kind", "Deprecation")
+		shown := resources
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		for _, r := range shown {
+			rows.row(r.Name, r.Kind, orDash(r.DeprecationMessage.String))
+		}
+		text.WriteString(rows.String())
+		if len(shown) < len(resources) {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+		}
+		text.WriteString("\n")
+	}
+
+	if len(attrs) > 0 {
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Attributes"))
+		rows := st.table("Resource", "Attribute", "Deprecation")
+		shown := attrs
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		for _, a := range shown {
+			resource := a.ResourceName
+			if a.ResourceKind == "data_source" {
+				resource += " (data source)"
+			}
+			rows.row(resource, a.Name, orDash(a.Message))
+		}
+		text.WriteString(rows.String())
+		if len(shown) < len(attrs) {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(attrs))))
+		}
+	}
+
+	return text.String()
+}
+
+// DeprecationReplacement maps a deprecated resource or attribute to the replacement its
+// deprecation message names. Indexed reports whether the replacement exists in the index.
+type DeprecationReplacement struct {
+	Deprecated  string
+	Kind        string
+	Replacement string
+	Indexed     bool
+	Message     string
+}
+
+// DeprecationReplacements renders old->new mappings extracted from deprecation messages as a
+// migration table. unnamed counts deprecations whose message names no replacement.
+func DeprecationReplacements(st Style, scope string, mappings []DeprecationReplacement, unnamed, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Deprecation Replacements (%s)", scope)))
+
+	if len(mappings) == 0 && unnamed == 0 {
+		text.WriteString("No deprecated resources or attributes found. Run sync_provider first or adjust the scope.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Mappings"), len(mappings))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Without a Named Replacement"), unnamed)
+
+	if len(mappings) > 0 {
+		rows := st.table("Deprecated", "Kind", "Replacement", "Indexed")
+		shown := mappings
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+		for _, m := range shown {
+			indexed := "no"
+			if m.Indexed {
+				indexed = "yes"
+			}
+			rows.row(m.Deprecated, m.Kind, m.Replacement, indexed)
+		}
+		text.WriteString(rows.String())
+		if len(shown) < len(mappings) {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(mappings))))
+		}
+		text.WriteString("\n")
+	}
+
+	fmt.Fprintf(&text, "%s\n", st.italic("Replacements are extracted from free-text deprecation messages; rows marked \"no\" name something that is not in the index. Use get_deprecations for the full messages."))
+	return text.String()
+}
+
+// UnresolvedResources renders definitions without parsed attributes together with the reason the
+// parser recorded, grouped counts first.
+func UnresolvedResources(st Style, scope string, resources []database.ProviderResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Unresolved Resources (%s)", scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("Every indexed definition has a parsed schema. Run sync_provider first or adjust resource_prefix.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	counts := make(map[string]int)
+	for _, r := range resources {
+		counts[unresolvedReasonLabel(r.UnresolvedReason.String)]++
+	}
+	writeCounts(&text, counts)
+
+	rows := st.table("Name", "Kind", "Reason", "File")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Name, r.Kind, unresolvedReasonLabel(r.UnresolvedReason.String), orDash(r.FilePath.String))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+	}
+
+	return text.String()
+}
+
+// ResourcesWithoutTimeouts renders definitions whose schema declares no Timeouts block.
+func ResourcesWithoutTimeouts(st Style, scope string, resources []database.ProviderResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resources Without Timeouts (%s)", scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("Every definition with a parsed schema function declares Timeouts. Run sync_provider first or adjust resource_prefix.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	counts := make(map[string]int)
+	for _, r := range resources {
+		counts[r.Kind]++
+	}
+	writeCounts(&text, counts)
+
+	rows := st.table("Name", "Kind", "File")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Name, r.Kind, orDash(r.FilePath.String))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+	}
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Typed resources declare timeouts in a Timeout() method and are not checked; see list_unresolved_resources."))
+
+	return text.String()
+}
+
+// MissingArgumentsResource is a definition lacking expected standard arguments, with an
+// assessment of whether the gap comes from the parser.
+type MissingArgumentsResource struct {
+	Resource   database.ProviderResource
+	Missing    []string
+	Assessment string
+}
+
+// ResourcesMissingArguments renders definitions that lack some of the expected arguments, with
+// counts per assessment first.
+func ResourcesMissingArguments(st Style, scope string, expected []string, resources []MissingArgumentsResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resources Missing Common Arguments (%s)", scope)))
+	fmt.Fprintf(&text, "%s: %s\n", st.bold("Expected"), strings.Join(expected, ", "))
+
+	if len(resources) == 0 {
+		text.WriteString("\nEvery definition declares the expected arguments. Run sync_provider first or adjust the scope.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	counts := make(map[string]int)
+	for _, r := range resources {
+		label, _, _ := strings.Cut(r.Assessment, ":")
+		counts[label]++
+	}
+	writeCounts(&text, counts)
+
+	rows := st.table("Name", "Missing", "Assessment")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Resource.Name, strings.Join(r.Missing, ", "), orDash(r.Assessment))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+	}
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Parse misses usually come from schema helpers the parser cannot resolve; see list_unresolved_resources for definitions without any schema."))
+
+	return text.String()
+}
+
+func unresolvedReasonLabel(reason string) string {
+	switch reason {
+	case database.UnresolvedTyped:
+		return "typed resource (schema in struct methods)"
+	case database.UnresolvedMissingFunction:
+		return "registered function not found"
+	case database.UnresolvedParseError:
+		return "schema could not be parsed"
+	default:
+		return "schema parsed without attributes"
+	}
+}
+
+// ParseCacheStatus renders parse_cache totals and the cached files whose hash no longer matches
+// the indexed content.
+func ParseCacheStatus(st Style, summary *database.ParseCacheSummary, stale []database.StaleParseCacheEntry, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Parse Cache Status"))
+
+	if summary == nil || summary.Files == 0 {
+		text.WriteString("The parse cache is empty. Run sync_provider to parse the provider source.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d", st.bold("Cached Files"), summary.Files)
+	if summary.IndexedGoFiles > 0 {
+		fmt.Fprintf(&text, " of %d indexed Go files", summary.IndexedGoFiles)
+	}
+	text.WriteString("\n")
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Parsed Resources"), summary.Resources)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Parsed Attributes"), summary.Attributes)
+	if !summary.LastParsedAt.IsZero() {
+		fmt.Fprintf(&text, "%s: %s\n", st.bold("Last Parsed"), summary.LastParsedAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Stale Entries"), len(stale))
+
+	if len(stale) == 0 {
+		text.WriteString("Every cached hash matches the indexed file content.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s\n\n", st.italic("A stale entry means the file changed (or disappeared) after it was parsed; resources defined there may be missing or outdated until the next sync_provider."))
+	rows := st.table("File", "Status", "Resources", "Attributes", "Parsed At")
+	shown := stale
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, entry := range shown {
+		status := "hash changed"
+		if entry.Missing {
+			status = "file missing"
+		}
+		parsedAt := ""
+		if !entry.Entry.ParsedAt.IsZero() {
+			parsedAt = entry.Entry.ParsedAt.UTC().Format("2006-01-02 15:04")
+		}
+		rows.row(orDash(entry.Entry.FilePath), status, fmt.Sprint(entry.Entry.ResourceCount), fmt.Sprint(entry.Entry.AttributeCount), parsedAt)
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(stale) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(stale))))
+	}
+
+	return text.String()
+}
+
+// AttributeUsage renders provider-wide counts for attribute names, optionally listing the
+// definitions that declare each one.
+func AttributeUsage(st Style, query string, exact bool, usages []database.AttributeUsage, resources map[string][]database.ProviderResource) string {
+	var text strings.Builder
+	match := "containing"
+	if exact {
+		match = "named"
+	}
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute Usage (%s '%s')", match, query)))
+
+	if len(usages) == 0 {
+		text.WriteString("No matching top-level attributes found. Run sync_provider first or adjust name.\n")
+		return text.String()
+	}
+
+	rows := st.table("Attribute", "Resources", "Data Sources", "Total")
+	for _, u := range usages {
+		rows.row(u.Name, fmt.Sprint(u.Resources), fmt.Sprint(u.DataSources), fmt.Sprint(u.Resources+u.DataSources))
+	}
+	text.WriteString(rows.String())
+
+	for _, u := range usages {
+		defs := resources[u.Name]
+		if len(defs) == 0 {
+			continue
+		}
+		fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, u.Name))
+		for _, r := range defs {
+			fmt.Fprintf(&text, "- %s (%s)\n", r.Name, r.Kind)
+		}
+		if total := u.Resources + u.DataSources; len(defs) < total {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(defs), total)))
+		}
+	}
+	return text.String()
+}
+
+// ResourcesWithAttributes renders definitions that declare every attribute in names.
+func ResourcesWithAttributes(st Style, names []string, scope string, resources []database.ProviderResource, limit int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resources With %s (%s)", strings.Join(names, " + "), scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("No definition declares all of these attributes. Names must match top-level attributes exactly; run sync_provider first if the index is empty.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), len(resources))
+	counts := make(map[string]int)
+	for _, r := range resources {
+		counts[r.Kind]++
+	}
+	writeCounts(&text, counts)
+
+	rows := st.table("Name", "Kind", "File")
+	shown := resources
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, r := range shown {
+		rows.row(r.Name, r.Kind, orDash(r.FilePath.String))
+	}
+	text.WriteString(rows.String())
+	if len(shown) < len(resources) {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d.", len(shown), len(resources))))
+	}
+
+	return text.String()
+}
+
+// ProviderSummary pairs an indexed repository with its definition counts.
+type ProviderSummary struct {
+	Repository  database.Repository
+	Resources   int
+	DataSources int
+}
+
+// ProviderList renders the indexed provider repositories with their counts and sync times.
+func ProviderList(st Style, providers []ProviderSummary) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, "Indexed Providers"))
+
+	if len(providers) == 0 {
+		text.WriteString("No providers indexed yet. Run sync_provider first.\n")
+		return text.String()
+	}
+
+	rows := st.table("Repository", "Full Name", "Resources", "Data Sources", "Synced At")
+	for _, p := range providers {
+		synced := "never"
+		if !p.Repository.SyncedAt.IsZero() {
+			synced = p.Repository.SyncedAt.UTC().Format(time.RFC3339)
+		}
+		rows.row(p.Repository.Name, cmp.Or(p.Repository.FullName, "-"), fmt.Sprint(p.Resources), fmt.Sprint(p.DataSources), synced)
+	}
+	text.WriteString(rows.String())
+
+	fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Pass a repository name as the %s argument of get_file_content to read from that provider.", st.code("repository"))))
+	return text.String()
+}
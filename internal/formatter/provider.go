@@ -8,14 +8,17 @@ import (
 )
 
 type SchemaRenderOptions struct {
-	FilterSummary string
-	Compact       bool
-	Filtered      bool
+	FilterSummary  string
+	Compact        bool
+	Filtered       bool
+	DescMaxChars   int
+	SchemaVersion  string // set when rendering a per-release snapshot instead of the current schema
+	SupportsImport *bool  // nil when import support is unknown
 }
 
-func ProviderResourceList(resources []database.ProviderResource) string {
+func ProviderResourceList(st Style, resources []database.ProviderResource) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# AzureRM Provider Definitions (%d)\n\n", len(resources))
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("AzureRM Provider Definitions (%d)", len(resources))))
 
 	if len(resources) == 0 {
 		text.WriteString("No provider resources indexed. Run sync_provider to load the repository.\n")
@@ -27,7 +30,7 @@ func ProviderResourceList(resources []database.ProviderResource) string {
 		if resource.DisplayName.Valid {
 			title = fmt.Sprintf("%s (%s)", resource.DisplayName.String, resource.Name)
 		}
-		fmt.Fprintf(&text, "**%s** — %s\n", title, resource.Kind)
+		fmt.Fprintf(&text, "%s — %s\n", st.bold(title), resource.Kind)
 		if resource.Description.Valid {
 			fmt.Fprintf(&text, "  %s\n", resource.Description.String)
 		}
@@ -57,47 +60,63 @@ func ProviderResourceListCompact(resources []database.ProviderResource) string {
 	return text.String()
 }
 
-func ProviderResourceDetail(resource *database.ProviderResource, attrs []database.ProviderAttribute, opts SchemaRenderOptions) string {
+func ProviderResourceDetail(st Style, resource *database.ProviderResource, attrs []database.ProviderAttribute, opts SchemaRenderOptions) string {
 	var text strings.Builder
 	title := resource.Name
 	if resource.DisplayName.Valid {
 		title = fmt.Sprintf("%s (%s)", resource.DisplayName.String, resource.Name)
 	}
-	fmt.Fprintf(&text, "# %s\n\n", title)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, title))
 	kindLabel := "Resource"
 	if resource.Kind == "data_source" {
 		kindLabel = "Data Source"
 	}
-	fmt.Fprintf(&text, "**Kind:** %s\n", kindLabel)
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Kind:"), kindLabel)
 	if resource.FilePath.Valid {
-		fmt.Fprintf(&text, "**File:** %s\n", resource.FilePath.String)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), resource.FilePath.String)
 	}
 	if resource.Description.Valid {
-		fmt.Fprintf(&text, "**Description:** %s\n", resource.Description.String)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Description:"), resource.Description.String)
 	}
 	if resource.DeprecationMessage.Valid {
-		fmt.Fprintf(&text, "**Deprecation:** %s\n", resource.DeprecationMessage.String)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Deprecation:"), resource.DeprecationMessage.String)
+	}
+	if resource.VersionAdded.Valid {
+		fmt.Fprintf(&text, "%s v%s\n", st.bold("Added In:"), resource.VersionAdded.String)
+	}
+	if resource.VersionRemoved.Valid {
+		fmt.Fprintf(&text, "%s v%s\n", st.bold("Removed In:"), resource.VersionRemoved.String)
+	}
+	if opts.SupportsImport != nil {
+		importable := "No"
+		if *opts.SupportsImport {
+			importable = "Yes"
+		}
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Supports Import:"), importable)
+	}
+	if opts.SchemaVersion != "" {
+		fmt.Fprintf(&text, "%s v%s\n", st.bold("Schema Snapshot:"), opts.SchemaVersion)
 	}
 	text.WriteString("\n")
 
 	if resource.BreakingChanges.Valid && resource.BreakingChanges.String != "" {
-		text.WriteString("## Breaking & Conflicting Properties\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Breaking & Conflicting Properties"))
 		text.WriteString(resource.BreakingChanges.String)
 		text.WriteString("\n\n")
 	}
 
 	if opts.FilterSummary != "" {
-		fmt.Fprintf(&text, "_Filters applied_: %s\n\n", opts.FilterSummary)
+		fmt.Fprintf(&text, "%s: %s\n\n", st.italic("Filters applied"), opts.FilterSummary)
 	}
 
-	text.WriteString(formatAttributesSection(attrs, opts))
-	text.WriteString(formatRelationshipNotes(attrs))
+	text.WriteString(formatAttributesSection(st, attrs, opts))
+	text.WriteString(formatRelationshipNotes(st, attrs))
 	return text.String()
 }
 
-func formatAttributesSection(attrs []database.ProviderAttribute, opts SchemaRenderOptions) string {
+func formatAttributesSection(st Style, attrs []database.ProviderAttribute, opts SchemaRenderOptions) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "## Attributes (%d)\n\n", len(attrs))
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, fmt.Sprintf("Attributes (%d)", len(attrs))))
 
 	if len(attrs) == 0 {
 		if opts.Filtered {
@@ -110,54 +129,59 @@ func formatAttributesSection(attrs []database.ProviderAttribute, opts SchemaRend
 
 	if opts.Compact {
 		for _, attr := range attrs {
-			desc := attributeDescription(attr)
+			desc := TruncateDescription(attributeDescription(attr), opts.DescMaxChars)
 			flags := strings.Join(attributeFlags(attr), ", ")
 			if flags == "" {
 				flags = "-"
 			}
-			fmt.Fprintf(&text, "- `%s` (%s) — %s\n", attr.Name, flags, desc)
+			if cardinality := attributeCardinality(attr); cardinality != "" {
+				flags += "; " + cardinality
+			}
+			fmt.Fprintf(&text, "- %s (%s) — %s\n", st.code(attr.Name), flags, desc)
 		}
 		text.WriteString("\n")
 		return text.String()
 	}
 
-	text.WriteString("| Name | Type | Flags | Description |\n")
-	text.WriteString("|------|------|-------|-------------|\n")
+	rows := st.table("Name", "Type", "Flags", "Description")
 	for _, attr := range attrs {
-		typeLabel := attr.Type.String
+		typeLabel := attributeTypeLabel(attr)
 		if typeLabel == "" {
 			typeLabel = "(derived)"
 		}
+		if cardinality := attributeCardinality(attr); cardinality != "" {
+			typeLabel += " (" + cardinality + ")"
+		}
 		flags := strings.Join(attributeFlags(attr), ", ")
 		if flags == "" {
 			flags = "-"
 		}
-		desc := attributeDescription(attr)
-		fmt.Fprintf(&text, "| %s | %s | %s | %s |\n",
-			attr.Name,
-			escapePipes(typeLabel),
-			escapePipes(flags),
-			escapePipes(desc),
-		)
+		desc := TruncateDescription(attributeDescription(attr), opts.DescMaxChars)
+		rows.row(attr.Name, orDash(typeLabel), orDash(flags), orDash(desc))
 	}
+	text.WriteString(rows.String())
 	text.WriteString("\n")
 	return text.String()
 }
 
-func formatRelationshipNotes(attrs []database.ProviderAttribute) string {
+func formatRelationshipNotes(st Style, attrs []database.ProviderAttribute) string {
 	var conflicts []string
 	var exclusives []string
 	var nested []string
 
 	for _, attr := range attrs {
 		if attr.ConflictsWith.Valid {
-			conflicts = append(conflicts, fmt.Sprintf("- `%s` conflicts with `%s`", attr.Name, attr.ConflictsWith.String))
+			conflicts = append(conflicts, fmt.Sprintf("- %s conflicts with %s", st.code(attr.Name), st.code(attr.ConflictsWith.String)))
 		}
 		if attr.ExactlyOneOf.Valid {
-			exclusives = append(exclusives, fmt.Sprintf("- `%s` exactly_one_of `%s`", attr.Name, attr.ExactlyOneOf.String))
+			exclusives = append(exclusives, fmt.Sprintf("- %s exactly_one_of %s", st.code(attr.Name), st.code(attr.ExactlyOneOf.String)))
 		}
 		if attr.NestedBlock {
-			nested = append(nested, fmt.Sprintf("- `%s` nested block → %s", attr.Name, attr.ElemSummary.String))
+			note := fmt.Sprintf("- %s nested block → %s", st.code(attr.Name), attr.ElemSummary.String)
+			if cardinality := attributeCardinality(attr); cardinality != "" {
+				note += " (" + cardinality + ")"
+			}
+			nested = append(nested, note)
 		}
 	}
 
@@ -166,19 +190,19 @@ func formatRelationshipNotes(attrs []database.ProviderAttribute) string {
 	}
 
 	var text strings.Builder
-	text.WriteString("## Relationship Notes\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Relationship Notes"))
 	if len(conflicts) > 0 {
-		text.WriteString("**Conflicts**\n")
+		fmt.Fprintf(&text, "%s\n", st.bold("Conflicts"))
 		text.WriteString(strings.Join(conflicts, "\n"))
 		text.WriteString("\n\n")
 	}
 	if len(exclusives) > 0 {
-		text.WriteString("**Mutually Exclusive**\n")
+		fmt.Fprintf(&text, "%s\n", st.bold("Mutually Exclusive"))
 		text.WriteString(strings.Join(exclusives, "\n"))
 		text.WriteString("\n\n")
 	}
 	if len(nested) > 0 {
-		text.WriteString("**Nested Blocks**\n")
+		fmt.Fprintf(&text, "%s\n", st.bold("Nested Blocks"))
 		text.WriteString(strings.Join(nested, "\n"))
 		text.WriteString("\n")
 	}
@@ -217,6 +241,25 @@ func attributeFlags(attr database.ProviderAttribute) []string {
 	return flags
 }
 
+// attributeTypeLabel renders lists, sets and maps of primitives Terraform-style, e.g. list(string);
+// other attributes keep their schema type constant.
+func attributeTypeLabel(attr database.ProviderAttribute) string {
+	if elem := attr.ElementType(); elem != "" {
+		switch collection := shortSchemaType(attr.Type.String); collection {
+		case "List", "Set", "Map":
+			return strings.ToLower(collection) + "(" + elem + ")"
+		}
+	}
+	return attr.Type.String
+}
+
+func attributeCardinality(attr database.ProviderAttribute) string {
+	if !attr.NestedBlock {
+		return ""
+	}
+	return blockCardinality(attr.Required, attr.Optional, attr.Computed, attr.MinItems.Int64, attr.MaxItems.Int64)
+}
+
 func attributeDescription(attr database.ProviderAttribute) string {
 	desc := attr.Description.String
 	if desc == "" {
@@ -231,32 +274,35 @@ func attributeDescription(attr database.ProviderAttribute) string {
 	return desc
 }
 
-func escapePipes(value string) string {
-	value = strings.TrimSpace(value)
-	if value == "" {
-		return "-"
+// TruncateDescription shortens text to maxChars runes, appending an ellipsis. maxChars <= 0 disables truncation.
+func TruncateDescription(desc string, maxChars int) string {
+	if maxChars <= 0 {
+		return desc
+	}
+	runes := []rune(strings.TrimSpace(desc))
+	if len(runes) <= maxChars {
+		return string(runes)
 	}
-	return strings.ReplaceAll(value, "|", "\\|")
+	return strings.TrimRight(string(runes[:maxChars]), " ") + "…"
 }
 
-func ProviderAttributeSearch(results []database.ProviderAttributeSearchResult) string {
+func ProviderAttributeSearch(st Style, results []database.ProviderAttributeSearchResult, descMaxChars int) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Attribute Search (%d matches)\n\n", len(results))
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute Search (%d matches)", len(results))))
 
 	if len(results) == 0 {
 		text.WriteString("No provider attributes matched the supplied filters.\n")
 		return text.String()
 	}
 
-	text.WriteString("| Resource | Attribute | Flags | Notes |\n")
-	text.WriteString("|----------|-----------|-------|-------|\n")
+	rows := st.table("Resource", "Attribute", "Flags", "Notes")
 	for _, res := range results {
 		resourceLabel := fmt.Sprintf("%s (%s)", res.ResourceName, res.ResourceKind)
 		flags := strings.Join(attributeFlags(res.Attribute), ", ")
 		if flags == "" {
 			flags = "-"
 		}
-		notes := attributeDescription(res.Attribute)
+		notes := TruncateDescription(attributeDescription(res.Attribute), descMaxChars)
 		if res.Attribute.ConflictsWith.Valid && res.Attribute.ConflictsWith.String != "" {
 			notes = fmt.Sprintf("%s — conflicts: %s", notes, res.Attribute.ConflictsWith.String)
 		}
@@ -269,13 +315,9 @@ func ProviderAttributeSearch(results []database.ProviderAttributeSearchResult) s
 		if res.ResourceFilePath.Valid {
 			notes = fmt.Sprintf("%s — %s", notes, res.ResourceFilePath.String)
 		}
-		fmt.Fprintf(&text, "| %s | `%s` | %s | %s |\n",
-			resourceLabel,
-			res.Attribute.Name,
-			escapePipes(flags),
-			escapePipes(notes),
-		)
+		rows.row(resourceLabel, st.code(res.Attribute.Name), orDash(flags), orDash(notes))
 	}
+	text.WriteString(rows.String())
 
 	text.WriteString("\n")
 	return text.String()
@@ -290,7 +332,50 @@ func ProviderAttributeSearchCompact(results []database.ProviderAttributeSearchRe
 	return text.String()
 }
 
-func ProviderSchemaSource(resourceName, section, filePath, functionName, snippet string, truncated bool) string {
+// AttributeDescriptionSearch renders attributes ranked by full-text relevance to query, best match first.
+func AttributeDescriptionSearch(st Style, query string, results []database.ProviderAttributeSearchResult, descMaxChars int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attributes Matching \"%s\" (%d matches)", query, len(results))))
+
+	if len(results) == 0 {
+		text.WriteString("No attribute names or descriptions matched the query. Try fewer or broader words.\n")
+		return text.String()
+	}
+
+	rows := st.table("#", "Resource", "Attribute", "Flags", "Description")
+	for i, res := range results {
+		flags := strings.Join(attributeFlags(res.Attribute), ", ")
+		if flags == "" {
+			flags = "-"
+		}
+		rows.row(fmt.Sprint(i+1), res.ResourceName, st.code(res.Attribute.Name), orDash(flags), orDash(TruncateDescription(attributeDescription(res.Attribute), descMaxChars)))
+	}
+	text.WriteString(rows.String())
+	return text.String()
+}
+
+// SchemaAttributeLines lists the top-level schema attributes of a definition with the file line
+// each one is declared on.
+func SchemaAttributeLines(st Style, resourceName, filePath, functionName string, lines []database.SchemaAttributeLine) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s Attribute Source Lines", resourceName)))
+	if filePath != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), filePath)
+	}
+	if functionName != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Function:"), functionName)
+	}
+	fmt.Fprintf(&text, "%s %d\n\n", st.bold("Attributes:"), len(lines))
+
+	rows := st.table("Attribute", "Line")
+	for _, line := range lines {
+		rows.row(orDash(line.Name), fmt.Sprint(line.Line))
+	}
+	text.WriteString(rows.String())
+	return text.String()
+}
+
+func ProviderSchemaSource(st Style, resourceName, section, filePath, functionName, snippet string, startLine, endLine int, truncated bool) string {
 	var text strings.Builder
 	sectionTitle := strings.TrimSpace(section)
 	if sectionTitle == "" {
@@ -299,25 +384,33 @@ func ProviderSchemaSource(resourceName, section, filePath, functionName, snippet
 		sectionTitle = strings.ToUpper(sectionTitle[:1]) + sectionTitle[1:]
 	}
 
-	fmt.Fprintf(&text, "# %s %s Source\n\n", resourceName, sectionTitle)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s %s Source", resourceName, sectionTitle)))
 	if filePath != "" {
-		fmt.Fprintf(&text, "**File:** %s\n", filePath)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), filePath)
 	}
 	if functionName != "" {
-		fmt.Fprintf(&text, "**Function:** %s\n", functionName)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Function:"), functionName)
+	}
+	if startLine > 0 {
+		location := fmt.Sprintf("line %d", startLine)
+		if endLine > startLine {
+			location = fmt.Sprintf("lines %d-%d", startLine, endLine)
+		}
+		if filePath != "" {
+			location += " of " + filePath
+		}
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Location:"), location)
 	}
-	fmt.Fprintf(&text, "**Section:** %s\n\n", sectionTitle)
+	fmt.Fprintf(&text, "%s %s\n\n", st.bold("Section:"), sectionTitle)
 
 	if strings.TrimSpace(snippet) == "" {
-		text.WriteString("Snippet not available. Run `get_file_content` to inspect the file directly.\n")
+		fmt.Fprintf(&text, "Snippet not available. Run %s to inspect the file directly.\n", st.code("get_file_content"))
 		return text.String()
 	}
 
-	text.WriteString("```go\n")
-	text.WriteString(snippet)
-	text.WriteString("\n```\n")
+	text.WriteString(st.codeFence("go", snippet))
 	if truncated {
-		text.WriteString("_Note: snippet trimmed for brevity._\n")
+		fmt.Fprintf(&text, "%s\n", st.italic("Note: snippet trimmed for brevity."))
 	}
 	return text.String()
 }
@@ -1,23 +1,36 @@
 package formatter
 
 import (
+	"cmp"
 	"fmt"
 	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
 )
 
-func UpdateBehaviorAnalysis(resourceName, attributeName string, canUpdateInPlace, requiresRecreation bool,
+// UpdateBehaviorAttributeMissing answers an update behavior query for an attribute the resource
+// does not declare, listing the attributes it does have.
+func UpdateBehaviorAttributeMissing(st Style, resourceName, attributeName string, suggestions []string) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Update Behavior: %s.%s", resourceName, attributeName)))
+	fmt.Fprintf(&text, "Attribute '%s' not found in resource schema.\n\n", attributeName)
+	fmt.Fprintf(&text, "Closest available attributes (ForceNew/in-place):\n%s\n", strings.Join(suggestions, "\n"))
+	return text.String()
+}
+
+func UpdateBehaviorAnalysis(st Style, resourceName, attributeName string, canUpdateInPlace, requiresRecreation bool,
 	isComputed, isOptional, isRequired bool, explanation, workaround string, hasCustomDiff bool, customDiffSnippet string,
 ) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Update Behavior: %s.%s\n\n", resourceName, attributeName)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Update Behavior: %s.%s", resourceName, attributeName)))
 
 	if canUpdateInPlace {
-		text.WriteString("**Can be updated in-place** (no recreation required)\n\n")
+		fmt.Fprintf(&text, "%s (no recreation required)\n\n", st.bold("Can be updated in-place"))
 	} else {
-		text.WriteString("**Requires resource recreation**\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.bold("Requires resource recreation"))
 	}
 
-	text.WriteString("## Attribute Flags\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Attribute Flags"))
 	flags := []string{}
 	if isRequired {
 		flags = append(flags, "Required")
@@ -37,85 +50,81 @@ func UpdateBehaviorAnalysis(resourceName, attributeName string, canUpdateInPlace
 	}
 
 	if explanation != "" {
-		text.WriteString("## Reason\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Reason"))
 		fmt.Fprintf(&text, "%s\n\n", explanation)
 	}
 
 	if hasCustomDiff {
-		text.WriteString("## CustomizeDiff Logic\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "CustomizeDiff Logic"))
 		text.WriteString("WARNING: This resource has CustomizeDiff logic that may allow conditional in-place updates even for ForceNew attributes.\n\n")
 		if customDiffSnippet != "" {
-			text.WriteString("```go\n")
-			text.WriteString(customDiffSnippet)
-			text.WriteString("\n```\n\n")
+			text.WriteString(st.codeFence("go", customDiffSnippet) + "\n")
 		}
 	}
 
 	if !canUpdateInPlace && workaround != "" {
-		text.WriteString("## Migration Path\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Migration Path"))
 		fmt.Fprintf(&text, "%s\n\n", workaround)
 	}
 
 	return text.String()
 }
 
-func BreakingChangeExplanation(resourceName, attributeName string, isBreaking bool,
+func BreakingChangeExplanation(st Style, resourceName, attributeName string, isBreaking bool,
 	reason, workaround string, required, optional, computed bool, deprecationNotice string,
 ) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Breaking Change Analysis: %s.%s\n\n", resourceName, attributeName)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Breaking Change Analysis: %s.%s", resourceName, attributeName)))
 
 	if isBreaking {
-		text.WriteString("**This attribute is marked ForceNew**\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.bold("This attribute is marked ForceNew"))
 		text.WriteString("Changing this attribute will trigger resource recreation.\n\n")
 	} else {
-		text.WriteString("**This attribute is NOT marked ForceNew**\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.bold("This attribute is NOT marked ForceNew"))
 		text.WriteString("This attribute can be updated in-place.\n\n")
 	}
 
-	text.WriteString("## Attribute Information\n\n")
-	fmt.Fprintf(&text, "- **Required**: %t\n", required)
-	fmt.Fprintf(&text, "- **Optional**: %t\n", optional)
-	fmt.Fprintf(&text, "- **Computed**: %t\n\n", computed)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Attribute Information"))
+	fmt.Fprintf(&text, "- %s: %t\n", st.bold("Required"), required)
+	fmt.Fprintf(&text, "- %s: %t\n", st.bold("Optional"), optional)
+	fmt.Fprintf(&text, "- %s: %t\n\n", st.bold("Computed"), computed)
 
 	if deprecationNotice != "" {
-		text.WriteString("**Deprecation Notice**\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.bold("Deprecation Notice"))
 		fmt.Fprintf(&text, "%s\n\n", deprecationNotice)
 	}
 
 	if reason != "" {
-		text.WriteString("## Why This Causes Recreation\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Why This Causes Recreation"))
 		fmt.Fprintf(&text, "%s\n\n", reason)
 	}
 
 	if isBreaking && workaround != "" {
-		text.WriteString("## Migration Strategy\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Migration Strategy"))
 		fmt.Fprintf(&text, "%s\n\n", workaround)
 	}
 
 	return text.String()
 }
 
-func ValidationSuggestions(resourceName string, totalAttributes, suggestionsCount int, suggestions []ValidationSuggestion) string {
+func ValidationSuggestions(st Style, resourceName string, totalAttributes, suggestionsCount int, suggestions []ValidationSuggestion) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Validation Analysis: %s\n\n", resourceName)
-	fmt.Fprintf(&text, "**Total Attributes**: %d\n", totalAttributes)
-	fmt.Fprintf(&text, "**Suggestions**: %d\n\n", suggestionsCount)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Validation Analysis: %s", resourceName)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Total Attributes"), totalAttributes)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Suggestions"), suggestionsCount)
 
 	if suggestionsCount == 0 {
 		text.WriteString("No validation improvements identified.\n")
 		return text.String()
 	}
 
-	text.WriteString("## Suggested Improvements\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Suggested Improvements"))
 	for i, sugg := range suggestions {
-		fmt.Fprintf(&text, "### %d. %s\n\n", i+1, sugg.Attribute)
-		fmt.Fprintf(&text, "**Issue**: %s\n\n", sugg.Issue)
-		fmt.Fprintf(&text, "**Suggestion**: %s\n\n", sugg.Suggestion)
+		fmt.Fprintf(&text, "%s\n\n", st.heading(3, fmt.Sprintf("%d. %s", i+1, sugg.Attribute)))
+		fmt.Fprintf(&text, "%s: %s\n\n", st.bold("Issue"), sugg.Issue)
+		fmt.Fprintf(&text, "%s: %s\n\n", st.bold("Suggestion"), sugg.Suggestion)
 		if sugg.Example != "" {
-			text.WriteString("**Example**:\n```go\n")
-			text.WriteString(sugg.Example)
-			text.WriteString("\n```\n\n")
+			fmt.Fprintf(&text, "%s:\n%s\n", st.bold("Example"), st.codeFence("go", sugg.Example))
 		}
 	}
 
@@ -129,131 +138,184 @@ type ValidationSuggestion struct {
 	Example    string
 }
 
-func AttributeDependencies(resourceName, attributeName string, conflictsWith, exactlyOneOf, atLeastOneOf, requiredWith []string,
+func AttributeDependencies(st Style, resourceName, attributeName string, conflictsWith, exactlyOneOf, atLeastOneOf, requiredWith []string,
 	isRequired, isOptional, isComputed, forcesRecreation bool, dependencyVisualization string,
 ) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Attribute Dependencies: %s.%s\n\n", resourceName, attributeName)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute Dependencies: %s.%s", resourceName, attributeName)))
 
-	text.WriteString("## Flags\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Flags"))
 	if isRequired {
-		text.WriteString("- **Required**\n")
+		fmt.Fprintf(&text, "- %s\n", st.bold("Required"))
 	}
 	if isOptional {
-		text.WriteString("- **Optional**\n")
+		fmt.Fprintf(&text, "- %s\n", st.bold("Optional"))
 	}
 	if isComputed {
-		text.WriteString("- **Computed**\n")
+		fmt.Fprintf(&text, "- %s\n", st.bold("Computed"))
 	}
 	if forcesRecreation {
-		text.WriteString("- **ForceNew** - triggers recreation\n")
+		fmt.Fprintf(&text, "- %s - triggers recreation\n", st.bold("ForceNew"))
 	}
 	text.WriteString("\n")
 
 	hasAnyDeps := len(conflictsWith) > 0 || len(exactlyOneOf) > 0 || len(atLeastOneOf) > 0 || len(requiredWith) > 0
 
 	if !hasAnyDeps {
-		text.WriteString("## Dependencies\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Dependencies"))
 		text.WriteString("No attribute dependencies or constraints defined.\n\n")
 	} else {
 		if len(conflictsWith) > 0 {
-			text.WriteString("## ConflictsWith\n\n")
+			fmt.Fprintf(&text, "%s\n\n", st.heading(2, "ConflictsWith"))
 			text.WriteString("This attribute conflicts with the following attributes (cannot be set together):\n\n")
 			for _, attr := range conflictsWith {
-				fmt.Fprintf(&text, "- `%s`\n", attr)
+				fmt.Fprintf(&text, "- %s\n", st.code(attr))
 			}
 			text.WriteString("\n")
 		}
 
 		if len(exactlyOneOf) > 0 {
-			text.WriteString("## ExactlyOneOf\n\n")
+			fmt.Fprintf(&text, "%s\n\n", st.heading(2, "ExactlyOneOf"))
 			text.WriteString("Exactly one of the following attributes must be specified:\n\n")
 			for _, attr := range exactlyOneOf {
-				fmt.Fprintf(&text, "- `%s`\n", attr)
+				fmt.Fprintf(&text, "- %s\n", st.code(attr))
 			}
 			text.WriteString("\n")
 		}
 
 		if len(atLeastOneOf) > 0 {
-			text.WriteString("## AtLeastOneOf\n\n")
+			fmt.Fprintf(&text, "%s\n\n", st.heading(2, "AtLeastOneOf"))
 			text.WriteString("At least one of the following attributes must be specified:\n\n")
 			for _, attr := range atLeastOneOf {
-				fmt.Fprintf(&text, "- `%s`\n", attr)
+				fmt.Fprintf(&text, "- %s\n", st.code(attr))
 			}
 			text.WriteString("\n")
 		}
 
 		if len(requiredWith) > 0 {
-			text.WriteString("## RequiredWith\n\n")
+			fmt.Fprintf(&text, "%s\n\n", st.heading(2, "RequiredWith"))
 			text.WriteString("If this attribute is set, the following attributes are also required:\n\n")
 			for _, attr := range requiredWith {
-				fmt.Fprintf(&text, "- `%s`\n", attr)
+				fmt.Fprintf(&text, "- %s\n", st.code(attr))
 			}
 			text.WriteString("\n")
 		}
 	}
 
 	if dependencyVisualization != "" {
-		text.WriteString("## Dependency Graph\n\n")
-		text.WriteString("```\n")
-		text.WriteString(dependencyVisualization)
-		text.WriteString("\n```\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Dependency Graph"))
+		text.WriteString(st.codeFence("", dependencyVisualization) + "\n")
 	}
 
 	return text.String()
 }
 
-func ResourceComparison(resourceA, resourceB string, similarityScore float64,
+func ResourceComparison(st Style, resourceA, resourceB string, similarityScore float64,
 	totalAttrsA, totalAttrsB, commonCount, uniqueACount, uniqueBCount, forceNewA, forceNewB int,
 	commonNames, uniqueANames, uniqueBNames []string, namesTruncated bool,
 ) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Resource Comparison: %s vs %s\n\n", resourceA, resourceB)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resource Comparison: %s vs %s", resourceA, resourceB)))
 
-	text.WriteString("## Similarity\n\n")
-	fmt.Fprintf(&text, "**Similarity Score**: %.1f%%\n\n", similarityScore*100)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Similarity"))
+	fmt.Fprintf(&text, "%s: %.1f%%\n\n", st.bold("Similarity Score"), similarityScore*100)
 
-	text.WriteString("## Overview\n\n")
-	fmt.Fprintf(&text, "| Metric | %s | %s |\n", resourceA, resourceB)
-	text.WriteString("|--------|------------|------------|\n")
-	fmt.Fprintf(&text, "| Total Attributes | %d | %d |\n", totalAttrsA, totalAttrsB)
-	fmt.Fprintf(&text, "| ForceNew Count | %d | %d |\n", forceNewA, forceNewB)
-	fmt.Fprintf(&text, "| Unique Attributes | %d | %d |\n", uniqueACount, uniqueBCount)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Overview"))
+	rows := st.table("Metric", resourceA, resourceB)
+	rows.row("Total Attributes", fmt.Sprint(totalAttrsA), fmt.Sprint(totalAttrsB))
+	rows.row("ForceNew Count", fmt.Sprint(forceNewA), fmt.Sprint(forceNewB))
+	rows.row("Unique Attributes", fmt.Sprint(uniqueACount), fmt.Sprint(uniqueBCount))
+	text.WriteString(rows.String())
 	text.WriteString("\n")
 
-	fmt.Fprintf(&text, "**Common Attributes**: %d\n\n", commonCount)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Common Attributes"), commonCount)
 
 	if len(commonNames) > 0 {
-		text.WriteString("### Shared Attributes\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(3, "Shared Attributes"))
 		for _, name := range commonNames {
-			fmt.Fprintf(&text, "- `%s`\n", name)
+			fmt.Fprintf(&text, "- %s\n", st.code(name))
 		}
 		text.WriteString("\n")
 	}
 
 	if len(uniqueANames) > 0 {
-		fmt.Fprintf(&text, "### Unique to %s\n\n", resourceA)
+		fmt.Fprintf(&text, "%s\n\n", st.heading(3, fmt.Sprintf("Unique to %s", resourceA)))
 		for _, name := range uniqueANames {
-			fmt.Fprintf(&text, "- `%s`\n", name)
+			fmt.Fprintf(&text, "- %s\n", st.code(name))
 		}
 		text.WriteString("\n")
 	}
 
 	if len(uniqueBNames) > 0 {
-		fmt.Fprintf(&text, "### Unique to %s\n\n", resourceB)
+		fmt.Fprintf(&text, "%s\n\n", st.heading(3, fmt.Sprintf("Unique to %s", resourceB)))
 		for _, name := range uniqueBNames {
-			fmt.Fprintf(&text, "- `%s`\n", name)
+			fmt.Fprintf(&text, "- %s\n", st.code(name))
 		}
 		text.WriteString("\n")
 	}
 
 	if namesTruncated {
-		text.WriteString("_Note: Attribute lists truncated for readability. Use max_names=-1 to see all._\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.italic("Note: Attribute lists truncated for readability. Use max_names=-1 to see all."))
+	}
+
+	return text.String()
+}
+
+// KindLabel renders a definition kind such as "data_source" for prose ("data source").
+func KindLabel(kind string) string {
+	return strings.ReplaceAll(kind, "_", " ")
+}
+
+// AttributeFlagDifferences lists attributes present on both definitions whose type or
+// required/optional/computed mode differs, e.g. an argument of a resource that its data source
+// only exports. ForceNew is compared only when withForceNew is set; data sources never replace
+// anything.
+func AttributeFlagDifferences(st Style, labelA, labelB string, attrsA, attrsB []database.ProviderAttribute, withForceNew bool) string {
+	byName := make(map[string]database.ProviderAttribute, len(attrsB))
+	for _, attr := range attrsB {
+		byName[attr.Name] = attr
+	}
+
+	rows := st.table("Attribute", labelA, labelB)
+	for _, a := range attrsA {
+		b, ok := byName[a.Name]
+		if !ok {
+			continue
+		}
+		shapeA, shapeB := attributeShape(a, withForceNew), attributeShape(b, withForceNew)
+		if shapeA != shapeB {
+			rows.row(a.Name, shapeA, shapeB)
+		}
+	}
+	if len(rows.rows) == 0 {
+		return st.italic("Shared attributes have the same type and mode on both sides.") + "\n"
 	}
 
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(3, fmt.Sprintf("Flag Differences (%d)", len(rows.rows))))
+	text.WriteString(rows.String())
+	text.WriteString("\n")
 	return text.String()
 }
 
+// attributeShape summarizes an attribute's type and mode, e.g. "String, optional+computed".
+func attributeShape(attr database.ProviderAttribute, withForceNew bool) string {
+	var modes []string
+	if attr.Required {
+		modes = append(modes, "required")
+	}
+	if attr.Optional {
+		modes = append(modes, "optional")
+	}
+	if attr.Computed {
+		modes = append(modes, "computed")
+	}
+	if withForceNew && attr.ForceNew {
+		modes = append(modes, "force_new")
+	}
+	return fmt.Sprintf("%s, %s", cmp.Or(shortSchemaType(attr.Type.String), "unknown"), cmp.Or(strings.Join(modes, "+"), "unset"))
+}
+
 type SimilarResource struct {
 	Name            string
 	SimilarityScore float64
@@ -261,11 +323,11 @@ type SimilarResource struct {
 	FilePath        string
 }
 
-func SimilarResources(targetResource string, threshold float64, matchesFound int, resources []SimilarResource) string {
+func SimilarResources(st Style, targetResource string, threshold float64, matchesFound int, resources []SimilarResource) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Similar Resources to %s\n\n", targetResource)
-	fmt.Fprintf(&text, "**Similarity Threshold**: %.0f%%\n", threshold*100)
-	fmt.Fprintf(&text, "**Matches Found**: %d\n\n", matchesFound)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Similar Resources to %s", targetResource)))
+	fmt.Fprintf(&text, "%s: %.0f%%\n", st.bold("Similarity Threshold"), threshold*100)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches Found"), matchesFound)
 
 	if matchesFound == 0 {
 		text.WriteString("No resources found matching the similarity threshold.\n\n")
@@ -273,15 +335,54 @@ func SimilarResources(targetResource string, threshold float64, matchesFound int
 		return text.String()
 	}
 
-	text.WriteString("## Similar Resources (Ranked by Similarity)\n\n")
-	text.WriteString("| Rank | Resource | Similarity | Common Attributes |\n")
-	text.WriteString("|------|----------|------------|-------------------|\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Similar Resources (Ranked by Similarity)"))
+	rows := st.table("Rank", "Resource", "Similarity", "Common Attributes")
 
 	for i, res := range resources {
-		fmt.Fprintf(&text, "| %d | %s | %.1f%% | %d |\n",
-			i+1, res.Name, res.SimilarityScore*100, res.CommonAttrCount)
+		rows.row(fmt.Sprint(i+1), res.Name, fmt.Sprintf("%.1f%%", res.SimilarityScore*100), fmt.Sprint(res.CommonAttrCount))
 	}
+	text.WriteString(rows.String())
 	text.WriteString("\n")
 
 	return text.String()
 }
+
+// SimilarResourcesCapped notes that only the top shown of matches similar resources were listed
+// because results stop at limit.
+func SimilarResourcesCapped(st Style, shown, matches, limit int) string {
+	return st.italic(fmt.Sprintf("Showing the top %d of %d matches; results are capped at %d. Raise similarity_threshold to narrow the list.", shown, matches, limit)) + "\n"
+}
+
+// AttributeMatch is a candidate equivalent of an attribute in another resource.
+type AttributeMatch struct {
+	Path      string
+	Match     string // "exact name", "normalized name" or "description"
+	Score     float64
+	Attribute database.NestedAttribute
+}
+
+// SimilarAttributes formats the candidate equivalents in resourceB of one attribute of resourceA.
+func SimilarAttributes(st Style, resourceA, attribute, resourceB string, source database.NestedAttribute, matches []AttributeMatch, total int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Equivalents of %s.%s in %s", resourceA, attribute, resourceB)))
+	fmt.Fprintf(&text, "%s: %s (%s)\n", st.bold("Source"), st.code(attribute), strings.Join(append([]string{cmp.Or(shortSchemaType(source.Type), "-")}, nestedAttributeFlags(source)...), ", "))
+	if source.Description != "" {
+		fmt.Fprintf(&text, "%s: %s\n", st.bold("Description"), source.Description)
+	}
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Candidates Found"), total)
+
+	if total == 0 {
+		fmt.Fprintf(&text, "No attribute of %s matches by name or description.\n", resourceB)
+		return text.String()
+	}
+
+	rows := st.table("Attribute", "Match", "Score", "Type", "Flags", "Description")
+	for _, m := range matches {
+		rows.row(m.Path, m.Match, fmt.Sprintf("%.0f%%", m.Score*100), cmp.Or(shortSchemaType(m.Attribute.Type), "-"), strings.Join(nestedAttributeFlags(m.Attribute), ", "), orDash(m.Attribute.Description))
+	}
+	text.WriteString(rows.String())
+	if len(matches) < total {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d candidates.", len(matches), total)))
+	}
+	return text.String()
+}
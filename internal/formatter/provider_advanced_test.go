@@ -8,6 +8,7 @@ import (
 func TestUpdateBehaviorAnalysis(t *testing.T) {
 	t.Run("can update in place", func(t *testing.T) {
 		result := UpdateBehaviorAnalysis(
+			Style{},
 			"azurerm_virtual_network", "address_space",
 			true, false,
 			false, true, false,
@@ -32,6 +33,7 @@ func TestUpdateBehaviorAnalysis(t *testing.T) {
 
 	t.Run("requires recreation", func(t *testing.T) {
 		result := UpdateBehaviorAnalysis(
+			Style{},
 			"azurerm_storage_account", "name",
 			false, true,
 			false, false, true,
@@ -59,6 +61,7 @@ func TestUpdateBehaviorAnalysis(t *testing.T) {
 
 	t.Run("with custom diff", func(t *testing.T) {
 		result := UpdateBehaviorAnalysis(
+			Style{},
 			"azurerm_kubernetes_cluster", "node_pool",
 			false, true,
 			true, true, false,
@@ -83,6 +86,7 @@ func TestUpdateBehaviorAnalysis(t *testing.T) {
 
 	t.Run("all flags set", func(t *testing.T) {
 		result := UpdateBehaviorAnalysis(
+			Style{},
 			"azurerm_resource", "attr",
 			false, true,
 			true, true, true,
@@ -107,6 +111,7 @@ func TestUpdateBehaviorAnalysis(t *testing.T) {
 func TestBreakingChangeExplanation(t *testing.T) {
 	t.Run("breaking attribute", func(t *testing.T) {
 		result := BreakingChangeExplanation(
+			Style{},
 			"azurerm_storage_account", "account_tier",
 			true,
 			"The account tier cannot be changed on an existing storage account.",
@@ -134,6 +139,7 @@ func TestBreakingChangeExplanation(t *testing.T) {
 
 	t.Run("non-breaking attribute", func(t *testing.T) {
 		result := BreakingChangeExplanation(
+			Style{},
 			"azurerm_virtual_network", "tags",
 			false,
 			"",
@@ -155,6 +161,7 @@ func TestBreakingChangeExplanation(t *testing.T) {
 
 	t.Run("with deprecation notice", func(t *testing.T) {
 		result := BreakingChangeExplanation(
+			Style{},
 			"azurerm_resource", "old_attr",
 			false,
 			"",
@@ -174,7 +181,7 @@ func TestBreakingChangeExplanation(t *testing.T) {
 
 func TestValidationSuggestions(t *testing.T) {
 	t.Run("no suggestions", func(t *testing.T) {
-		result := ValidationSuggestions("azurerm_storage_account", 50, 0, nil)
+		result := ValidationSuggestions(Style{}, "azurerm_storage_account", 50, 0, nil)
 
 		if !strings.Contains(result, "# Validation Analysis: azurerm_storage_account") {
 			t.Error("expected header")
@@ -206,7 +213,7 @@ func TestValidationSuggestions(t *testing.T) {
 			},
 		}
 
-		result := ValidationSuggestions("azurerm_storage_account", 50, 2, suggestions)
+		result := ValidationSuggestions(Style{}, "azurerm_storage_account", 50, 2, suggestions)
 
 		if !strings.Contains(result, "## Suggested Improvements") {
 			t.Error("expected improvements section")
@@ -235,6 +242,7 @@ func TestValidationSuggestions(t *testing.T) {
 func TestAttributeDependencies(t *testing.T) {
 	t.Run("no dependencies", func(t *testing.T) {
 		result := AttributeDependencies(
+			Style{},
 			"azurerm_resource", "attr",
 			nil, nil, nil, nil,
 			false, true, false, false,
@@ -254,6 +262,7 @@ func TestAttributeDependencies(t *testing.T) {
 
 	t.Run("with all dependency types", func(t *testing.T) {
 		result := AttributeDependencies(
+			Style{},
 			"azurerm_resource", "attr",
 			[]string{"other_attr"},
 			[]string{"option_a", "option_b"},
@@ -303,6 +312,7 @@ func TestAttributeDependencies(t *testing.T) {
 
 	t.Run("computed and optional", func(t *testing.T) {
 		result := AttributeDependencies(
+			Style{},
 			"azurerm_resource", "id",
 			nil, nil, nil, nil,
 			false, true, true, false,
@@ -321,6 +331,7 @@ func TestAttributeDependencies(t *testing.T) {
 func TestResourceComparison(t *testing.T) {
 	t.Run("similar resources", func(t *testing.T) {
 		result := ResourceComparison(
+			Style{},
 			"azurerm_storage_account", "azurerm_storage_account_blob_container",
 			0.75,
 			50, 30, 20, 30, 10, 5, 3,
@@ -367,6 +378,7 @@ func TestResourceComparison(t *testing.T) {
 
 	t.Run("truncated lists", func(t *testing.T) {
 		result := ResourceComparison(
+			Style{},
 			"azurerm_a", "azurerm_b",
 			0.5,
 			100, 100, 50, 50, 50, 10, 10,
@@ -386,6 +398,7 @@ func TestResourceComparison(t *testing.T) {
 
 	t.Run("empty unique lists", func(t *testing.T) {
 		result := ResourceComparison(
+			Style{},
 			"azurerm_a", "azurerm_b",
 			1.0,
 			10, 10, 10, 0, 0, 2, 2,
@@ -406,7 +419,7 @@ func TestResourceComparison(t *testing.T) {
 
 func TestSimilarResources(t *testing.T) {
 	t.Run("no matches", func(t *testing.T) {
-		result := SimilarResources("azurerm_nonexistent", 0.9, 0, nil)
+		result := SimilarResources(Style{}, "azurerm_nonexistent", 0.9, 0, nil)
 
 		if !strings.Contains(result, "# Similar Resources to azurerm_nonexistent") {
 			t.Error("expected header")
@@ -432,7 +445,7 @@ func TestSimilarResources(t *testing.T) {
 			{Name: "azurerm_storage_account_table", SimilarityScore: 0.75, CommonAttrCount: 30},
 		}
 
-		result := SimilarResources("azurerm_storage_account", 0.7, 3, resources)
+		result := SimilarResources(Style{}, "azurerm_storage_account", 0.7, 3, resources)
 
 		if !strings.Contains(result, "## Similar Resources (Ranked by Similarity)") {
 			t.Error("expected similar resources section")
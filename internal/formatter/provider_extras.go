@@ -1,13 +1,16 @@
 package formatter
 
 import (
+	"cmp"
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
 )
 
 // ResourceDocs renders documentation extracted from the provider docs tree.
-func ResourceDocs(resourceName, kind, filePath, section string, sectionFound bool, content string) string {
+func ResourceDocs(st Style, resourceName, kind, filePath, section string, sectionFound bool, content string) string {
 	var text strings.Builder
 
 	titleKind := "Resource"
@@ -15,16 +18,16 @@ func ResourceDocs(resourceName, kind, filePath, section string, sectionFound boo
 		titleKind = "Data Source"
 	}
 
-	fmt.Fprintf(&text, "# Documentation: %s\n\n", resourceName)
-	fmt.Fprintf(&text, "**Kind:** %s\n", titleKind)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Documentation: %s", resourceName)))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Kind:"), titleKind)
 	if filePath != "" {
-		fmt.Fprintf(&text, "**Source:** %s\n", filePath)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Source:"), filePath)
 	}
 	if section != "" {
 		if sectionFound {
-			fmt.Fprintf(&text, "**Section:** %s\n", section)
+			fmt.Fprintf(&text, "%s %s\n", st.bold("Section:"), section)
 		} else {
-			fmt.Fprintf(&text, "**Section:** %s (not found, showing closest match)\n", section)
+			fmt.Fprintf(&text, "%s %s (not found, showing closest match)\n", st.bold("Section:"), section)
 		}
 	}
 	text.WriteString("\n")
@@ -40,7 +43,7 @@ type ResourceTestFile struct {
 }
 
 // ResourceTestOverview renders a summary of acceptance tests associated with a resource or data source.
-func ResourceTestOverview(resourceName, kind string, files []ResourceTestFile) string {
+func ResourceTestOverview(st Style, resourceName, kind string, files []ResourceTestFile) string {
 	var text strings.Builder
 
 	titleKind := "Resource"
@@ -48,7 +51,7 @@ func ResourceTestOverview(resourceName, kind string, files []ResourceTestFile) s
 		titleKind = "Data Source"
 	}
 
-	fmt.Fprintf(&text, "# Acceptance Tests for %s (%s)\n\n", resourceName, titleKind)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Acceptance Tests for %s (%s)", resourceName, titleKind)))
 
 	if len(files) == 0 {
 		text.WriteString("No acceptance tests were discovered for this definition.\n")
@@ -62,9 +65,9 @@ func ResourceTestOverview(resourceName, kind string, files []ResourceTestFile) s
 	fmt.Fprintf(&text, "Discovered %d test file(s) with %d test case(s).\n\n", len(files), totalTests)
 
 	for _, file := range files {
-		fmt.Fprintf(&text, "## %s\n", file.FilePath)
+		fmt.Fprintf(&text, "%s\n", st.heading(2, file.FilePath))
 		if len(file.Tests) == 0 {
-			text.WriteString("_No matching test cases found in this file._\n\n")
+			fmt.Fprintf(&text, "%s\n\n", st.italic("No matching test cases found in this file."))
 			continue
 		}
 		for _, test := range file.Tests {
@@ -76,8 +79,33 @@ func ResourceTestOverview(resourceName, kind string, files []ResourceTestFile) s
 	return text.String()
 }
 
-// FeatureFlagInfo captures metadata about a provider feature flag.
+// ResourceTestSource holds the source of a single acceptance test function.
+type ResourceTestSource struct {
+	FilePath  string
+	StartLine int
+	EndLine   int
+	Source    string
+}
+
+// ResourceTest renders the source of one acceptance test for a resource or data source.
+func ResourceTest(st Style, resourceName, testName string, test ResourceTestSource) string {
+	var text strings.Builder
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, testName))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Resource:"), resourceName)
+	fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), test.FilePath)
+	if test.StartLine > 0 {
+		fmt.Fprintf(&text, "%s %d-%d\n", st.bold("Lines:"), test.StartLine, test.EndLine)
+	}
+
+	text.WriteString("\n" + st.codeFence("go", strings.TrimRight(test.Source, "\n")))
+	return text.String()
+}
+
+// FeatureFlagInfo captures metadata about a provider feature flag. Flags of the nested features
+// block carry the dotted path of their sub-block in Block, e.g. "key_vault".
 type FeatureFlagInfo struct {
+	Block       string
 	Key         string
 	Description string
 	Default     string
@@ -85,33 +113,60 @@ type FeatureFlagInfo struct {
 	DisabledFor []string
 }
 
-// FeatureFlagList renders the available feature flags and their metadata.
-func FeatureFlagList(flags []FeatureFlagInfo) string {
+// FeatureFlagList renders the available feature flags and their metadata. Flags of the nested
+// features block are listed per sub-block after the flat flags.
+func FeatureFlagList(st Style, flags []FeatureFlagInfo) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Feature Flags (%d)\n\n", len(flags))
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Feature Flags (%d)", len(flags))))
 
 	if len(flags) == 0 {
 		text.WriteString("No feature flags were detected in the provider configuration.\n")
 		return text.String()
 	}
 
-	sort.Slice(flags, func(i, j int) bool {
+	sort.SliceStable(flags, func(i, j int) bool {
+		if flags[i].Block != flags[j].Block {
+			return flags[i].Block < flags[j].Block
+		}
 		return flags[i].Key < flags[j].Key
 	})
 
+	block := ""
 	for _, flag := range flags {
-		fmt.Fprintf(&text, "## %s\n", flag.Key)
+		if flag.Block != "" {
+			if block == "" {
+				fmt.Fprintf(&text, "%s\n\n", st.heading(2, "features {} Block"))
+			}
+			if flag.Block != block {
+				if block != "" {
+					text.WriteString("\n")
+				}
+				fmt.Fprintf(&text, "%s\n\n", st.heading(3, flag.Block))
+				block = flag.Block
+			}
+			fmt.Fprintf(&text, "- %s", st.code(flag.Key))
+			if flag.Default != "" {
+				fmt.Fprintf(&text, " (default: %s)", flag.Default)
+			}
+			if flag.Description != "" {
+				fmt.Fprintf(&text, ": %s", strings.Join(strings.Fields(flag.Description), " "))
+			}
+			text.WriteString("\n")
+			continue
+		}
+
+		fmt.Fprintf(&text, "%s\n", st.heading(2, flag.Key))
 		if flag.Description != "" {
 			fmt.Fprintf(&text, "%s\n\n", flag.Description)
 		}
 		if flag.Stage != "" {
-			fmt.Fprintf(&text, "- **Stage:** %s\n", flag.Stage)
+			fmt.Fprintf(&text, "- %s %s\n", st.bold("Stage:"), flag.Stage)
 		}
 		if flag.Default != "" {
-			fmt.Fprintf(&text, "- **Default:** %s\n", flag.Default)
+			fmt.Fprintf(&text, "- %s %s\n", st.bold("Default:"), flag.Default)
 		}
 		if len(flag.DisabledFor) > 0 {
-			fmt.Fprintf(&text, "- **Disabled for:** %s\n", strings.Join(flag.DisabledFor, ", "))
+			fmt.Fprintf(&text, "- %s %s\n", st.bold("Disabled for:"), strings.Join(flag.DisabledFor, ", "))
 		}
 		text.WriteString("\n")
 	}
@@ -137,7 +192,7 @@ type ResourceBehaviorInfo struct {
 }
 
 // ResourceBehaviors renders the behavioural summary for a resource/data source.
-func ResourceBehaviors(resourceName, kind string, info ResourceBehaviorInfo) string {
+func ResourceBehaviors(st Style, resourceName, kind string, info ResourceBehaviorInfo) string {
 	var text strings.Builder
 
 	titleKind := "Resource"
@@ -145,23 +200,23 @@ func ResourceBehaviors(resourceName, kind string, info ResourceBehaviorInfo) str
 		titleKind = "Data Source"
 	}
 
-	fmt.Fprintf(&text, "# Behaviors for %s (%s)\n\n", resourceName, titleKind)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Behaviors for %s (%s)", resourceName, titleKind)))
 	if info.FilePath != "" {
-		fmt.Fprintf(&text, "**File:** %s\n", info.FilePath)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), info.FilePath)
 	}
 	if info.FunctionName != "" {
-		fmt.Fprintf(&text, "**Function:** %s\n", info.FunctionName)
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Function:"), info.FunctionName)
 	}
 	text.WriteString("\n")
 
 	if len(info.Timeouts) > 0 {
-		text.WriteString("## Timeouts\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Timeouts"))
 		for _, t := range info.Timeouts {
 			fmt.Fprintf(&text, "- %s: %s\n", t.Name, t.Value)
 		}
 		text.WriteString("\n")
 	} else if strings.TrimSpace(info.TimeoutsRaw) != "" {
-		text.WriteString("## Timeouts\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Timeouts"))
 		text.WriteString(info.TimeoutsRaw)
 		if !strings.HasSuffix(info.TimeoutsRaw, "\n") {
 			text.WriteString("\n")
@@ -170,7 +225,7 @@ func ResourceBehaviors(resourceName, kind string, info ResourceBehaviorInfo) str
 	}
 
 	if len(info.CustomizeDiff) > 0 {
-		text.WriteString("## CustomizeDiff\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "CustomizeDiff"))
 		for _, entry := range info.CustomizeDiff {
 			fmt.Fprintf(&text, "- %s\n", entry)
 		}
@@ -178,7 +233,7 @@ func ResourceBehaviors(resourceName, kind string, info ResourceBehaviorInfo) str
 	}
 
 	if strings.TrimSpace(info.Importer) != "" {
-		text.WriteString("## Importer\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Importer"))
 		text.WriteString(info.Importer)
 		if !strings.HasSuffix(info.Importer, "\n") {
 			text.WriteString("\n")
@@ -187,7 +242,7 @@ func ResourceBehaviors(resourceName, kind string, info ResourceBehaviorInfo) str
 	}
 
 	if len(info.Notes) > 0 {
-		text.WriteString("## Additional Notes\n\n")
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Additional Notes"))
 		for _, note := range info.Notes {
 			fmt.Fprintf(&text, "- %s\n", note)
 		}
@@ -211,9 +266,9 @@ type ExampleFile struct {
 }
 
 // ExampleDirectory renders the files that make up an example scenario.
-func ExampleDirectory(examplePath string, files []ExampleFile) string {
+func ExampleDirectory(st Style, examplePath string, files []ExampleFile) string {
 	var text strings.Builder
-	fmt.Fprintf(&text, "# Example: %s\n\n", examplePath)
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Example: %s", examplePath)))
 
 	if len(files) == 0 {
 		text.WriteString("No files were found for this example.\n")
@@ -227,14 +282,14 @@ func ExampleDirectory(examplePath string, files []ExampleFile) string {
 	})
 
 	for _, file := range files {
-		fmt.Fprintf(&text, "## %s\n\n", file.FilePath)
-		text.WriteString(renderExampleFileContent(file))
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, file.FilePath))
+		text.WriteString(renderExampleFileContent(st, file))
 	}
 
 	return text.String()
 }
 
-func renderExampleFileContent(file ExampleFile) string {
+func renderExampleFileContent(st Style, file ExampleFile) string {
 	var text strings.Builder
 
 	language := ""
@@ -251,30 +306,148 @@ func renderExampleFileContent(file ExampleFile) string {
 		language = ""
 	}
 
-	if language == "" && !strings.HasSuffix(file.FileName, ".md") {
-		text.WriteString("```\n")
+	if strings.HasSuffix(file.FileName, ".md") {
 		text.WriteString(file.Content)
 		if !strings.HasSuffix(file.Content, "\n") {
 			text.WriteString("\n")
 		}
-		text.WriteString("```\n\n")
+		text.WriteString("\n")
 		return text.String()
 	}
 
-	if strings.HasSuffix(file.FileName, ".md") {
-		text.WriteString(file.Content)
-		if !strings.HasSuffix(file.Content, "\n") {
-			text.WriteString("\n")
+	text.WriteString(st.codeFence(language, file.Content) + "\n")
+	return text.String()
+}
+
+// SourceURL renders a GitHub permalink to the implementation of a resource.
+func SourceURL(st Style, resourceName, link, filePath, functionName, ref string, startLine, endLine int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s Source", resourceName)))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("URL:"), link)
+	fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), filePath)
+	if functionName != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Function:"), functionName)
+	}
+	switch {
+	case startLine > 0 && endLine > startLine:
+		fmt.Fprintf(&text, "%s %d-%d\n", st.bold("Lines:"), startLine, endLine)
+	case startLine > 0:
+		fmt.Fprintf(&text, "%s %d\n", st.bold("Line:"), startLine)
+	}
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Ref:"), ref)
+	if ref == "HEAD" {
+		fmt.Fprintf(&text, "\n%s\n", st.italic("HEAD follows the default branch, so line anchors may drift after new commits; start the server with --ref to pin a tag."))
+	}
+	return text.String()
+}
+
+// RegistrationInfo describes the service a definition is registered under and where issues
+// about it are tracked.
+type RegistrationInfo struct {
+	ResourceName      string
+	Kind              string
+	FilePath          string
+	ServiceName       string
+	ServiceFile       string
+	WebsiteCategories []string
+	GitHubLabel       string
+	IssuesURL         string
+	NewIssueURL       string
+}
+
+func Registration(st Style, info RegistrationInfo) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s Registration", info.ResourceName)))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Kind:"), info.Kind)
+	if info.FilePath != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("File:"), info.FilePath)
+	}
+
+	if info.ServiceName == "" {
+		text.WriteString("\nNo service registration is linked to this definition. Run sync_provider to refresh service metadata.\n")
+		if info.NewIssueURL != "" {
+			fmt.Fprintf(&text, "\n%s %s\n", st.bold("Open an issue:"), info.NewIssueURL)
 		}
-		text.WriteString("\n")
 		return text.String()
 	}
 
-	text.WriteString("```" + language + "\n")
-	text.WriteString(file.Content)
-	if !strings.HasSuffix(file.Content, "\n") {
-		text.WriteString("\n")
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Service"))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Name:"), info.ServiceName)
+	if info.ServiceFile != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Registration:"), info.ServiceFile)
+	}
+	if len(info.WebsiteCategories) > 0 {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Website Categories:"), strings.Join(info.WebsiteCategories, ", "))
+	}
+
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Where to Report"))
+	if info.GitHubLabel != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("GitHub Label:"), st.code(info.GitHubLabel))
+	} else {
+		fmt.Fprintf(&text, "%s\n", st.italic("The service does not declare an associated GitHub label."))
+	}
+	if info.IssuesURL != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Open Issues:"), info.IssuesURL)
+	}
+	if info.NewIssueURL != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("New Issue:"), info.NewIssueURL)
+	}
+	return text.String()
+}
+
+// ResourceLocationInfo lists the files that implement, register and document a definition.
+type ResourceLocationInfo struct {
+	ResourceName       string
+	Kind               string
+	ImplementationFile string
+	FunctionName       string
+	ServiceName        string
+	RegistrationFile   string
+	DocFile            string
+}
+
+func ResourceLocation(st Style, loc ResourceLocationInfo) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s Location", loc.ResourceName)))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Kind:"), loc.Kind)
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Implementation:"), cmp.Or(loc.ImplementationFile, st.italic("not recorded")))
+	if loc.FunctionName != "" {
+		fmt.Fprintf(&text, "%s %s\n", st.bold("Function:"), loc.FunctionName)
+	}
+	registration := cmp.Or(loc.RegistrationFile, st.italic("not recorded"))
+	if loc.ServiceName != "" {
+		registration = fmt.Sprintf("%s (service %s)", registration, loc.ServiceName)
+	}
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Registration:"), registration)
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Documentation:"), cmp.Or(loc.DocFile, st.italic("not found")))
+
+	if loc.ImplementationFile == "" || loc.RegistrationFile == "" || loc.DocFile == "" {
+		fmt.Fprintf(&text, "\n%s\n", st.italic("Missing paths usually mean the index is stale; run sync_provider to refresh it."))
+	}
+	return text.String()
+}
+
+// SDKClients renders the go-azure-sdk packages imported by a definition's implementation file.
+func SDKClients(st Style, resourceName, kind, filePath string, packages []database.SDKPackage) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s SDK Clients", resourceName)))
+	fmt.Fprintf(&text, "%s %s\n", st.bold("Kind:"), kind)
+	fmt.Fprintf(&text, "%s %s\n\n", st.bold("File:"), cmp.Or(filePath, st.italic("not recorded")))
+
+	if len(packages) == 0 {
+		text.WriteString("The implementation file imports no go-azure-sdk API packages. It may call the API through another SDK or through a helper in a different file.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s %d\n\n", st.bold("Packages:"), len(packages))
+	rows := st.table("Service", "API Version", "Resource", "Plane", "Import")
+	for _, pkg := range packages {
+		importPath := st.code(pkg.Path)
+		if pkg.Alias != "" {
+			importPath = pkg.Alias + " " + importPath
+		}
+		rows.row(pkg.Service, cmp.Or(pkg.APIVersion, "-"), cmp.Or(pkg.Resource, "-"), pkg.Plane, importPath)
 	}
-	text.WriteString("```\n\n")
+	text.WriteString(rows.String())
 	return text.String()
 }
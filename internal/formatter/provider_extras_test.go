@@ -8,6 +8,7 @@ import (
 func TestResourceDocs(t *testing.T) {
 	t.Run("resource with section found", func(t *testing.T) {
 		result := ResourceDocs(
+			Style{},
 			"azurerm_virtual_network",
 			"resource",
 			"website/docs/r/virtual_network.html.markdown",
@@ -38,6 +39,7 @@ func TestResourceDocs(t *testing.T) {
 
 	t.Run("data source with section not found", func(t *testing.T) {
 		result := ResourceDocs(
+			Style{},
 			"azurerm_resource_group",
 			"data_source",
 			"website/docs/d/resource_group.html.markdown",
@@ -56,6 +58,7 @@ func TestResourceDocs(t *testing.T) {
 
 	t.Run("no section specified", func(t *testing.T) {
 		result := ResourceDocs(
+			Style{},
 			"azurerm_resource",
 			"resource",
 			"",
@@ -75,7 +78,7 @@ func TestResourceDocs(t *testing.T) {
 
 func TestResourceTestOverview(t *testing.T) {
 	t.Run("no tests found", func(t *testing.T) {
-		result := ResourceTestOverview("azurerm_unknown_resource", "resource", nil)
+		result := ResourceTestOverview(Style{}, "azurerm_unknown_resource", "resource", nil)
 
 		if !strings.Contains(result, "# Acceptance Tests for azurerm_unknown_resource (Resource)") {
 			t.Error("expected header")
@@ -97,7 +100,7 @@ func TestResourceTestOverview(t *testing.T) {
 			},
 		}
 
-		result := ResourceTestOverview("azurerm_virtual_network", "data_source", files)
+		result := ResourceTestOverview(Style{}, "azurerm_virtual_network", "data_source", files)
 
 		if !strings.Contains(result, "(Data Source)") {
 			t.Error("expected data source label")
@@ -121,7 +124,7 @@ func TestResourceTestOverview(t *testing.T) {
 			},
 		}
 
-		result := ResourceTestOverview("azurerm_resource", "resource", files)
+		result := ResourceTestOverview(Style{}, "azurerm_resource", "resource", files)
 
 		if !strings.Contains(result, "_No matching test cases found in this file._") {
 			t.Error("expected no tests message for empty file")
@@ -131,7 +134,7 @@ func TestResourceTestOverview(t *testing.T) {
 
 func TestFeatureFlagList(t *testing.T) {
 	t.Run("no flags", func(t *testing.T) {
-		result := FeatureFlagList(nil)
+		result := FeatureFlagList(Style{}, nil)
 
 		if !strings.Contains(result, "# Feature Flags (0)") {
 			t.Error("expected header with count")
@@ -159,7 +162,7 @@ func TestFeatureFlagList(t *testing.T) {
 			},
 		}
 
-		result := FeatureFlagList(flags)
+		result := FeatureFlagList(Style{}, flags)
 
 		if !strings.Contains(result, "# Feature Flags (2)") {
 			t.Error("expected header with count")
@@ -188,7 +191,7 @@ func TestFeatureFlagList(t *testing.T) {
 			},
 		}
 
-		result := FeatureFlagList(flags)
+		result := FeatureFlagList(Style{}, flags)
 
 		if !strings.Contains(result, "## simple_flag") {
 			t.Error("expected flag header")
@@ -204,7 +207,7 @@ func TestFeatureFlagList(t *testing.T) {
 
 func TestResourceBehaviors(t *testing.T) {
 	t.Run("no behaviors", func(t *testing.T) {
-		result := ResourceBehaviors("azurerm_resource", "resource", ResourceBehaviorInfo{})
+		result := ResourceBehaviors(Style{}, "azurerm_resource", "resource", ResourceBehaviorInfo{})
 
 		if !strings.Contains(result, "# Behaviors for azurerm_resource (Resource)") {
 			t.Error("expected header")
@@ -235,7 +238,7 @@ func TestResourceBehaviors(t *testing.T) {
 			},
 		}
 
-		result := ResourceBehaviors("azurerm_virtual_network", "data_source", info)
+		result := ResourceBehaviors(Style{}, "azurerm_virtual_network", "data_source", info)
 
 		if !strings.Contains(result, "(Data Source)") {
 			t.Error("expected data source label")
@@ -277,7 +280,7 @@ func TestResourceBehaviors(t *testing.T) {
 			TimeoutsRaw: "Create: 30 minutes\nUpdate: 30 minutes",
 		}
 
-		result := ResourceBehaviors("azurerm_resource", "resource", info)
+		result := ResourceBehaviors(Style{}, "azurerm_resource", "resource", info)
 
 		if !strings.Contains(result, "## Timeouts") {
 			t.Error("expected timeouts section")
@@ -290,7 +293,7 @@ func TestResourceBehaviors(t *testing.T) {
 
 func TestExampleDirectory(t *testing.T) {
 	t.Run("no files", func(t *testing.T) {
-		result := ExampleDirectory("examples/virtual_network/basic", nil)
+		result := ExampleDirectory(Style{}, "examples/virtual_network/basic", nil)
 
 		if !strings.Contains(result, "# Example: examples/virtual_network/basic") {
 			t.Error("expected header")
@@ -339,7 +342,7 @@ func TestExampleDirectory(t *testing.T) {
 			},
 		}
 
-		result := ExampleDirectory("examples/virtual_network/basic", files)
+		result := ExampleDirectory(Style{}, "examples/virtual_network/basic", files)
 
 		if !strings.Contains(result, "Contains 7 file(s)") {
 			t.Error("expected file count")
@@ -386,7 +389,7 @@ func TestExampleDirectory(t *testing.T) {
 			},
 		}
 
-		result := ExampleDirectory("examples/test", files)
+		result := ExampleDirectory(Style{}, "examples/test", files)
 
 		// Should have added newline before closing code block
 		if !strings.Contains(result, "resource {}\n```") {
@@ -403,7 +406,7 @@ func TestExampleDirectory(t *testing.T) {
 			},
 		}
 
-		result := ExampleDirectory("examples/test", files)
+		result := ExampleDirectory(Style{}, "examples/test", files)
 
 		if !strings.Contains(result, "```yaml") {
 			t.Error("expected YAML code block for .yml file")
@@ -452,7 +455,7 @@ func TestRenderExampleFileContent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := renderExampleFileContent(tt.file)
+			result := renderExampleFileContent(Style{}, tt.file)
 
 			if tt.wantNoLang {
 				if strings.Contains(result, "```hcl") || strings.Contains(result, "```yaml") ||
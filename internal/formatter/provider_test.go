@@ -10,7 +10,7 @@ import (
 )
 
 func TestProviderResourceListEmpty(t *testing.T) {
-	out := ProviderResourceList(nil)
+	out := ProviderResourceList(Style{}, nil)
 	if !strings.Contains(out, "No provider resources indexed") {
 		t.Fatalf("expected empty notice, got: %s", out)
 	}
@@ -26,7 +26,7 @@ func TestProviderResourceListNonEmpty(t *testing.T) {
 			Description: sql.NullString{Valid: true, String: "desc"},
 		},
 	}
-	out := ProviderResourceList(resources)
+	out := ProviderResourceList(Style{}, resources)
 	if !strings.Contains(out, "azurerm_example") || !strings.Contains(out, "Example") || !strings.Contains(out, "resource") {
 		t.Fatalf("expected resource details, got: %s", out)
 	}
@@ -56,7 +56,7 @@ func TestProviderResourceDetail(t *testing.T) {
 		{Name: "count", Computed: true, Optional: true},
 	}
 
-	out := ProviderResourceDetail(resource, attrs, SchemaRenderOptions{Compact: true})
+	out := ProviderResourceDetail(Style{}, resource, attrs, SchemaRenderOptions{Compact: true})
 	if !strings.Contains(out, "# Example") || !strings.Contains(out, "Attributes (2)") {
 		t.Fatalf("expected heading and attributes count, got: %s", out)
 	}
@@ -66,11 +66,17 @@ func TestProviderResourceDetail(t *testing.T) {
 }
 
 func TestProviderSchemaSource(t *testing.T) {
-	out := ProviderSchemaSource("azurerm_example", "schema", "path.go", "Example", "fn()", true)
+	out := ProviderSchemaSource(Style{}, "azurerm_example", "schema", "path.go", "Example", "fn()", 120, 245, true)
 	if !strings.Contains(out, "path.go") || !strings.Contains(out, "fn()") || !strings.Contains(out, "Note") {
 		t.Fatalf("expected schema source content, got: %s", out)
 	}
-	empty := ProviderSchemaSource("azurerm_example", "", "", "", "", false)
+	if !strings.Contains(out, "**Location:** lines 120-245 of path.go") {
+		t.Fatalf("expected line range, got: %s", out)
+	}
+	empty := ProviderSchemaSource(Style{}, "azurerm_example", "", "", "", "", 0, 0, false)
+	if strings.Contains(empty, "Location") {
+		t.Fatalf("expected no location without line numbers, got: %s", empty)
+	}
 	if !strings.Contains(empty, "Snippet not available") {
 		t.Fatalf("expected fallback message, got: %s", empty)
 	}
@@ -78,7 +84,7 @@ func TestProviderSchemaSource(t *testing.T) {
 
 func TestProviderAttributeSearch(t *testing.T) {
 	t.Run("no matches", func(t *testing.T) {
-		result := ProviderAttributeSearch(nil)
+		result := ProviderAttributeSearch(Style{}, nil, 0)
 		if !strings.Contains(result, "# Attribute Search (0 matches)") {
 			t.Error("expected header with zero count")
 		}
@@ -113,7 +119,7 @@ func TestProviderAttributeSearch(t *testing.T) {
 			},
 		}
 
-		result := ProviderAttributeSearch(results)
+		result := ProviderAttributeSearch(Style{}, results, 0)
 
 		if !strings.Contains(result, "# Attribute Search (2 matches)") {
 			t.Error("expected header with match count")
@@ -172,29 +178,6 @@ func TestProviderAttributeSearchCompact(t *testing.T) {
 	}
 }
 
-func TestEscapePipes(t *testing.T) {
-	tests := []struct {
-		input    string
-		expected string
-	}{
-		{"simple text", "simple text"},
-		{"text|with|pipes", "text\\|with\\|pipes"},
-		{"", "-"},
-		{"  ", "-"},
-		{"  trimmed  ", "trimmed"},
-		{"|pipe|start|end|", "\\|pipe\\|start\\|end\\|"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.input, func(t *testing.T) {
-			result := escapePipes(tt.input)
-			if result != tt.expected {
-				t.Errorf("escapePipes(%q) = %q, expected %q", tt.input, result, tt.expected)
-			}
-		})
-	}
-}
-
 func TestAttributeFlags(t *testing.T) {
 	t.Run("all flags set", func(t *testing.T) {
 		attr := database.ProviderAttribute{
@@ -270,10 +253,35 @@ func TestAttributeDescription(t *testing.T) {
 	})
 }
 
+func TestTruncateDescription(t *testing.T) {
+	desc := "Specifies the supported Azure location where the resource exists."
+	if got := TruncateDescription(desc, 0); got != desc {
+		t.Errorf("expected no truncation when disabled, got %q", got)
+	}
+	if got := TruncateDescription(desc, len(desc)); got != desc {
+		t.Errorf("expected no truncation at exact length, got %q", got)
+	}
+	if got := TruncateDescription(desc, 20); got != "Specifies the suppor…" {
+		t.Errorf("expected truncation at 20 chars, got %q", got)
+	}
+	if got := TruncateDescription("Specifies the name", 10); got != "Specifies…" {
+		t.Errorf("expected trailing space trimmed before ellipsis, got %q", got)
+	}
+	if got := TruncateDescription("Größe der Datei", 5); got != "Größe…" {
+		t.Errorf("expected rune-safe truncation, got %q", got)
+	}
+
+	attrs := []database.ProviderAttribute{{Name: "location", Required: true, Description: sql.NullString{Valid: true, String: desc}}}
+	result := formatAttributesSection(Style{}, attrs, SchemaRenderOptions{DescMaxChars: 20})
+	if !strings.Contains(result, "Specifies the suppor…") || strings.Contains(result, "resource exists") {
+		t.Errorf("expected truncated description in table, got %s", result)
+	}
+}
+
 func TestFormatAttributesSection(t *testing.T) {
 	t.Run("empty with filter", func(t *testing.T) {
 		opts := SchemaRenderOptions{Filtered: true}
-		result := formatAttributesSection(nil, opts)
+		result := formatAttributesSection(Style{}, nil, opts)
 		if !strings.Contains(result, "No attributes matched the requested filters") {
 			t.Error("expected filtered empty message")
 		}
@@ -281,7 +289,7 @@ func TestFormatAttributesSection(t *testing.T) {
 
 	t.Run("empty without filter", func(t *testing.T) {
 		opts := SchemaRenderOptions{Filtered: false}
-		result := formatAttributesSection(nil, opts)
+		result := formatAttributesSection(Style{}, nil, opts)
 		if !strings.Contains(result, "No schema attributes were parsed") {
 			t.Error("expected non-filtered empty message")
 		}
@@ -292,7 +300,7 @@ func TestFormatAttributesSection(t *testing.T) {
 			{Name: "name", Required: true, Description: sql.NullString{Valid: true, String: "The name"}},
 		}
 		opts := SchemaRenderOptions{Compact: true}
-		result := formatAttributesSection(attrs, opts)
+		result := formatAttributesSection(Style{}, attrs, opts)
 
 		if !strings.Contains(result, "## Attributes (1)") {
 			t.Error("expected attributes header")
@@ -310,7 +318,7 @@ func TestFormatAttributesSection(t *testing.T) {
 			{Name: "name", Required: true, Type: sql.NullString{Valid: true, String: "string"}},
 		}
 		opts := SchemaRenderOptions{Compact: false}
-		result := formatAttributesSection(attrs, opts)
+		result := formatAttributesSection(Style{}, attrs, opts)
 
 		if !strings.Contains(result, "| Name | Type | Flags | Description |") {
 			t.Error("expected table header")
@@ -325,7 +333,7 @@ func TestFormatAttributesSection(t *testing.T) {
 			{Name: "attr", Type: sql.NullString{}},
 		}
 		opts := SchemaRenderOptions{Compact: false}
-		result := formatAttributesSection(attrs, opts)
+		result := formatAttributesSection(Style{}, attrs, opts)
 
 		if !strings.Contains(result, "(derived)") {
 			t.Error("expected derived type placeholder")
@@ -338,7 +346,7 @@ func TestFormatRelationshipNotes(t *testing.T) {
 		attrs := []database.ProviderAttribute{
 			{Name: "simple"},
 		}
-		result := formatRelationshipNotes(attrs)
+		result := formatRelationshipNotes(Style{}, attrs)
 		if result != "" {
 			t.Error("expected empty result for no relationships")
 		}
@@ -360,7 +368,7 @@ func TestFormatRelationshipNotes(t *testing.T) {
 				ElemSummary: sql.NullString{Valid: true, String: "list of objects"},
 			},
 		}
-		result := formatRelationshipNotes(attrs)
+		result := formatRelationshipNotes(Style{}, attrs)
 
 		if !strings.Contains(result, "## Relationship Notes") {
 			t.Error("expected relationship notes header")
@@ -393,7 +401,7 @@ func TestProviderResourceDetailDataSource(t *testing.T) {
 	}
 	attrs := []database.ProviderAttribute{}
 
-	result := ProviderResourceDetail(resource, attrs, SchemaRenderOptions{})
+	result := ProviderResourceDetail(Style{}, resource, attrs, SchemaRenderOptions{})
 
 	// Verify structure
 	lines := strings.Split(result, "\n")
@@ -421,7 +429,7 @@ func TestProviderResourceDetailWithFilters(t *testing.T) {
 	}
 	attrs := []database.ProviderAttribute{}
 
-	result := ProviderResourceDetail(resource, attrs, SchemaRenderOptions{
+	result := ProviderResourceDetail(Style{}, resource, attrs, SchemaRenderOptions{
 		FilterSummary: "required=true, force_new=true",
 		Filtered:      true,
 	})
@@ -439,7 +447,7 @@ func TestProviderResourceListWithDeprecation(t *testing.T) {
 			DeprecationMessage: sql.NullString{Valid: true, String: "Use azurerm_new_resource instead"},
 		},
 	}
-	result := ProviderResourceList(resources)
+	result := ProviderResourceList(Style{}, resources)
 
 	if !strings.Contains(result, "⚠️ Deprecated: Use azurerm_new_resource instead") {
 		t.Error("expected deprecation warning")
@@ -1,11 +1,14 @@
 package formatter
 
 import (
+	"cmp"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/indexer"
 )
 
 func ReleaseSummary(repoFullName string, release *database.ProviderRelease, entries []database.ProviderReleaseEntry) string {
@@ -40,6 +43,43 @@ func ReleaseSummary(repoFullName string, release *database.ProviderRelease, entr
 	return b.String()
 }
 
+// IndexedVersionInfo describes which provider version the indexed data corresponds to.
+type IndexedVersionInfo struct {
+	Repository  string
+	Release     *database.ProviderRelease // nil when no release metadata is indexed
+	Ref         string                    // ref pinned with --ref; empty for the default branch
+	LastUpdated string                    // repository updated_at reported by GitHub at sync time
+	SyncedAt    time.Time
+}
+
+// IndexedVersion renders the latest indexed release and when the repository was synced.
+func IndexedVersion(st Style, info IndexedVersionInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", st.heading(1, "Indexed Provider Version"))
+	fmt.Fprintf(&b, "%s %s\n", st.bold("Repository:"), info.Repository)
+	if info.Release != nil {
+		fmt.Fprintf(&b, "%s %s (%s)\n", st.bold("Latest release:"), info.Release.Version, info.Release.Tag)
+		fmt.Fprintf(&b, "%s %s\n", st.bold("Released:"), releaseDateOrFallback(info.Release))
+	} else {
+		fmt.Fprintf(&b, "%s none indexed\n", st.bold("Latest release:"))
+	}
+	fmt.Fprintf(&b, "%s %s\n", st.bold("Synced ref:"), cmp.Or(info.Ref, "default branch"))
+	if info.LastUpdated != "" {
+		fmt.Fprintf(&b, "%s %s\n", st.bold("Repository updated:"), info.LastUpdated)
+	}
+	if !info.SyncedAt.IsZero() {
+		fmt.Fprintf(&b, "%s %s\n", st.bold("Synced at:"), info.SyncedAt.UTC().Format(time.RFC3339))
+	}
+
+	switch {
+	case info.Release == nil:
+		fmt.Fprintf(&b, "\n%s\n", st.italic("No release metadata is indexed; run sync_provider to capture it."))
+	case info.Ref == "":
+		fmt.Fprintf(&b, "\n%s\n", st.italic(fmt.Sprintf("Schemas come from the default branch and may include changes released after %s.", info.Release.Version)))
+	}
+	return b.String()
+}
+
 type sectionGrouping struct {
 	order   []string
 	entries map[string][]string
@@ -124,3 +164,448 @@ func shortSHA(sha string) string {
 	}
 	return sha
 }
+
+// ResourceLifecycle renders when each definition was added to and (if applicable) removed from the provider.
+func ResourceLifecycle(st Style, scope string, resources []database.ProviderResource) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resource Lifecycle (%s)", scope)))
+
+	if len(resources) == 0 {
+		text.WriteString("No lifecycle data recorded. Versions are taken from the indexed CHANGELOG.md history; run sync_provider first.\n")
+		return text.String()
+	}
+
+	removed := 0
+	for _, r := range resources {
+		if r.VersionRemoved.Valid {
+			removed++
+		}
+	}
+	fmt.Fprintf(&text, "%s: %d (%d removed)\n\n", st.bold("Definitions"), len(resources), removed)
+
+	rows := st.table("Name", "Kind", "Added", "Removed")
+	for _, r := range resources {
+		rows.row(r.Name, r.Kind, lifecycleVersion(r.VersionAdded), lifecycleVersion(r.VersionRemoved))
+	}
+	text.WriteString(rows.String())
+
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Versions come from the indexed changelog history; definitions added before the oldest indexed release show no added version."))
+	return text.String()
+}
+
+func lifecycleVersion(version sql.NullString) string {
+	if !version.Valid || version.String == "" {
+		return "-"
+	}
+	return "v" + version.String
+}
+
+// TagList renders repository tags with their commit SHAs.
+func TagList(st Style, repoFullName string, tags []indexer.GitHubTag, total int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Tags for %s", repoFullName)))
+
+	if len(tags) == 0 {
+		text.WriteString("No tags returned by GitHub.\n")
+		return text.String()
+	}
+
+	rows := st.table("Tag", "Commit")
+	for _, tag := range tags {
+		rows.row(tag.Name, orDash(shortSHA(tag.Commit.SHA)))
+	}
+	text.WriteString(rows.String())
+
+	if len(tags) < total {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d fetched tags.", len(tags), total)))
+	}
+	return text.String()
+}
+
+// FileDiff renders the compare patch for a single file between two tags.
+func FileDiff(st Style, repoFullName, baseTag, headTag string, file indexer.GitHubCompareFile, patch string, truncated bool, maxLines int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s: %s...%s", file.Filename, baseTag, headTag)))
+	fmt.Fprintf(&text, "%s: %s\n", st.bold("Repository"), repoFullName)
+	if file.Status != "" {
+		fmt.Fprintf(&text, "%s: %s\n", st.bold("Status"), file.Status)
+	}
+	if file.PreviousFilename != "" && file.PreviousFilename != file.Filename {
+		fmt.Fprintf(&text, "%s: %s\n", st.bold("Renamed From"), file.PreviousFilename)
+	}
+	fmt.Fprintf(&text, "%s: +%d -%d\n\n", st.bold("Changes"), file.Additions, file.Deletions)
+
+	if strings.TrimSpace(patch) == "" {
+		text.WriteString("GitHub did not return a patch for this file (binary or too large to diff).\n")
+		return text.String()
+	}
+
+	text.WriteString(st.codeFence("diff", patch))
+	if truncated {
+		fmt.Fprintf(&text, "\n… showing first %d diff lines\n", maxLines)
+	}
+	return text.String()
+}
+
+// ReleaseAreaStats counts the files a release changed within one area of the repository.
+type ReleaseAreaStats struct {
+	Area      string
+	Added     int
+	Modified  int
+	Removed   int
+	Renamed   int
+	Additions int
+	Deletions int
+}
+
+// Files is the number of changed files in the area.
+func (a ReleaseAreaStats) Files() int {
+	return a.Added + a.Modified + a.Removed + a.Renamed
+}
+
+// ReleaseFileStats renders per-area file change counts between two tags.
+func ReleaseFileStats(st Style, baseTag, headTag string, areas []ReleaseAreaStats, capped bool) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Changed Files: %s...%s", baseTag, headTag)))
+
+	if len(areas) == 0 {
+		text.WriteString("GitHub reported no changed files between these tags.\n")
+		return text.String()
+	}
+
+	var total ReleaseAreaStats
+	for _, a := range areas {
+		total.Added += a.Added
+		total.Modified += a.Modified
+		total.Removed += a.Removed
+		total.Renamed += a.Renamed
+		total.Additions += a.Additions
+		total.Deletions += a.Deletions
+	}
+	fmt.Fprintf(&text, "%s: %d (%d added, %d modified, %d removed, %d renamed)\n", st.bold("Files"), total.Files(), total.Added, total.Modified, total.Removed, total.Renamed)
+	fmt.Fprintf(&text, "%s: +%d -%d\n", st.bold("Lines"), total.Additions, total.Deletions)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Areas"), len(areas))
+
+	rows := st.table("Area", "Files", "Added", "Modified", "Removed", "Renamed", "Lines")
+	for _, a := range areas {
+		rows.row(orDash(a.Area), fmt.Sprint(a.Files()), fmt.Sprint(a.Added), fmt.Sprint(a.Modified), fmt.Sprint(a.Removed), fmt.Sprint(a.Renamed), fmt.Sprintf("+%d -%d", a.Additions, a.Deletions))
+	}
+	text.WriteString(rows.String())
+
+	if capped {
+		fmt.Fprintf(&text, "\n%s\n", st.italic("GitHub returned its maximum number of files for this comparison, so counts may be incomplete."))
+	}
+	return text.String()
+}
+
+// PropertyAddition is a resource attribute introduced by a release entry.
+type PropertyAddition struct {
+	Version      string
+	ResourceName string
+	Attribute    string
+}
+
+// RecentlyAddedProperties renders property additions parsed from recent release entries.
+func RecentlyAddedProperties(st Style, scope string, releaseCount int, additions []PropertyAddition) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Recently Added Properties (%s)", scope)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Releases Scanned"), releaseCount)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Additions"), len(additions))
+
+	if len(additions) == 0 {
+		fmt.Fprintf(&text, "No \"support for the %s property\" entries found in the scanned releases.\n", st.code("x"))
+		return text.String()
+	}
+
+	rows := st.table("Version", "Resource", "Attribute")
+	for _, a := range additions {
+		rows.row(fmt.Sprintf("v%s", a.Version), a.ResourceName, orDash(a.Attribute))
+	}
+	text.WriteString(rows.String())
+	return text.String()
+}
+
+// BreakingChangeFinding is one schema change between two versions that can break existing configurations.
+type BreakingChangeFinding struct {
+	Resource string
+	Path     string
+	Change   string
+	Detail   string
+}
+
+// BreakingChangeSkip records a resource that could not be diffed and why.
+type BreakingChangeSkip struct {
+	Resource string
+	Reason   string
+}
+
+// BreakingChangeScan renders the schema changes found between two provider versions.
+func BreakingChangeScan(st Style, fromVersion, toVersion string, releases, scanned int, findings []BreakingChangeFinding, skipped []BreakingChangeSkip) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Breaking Change Scan: v%s → v%s", fromVersion, toVersion)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Releases In Range"), releases)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Resources Diffed"), scanned)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Findings"), len(findings))
+
+	if len(findings) == 0 {
+		text.WriteString("No attributes gained ForceNew, lost Optional, were removed, or were added as required in the diffed resources.\n")
+	} else {
+		rows := st.table("Resource", "Attribute", "Change", "Detail")
+		for _, f := range findings {
+			rows.row(f.Resource, f.Path, f.Change, orDash(f.Detail))
+		}
+		text.WriteString(rows.String())
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Not Diffed"))
+		for _, s := range skipped {
+			fmt.Fprintf(&text, "- %s: %s\n", s.Resource, s.Reason)
+		}
+	}
+
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Only resources named in changelog entries within the range are diffed; schema helpers defined outside the resource file are not resolved."))
+	return text.String()
+}
+
+// AttributeIntroduction describes the evidence for when an attribute first appeared.
+type AttributeIntroduction struct {
+	ResourceName     string
+	Attribute        string
+	Source           string // "snapshots", "changelog" or "" when nothing matched
+	Version          string // earliest version the attribute is known to exist in
+	AbsentIn         string // newest snapshot before Version that lacks the attribute
+	SnapshotVersions []string
+	EntryTitle       string
+	ResourceAddedIn  string
+}
+
+// AttributeIntroducedIn renders the release an attribute was introduced in.
+func AttributeIntroducedIn(st Style, info AttributeIntroduction) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("%s.%s: Introduced In", info.ResourceName, info.Attribute)))
+
+	switch info.Source {
+	case "snapshots":
+		fmt.Fprintf(&text, "%s v%s\n", st.bold("Introduced In:"), info.Version)
+		fmt.Fprintf(&text, "%s schema snapshots\n", st.bold("Source:"))
+		if info.AbsentIn != "" {
+			fmt.Fprintf(&text, "%s v%s\n", st.bold("Absent In:"), info.AbsentIn)
+		} else {
+			fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("v%s is the oldest recorded snapshot, so the attribute may predate it.", info.Version)))
+		}
+	case "changelog":
+		fmt.Fprintf(&text, "%s v%s\n", st.bold("Introduced In:"), info.Version)
+		fmt.Fprintf(&text, "%s changelog\n", st.bold("Source:"))
+		if info.EntryTitle != "" {
+			fmt.Fprintf(&text, "%s %s\n", st.bold("Entry:"), info.EntryTitle)
+		}
+	default:
+		text.WriteString("No schema snapshot or changelog entry records when this attribute was introduced.\n")
+	}
+
+	if info.ResourceAddedIn != "" {
+		fmt.Fprintf(&text, "\nThe resource itself was added in v%s.\n", info.ResourceAddedIn)
+	}
+	if len(info.SnapshotVersions) > 0 {
+		versions := make([]string, 0, len(info.SnapshotVersions))
+		for _, v := range info.SnapshotVersions {
+			versions = append(versions, "v"+v)
+		}
+		fmt.Fprintf(&text, "\n%s: %s\n", st.italic("Snapshots checked"), strings.Join(versions, ", "))
+	} else if info.Source != "snapshots" {
+		fmt.Fprintf(&text, "\n%s\n", st.italic("No schema snapshots are recorded for this resource; sync with --ref <tag> to record one per release."))
+	}
+	return text.String()
+}
+
+// BackfilledRelease is a changelog release stored by backfill_releases.
+type BackfilledRelease struct {
+	Version string
+	Date    string
+	Entries int
+}
+
+// BackfillSkip is a changelog release backfill_releases left untouched, with the reason.
+type BackfillSkip struct {
+	Version string
+	Reason  string
+}
+
+// ReleaseBackfill summarizes a multi-release changelog backfill.
+func ReleaseBackfill(st Style, since string, stored []BackfilledRelease, skipped []BackfillSkip) string {
+	entries := 0
+	for _, rel := range stored {
+		entries += rel.Entries
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", st.heading(1, fmt.Sprintf("Changelog Backfill since v%s", since)))
+	fmt.Fprintf(&b, "%s: %d\n", st.bold("Releases stored"), len(stored))
+	fmt.Fprintf(&b, "%s: %d\n", st.bold("Entries stored"), entries)
+	fmt.Fprintf(&b, "%s: %d\n", st.bold("Skipped"), len(skipped))
+
+	if len(stored) > 0 {
+		rows := st.table("Version", "Date", "Entries")
+		for _, rel := range stored {
+			rows.row(fmt.Sprintf("v%s", rel.Version), cmp.Or(rel.Date, "unknown"), fmt.Sprint(rel.Entries))
+		}
+		b.WriteString("\n" + rows.String())
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(&b, "\n%s\n\n", st.heading(2, "Skipped"))
+		for _, skip := range skipped {
+			fmt.Fprintf(&b, "- v%s: %s\n", skip.Version, skip.Reason)
+		}
+		fmt.Fprintf(&b, "\n%s\n", st.italic("Pass force=true to replace releases stored by a full sync."))
+	}
+	return b.String()
+}
+
+// ResourceChange is the latest release that changed a resource: the changelog entries naming it
+// and, when the compare data has it, the diff of its file.
+type ResourceChange struct {
+	Release   *database.ProviderRelease
+	Entries   []database.ProviderReleaseEntry
+	File      string
+	Patch     string
+	Truncated bool
+	MaxLines  int
+	DiffNote  string // why no diff is shown
+}
+
+// WhatsNewForResource renders the latest release touching a resource with its entries and diff.
+func WhatsNewForResource(st Style, resourceName string, change ResourceChange) string {
+	release := change.Release
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", st.heading(1, fmt.Sprintf("What's New: %s", resourceName)))
+	fmt.Fprintf(&b, "%s %s (%s)\n", st.bold("Release:"), release.Version, release.Tag)
+	fmt.Fprintf(&b, "%s %s\n", st.bold("Released:"), releaseDateOrFallback(release))
+	if release.ComparisonURL.Valid && release.ComparisonURL.String != "" {
+		fmt.Fprintf(&b, "%s %s\n", st.bold("Compare:"), release.ComparisonURL.String)
+	}
+
+	fmt.Fprintf(&b, "\n%s\n\n", st.heading(2, "Changelog Entries"))
+	for _, entry := range change.Entries {
+		fmt.Fprintf(&b, "- [%s] %s\n", entry.Section, entry.Title)
+	}
+
+	fmt.Fprintf(&b, "\n%s\n\n", st.heading(2, "Diff"))
+	if change.Patch == "" {
+		fmt.Fprintf(&b, "%s\n", st.italic(change.DiffNote))
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%s %s\n\n", st.bold("File:"), change.File)
+	b.WriteString(st.codeFence("diff", change.Patch))
+	if change.Truncated {
+		fmt.Fprintf(&b, "\n%s\n", st.italic(fmt.Sprintf("Showing the first %d diff lines; pass a larger max_context_lines for more.", change.MaxLines)))
+	}
+	return b.String()
+}
+
+// ProviderDiffItem is one changelog entry in a provider diff summary.
+type ProviderDiffItem struct {
+	Resource string
+	Kind     string // new resources only: "resource", "data source", ...
+	Version  string
+	Title    string
+}
+
+// ProviderDiff groups the changelog entries between two versions by upgrade impact.
+type ProviderDiff struct {
+	FromVersion  string
+	ToVersion    string
+	Indexed      string // indexed version, one end of the range
+	Releases     int
+	New          []ProviderDiffItem
+	Removed      []ProviderDiffItem
+	Breaking     []ProviderDiffItem
+	Deprecations []ProviderDiffItem
+	Enhancements int
+	BugFixes     int
+	Touched      int // distinct resources named by any entry in range
+	MaxItems     int // per-category cap, 0 for no cap
+}
+
+// ProviderDiffSummary renders what changes when moving between two provider versions.
+func ProviderDiffSummary(st Style, summary ProviderDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", st.heading(1, fmt.Sprintf("Provider Diff Summary: v%s → v%s", summary.FromVersion, summary.ToVersion)))
+	fmt.Fprintf(&b, "%s: v%s\n", st.bold("Indexed Version"), summary.Indexed)
+	fmt.Fprintf(&b, "%s: %d\n", st.bold("Releases In Range"), summary.Releases)
+	fmt.Fprintf(&b, "%s: %d\n\n", st.bold("Resources Touched"), summary.Touched)
+
+	rows := st.table("Impact", "Entries")
+	rows.row("New resources", fmt.Sprint(len(summary.New)))
+	rows.row("Removed resources", fmt.Sprint(len(summary.Removed)))
+	rows.row("Breaking changes", fmt.Sprint(len(summary.Breaking)))
+	rows.row("Deprecations", fmt.Sprint(len(summary.Deprecations)))
+	rows.row("Enhancements", fmt.Sprint(summary.Enhancements))
+	rows.row("Bug fixes", fmt.Sprint(summary.BugFixes))
+	b.WriteString(rows.String())
+
+	writeProviderDiffSection(st, &b, "Breaking Changes", summary.Breaking, summary.MaxItems, false)
+	writeProviderDiffSection(st, &b, "Removed Resources", summary.Removed, summary.MaxItems, false)
+	writeProviderDiffSection(st, &b, "Deprecations", summary.Deprecations, summary.MaxItems, false)
+	writeProviderDiffSection(st, &b, "New Resources", summary.New, summary.MaxItems, true)
+
+	fmt.Fprintf(&b, "\n%s\n", st.italic(fmt.Sprintf("Summarized from changelog entries. Run scan_breaking_changes with from_version=%s and to_version=%s for attribute-level schema diffs.", summary.FromVersion, summary.ToVersion)))
+	return b.String()
+}
+
+func writeProviderDiffSection(st Style, b *strings.Builder, title string, items []ProviderDiffItem, maxItems int, withKind bool) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s\n\n", st.heading(2, fmt.Sprintf("%s (%d)", title, len(items))))
+	shown := items
+	if maxItems > 0 && len(shown) > maxItems {
+		shown = shown[:maxItems]
+	}
+	if withKind {
+		rows := st.table("Resource", "Kind", "Version")
+		for _, item := range shown {
+			rows.row(item.Resource, item.Kind, item.Version)
+		}
+		b.WriteString(rows.String())
+	} else {
+		rows := st.table("Resource", "Version", "Entry")
+		for _, item := range shown {
+			rows.row(cmp.Or(item.Resource, "-"), item.Version, orDash(item.Title))
+		}
+		b.WriteString(rows.String())
+	}
+	if len(shown) < len(items) {
+		fmt.Fprintf(b, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d; raise max_items for more.", len(shown), len(items))))
+	}
+}
+
+// ReleaseSnippet renders one changelog entry of a release with the diff of the file it touched;
+// the include flags pick which parts appear.
+func ReleaseSnippet(st Style, repo *database.Repository, release *database.ProviderRelease, entry *database.ProviderReleaseEntry, filename, patch string, truncated bool, maxLines int, includeHeader, includeFile, includeDiff, includeCompare bool) string {
+	var b strings.Builder
+	fullName := repo.FullName
+	if fullName == "" {
+		fullName = repo.Name
+	}
+
+	if includeHeader {
+		fmt.Fprintf(&b, "Release %s – %s\n", release.Version, entry.Title)
+		fmt.Fprintf(&b, "Repository: %s\n", fullName)
+	}
+	if includeFile && filename != "" {
+		fmt.Fprintf(&b, "File: %s\n", filename)
+	}
+	if includeDiff {
+		b.WriteString(strings.TrimSuffix(st.codeFence("diff", patch), "\n"))
+		if truncated {
+			fmt.Fprintf(&b, "\n… showing first %d diff lines", maxLines)
+		}
+	}
+	if includeCompare && release.ComparisonURL.Valid && release.ComparisonURL.String != "" {
+		fmt.Fprintf(&b, "\nCompare: %s", release.ComparisonURL.String)
+	}
+	return b.String()
+}
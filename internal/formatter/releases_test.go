@@ -64,3 +64,36 @@ func TestRenderHelpers(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestReleaseSnippetGolden(t *testing.T) {
+	repo := &database.Repository{
+		Name:     "terraform-provider-azurerm",
+		FullName: "hashicorp/terraform-provider-azurerm",
+	}
+	release := &database.ProviderRelease{
+		Version:         "1.2.3",
+		ComparisonURL:   sql.NullString{Valid: true, String: "https://example.com/compare"},
+		PreviousTag:     sql.NullString{Valid: true, String: "v1.2.2"},
+		PreviousVersion: sql.NullString{Valid: true, String: "1.2.2"},
+	}
+	entry := &database.ProviderReleaseEntry{
+		Title: "Bug fix",
+	}
+
+	output := ReleaseSnippet(Style{}, repo, release, entry, "path/to/resource.go", "diffline1\n+diffline2", true, 2, true, true, true, true)
+	want := strings.Join([]string{
+		"Release 1.2.3 – Bug fix",
+		"Repository: hashicorp/terraform-provider-azurerm",
+		"File: path/to/resource.go",
+		"```diff",
+		"diffline1",
+		"+diffline2",
+		"```",
+		"… showing first 2 diff lines",
+		"Compare: https://example.com/compare",
+	}, "\n")
+
+	if strings.TrimSpace(want) != strings.TrimSpace(output) {
+		t.Fatalf("golden mismatch\nwant:\n%s\n\ngot:\n%s", want, output)
+	}
+}
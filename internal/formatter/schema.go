@@ -0,0 +1,381 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// AttributePathResolution describes the outcome of walking a dotted attribute path through a schema.
+type AttributePathResolution struct {
+	Path          string
+	Valid         bool
+	Resolved      []string
+	FailedIndex   int
+	FailedSegment string
+	Reason        string
+	Alternatives  []string
+	Leaf          *database.NestedAttribute
+}
+
+// AttributePath renders the validation result for a dotted attribute path.
+func AttributePath(st Style, resourceName string, res AttributePathResolution) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute Path: %s.%s", resourceName, res.Path)))
+
+	if res.Valid {
+		fmt.Fprintf(&text, "%s\n\n", st.bold("Valid path"))
+		if res.Leaf != nil {
+			leaf := res.Leaf
+			fmt.Fprintf(&text, "- %s: %s\n", st.bold("Attribute"), leaf.Name)
+			if leaf.Type != "" {
+				fmt.Fprintf(&text, "- %s: %s\n", st.bold("Type"), leaf.Type)
+			}
+			if flags := nestedAttributeFlags(*leaf); len(flags) > 0 {
+				fmt.Fprintf(&text, "- %s: %s\n", st.bold("Flags"), strings.Join(flags, ", "))
+			}
+			if cardinality := nestedBlockCardinality(*leaf); cardinality != "" {
+				fmt.Fprintf(&text, "- %s: %s\n", st.bold("Cardinality"), cardinality)
+			}
+			if leaf.Description != "" {
+				fmt.Fprintf(&text, "- %s: %s\n", st.bold("Description"), leaf.Description)
+			}
+			if len(leaf.Children) > 0 {
+				fmt.Fprintf(&text, "- %s: %d\n", st.bold("Nested Attributes"), len(leaf.Children))
+			}
+		}
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s\n\n", st.bold("Invalid path"))
+	if len(res.Resolved) > 0 {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Resolved"), strings.Join(res.Resolved, "."))
+	}
+	fmt.Fprintf(&text, "- %s: %s (segment %d)\n", st.bold("Failed Segment"), st.code(res.FailedSegment), res.FailedIndex+1)
+	fmt.Fprintf(&text, "- %s: %s\n", st.bold("Reason"), res.Reason)
+
+	if len(res.Alternatives) > 0 {
+		fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Valid Alternatives"))
+		for _, alt := range res.Alternatives {
+			fmt.Fprintf(&text, "- %s\n", alt)
+		}
+	}
+
+	return text.String()
+}
+
+func nestedAttributeFlags(attr database.NestedAttribute) []string {
+	var flags []string
+	if attr.Required {
+		flags = append(flags, "required")
+	}
+	if attr.Optional {
+		flags = append(flags, "optional")
+	}
+	if attr.Computed {
+		flags = append(flags, "computed")
+	}
+	if attr.ForceNew {
+		flags = append(flags, "force_new")
+	}
+	if attr.Sensitive {
+		flags = append(flags, "sensitive")
+	}
+	if attr.Deprecated != "" {
+		flags = append(flags, "deprecated")
+	}
+	if attr.NestedBlock {
+		flags = append(flags, "nested")
+	}
+	if attr.MaxItems > 0 {
+		flags = append(flags, fmt.Sprintf("max=%d", attr.MaxItems))
+	}
+	if attr.MinItems > 0 {
+		flags = append(flags, fmt.Sprintf("min=%d", attr.MinItems))
+	}
+	return flags
+}
+
+// blockCardinality describes how many instances of a nested block a configuration may declare,
+// derived from MinItems/MaxItems. A MaxItems: 1 block is a single object written once, not a
+// list of one, so it is labelled apart from repeatable blocks. Computed-only blocks return ""
+// since users never set them.
+func blockCardinality(required, optional, computed bool, minItems, maxItems int64) string {
+	if computed && !required && !optional {
+		return ""
+	}
+	if required && minItems < 1 {
+		minItems = 1
+	}
+	switch {
+	case maxItems == 1 && minItems >= 1:
+		return "single block (object), 1 required"
+	case maxItems == 1:
+		return "single block (object), 0 or 1 allowed"
+	case minItems > 0 && maxItems > 0:
+		return fmt.Sprintf("repeatable block, %d-%d required", minItems, maxItems)
+	case minItems > 0:
+		return fmt.Sprintf("repeatable block, %d+ required", minItems)
+	case maxItems > 0:
+		return fmt.Sprintf("repeatable block, 0-%d allowed", maxItems)
+	default:
+		return "repeatable block, 0+ allowed"
+	}
+}
+
+func nestedBlockCardinality(attr database.NestedAttribute) string {
+	if !attr.NestedBlock {
+		return ""
+	}
+	return blockCardinality(attr.Required, attr.Optional, attr.Computed, attr.MinItems, attr.MaxItems)
+}
+
+// ResourceSchemaBatchError records a name that could not be resolved during a batch lookup.
+type ResourceSchemaBatchError struct {
+	Name    string
+	Message string
+}
+
+// ResourceSchemaBatch renders several resource schemas in one response, followed by per-name errors.
+func ResourceSchemaBatch(st Style, sections []string, errs []ResourceSchemaBatchError) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Resource Schemas (%d found, %d errors)", len(sections), len(errs))))
+
+	for i, section := range sections {
+		if i > 0 {
+			text.WriteString("\n---\n\n")
+		}
+		text.WriteString(strings.TrimRight(section, "\n"))
+		text.WriteString("\n")
+	}
+
+	if len(errs) > 0 {
+		if len(sections) > 0 {
+			text.WriteString("\n")
+		}
+		fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Errors"))
+		for _, e := range errs {
+			fmt.Fprintf(&text, "- %s: %s\n", e.Name, e.Message)
+		}
+	}
+
+	return text.String()
+}
+
+// AttributeDetail renders a single attribute with its full, untruncated description.
+func AttributeDetail(st Style, resourceName, path string, attr database.NestedAttribute) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute: %s.%s", resourceName, path)))
+
+	if attr.Type != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Type"), attr.Type)
+	}
+	if flags := nestedAttributeFlags(attr); len(flags) > 0 {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Flags"), strings.Join(flags, ", "))
+	}
+	if cardinality := nestedBlockCardinality(attr); cardinality != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Cardinality"), cardinality)
+	}
+	if attr.Deprecated != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Deprecated"), attr.Deprecated)
+	}
+	if attr.ConflictsWith != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Conflicts With"), attr.ConflictsWith)
+	}
+	if attr.ExactlyOneOf != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Exactly One Of"), attr.ExactlyOneOf)
+	}
+	if attr.AtLeastOneOf != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("At Least One Of"), attr.AtLeastOneOf)
+	}
+	if attr.Validation != "" {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Validation"), st.code(attr.Validation))
+	}
+	if len(attr.Children) > 0 {
+		fmt.Fprintf(&text, "- %s: %s\n", st.bold("Nested Attributes"), strings.Join(nestedChildNames(attr.Children), ", "))
+	}
+
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Description"))
+	if desc := strings.TrimSpace(attr.Description); desc != "" {
+		text.WriteString(desc)
+		text.WriteString("\n")
+	} else {
+		text.WriteString("No description available.\n")
+	}
+
+	return text.String()
+}
+
+// AttributeExplanation gathers what is known about one attribute across the schema index and the
+// resource documentation.
+type AttributeExplanation struct {
+	ResourceName  string
+	Path          string
+	Attribute     database.NestedAttribute
+	AllowedValues []string
+	IgnoreCase    bool
+	RequiredWith  string // recorded for top-level attributes only
+	DefaultValue  string // recorded for top-level attributes only
+	DocFile       string
+	DocParagraphs []string
+}
+
+// ExplainAttribute extends AttributeDetail with constraints derived from validation and the
+// documentation paragraphs that describe the attribute.
+func ExplainAttribute(st Style, e AttributeExplanation) string {
+	var text strings.Builder
+	text.WriteString(AttributeDetail(st, e.ResourceName, e.Path, e.Attribute))
+
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Constraints"))
+	var constraints []string
+	if e.RequiredWith != "" {
+		constraints = append(constraints, fmt.Sprintf("- %s: %s", st.bold("Required With"), e.RequiredWith))
+	}
+	if e.DefaultValue != "" {
+		constraints = append(constraints, fmt.Sprintf("- %s: %s", st.bold("Default"), st.code(e.DefaultValue)))
+	}
+	if len(e.AllowedValues) > 0 {
+		values := make([]string, len(e.AllowedValues))
+		for i, value := range e.AllowedValues {
+			values[i] = st.code(value)
+		}
+		line := fmt.Sprintf("- %s: %s", st.bold("Allowed Values"), strings.Join(values, ", "))
+		if e.IgnoreCase {
+			line += " (case-insensitive)"
+		}
+		constraints = append(constraints, line)
+	}
+	if len(constraints) == 0 {
+		text.WriteString("No constraints beyond the schema flags above.\n")
+	} else {
+		text.WriteString(strings.Join(constraints, "\n"))
+		text.WriteString("\n")
+	}
+
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Documentation"))
+	switch {
+	case e.DocFile == "":
+		text.WriteString("Documentation not found for this resource. Ensure the repository sync is up-to-date.\n")
+	case len(e.DocParagraphs) == 0:
+		fmt.Fprintf(&text, "%s is not described in %s.\n", st.code(e.Path), e.DocFile)
+	default:
+		fmt.Fprintf(&text, "%s: %s\n\n", st.bold("Source"), e.DocFile)
+		for _, paragraph := range e.DocParagraphs {
+			text.WriteString(paragraph)
+			text.WriteString("\n\n")
+		}
+	}
+
+	return strings.TrimRight(text.String(), "\n") + "\n"
+}
+
+func nestedChildNames(children []database.NestedAttribute) []string {
+	names := make([]string, 0, len(children))
+	for _, child := range children {
+		names = append(names, child.Name)
+	}
+	return names
+}
+
+// AttributeTree renders a resource schema as an indented outline, nested blocks ending in "/".
+// Blocks deeper than maxDepth are collapsed to a child count and output stops after maxLines
+// outline lines; zero disables either bound.
+func AttributeTree(st Style, resourceName string, tree []database.NestedAttribute, maxDepth, maxLines int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attribute Tree for %s", resourceName)))
+
+	if len(tree) == 0 {
+		text.WriteString("No attributes are indexed for this definition.\n")
+		return text.String()
+	}
+
+	w := attributeTreeWriter{maxDepth: maxDepth, maxLines: maxLines}
+	w.write(tree, 0)
+
+	text.WriteString(st.codeFence("", strings.Join(w.lines, "\n")))
+	if w.omitted > 0 {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d lines; raise max_lines to see the rest.", len(w.lines), len(w.lines)+w.omitted)))
+	}
+	if w.collapsed > 0 {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("%d block(s) deeper than max_depth %d were collapsed.", w.collapsed, maxDepth)))
+	}
+	return text.String()
+}
+
+type attributeTreeWriter struct {
+	maxDepth  int
+	maxLines  int
+	lines     []string
+	omitted   int
+	collapsed int
+}
+
+func (w *attributeTreeWriter) add(line string) {
+	if w.maxLines > 0 && len(w.lines) >= w.maxLines {
+		w.omitted++
+		return
+	}
+	w.lines = append(w.lines, line)
+}
+
+func (w *attributeTreeWriter) write(level []database.NestedAttribute, depth int) {
+	indent := strings.Repeat("  ", depth)
+	for _, attr := range level {
+		if !attr.NestedBlock {
+			w.add(fmt.Sprintf("%s%s (%s)", indent, attr.Name, strings.Join(attributeTreeLabels(attr), ", ")))
+			continue
+		}
+
+		line := fmt.Sprintf("%s%s/ (%s)", indent, attr.Name, strings.Join(attributeTreeLabels(attr), ", "))
+		switch {
+		case len(attr.Children) == 0:
+			w.add(line + " — nested schema not available")
+		case w.maxDepth > 0 && depth+1 >= w.maxDepth:
+			w.add(fmt.Sprintf("%s … %d nested attribute(s)", line, len(attr.Children)))
+			w.collapsed++
+		default:
+			w.add(line)
+			w.write(attr.Children, depth+1)
+		}
+	}
+}
+
+func attributeTreeLabels(attr database.NestedAttribute) []string {
+	var labels []string
+	switch {
+	case attr.Required:
+		labels = append(labels, "Required")
+	case attr.Optional && attr.Computed:
+		labels = append(labels, "Optional", "Computed")
+	case attr.Optional:
+		labels = append(labels, "Optional")
+	case attr.Computed:
+		labels = append(labels, "Computed")
+	}
+	if attr.NestedBlock {
+		if cardinality := nestedBlockCardinality(attr); cardinality != "" {
+			labels = append(labels, cardinality)
+		}
+	} else if t := shortSchemaType(attr.Type); t != "" {
+		labels = append(labels, t)
+	}
+	if attr.ForceNew {
+		labels = append(labels, "ForceNew")
+	}
+	if attr.Sensitive {
+		labels = append(labels, "Sensitive")
+	}
+	if attr.Deprecated != "" {
+		labels = append(labels, "Deprecated")
+	}
+	return labels
+}
+
+// shortSchemaType turns "pluginsdk.TypeString" into "String".
+func shortSchemaType(t string) string {
+	if i := strings.LastIndex(t, "."); i >= 0 {
+		t = t[i+1:]
+	}
+	return strings.TrimPrefix(t, "Type")
+}
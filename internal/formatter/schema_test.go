@@ -0,0 +1,128 @@
+package formatter
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+func TestAttributePath(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		out := AttributePath(Style{}, "azurerm_example", AttributePathResolution{
+			Path:  "block.0.child",
+			Valid: true,
+			Leaf:  &database.NestedAttribute{Name: "child", Type: "pluginsdk.TypeString", Required: true, ForceNew: true},
+		})
+		if !strings.Contains(out, "# Attribute Path: azurerm_example.block.0.child") || !strings.Contains(out, "required, force_new") {
+			t.Fatalf("unexpected output: %s", out)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		out := AttributePath(Style{}, "azurerm_example", AttributePathResolution{
+			Path:          "block.0.nope",
+			Resolved:      []string{"block", "0"},
+			FailedIndex:   2,
+			FailedSegment: "nope",
+			Reason:        "attribute not found at this level",
+			Alternatives:  []string{"child"},
+		})
+		for _, want := range []string{"**Invalid path**", "**Resolved**: block.0", "`nope` (segment 3)", "## Valid Alternatives", "- child"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("expected %q in output: %s", want, out)
+			}
+		}
+	})
+}
+
+func TestBlockCardinality(t *testing.T) {
+	cases := []struct {
+		name                         string
+		required, optional, computed bool
+		minItems, maxItems           int64
+		want                         string
+	}{
+		{"required single", true, false, false, 1, 1, "single block (object), 1 required"},
+		{"required implied min", true, false, false, 0, 1, "single block (object), 1 required"},
+		{"optional single", false, true, false, 0, 1, "single block (object), 0 or 1 allowed"},
+		{"bounded", true, false, false, 2, 5, "repeatable block, 2-5 required"},
+		{"required unbounded", true, false, false, 0, 0, "repeatable block, 1+ required"},
+		{"optional capped", false, true, false, 0, 3, "repeatable block, 0-3 allowed"},
+		{"optional unbounded", false, true, false, 0, 0, "repeatable block, 0+ allowed"},
+		{"computed only", false, false, true, 0, 1, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := blockCardinality(tc.required, tc.optional, tc.computed, tc.minItems, tc.maxItems); got != tc.want {
+				t.Fatalf("blockCardinality() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBlockCardinalityRendering(t *testing.T) {
+	osDisk := database.ProviderAttribute{
+		Name:        "os_disk",
+		Type:        sql.NullString{String: "pluginsdk.TypeList", Valid: true},
+		Required:    true,
+		NestedBlock: true,
+		MaxItems:    sql.NullInt64{Int64: 1, Valid: true},
+		MinItems:    sql.NullInt64{Int64: 1, Valid: true},
+		ElemSummary: sql.NullString{String: "object", Valid: true},
+	}
+	dataDisk := database.ProviderAttribute{
+		Name:        "data_disk",
+		Type:        sql.NullString{String: "pluginsdk.TypeList", Valid: true},
+		Optional:    true,
+		NestedBlock: true,
+	}
+	resource := &database.ProviderResource{Name: "azurerm_linux_virtual_machine", Kind: "resource"}
+
+	out := ProviderResourceDetail(Style{}, resource, []database.ProviderAttribute{dataDisk, osDisk}, SchemaRenderOptions{})
+	if !strings.Contains(out, "| os_disk | pluginsdk.TypeList (single block (object), 1 required) |") {
+		t.Fatalf("expected os_disk cardinality in table, got:\n%s", out)
+	}
+	if !strings.Contains(out, "| data_disk | pluginsdk.TypeList (repeatable block, 0+ allowed) |") {
+		t.Fatalf("expected data_disk cardinality in table, got:\n%s", out)
+	}
+	if !strings.Contains(out, "`os_disk` nested block → object (single block (object), 1 required)") {
+		t.Fatalf("expected cardinality in relationship notes, got:\n%s", out)
+	}
+
+	compact := ProviderResourceDetail(Style{}, resource, []database.ProviderAttribute{osDisk}, SchemaRenderOptions{Compact: true})
+	if !strings.Contains(compact, "; single block (object), 1 required)") {
+		t.Fatalf("expected cardinality in compact output, got:\n%s", compact)
+	}
+
+	detail := AttributeDetail(Style{}, "azurerm_linux_virtual_machine", "os_disk", database.NestedAttributeFromProvider(osDisk))
+	if !strings.Contains(detail, "- **Cardinality**: single block (object), 1 required") {
+		t.Fatalf("expected cardinality in attribute detail, got:\n%s", detail)
+	}
+}
+
+func TestPrimitiveCollectionTypeRendering(t *testing.T) {
+	attrs := []database.ProviderAttribute{
+		{Name: "zones", Type: sql.NullString{String: "pluginsdk.TypeList", Valid: true}, Optional: true,
+			ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeString", Valid: true}},
+		{Name: "ports", Type: sql.NullString{String: "pluginsdk.TypeSet", Valid: true}, Optional: true,
+			ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeInt, ValidateFunc=validate.PortNumber", Valid: true}},
+		{Name: "tags", Type: sql.NullString{String: "pluginsdk.TypeMap", Valid: true}, Optional: true,
+			ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeString", Valid: true}},
+		{Name: "rule", Type: sql.NullString{String: "pluginsdk.TypeList", Valid: true}, Optional: true, NestedBlock: true},
+	}
+	resource := &database.ProviderResource{Name: "azurerm_example", Kind: "resource"}
+
+	out := ProviderResourceDetail(Style{}, resource, attrs, SchemaRenderOptions{})
+	for _, want := range []string{
+		"| zones | list(string) |",
+		"| ports | set(number) |",
+		"| tags | map(string) |",
+		"| rule | pluginsdk.TypeList (repeatable block, 0+ allowed) |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in table, got:\n%s", want, out)
+		}
+	}
+}
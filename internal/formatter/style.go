@@ -0,0 +1,183 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Style selects how tool output is presented. Formatters take the style and build either markdown
+// or plain text directly through its heading, emphasis, table and code fence helpers.
+type Style struct {
+	Plain bool // aligned columns instead of tables, no emphasis, heading or fence markup
+}
+
+// Supported output style names, as accepted by ParseStyle.
+const (
+	StyleMarkdown = "markdown"
+	StylePlain    = "plain"
+)
+
+// ParseStyle resolves a style name; empty selects markdown.
+func ParseStyle(name string) (Style, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", StyleMarkdown:
+		return Style{}, nil
+	case StylePlain:
+		return Style{Plain: true}, nil
+	default:
+		return Style{}, fmt.Errorf("unknown output format %q (want %s or %s)", name, StyleMarkdown, StylePlain)
+	}
+}
+
+// heading renders a section title without a trailing newline. Plain style underlines the first
+// two levels with = and - and leaves deeper levels bare.
+func (st Style) heading(level int, title string) string {
+	if !st.Plain {
+		return strings.Repeat("#", level) + " " + title
+	}
+	switch level {
+	case 1:
+		return title + "\n" + strings.Repeat("=", utf8.RuneCountInString(title))
+	case 2:
+		return title + "\n" + strings.Repeat("-", utf8.RuneCountInString(title))
+	default:
+		return title
+	}
+}
+
+func (st Style) bold(text string) string {
+	if st.Plain {
+		return text
+	}
+	return "**" + text + "**"
+}
+
+func (st Style) italic(text string) string {
+	if st.Plain {
+		return text
+	}
+	return "_" + text + "_"
+}
+
+// code marks an identifier or literal as inline code.
+func (st Style) code(text string) string {
+	if st.Plain {
+		return text
+	}
+	return "`" + text + "`"
+}
+
+// codeFence wraps content in a fenced code block tagged with lang. The fence is made longer than
+// any backtick run in content, so markdown files that contain fences of their own stay intact.
+// Plain style returns content as is, so files and schemas can be copied verbatim.
+func (st Style) codeFence(lang, content string) string {
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if st.Plain {
+		return content
+	}
+
+	longest, run := 0, 0
+	for i := 0; i < len(content); i++ {
+		if content[i] == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + lang + "\n" + content + fence + "\n"
+}
+
+// table collects rows so the style can lay them out once all are known: a markdown table, or
+// space-aligned columns under an underlined header in plain style.
+type table struct {
+	style  Style
+	header []string
+	rows   [][]string
+}
+
+func (st Style) table(header ...string) *table {
+	return &table{style: st, header: header}
+}
+
+// row adds a row of raw cell text; markdown output escapes the pipes in it.
+func (t *table) row(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// String renders the header and rows, each line ending in a newline.
+func (t *table) String() string {
+	if t.style.Plain {
+		return plainTable(t.header, t.rows)
+	}
+
+	var text strings.Builder
+	text.WriteString("| " + strings.Join(t.header, " | ") + " |\n")
+	for _, cell := range t.header {
+		text.WriteString("|" + strings.Repeat("-", len(cell)+2))
+	}
+	text.WriteString("|\n")
+	for _, row := range t.rows {
+		escaped := make([]string, len(row))
+		for i, cell := range row {
+			escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		text.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	}
+	return text.String()
+}
+
+// plainTable aligns rows into space-padded columns, underlining the header.
+func plainTable(header []string, rows [][]string) string {
+	widths := make([]int, len(header))
+	for j, cell := range header {
+		widths[j] = utf8.RuneCountInString(cell)
+	}
+	for _, row := range rows {
+		for j, cell := range row {
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[j] = max(widths[j], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var text strings.Builder
+	line := func(cells []string) {
+		var out strings.Builder
+		for j, cell := range cells {
+			if j > 0 {
+				out.WriteString("  ")
+			}
+			out.WriteString(cell)
+			if j < len(cells)-1 {
+				out.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			}
+		}
+		text.WriteString(strings.TrimRight(out.String(), " ") + "\n")
+	}
+
+	line(header)
+	rule := make([]string, len(header))
+	for j := range header {
+		rule[j] = strings.Repeat("-", widths[j])
+	}
+	line(rule)
+	for _, row := range rows {
+		line(row)
+	}
+	return text.String()
+}
+
+// orDash trims a cell value and stands in "-" when nothing is left.
+func orDash(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStyle(t *testing.T) {
+	for name, plain := range map[string]bool{"": false, "markdown": false, "Plain": true} {
+		style, err := ParseStyle(name)
+		if err != nil || style.Plain != plain {
+			t.Fatalf("ParseStyle(%q) = %+v, %v", name, style, err)
+		}
+	}
+	if _, err := ParseStyle("html"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestStyleHelpers(t *testing.T) {
+	markdown, plain := Style{}, Style{Plain: true}
+
+	if got := markdown.heading(1, "Schema"); got != "# Schema" {
+		t.Fatalf("unexpected markdown heading %q", got)
+	}
+	if got := plain.heading(1, "Schema"); got != "Schema\n======" {
+		t.Fatalf("unexpected plain heading %q", got)
+	}
+	if got := plain.heading(3, "Schema"); got != "Schema" {
+		t.Fatalf("unexpected plain sub-heading %q", got)
+	}
+	if got := markdown.bold("Resource") + " " + markdown.code("name"); got != "**Resource** `name`" {
+		t.Fatalf("unexpected markdown emphasis %q", got)
+	}
+	if got := plain.bold("Resource") + " " + plain.italic("note") + " " + plain.code("name"); got != "Resource note name" {
+		t.Fatalf("unexpected plain emphasis %q", got)
+	}
+}
+
+func TestCodeFenceOutgrowsNestedFences(t *testing.T) {
+	content := "# Example\n\n```hcl\nresource \"x\" \"y\" {}\n```"
+	out := Style{}.codeFence("markdown", content)
+	if !strings.HasPrefix(out, "````markdown\n") || !strings.HasSuffix(out, "\n````\n") {
+		t.Fatalf("expected a four-backtick fence, got %q", out)
+	}
+	if !strings.Contains(out, content) {
+		t.Fatalf("expected content to be kept intact, got %q", out)
+	}
+
+	if got := (Style{Plain: true}).codeFence("markdown", content); got != content+"\n" {
+		t.Fatalf("expected plain style to return the content verbatim, got %q", got)
+	}
+}
+
+func TestTable(t *testing.T) {
+	for _, tt := range []struct {
+		style Style
+		want  string
+	}{
+		{Style{}, "| Resource | Notes |\n" +
+			"|----------|-------|\n" +
+			"| azurerm_example | a \\| b |\n" +
+			"| azurerm_example_long | - |\n"},
+		{Style{Plain: true}, "Resource              Notes\n" +
+			"--------------------  -----\n" +
+			"azurerm_example       a | b\n" +
+			"azurerm_example_long  -\n"},
+	} {
+		rows := tt.style.table("Resource", "Notes")
+		rows.row("azurerm_example", "a | b")
+		rows.row("azurerm_example_long", orDash(" "))
+		if got := rows.String(); got != tt.want {
+			t.Fatalf("plain=%v: got\n%s\nwant\n%s", tt.style.Plain, got, tt.want)
+		}
+	}
+}
+
+func TestOrDash(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"simple text", "simple text"},
+		{"text|with|pipes", "text|with|pipes"},
+		{"", "-"},
+		{"  ", "-"},
+		{"  trimmed  ", "trimmed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if result := orDash(tt.input); result != tt.expected {
+				t.Errorf("orDash(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -3,17 +3,18 @@ package formatter
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dkooll/aztfmcp/internal/indexer"
 )
 
-func SyncProgress(progress *indexer.SyncProgress) string {
+func SyncProgress(st Style, progress *indexer.SyncProgress) string {
 	if progress == nil {
 		return ""
 	}
 
 	var text strings.Builder
-	text.WriteString("## Summary\n\n")
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Summary"))
 	succeeded := progress.ProcessedRepos - len(progress.Errors)
 	fmt.Fprintf(&text, "Successfully synced %d/%d repositories\n\n", succeeded, progress.TotalRepos)
 
@@ -37,5 +38,119 @@ func SyncProgress(progress *indexer.SyncProgress) string {
 		}
 	}
 
+	text.WriteString(parseFailureList(progress.ParseFailures))
+	text.WriteString(SyncTimings(st, progress.Timings))
 	return text.String()
 }
+
+// SyncTimings renders the stage breakdown of a profiled sync, or nothing when profiling was off.
+func SyncTimings(st Style, timings *indexer.SyncTimings) string {
+	if timings == nil {
+		return ""
+	}
+
+	total := timings.Download + timings.FileInserts + timings.Parse + timings.Releases
+	share := func(d time.Duration) string {
+		if total <= 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.0f%%", 100*float64(d)/float64(total))
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Timing Breakdown"))
+	rows := st.table("Stage", "Duration", "Share")
+	for _, stage := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"Download", timings.Download},
+		{"File inserts", timings.FileInserts},
+		{"Parse", timings.Parse},
+		{"Releases", timings.Releases},
+	} {
+		rows.row(stage.name, stage.duration.Round(time.Millisecond).String(), share(stage.duration))
+	}
+	text.WriteString(rows.String())
+	fmt.Fprintf(&text, "\n%s\n", st.italic("Durations are summed over repositories; concurrent workers can make the total exceed the wall-clock time."))
+	return text.String()
+}
+
+// RunningSyncProgress renders the live counters of a sync that has not finished yet. A nil or empty
+// progress means the job is still listing repositories.
+func RunningSyncProgress(st Style, progress *indexer.SyncProgress) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Progress"))
+	if progress == nil || progress.TotalRepos == 0 {
+		text.WriteString("Listing repositories from GitHub...\n\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "Processed: %d/%d repositories", progress.ProcessedRepos, progress.TotalRepos)
+	if progress.SkippedRepos > 0 {
+		fmt.Fprintf(&text, " (%d up-to-date)", progress.SkippedRepos)
+	}
+	text.WriteString("\n")
+	if progress.CurrentRepo != "" {
+		fmt.Fprintf(&text, "Current repository: %s\n", progress.CurrentRepo)
+	}
+	if len(progress.Errors) > 0 {
+		fmt.Fprintf(&text, "Errors so far: %d\n", len(progress.Errors))
+	}
+	if len(progress.ParseFailures) > 0 {
+		fmt.Fprintf(&text, "Go files that failed to parse so far: %d\n", len(progress.ParseFailures))
+	}
+	text.WriteString("\n")
+	return text.String()
+}
+
+// parseFailureList lists the Go files a sync skipped because they failed to parse, so degraded
+// coverage is visible instead of only logged.
+func parseFailureList(paths []string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	var text strings.Builder
+	fmt.Fprintf(&text, "\n%d Go files failed to parse and were skipped; resources defined in them may be missing:\n", len(paths))
+	for i, path := range paths {
+		if i >= 10 {
+			fmt.Fprintf(&text, "... and %d more files\n", len(paths)-10)
+			break
+		}
+		fmt.Fprintf(&text, "- %s\n", path)
+	}
+	return text.String()
+}
+
+// GitHubCacheStats renders the size and age of the in-memory GitHub response cache.
+func GitHubCacheStats(st Style, stats indexer.CacheStats, now time.Time) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "GitHub Cache"))
+	fmt.Fprintf(&text, "%s: %d (%d expired)\n", st.bold("Entries"), stats.Entries, stats.Expired)
+	fmt.Fprintf(&text, "%s: %s\n", st.bold("Size"), formatBytes(stats.Bytes))
+	if !stats.Oldest.IsZero() {
+		fmt.Fprintf(&text, "%s: %s old\n", st.bold("Oldest Entry"), now.Sub(stats.Oldest).Round(time.Second))
+	}
+	fmt.Fprintf(&text, "%s: %s\n\n", st.bold("TTL"), stats.TTL)
+	fmt.Fprintf(&text, "%s\n", st.italic("Use clear_github_cache to force fresh GitHub responses without a full resync."))
+	return text.String()
+}
+
+// GitHubCacheCleared confirms a clear_github_cache call.
+func GitHubCacheCleared(entries int) string {
+	if entries == 0 {
+		return "GitHub cache was already empty.\n"
+	}
+	return fmt.Sprintf("Cleared %d cached GitHub responses. The next sync or lookup will fetch fresh data.\n", entries)
+}
+
+func formatBytes(n int) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
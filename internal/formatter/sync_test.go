@@ -3,6 +3,7 @@ package formatter
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dkooll/aztfmcp/internal/indexer"
 )
@@ -14,11 +15,57 @@ func TestSyncProgress(t *testing.T) {
 		Errors:         []string{"err1", "err2", "err3", "err4", "err5", "err6", "err7", "err8", "err9", "err10", "err11"},
 		UpdatedRepos:   []string{"a", "b", "c"},
 	}
-	out := SyncProgress(progress)
+	out := SyncProgress(Style{}, progress)
 	if !strings.Contains(out, "Successfully synced 0/11") {
 		t.Fatalf("expected success count, got: %s", out)
 	}
 	if !strings.Contains(out, "... and 1 more errors") || !strings.Contains(out, "- a") {
 		t.Fatalf("expected truncation of errors, got: %s", out)
 	}
+	if strings.Contains(out, "failed to parse") {
+		t.Fatalf("expected no parse failure section without failures, got: %s", out)
+	}
+
+	progress.ParseFailures = []string{"internal/services/network/broken.go"}
+	out = SyncProgress(Style{}, progress)
+	if !strings.Contains(out, "1 Go files failed to parse") || !strings.Contains(out, "- internal/services/network/broken.go") {
+		t.Fatalf("expected parse failures listed, got: %s", out)
+	}
+}
+
+func TestRunningSyncProgress(t *testing.T) {
+	if got := RunningSyncProgress(Style{}, nil); !strings.Contains(got, "Listing repositories") {
+		t.Fatalf("expected listing note before totals are known, got: %s", got)
+	}
+
+	got := RunningSyncProgress(Style{}, &indexer.SyncProgress{
+		TotalRepos:     5,
+		ProcessedRepos: 3,
+		SkippedRepos:   2,
+		CurrentRepo:    "repo-c",
+		Errors:         []string{"boom"},
+		ParseFailures:  []string{"a.go", "b.go"},
+	})
+	for _, want := range []string{"Processed: 3/5 repositories (2 up-to-date)", "Current repository: repo-c", "Errors so far: 1", "Go files that failed to parse so far: 2"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q, got: %s", want, got)
+		}
+	}
+}
+
+func TestSyncTimings(t *testing.T) {
+	if got := SyncTimings(Style{}, nil); got != "" {
+		t.Fatalf("expected no output without profiling, got: %s", got)
+	}
+
+	got := SyncProgress(Style{}, &indexer.SyncProgress{
+		TotalRepos:     1,
+		ProcessedRepos: 1,
+		Timings:        &indexer.SyncTimings{Download: 3 * time.Second, Parse: time.Second},
+	})
+	for _, want := range []string{"## Timing Breakdown", "| Download | 3s | 75% |", "| Parse | 1s | 25% |", "| Releases | 0s | 0% |"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q, got: %s", want, got)
+		}
+	}
 }
@@ -0,0 +1,165 @@
+package formatter
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// tfSchemaBlock and friends mirror the block model of `terraform providers schema -json`.
+type tfSchemaBlock struct {
+	Attributes      map[string]*tfSchemaAttribute `json:"attributes,omitempty"`
+	BlockTypes      map[string]*tfSchemaBlockType `json:"block_types,omitempty"`
+	Description     string                        `json:"description,omitempty"`
+	DescriptionKind string                        `json:"description_kind"`
+}
+
+type tfSchemaAttribute struct {
+	Type            any    `json:"type"`
+	Description     string `json:"description,omitempty"`
+	DescriptionKind string `json:"description_kind"`
+	Required        bool   `json:"required,omitempty"`
+	Optional        bool   `json:"optional,omitempty"`
+	Computed        bool   `json:"computed,omitempty"`
+	Sensitive       bool   `json:"sensitive,omitempty"`
+	Deprecated      bool   `json:"deprecated,omitempty"`
+}
+
+type tfSchemaBlockType struct {
+	NestingMode string         `json:"nesting_mode"`
+	Block       *tfSchemaBlock `json:"block"`
+	MinItems    int64          `json:"min_items,omitempty"`
+	MaxItems    int64          `json:"max_items,omitempty"`
+}
+
+type tfSchemaResource struct {
+	Version int            `json:"version"`
+	Block   *tfSchemaBlock `json:"block"`
+}
+
+// TerraformSchemaJSON renders a resource as a `terraform providers schema -json` document holding
+// only that resource, keyed by providerSource (e.g. registry.terraform.io/hashicorp/azurerm).
+// Element types the parser could not resolve are emitted as "dynamic"; the schema version is not
+// parsed and is always 0.
+func TerraformSchemaJSON(providerSource string, resource *database.ProviderResource, attrs []database.ProviderAttribute) (string, error) {
+	tree := make([]database.NestedAttribute, 0, len(attrs))
+	elemTypes := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		tree = append(tree, database.NestedAttributeFromProvider(attr))
+		elemTypes[attr.Name] = attr.ElementType()
+	}
+
+	block := tfSchemaBlockFrom(tree, elemTypes)
+	if _, ok := block.Attributes["id"]; !ok {
+		if block.Attributes == nil {
+			block.Attributes = make(map[string]*tfSchemaAttribute)
+		}
+		// The plugin SDK adds an implicit id attribute to every resource and data source.
+		block.Attributes["id"] = &tfSchemaAttribute{Type: "string", DescriptionKind: "plain", Optional: true, Computed: true}
+	}
+
+	group := "resource_schemas"
+	if resource.Kind == "data_source" {
+		group = "data_source_schemas"
+	}
+	doc := map[string]any{
+		"format_version": "1.0",
+		"provider_schemas": map[string]any{
+			providerSource: map[string]any{
+				group: map[string]tfSchemaResource{
+					resource.Name: {Version: 0, Block: block},
+				},
+			},
+		},
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// TerraformSchemaExport wraps the JSON document in a fenced block under a short header.
+func TerraformSchemaExport(st Style, resourceName, schemaJSON string) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Terraform Schema JSON: %s", resourceName)))
+	text.WriteString(st.codeFence("json", schemaJSON))
+	return text.String()
+}
+
+// tfSchemaBlockFrom converts one level of the schema tree. elemTypes holds the resolved element
+// types of collection attributes at this level, keyed by name; nil when unknown.
+func tfSchemaBlockFrom(level []database.NestedAttribute, elemTypes map[string]string) *tfSchemaBlock {
+	block := &tfSchemaBlock{DescriptionKind: "plain"}
+	for _, attr := range level {
+		computedOnly := attr.Computed && !attr.Optional && !attr.Required
+		switch {
+		case attr.NestedBlock && !computedOnly:
+			if block.BlockTypes == nil {
+				block.BlockTypes = make(map[string]*tfSchemaBlockType)
+			}
+			block.BlockTypes[attr.Name] = &tfSchemaBlockType{
+				NestingMode: tfNestingMode(attr.Type),
+				Block:       tfSchemaBlockFrom(attr.Children, nil),
+				MinItems:    attr.MinItems,
+				MaxItems:    attr.MaxItems,
+			}
+		default:
+			if block.Attributes == nil {
+				block.Attributes = make(map[string]*tfSchemaAttribute)
+			}
+			block.Attributes[attr.Name] = &tfSchemaAttribute{
+				Type:            tfAttributeType(attr, elemTypes[attr.Name]),
+				Description:     attr.Description,
+				DescriptionKind: "plain",
+				Required:        attr.Required,
+				Optional:        attr.Optional,
+				Computed:        attr.Computed,
+				Sensitive:       attr.Sensitive,
+				Deprecated:      attr.Deprecated != "",
+			}
+		}
+	}
+	return block
+}
+
+// tfAttributeType returns the cty type JSON of an attribute. Computed-only nested blocks are
+// exposed as collections of objects, as the plugin SDK does.
+func tfAttributeType(attr database.NestedAttribute, elem string) any {
+	if attr.NestedBlock {
+		fields := make(map[string]any, len(attr.Children))
+		for _, child := range attr.Children {
+			fields[child.Name] = tfAttributeType(child, "")
+		}
+		return []any{tfNestingMode(attr.Type), []any{"object", fields}}
+	}
+
+	switch shortSchemaType(attr.Type) {
+	case "String":
+		return "string"
+	case "Int", "Float":
+		return "number"
+	case "Bool":
+		return "bool"
+	case "List":
+		return []any{"list", cmp.Or(elem, "dynamic")}
+	case "Set":
+		return []any{"set", cmp.Or(elem, "dynamic")}
+	case "Map":
+		// The plugin SDK defaults map elements to strings when no Elem is declared.
+		return []any{"map", cmp.Or(elem, "string")}
+	default:
+		return "dynamic"
+	}
+}
+
+func tfNestingMode(schemaType string) string {
+	if shortSchemaType(schemaType) == "Set" {
+		return "set"
+	}
+	return "list"
+}
@@ -0,0 +1,78 @@
+package formatter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+func TestTerraformSchemaJSON(t *testing.T) {
+	resource := &database.ProviderResource{Name: "azurerm_storage_account", Kind: "resource"}
+	attrs := []database.ProviderAttribute{
+		{Name: "name", Type: sql.NullString{String: "pluginsdk.TypeString", Valid: true}, Required: true, ForceNew: true, Description: sql.NullString{String: "The name.", Valid: true}},
+		{Name: "primary_access_key", Type: sql.NullString{String: "pluginsdk.TypeString", Valid: true}, Computed: true, Sensitive: true},
+		{Name: "ip_rules", Type: sql.NullString{String: "pluginsdk.TypeSet", Valid: true}, Optional: true, ElemSummary: sql.NullString{String: "Type=pluginsdk.TypeString", Valid: true}},
+		{Name: "tags", Type: sql.NullString{String: "pluginsdk.TypeMap", Valid: true}, Optional: true},
+		{
+			Name: "network_rules", Type: sql.NullString{String: "pluginsdk.TypeList", Valid: true}, Optional: true, NestedBlock: true,
+			MaxItems:       sql.NullInt64{Int64: 1, Valid: true},
+			ElemSchemaJSON: sql.NullString{String: database.EncodeNestedSchema([]database.NestedAttribute{{Name: "default_action", Type: "pluginsdk.TypeString", Required: true}}), Valid: true},
+		},
+		{
+			Name: "identity_ids", Type: sql.NullString{String: "pluginsdk.TypeList", Valid: true}, Computed: true, NestedBlock: true,
+			ElemSchemaJSON: sql.NullString{String: database.EncodeNestedSchema([]database.NestedAttribute{{Name: "principal_id", Type: "pluginsdk.TypeString", Computed: true}}), Valid: true},
+		},
+	}
+
+	out, err := TerraformSchemaJSON("registry.terraform.io/hashicorp/azurerm", resource, attrs)
+	if err != nil {
+		t.Fatalf("TerraformSchemaJSON failed: %v", err)
+	}
+
+	var doc struct {
+		FormatVersion   string `json:"format_version"`
+		ProviderSchemas map[string]struct {
+			ResourceSchemas map[string]struct {
+				Block struct {
+					Attributes map[string]map[string]any `json:"attributes"`
+					BlockTypes map[string]map[string]any `json:"block_types"`
+				} `json:"block"`
+			} `json:"resource_schemas"`
+		} `json:"provider_schemas"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, out)
+	}
+	block := doc.ProviderSchemas["registry.terraform.io/hashicorp/azurerm"].ResourceSchemas["azurerm_storage_account"].Block
+
+	for name, want := range map[string]any{
+		"name":               "string",
+		"primary_access_key": "string",
+		"ip_rules":           []any{"set", "string"},
+		"tags":               []any{"map", "string"},
+		"identity_ids":       []any{"list", []any{"object", map[string]any{"principal_id": "string"}}},
+		"id":                 "string",
+	} {
+		if got := block.Attributes[name]["type"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("attribute %s: type = %#v, want %#v", name, got, want)
+		}
+	}
+	if block.Attributes["name"]["required"] != true || block.Attributes["name"]["description"] != "The name." {
+		t.Errorf("expected required name with description, got %#v", block.Attributes["name"])
+	}
+	if block.Attributes["primary_access_key"]["sensitive"] != true || block.Attributes["primary_access_key"]["computed"] != true {
+		t.Errorf("expected computed sensitive key, got %#v", block.Attributes["primary_access_key"])
+	}
+
+	rules := block.BlockTypes["network_rules"]
+	if rules["nesting_mode"] != "list" || rules["max_items"] != float64(1) {
+		t.Errorf("unexpected network_rules block type: %#v", rules)
+	}
+	if !strings.Contains(out, `"default_action": {`) {
+		t.Errorf("expected nested block attributes in output:\n%s", out)
+	}
+}
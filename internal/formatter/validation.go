@@ -0,0 +1,411 @@
+package formatter
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// ValidationCategory summarises attributes sharing the same kind of validation.
+type ValidationCategory struct {
+	Name     string
+	Count    int
+	Examples []string
+}
+
+// ValidationCategories renders attribute counts and examples grouped by validation category.
+func ValidationCategories(st Style, scope string, totalValidated int, categories []ValidationCategory) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Validation Categories: %s", scope)))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Validated Attributes"), totalValidated)
+
+	if totalValidated == 0 || len(categories) == 0 {
+		text.WriteString("No attributes with validation found.\n")
+		return text.String()
+	}
+
+	rows := st.table("Category", "Attributes", "Examples")
+	for _, category := range categories {
+		rows.row(category.Name, fmt.Sprint(category.Count), orDash(strings.Join(category.Examples, ", ")))
+	}
+	text.WriteString(rows.String())
+	text.WriteString("\n")
+
+	return text.String()
+}
+
+// AllowedValues renders the enum values accepted by a StringInSlice-validated attribute.
+func AllowedValues(st Style, resourceName, attributeName, validation string, values []string, ignoreCase bool) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Allowed Values: %s.%s", resourceName, attributeName)))
+
+	if len(values) == 0 {
+		text.WriteString("No literal enum values could be extracted from the validation.\n")
+		if strings.TrimSpace(validation) != "" {
+			fmt.Fprintf(&text, "\n%s:\n%s", st.bold("Validation"), st.codeFence("go", strings.TrimSpace(validation)))
+		}
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Values"), len(values))
+	if ignoreCase {
+		fmt.Fprintf(&text, "%s: no\n\n", st.bold("Case Sensitive"))
+	} else {
+		fmt.Fprintf(&text, "%s: yes\n\n", st.bold("Case Sensitive"))
+	}
+	for _, value := range values {
+		fmt.Fprintf(&text, "- %s\n", st.code(value))
+	}
+
+	return text.String()
+}
+
+// AllowedValueMatch is an attribute whose enum validation accepts a searched value.
+type AllowedValueMatch struct {
+	ResourceName  string
+	AttributeName string
+	Values        []string
+}
+
+// AttributesWithValue renders attributes whose StringInSlice enum includes the requested value.
+func AttributesWithValue(st Style, value string, matches []AllowedValueMatch) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Attributes Accepting \"%s\" (%d matches)", value, len(matches))))
+
+	if len(matches) == 0 {
+		text.WriteString("No attributes with an enum validation accepting this value were found.\n")
+		return text.String()
+	}
+
+	rows := st.table("Resource", "Attribute", "Allowed Values")
+	for _, match := range matches {
+		rows.row(match.ResourceName, match.AttributeName, orDash(strings.Join(match.Values, ", ")))
+	}
+	text.WriteString(rows.String())
+	text.WriteString("\n")
+
+	return text.String()
+}
+
+// DescriptionStyleRule summarises how often a description style rule was violated.
+type DescriptionStyleRule struct {
+	Name  string
+	Hint  string
+	Count int
+}
+
+// DescriptionStyleViolation records the rules a single attribute description breaks.
+type DescriptionStyleViolation struct {
+	ResourceName  string
+	AttributePath string
+	Rules         []string
+	Description   string
+}
+
+// DescriptionStyleReport renders description lint results grouped by rule, followed by individual violations.
+func DescriptionStyleReport(st Style, scope string, checked int, rules []DescriptionStyleRule, violations []DescriptionStyleViolation, total int) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Description Style: %s", scope)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Descriptions Checked"), checked)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Violations"), total)
+
+	if total == 0 {
+		text.WriteString("All checked descriptions follow the style rules.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Rules"))
+	for _, rule := range rules {
+		if rule.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(&text, "- %s (%d): %s\n", st.bold(rule.Name), rule.Count, rule.Hint)
+	}
+	text.WriteString("\n")
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Violations"))
+	rows := st.table("Attribute", "Rules", "Description")
+	for _, v := range violations {
+		rows.row(fmt.Sprintf("%s.%s", v.ResourceName, v.AttributePath), strings.Join(v.Rules, ", "), orDash(TruncateDescription(v.Description, 80)))
+	}
+	text.WriteString(rows.String())
+	if len(violations) < total {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d violations.", len(violations), total)))
+	}
+
+	return text.String()
+}
+
+// ConstraintCheck summarises how often one constraint group check failed.
+type ConstraintCheck struct {
+	Name  string
+	Hint  string
+	Count int
+}
+
+// ConstraintAnomaly is one inconsistency between the constraint lists of a schema.
+type ConstraintAnomaly struct {
+	Check      string
+	Attribute  string   // schema path of the attribute declaring the constraint
+	Constraint string   // ExactlyOneOf, AtLeastOneOf or ConflictsWith
+	Involved   []string // other attribute paths the anomaly concerns
+}
+
+// ConstraintGroupReport renders constraint group lint results grouped by check, followed by each anomaly.
+func ConstraintGroupReport(st Style, resourceName string, checked int, checks []ConstraintCheck, anomalies []ConstraintAnomaly) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Constraint Groups: %s", resourceName)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Attributes With Constraints"), checked)
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Anomalies"), len(anomalies))
+
+	if len(anomalies) == 0 {
+		text.WriteString("ExactlyOneOf, AtLeastOneOf and ConflictsWith declarations are consistent.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Checks"))
+	for _, check := range checks {
+		if check.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(&text, "- %s (%d): %s\n", st.bold(check.Name), check.Count, check.Hint)
+	}
+	text.WriteString("\n")
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Anomalies"))
+	rows := st.table("Check", "Attribute", "Constraint", "Involves")
+	for _, a := range anomalies {
+		rows.row(a.Check, a.Attribute, a.Constraint, orDash(strings.Join(a.Involved, ", ")))
+	}
+	text.WriteString(rows.String())
+	return text.String()
+}
+
+// ConstraintGraph holds the ConflictsWith pairs and ExactlyOneOf/AtLeastOneOf groups of one schema,
+// keyed by attribute path.
+type ConstraintGraph struct {
+	Nodes     []string
+	Conflicts [][2]string
+	Groups    []ConstraintGraphGroup
+}
+
+// ConstraintGraphGroup is one ExactlyOneOf or AtLeastOneOf group with its member paths.
+type ConstraintGraphGroup struct {
+	Kind    string
+	Members []string
+}
+
+// ConstraintsDiagram renders a constraint graph as a Mermaid flowchart: attributes are nodes,
+// ConflictsWith pairs are dotted edges and each group is a hub node linked to its members.
+func ConstraintsDiagram(st Style, resourceName string, graph ConstraintGraph) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Constraints Diagram: %s", resourceName)))
+
+	if len(graph.Nodes) == 0 {
+		text.WriteString("No ConflictsWith, ExactlyOneOf or AtLeastOneOf constraints are declared.\n")
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Attributes"), len(graph.Nodes))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Conflicting Pairs"), len(graph.Conflicts))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Groups"), len(graph.Groups))
+
+	ids := make(map[string]string, len(graph.Nodes))
+	var diagram strings.Builder
+	diagram.WriteString("flowchart LR\n")
+	for i, node := range graph.Nodes {
+		ids[node] = fmt.Sprintf("a%d", i+1)
+		fmt.Fprintf(&diagram, "    %s[\"%s\"]\n", ids[node], node)
+	}
+	for _, pair := range graph.Conflicts {
+		fmt.Fprintf(&diagram, "    %s -. conflicts .- %s\n", ids[pair[0]], ids[pair[1]])
+	}
+	for i, group := range graph.Groups {
+		hub := fmt.Sprintf("g%d", i+1)
+		label := "exactly one of"
+		if group.Kind == "AtLeastOneOf" {
+			label = "at least one of"
+		}
+		fmt.Fprintf(&diagram, "    %s{{\"%s\"}}\n", hub, label)
+		for _, member := range group.Members {
+			fmt.Fprintf(&diagram, "    %s --- %s\n", ids[member], hub)
+		}
+	}
+	text.WriteString(st.codeFence("mermaid", diagram.String()) + "\n")
+
+	fmt.Fprintf(&text, "%s\n", st.italic("Dotted edges join attributes that conflict; hexagons group attributes of which exactly one, or at least one, must be set."))
+	return text.String()
+}
+
+// UnvalidatedAttributes renders configurable attributes that have no validation function.
+func UnvalidatedAttributes(st Style, scope string, total int, results []database.ProviderAttributeSearchResult) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Unvalidated Attributes: %s", scope)))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Matches"), total)
+
+	if total == 0 {
+		text.WriteString("Every configurable string and integer attribute in scope has a validation function.\n")
+		return text.String()
+	}
+
+	rows := st.table("Resource", "Attribute", "Type", "Flags")
+	for _, res := range results {
+		attr := res.Attribute
+		flags := "optional"
+		if attr.Required {
+			flags = "required"
+		}
+		if attr.Computed {
+			flags += ", computed"
+		}
+		rows.row(res.ResourceName, attr.Name, orDash(attr.Type.String), flags)
+	}
+	text.WriteString(rows.String())
+	if len(results) < total {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("Showing %d of %d matches; raise limit to see more.", len(results), total)))
+	}
+	return text.String()
+}
+
+// ConfigIssue is one problem validate_config found in an HCL block.
+type ConfigIssue struct {
+	Kind    string
+	Path    string // dotted argument path; empty for syntax errors
+	Line    int    // line in the submitted HCL, 0 when unknown
+	Message string
+}
+
+// ConfigValidation renders validate_config results. kind is empty when the HCL could not be parsed
+// and the schema was never consulted.
+func ConfigValidation(st Style, resourceName, kind string, issues []ConfigIssue) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Config Validation: %s", cmp.Or(resourceName, "HCL"))))
+	if kind != "" {
+		fmt.Fprintf(&text, "%s: %s\n", st.bold("Kind"), kind)
+	}
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Issues"), len(issues))
+
+	if len(issues) == 0 {
+		text.WriteString("Every argument and block is declared by the schema and all required arguments are set.\n\n")
+	} else {
+		rows := st.table("Line", "Kind", "Path", "Issue")
+		for _, issue := range issues {
+			line := "-"
+			if issue.Line > 0 {
+				line = fmt.Sprintf("%d", issue.Line)
+			}
+			rows.row(line, issue.Kind, cmp.Or(issue.Path, "-"), orDash(issue.Message))
+		}
+		text.WriteString(rows.String())
+		text.WriteString("\n")
+	}
+
+	fmt.Fprintf(&text, "%s\n", st.italic("Only argument names, block structure and presence are checked; values, types and validation functions are not evaluated."))
+	return text.String()
+}
+
+// ShadowedAttribute is an attribute inside a nested block that shares its name with a top-level attribute.
+type ShadowedAttribute struct {
+	Path        string
+	TopLevel    database.NestedAttribute
+	Nested      database.NestedAttribute
+	Differences []string // aspects in which the two declarations behave differently
+}
+
+// ShadowedAttributes renders the nested attributes that repeat a top-level attribute name, with the
+// nested blocks whose schema is not stored and so could not be checked.
+func ShadowedAttributes(st Style, resourceName string, shadowed []ShadowedAttribute, unresolved []string) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Shadowed Attributes: %s", resourceName)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Collisions"), len(shadowed))
+	if len(unresolved) > 0 {
+		fmt.Fprintf(&text, "%s: %d\n", st.bold("Unchecked Blocks"), len(unresolved))
+	}
+	text.WriteString("\n")
+
+	if len(shadowed) == 0 {
+		text.WriteString("No nested block attribute repeats a top-level attribute name.\n")
+	} else {
+		rows := st.table("Nested Path", "Top-Level", "Nested", "Differs In")
+		for _, s := range shadowed {
+			rows.row(s.Path, shadowedSummary(s.TopLevel), shadowedSummary(s.Nested), cmp.Or(strings.Join(s.Differences, ", "), "-"))
+		}
+		text.WriteString(rows.String())
+		fmt.Fprintf(&text, "\n%s\n", st.italic("Same-named attributes at different levels are easy to set in the wrong place; differing behaviour makes the mistake costlier."))
+	}
+
+	if len(unresolved) > 0 {
+		fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Unchecked Blocks"))
+		text.WriteString("These nested blocks have no stored schema, so their attributes could not be compared:\n\n")
+		for _, path := range unresolved {
+			fmt.Fprintf(&text, "- %s\n", path)
+		}
+	}
+	return text.String()
+}
+
+func shadowedSummary(attr database.NestedAttribute) string {
+	summary := cmp.Or(shortSchemaType(attr.Type), "unknown")
+	if flags := nestedAttributeFlags(attr); len(flags) > 0 {
+		summary += " (" + strings.Join(flags, ", ") + ")"
+	}
+	return summary
+}
+
+// Constraint kinds linking attributes in a constraint closure.
+const (
+	ConstraintConflictsWith = "ConflictsWith"
+	ConstraintExactlyOneOf  = "ExactlyOneOf"
+	ConstraintAtLeastOneOf  = "AtLeastOneOf"
+	ConstraintRequiredWith  = "RequiredWith"
+)
+
+// ConstraintEdge is one constraint declared on From that names To. RequiredWith is directional;
+// the other kinds tie both attributes together whichever side declares them.
+type ConstraintEdge struct {
+	Kind string
+	From string
+	To   string
+}
+
+// ConstraintNode is an attribute reached from the starting attribute, Depth hops away through Via.
+type ConstraintNode struct {
+	Path  string
+	Depth int
+	Via   ConstraintEdge // zero for the starting attribute
+}
+
+// ConstraintClosure renders the attributes transitively tied to start by constraints, with the edges among them.
+func ConstraintClosure(st Style, resourceName, start string, maxDepth int, nodes []ConstraintNode, edges []ConstraintEdge, truncated bool) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", st.heading(1, fmt.Sprintf("Constraint Closure: %s.%s", resourceName, start)))
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Connected Attributes"), len(nodes)-1)
+	fmt.Fprintf(&text, "%s: %d\n", st.bold("Constraints"), len(edges))
+	fmt.Fprintf(&text, "%s: %d\n\n", st.bold("Max Depth"), maxDepth)
+
+	if len(nodes) <= 1 {
+		fmt.Fprintf(&text, "%s declares no constraints and no other attribute names it.\n", st.code(start))
+		return text.String()
+	}
+
+	fmt.Fprintf(&text, "%s\n\n", st.heading(2, "Attributes"))
+	rows := st.table("Attribute", "Depth", "Reached Via")
+	for _, node := range nodes[1:] {
+		rows.row(node.Path, fmt.Sprint(node.Depth), fmt.Sprintf("%s %s → %s", node.Via.Kind, node.Via.From, node.Via.To))
+	}
+	text.WriteString(rows.String())
+
+	fmt.Fprintf(&text, "\n%s\n\n", st.heading(2, "Constraints"))
+	rows = st.table("Declared On", "Constraint", "Names")
+	for _, edge := range edges {
+		rows.row(edge.From, edge.Kind, edge.To)
+	}
+	text.WriteString(rows.String())
+
+	if truncated {
+		fmt.Fprintf(&text, "\n%s\n", st.italic(fmt.Sprintf("More attributes are linked beyond depth %d; raise max_depth to follow them.", maxDepth)))
+	}
+	return text.String()
+}
@@ -0,0 +1,85 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidationCategories(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		out := ValidationCategories(Style{}, "azurerm_example", 0, nil)
+		if !strings.Contains(out, "No attributes with validation found") {
+			t.Fatalf("expected empty message, got %s", out)
+		}
+	})
+
+	t.Run("with categories", func(t *testing.T) {
+		out := ValidationCategories(Style{}, "azurerm_example", 2, []ValidationCategory{
+			{Name: "enum", Count: 1, Examples: []string{"azurerm_example.sku"}},
+			{Name: "regex", Count: 1, Examples: []string{"azurerm_example.name"}},
+		})
+		if !strings.Contains(out, "# Validation Categories: azurerm_example") {
+			t.Fatalf("missing header: %s", out)
+		}
+		if !strings.Contains(out, "| enum | 1 | azurerm_example.sku |") || !strings.Contains(out, "| regex | 1 | azurerm_example.name |") {
+			t.Fatalf("missing category rows: %s", out)
+		}
+	})
+}
+
+func TestAllowedValues(t *testing.T) {
+	out := AllowedValues(Style{}, "azurerm_example", "sku", "", []string{"Basic", "Standard"}, true)
+	if !strings.Contains(out, "**Values**: 2") || !strings.Contains(out, "**Case Sensitive**: no") || !strings.Contains(out, "- `Standard`") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+
+	out = AllowedValues(Style{}, "azurerm_example", "sku", "validation.StringInSlice(skus, false)", nil, false)
+	if !strings.Contains(out, "No literal enum values") || !strings.Contains(out, "skus") {
+		t.Fatalf("expected fallback with validation, got %s", out)
+	}
+}
+
+func TestAttributesWithValue(t *testing.T) {
+	out := AttributesWithValue(Style{}, "Premium", nil)
+	if !strings.Contains(out, "(0 matches)") || !strings.Contains(out, "No attributes") {
+		t.Fatalf("unexpected empty output: %s", out)
+	}
+
+	out = AttributesWithValue(Style{}, "Premium", []AllowedValueMatch{{ResourceName: "azurerm_example", AttributeName: "sku", Values: []string{"Standard", "Premium"}}})
+	if !strings.Contains(out, "| azurerm_example | sku | Standard, Premium |") {
+		t.Fatalf("unexpected output: %s", out)
+	}
+}
+
+func TestConfigValidation(t *testing.T) {
+	clean := ConfigValidation(Style{}, "azurerm_example", "resource", nil)
+	if !strings.Contains(clean, "**Issues**: 0") || !strings.Contains(clean, "all required arguments are set") {
+		t.Fatalf("expected clean summary, got: %s", clean)
+	}
+
+	out := ConfigValidation(Style{}, "", "", []ConfigIssue{
+		{Kind: "syntax_error", Message: "Unclosed configuration block: a | b"},
+		{Kind: "unknown_argument", Path: "foo", Line: 3, Message: "'foo' is not an argument of this block"},
+	})
+	for _, want := range []string{"# Config Validation: HCL", "| - | syntax_error | - | Unclosed configuration block: a \\| b |", "| 3 | unknown_argument | foo |"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q, got: %s", want, out)
+		}
+	}
+	if strings.Contains(out, "**Kind**") {
+		t.Fatalf("expected no kind line when the schema was not consulted: %s", out)
+	}
+}
+
+func TestConstraintsDiagram(t *testing.T) {
+	out := ConstraintsDiagram(Style{}, "azurerm_example", ConstraintGraph{
+		Nodes:     []string{"a", "b", "c"},
+		Conflicts: [][2]string{{"a", "c"}},
+		Groups:    []ConstraintGraphGroup{{Kind: "ExactlyOneOf", Members: []string{"a", "b"}}},
+	})
+	for _, want := range []string{"**Groups**: 1", "```mermaid\nflowchart LR\n", "a1 -. conflicts .- a3", `g1{{"exactly one of"}}`, "a2 --- g1"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q, got: %s", want, out)
+		}
+	}
+}
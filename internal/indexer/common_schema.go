@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"go/ast"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// commonSchemaHelper describes the schema returned by a well-known helper from another package,
+// such as commonschema.Location() from go-azure-helpers. Those helpers live outside the indexed
+// repository, so their shapes are recorded here instead of being parsed.
+type commonSchemaHelper struct {
+	attr     database.NestedAttribute
+	elemType string // element type constant of list, set and map attributes
+}
+
+const (
+	schemaTypeString = "pluginsdk.TypeString"
+	schemaTypeList   = "pluginsdk.TypeList"
+	schemaTypeSet    = "pluginsdk.TypeSet"
+	schemaTypeMap    = "pluginsdk.TypeMap"
+)
+
+var (
+	// identityChildren is the identity block shared by the commonschema identity helpers.
+	identityChildren = []database.NestedAttribute{
+		{Name: "identity_ids", Type: schemaTypeSet, Optional: true},
+		{Name: "principal_id", Type: schemaTypeString, Computed: true},
+		{Name: "tenant_id", Type: schemaTypeString, Computed: true},
+		{Name: "type", Type: schemaTypeString, Required: true},
+	}
+
+	commonSchemaHelpers = map[string]commonSchemaHelper{
+		"commonschema.Location":                                   {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, ForceNew: true, Validation: "location.EnhancedValidate"}},
+		"commonschema.LocationOptional":                           {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true, ForceNew: true, Validation: "location.EnhancedValidate"}},
+		"commonschema.LocationComputed":                           {attr: database.NestedAttribute{Type: schemaTypeString, Computed: true}},
+		"commonschema.LocationWithoutForceNew":                    {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, Validation: "location.EnhancedValidate"}},
+		"commonschema.ResourceGroupName":                          {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, ForceNew: true, Validation: "resourcegroups.ValidateName"}},
+		"commonschema.ResourceGroupNameForDataSource":             {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, Validation: "resourcegroups.ValidateName"}},
+		"commonschema.ResourceGroupNameOptional":                  {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true, Validation: "resourcegroups.ValidateName"}},
+		"commonschema.ResourceIDReferenceRequired":                {attr: database.NestedAttribute{Type: schemaTypeString, Required: true}},
+		"commonschema.ResourceIDReferenceRequiredForceNew":        {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, ForceNew: true}},
+		"commonschema.ResourceIDReferenceOptional":                {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true}},
+		"commonschema.ResourceIDReferenceOptionalForceNew":        {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true, ForceNew: true}},
+		"commonschema.ResourceIDReferenceComputed":                {attr: database.NestedAttribute{Type: schemaTypeString, Computed: true}},
+		"commonschema.EdgeZoneOptional":                           {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true, ForceNew: true}},
+		"commonschema.EdgeZoneComputed":                           {attr: database.NestedAttribute{Type: schemaTypeString, Computed: true}},
+		"commonschema.Tags":                                       {attr: database.NestedAttribute{Type: schemaTypeMap, Optional: true, Validation: "tags.Validate"}, elemType: schemaTypeString},
+		"commonschema.TagsForceNew":                               {attr: database.NestedAttribute{Type: schemaTypeMap, Optional: true, ForceNew: true, Validation: "tags.Validate"}, elemType: schemaTypeString},
+		"commonschema.TagsDataSource":                             {attr: database.NestedAttribute{Type: schemaTypeMap, Computed: true}, elemType: schemaTypeString},
+		"tags.Schema":                                             {attr: database.NestedAttribute{Type: schemaTypeMap, Optional: true, Validation: "tags.Validate"}, elemType: schemaTypeString},
+		"tags.ForceNewSchema":                                     {attr: database.NestedAttribute{Type: schemaTypeMap, Optional: true, ForceNew: true, Validation: "tags.Validate"}, elemType: schemaTypeString},
+		"tags.SchemaDataSource":                                   {attr: database.NestedAttribute{Type: schemaTypeMap, Computed: true}, elemType: schemaTypeString},
+		"commonschema.ZonesMultipleOptional":                      {attr: database.NestedAttribute{Type: schemaTypeSet, Optional: true, ForceNew: true}, elemType: schemaTypeString},
+		"commonschema.ZonesMultipleOptionalForceNew":              {attr: database.NestedAttribute{Type: schemaTypeSet, Optional: true, ForceNew: true}, elemType: schemaTypeString},
+		"commonschema.ZonesMultipleRequired":                      {attr: database.NestedAttribute{Type: schemaTypeSet, Required: true, ForceNew: true}, elemType: schemaTypeString},
+		"commonschema.ZonesMultipleComputed":                      {attr: database.NestedAttribute{Type: schemaTypeSet, Computed: true}, elemType: schemaTypeString},
+		"commonschema.ZoneSingleOptional":                         {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true}},
+		"commonschema.ZoneSingleOptionalForceNew":                 {attr: database.NestedAttribute{Type: schemaTypeString, Optional: true, ForceNew: true}},
+		"commonschema.ZoneSingleRequired":                         {attr: database.NestedAttribute{Type: schemaTypeString, Required: true, ForceNew: true}},
+		"commonschema.ZoneSingleComputed":                         {attr: database.NestedAttribute{Type: schemaTypeString, Computed: true}},
+		"commonschema.SystemAssignedIdentityOptional":             {attr: identityBlock(true, false)},
+		"commonschema.SystemAssignedIdentityRequired":             {attr: identityBlock(false, false)},
+		"commonschema.SystemAssignedIdentityComputed":             {attr: identityBlock(false, true)},
+		"commonschema.UserAssignedIdentityOptional":               {attr: identityBlock(true, false)},
+		"commonschema.UserAssignedIdentityRequired":               {attr: identityBlock(false, false)},
+		"commonschema.UserAssignedIdentityComputed":               {attr: identityBlock(false, true)},
+		"commonschema.SystemAssignedUserAssignedIdentityOptional": {attr: identityBlock(true, false)},
+		"commonschema.SystemAssignedUserAssignedIdentityRequired": {attr: identityBlock(false, false)},
+		"commonschema.SystemAssignedUserAssignedIdentityComputed": {attr: identityBlock(false, true)},
+		"commonschema.SystemOrUserAssignedIdentityOptional":       {attr: identityBlock(true, false)},
+		"commonschema.SystemOrUserAssignedIdentityRequired":       {attr: identityBlock(false, false)},
+		"commonschema.SystemOrUserAssignedIdentityComputed":       {attr: identityBlock(false, true)},
+	}
+)
+
+// RegisterSchemaHelper records the schema returned by a helper call so the parser can resolve it,
+// replacing any existing entry. call is the package-qualified function name as written at call
+// sites (e.g. "commonschema.Location"); elemType is the element type constant of list, set and
+// map schemas and empty otherwise. Register helpers before syncing: the registry is read
+// concurrently while files are parsed.
+func RegisterSchemaHelper(call string, attr database.NestedAttribute, elemType string) {
+	attr.Name = ""
+	commonSchemaHelpers[call] = commonSchemaHelper{attr: attr, elemType: elemType}
+}
+
+func identityBlock(optional, computed bool) database.NestedAttribute {
+	return database.NestedAttribute{
+		Type:        schemaTypeList,
+		Required:    !optional && !computed,
+		Optional:    optional,
+		Computed:    computed,
+		MaxItems:    1,
+		NestedBlock: true,
+		Children:    identityChildren,
+	}
+}
+
+// commonSchemaAttribute returns the attribute built by a call to a well-known schema helper such
+// as commonschema.Location() or tags.Schema(); ok is false for any other expression.
+func commonSchemaAttribute(name string, expr ast.Expr) (database.ProviderAttribute, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return database.ProviderAttribute{}, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return database.ProviderAttribute{}, false
+	}
+	pkg := identName(sel.X)
+	if pkg == "" {
+		return database.ProviderAttribute{}, false
+	}
+	helper, ok := commonSchemaHelpers[pkg+"."+sel.Sel.Name]
+	if !ok {
+		return database.ProviderAttribute{}, false
+	}
+
+	nested := helper.attr
+	nested.Name = name
+	attr := database.ProviderAttributeFromNested(nested)
+	if helper.elemType != "" {
+		attr.ElemType = nullString("&pluginsdk.Schema{Type: " + helper.elemType + "}")
+		attr.ElemSummary = nullString("Type=" + helper.elemType)
+	}
+	return attr, true
+}
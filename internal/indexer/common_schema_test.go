@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+const commonSchemaSource = `package resource
+
+func resourceResourceGroup() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name":     {Type: pluginsdk.TypeString, Required: true, ForceNew: true},
+			"location": commonschema.Location(),
+			"tags":     tags.Schema(),
+			"identity": commonschema.SystemAssignedIdentityOptional(),
+			"settings": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"zones": commonschema.ZonesMultipleOptional(),
+					},
+				},
+			},
+			"custom": customSchema(),
+		},
+	}
+}
+`
+
+func TestParseResourceSchemaSourceResolvesCommonSchemaHelpers(t *testing.T) {
+	attrs, err := ParseResourceSchemaSource("resource_group_resource.go", commonSchemaSource, "azurerm_resource_group")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	byName := make(map[string]database.ProviderAttribute, len(attrs))
+	for _, attr := range attrs {
+		byName[attr.Name] = attr
+	}
+
+	location := byName["location"]
+	if location.Type.String != "pluginsdk.TypeString" || !location.Required || !location.ForceNew || location.Validation.String != "location.EnhancedValidate" {
+		t.Fatalf("unexpected location attribute: %+v", location)
+	}
+
+	tags := byName["tags"]
+	if tags.Type.String != "pluginsdk.TypeMap" || !tags.Optional || tags.Required || tags.ElementType() != "string" {
+		t.Fatalf("unexpected tags attribute: %+v", tags)
+	}
+
+	identity := byName["identity"]
+	children := database.DecodeNestedSchema(identity.ElemSchemaJSON.String)
+	if !identity.NestedBlock || !identity.Optional || identity.MaxItems.Int64 != 1 || len(children) != 4 {
+		t.Fatalf("unexpected identity block: %+v", identity)
+	}
+
+	nested := database.DecodeNestedSchema(byName["settings"].ElemSchemaJSON.String)
+	if len(nested) != 1 || nested[0].Name != "zones" || nested[0].Type != "pluginsdk.TypeSet" || !nested[0].ForceNew {
+		t.Fatalf("expected zones helper resolved inside nested block, got %+v", nested)
+	}
+
+	if custom := byName["custom"]; custom.Type.Valid {
+		t.Fatalf("expected unknown helper to stay unresolved, got %+v", custom)
+	}
+}
+
+func TestRegisterSchemaHelper(t *testing.T) {
+	const call = "networkschema.SubnetID"
+	t.Cleanup(func() { delete(commonSchemaHelpers, call) })
+
+	source := `package network
+
+func resourceNetworkThing() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_group_name": commonschema.ResourceGroupName(),
+			"subnet_id":           networkschema.SubnetID(),
+			"address_prefixes":    networkschema.AddressPrefixes(),
+		},
+	}
+}
+`
+	RegisterSchemaHelper(call, database.NestedAttribute{Name: "ignored", Type: "pluginsdk.TypeString", Required: true, ForceNew: true}, "")
+
+	attrs, err := ParseResourceSchemaSource("network_thing_resource.go", source, "azurerm_network_thing")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	byName := make(map[string]database.ProviderAttribute, len(attrs))
+	for _, attr := range attrs {
+		byName[attr.Name] = attr
+	}
+
+	if rg := byName["resource_group_name"]; !rg.Required || !rg.ForceNew || rg.Validation.String != "resourcegroups.ValidateName" {
+		t.Fatalf("unexpected resource_group_name attribute: %+v", rg)
+	}
+	if subnet, ok := byName["subnet_id"]; !ok || !subnet.Required || !subnet.ForceNew {
+		t.Fatalf("expected registered helper resolved under its key name, got %+v", byName)
+	}
+	if prefixes := byName["address_prefixes"]; prefixes.Type.Valid {
+		t.Fatalf("expected unregistered helper to stay unresolved, got %+v", prefixes)
+	}
+}
@@ -9,36 +9,59 @@ import (
 	"go/printer"
 	"go/token"
 	"log"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dkooll/aztfmcp/internal/database"
 )
 
+// DefaultProviderPrefix is the resource name prefix assumed when a repository name does not
+// follow the terraform-provider-<name> convention.
+const DefaultProviderPrefix = "azurerm_"
+
+// ProviderPrefix derives the resource name prefix from a repository name, so
+// "hashicorp/terraform-provider-azuread" yields "azuread_". Fork suffixes such as
+// terraform-provider-azurerm-fork are ignored.
+func ProviderPrefix(repo string) string {
+	name := strings.ToLower(strings.TrimSpace(repo))
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name, ok := strings.CutPrefix(name, "terraform-provider-")
+	if !ok {
+		return DefaultProviderPrefix
+	}
+	name, _, _ = strings.Cut(name, "-")
+	if name == "" {
+		return DefaultProviderPrefix
+	}
+	return name + "_"
+}
+
 func (s *Syncer) parseProviderRepository(repositoryID int64, repo GitHubRepo) error {
 	files, err := s.db.GetRepositoryFiles(repositoryID)
 	if err != nil {
 		return err
 	}
 
-	var goFiles []providerGoFile
-	for _, file := range files {
-		if !strings.HasSuffix(file.FileName, ".go") {
-			continue
-		}
-
-		goFile, err := parseGoFile(file)
-		if err != nil {
-			log.Printf("Warning: failed to parse Go file %s: %v", file.FilePath, err)
-			continue
-		}
-		goFiles = append(goFiles, goFile)
+	goFiles, skipped, failed := s.parseGoFiles(files)
+	if len(failed) > 0 {
+		log.Printf("Warning: %d Go files in %s failed to parse and were skipped", len(failed), repo.Name)
+	}
+	if err := s.db.SetRepositoryParseFailures(repositoryID, failed); err != nil {
+		log.Printf("Warning: failed to record parse failures for %s: %v", repo.Name, err)
 	}
-
 	if len(goFiles) == 0 {
 		return fmt.Errorf("no Go files discovered in %s", repo.Name)
 	}
+	if skipped > 0 {
+		log.Printf("Parse cache: skipped %d unchanged Go files without provider definitions", skipped)
+	}
 
 	// Parse and store service metadata
 	servicesByName, err := s.parseServiceMetadata(repositoryID, goFiles)
@@ -46,8 +69,9 @@ func (s *Syncer) parseProviderRepository(repositoryID int64, repo GitHubRepo) er
 		log.Printf("Warning: failed to parse service metadata: %v", err)
 	}
 
-	parser := newProviderParser(goFiles)
+	parser := newProviderParser(goFiles, ProviderPrefix(repo.Name))
 	parsedResources := parser.Parse()
+	s.updateParseCache(goFiles, parser.symbols, parsedResources)
 	if len(parsedResources) == 0 {
 		return fmt.Errorf("no provider resources or data sources discovered in %s", repo.Name)
 	}
@@ -78,6 +102,12 @@ func (s *Syncer) parseProviderRepository(repositoryID int64, repo GitHubRepo) er
 			}
 		}
 
+		if version := s.snapshotVersion(); version != "" && len(resource.attributes) > 0 {
+			if err := s.db.ReplaceSchemaSnapshot(repositoryID, version, resource.resource.Name, resource.resource.Kind, resource.attributes); err != nil {
+				log.Printf("Warning: failed to record %s schema snapshot for %s: %v", version, resource.resource.Name, err)
+			}
+		}
+
 		if resource.source != nil {
 			if err := s.db.UpsertProviderResourceSource(
 				resourceID,
@@ -91,6 +121,18 @@ func (s *Syncer) parseProviderRepository(repositoryID int64, repo GitHubRepo) er
 				resource.source.importerSnippet(),
 			); err != nil {
 				log.Printf("Warning: failed to store source snippet for %s: %v", resource.resource.Name, err)
+			} else {
+				functionStart, functionEnd := resource.source.functionLines()
+				schemaStart, schemaEnd := resource.source.schemaLines()
+				if err := s.db.SetProviderResourceSourceLines(resourceID, functionStart, functionEnd, schemaStart, schemaEnd); err != nil {
+					log.Printf("Warning: failed to store source lines for %s: %v", resource.resource.Name, err)
+				}
+				if err := s.db.SetProviderResourceSourceAttributeLines(resourceID, resource.source.schemaAttributeLines()); err != nil {
+					log.Printf("Warning: failed to store attribute lines for %s: %v", resource.resource.Name, err)
+				}
+				if err := s.db.SetProviderResourceSourceSDKPackages(resourceID, extractSDKPackagesFromFile(resource.source.file)); err != nil {
+					log.Printf("Warning: failed to store SDK packages for %s: %v", resource.resource.Name, err)
+				}
 			}
 		}
 	}
@@ -99,6 +141,112 @@ func (s *Syncer) parseProviderRepository(repositoryID int64, repo GitHubRepo) er
 	return nil
 }
 
+// parseGoFiles parses the repository's Go files. A file is skipped when its content hash matches
+// the parse cache and its last parse found no resource functions or registrations: such a file
+// cannot contribute to the parsed provider schema until its content changes. The paths of files
+// that fail to parse are returned sorted.
+func (s *Syncer) parseGoFiles(files []database.RepositoryFile) ([]providerGoFile, int, []string) {
+	cache, err := s.db.ListParseCacheEntries()
+	if err != nil {
+		log.Printf("Warning: failed to load parse cache, parsing every file: %v", err)
+		cache = nil
+	}
+
+	workers := s.parseWorkers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	started := time.Now()
+	goFiles, skipped, failed := parseGoFilesConcurrently(files, cache, workers)
+	log.Printf("Parsed %d Go files in %s using %d workers", len(goFiles), time.Since(started).Round(time.Millisecond), workers)
+	return goFiles, skipped, failed
+}
+
+// parseGoFilesConcurrently spreads AST parsing over a pool of workers. Files are independent at
+// this stage, so only the cross-file providerParser that follows has to run on a single goroutine.
+// Results keep the order of files.
+func parseGoFilesConcurrently(files []database.RepositoryFile, cache map[string]database.ParseCacheEntry, workers int) ([]providerGoFile, int, []string) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]*providerGoFile, len(files))
+	failed := make([]bool, len(files))
+	var skipped atomic.Int64
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range jobs {
+				file := files[i]
+				if entry, ok := cache[file.FilePath]; ok && entry.SymbolCount == 0 &&
+					!strings.HasSuffix(file.FilePath, "registration.go") &&
+					entry.ContentHash == database.ContentHash(file.Content) {
+					skipped.Add(1)
+					continue
+				}
+
+				goFile, err := parseGoFile(file)
+				if err != nil {
+					log.Printf("Warning: failed to parse Go file %s: %v", file.FilePath, err)
+					failed[i] = true
+					continue
+				}
+				results[i] = &goFile
+			}
+		})
+	}
+
+	for i, file := range files {
+		if strings.HasSuffix(file.FileName, ".go") {
+			jobs <- i
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	goFiles := make([]providerGoFile, 0, len(files))
+	var failedPaths []string
+	for i, result := range results {
+		if result != nil {
+			goFiles = append(goFiles, *result)
+		}
+		if failed[i] {
+			failedPaths = append(failedPaths, files[i].FilePath)
+		}
+	}
+	sort.Strings(failedPaths)
+	return goFiles, int(skipped.Load()), failedPaths
+}
+
+// updateParseCache records the content hash of every parsed file together with the resources,
+// attributes and provider symbols it produced.
+func (s *Syncer) updateParseCache(goFiles []providerGoFile, symbols map[string]int, parsed []parsedProviderResource) {
+	resources := make(map[string]int)
+	attributes := make(map[string]int)
+	for _, resource := range parsed {
+		if resource.source == nil {
+			continue
+		}
+		resources[resource.source.filePath]++
+		attributes[resource.source.filePath] += len(resource.attributes)
+	}
+
+	for _, goFile := range goFiles {
+		path := goFile.repositoryFile.FilePath
+		entry := database.ParseCacheEntry{
+			FilePath:       path,
+			ContentHash:    database.ContentHash(goFile.repositoryFile.Content),
+			ResourceCount:  resources[path],
+			AttributeCount: attributes[path],
+			SymbolCount:    symbols[path],
+		}
+		if err := s.db.UpsertParseCacheEntry(&entry); err != nil {
+			log.Printf("Warning: failed to update parse cache for %s: %v", path, err)
+		}
+	}
+}
+
 type providerGoFile struct {
 	repositoryFile database.RepositoryFile
 	file           *ast.File
@@ -152,6 +300,57 @@ func (f *resourceFunc) schemaSnippet() string {
 	return exprToString(f.file.fset, schemaExpr)
 }
 
+// functionLines returns the first and last line (1-based) of the resource function declaration.
+func (f *resourceFunc) functionLines() (int, int) {
+	if f == nil || f.decl == nil {
+		return 0, 0
+	}
+	return lineRange(f.file, f.decl.Pos(), f.decl.End())
+}
+
+// schemaLines returns the line range of the Schema map, falling back to the whole resource literal.
+func (f *resourceFunc) schemaLines() (int, int) {
+	if f == nil || f.literal == nil {
+		return 0, 0
+	}
+
+	if schemaExpr := extractSchemaExpr(f.literal); schemaExpr != nil {
+		return lineRange(f.file, schemaExpr.Pos(), schemaExpr.End())
+	}
+	return lineRange(f.file, f.literal.Pos(), f.literal.End())
+}
+
+// schemaAttributeLines returns the declaration line of each string-keyed entry of the Schema map
+// literal, in source order. Schemas built by helper calls have no literal to inspect and yield nil.
+func (f *resourceFunc) schemaAttributeLines() []database.SchemaAttributeLine {
+	if f == nil || f.literal == nil || f.file.fset == nil {
+		return nil
+	}
+
+	schemaLit, ok := extractSchemaExpr(f.literal).(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	var lines []database.SchemaAttributeLine
+	for _, elt := range schemaLit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.BasicLit)
+		if !ok || key.Kind != token.STRING {
+			continue
+		}
+		name, err := strconv.Unquote(key.Value)
+		if err != nil {
+			continue
+		}
+		lines = append(lines, database.SchemaAttributeLine{Name: name, Line: f.file.fset.Position(key.Pos()).Line})
+	}
+	return lines
+}
+
 func (f *resourceFunc) customizeDiffSnippet() string {
 	if f == nil || f.literal == nil {
 		return ""
@@ -200,18 +399,27 @@ func (f *resourceFunc) importerSnippet() string {
 	return exprToString(f.file.fset, importerExpr)
 }
 
+const (
+	registrationUntyped = "untyped"
+	registrationTyped   = "typed"
+	registrationRemoved = database.RegistrationRemoved
+)
+
 type resourceRegistration struct {
 	TypeName string
 	FuncName string
 	Kind     string
+	Source   string
 }
 
 type providerParser struct {
 	files      []providerGoFile
 	funcByName map[string]providerGoFile
+	symbols    map[string]int // resource functions and registrations found per file path
+	prefix     string         // resource name prefix of the provider, e.g. "azurerm_"
 }
 
-func newProviderParser(files []providerGoFile) *providerParser {
+func newProviderParser(files []providerGoFile, prefix string) *providerParser {
 	funcByName := make(map[string]providerGoFile)
 	for _, f := range files {
 		for _, decl := range f.file.Decls {
@@ -223,7 +431,7 @@ func newProviderParser(files []providerGoFile) *providerParser {
 			}
 		}
 	}
-	return &providerParser{files: files, funcByName: funcByName}
+	return &providerParser{files: files, funcByName: funcByName, symbols: make(map[string]int), prefix: prefix}
 }
 
 func (p *providerParser) Parse() []parsedProviderResource {
@@ -231,39 +439,47 @@ func (p *providerParser) Parse() []parsedProviderResource {
 	registrations := p.collectResourceRegistrations()
 
 	var parsed []parsedProviderResource
+	index := make(map[string]int) // name|kind -> position in parsed
+	add := func(resource parsedProviderResource) {
+		key := resource.resource.Name + "|" + resource.resource.Kind
+		if i, exists := index[key]; exists {
+			if richerParse(resource, parsed[i]) {
+				parsed[i] = resource
+			}
+			return
+		}
+		index[key] = len(parsed)
+		parsed = append(parsed, resource)
+	}
+
 	for _, reg := range registrations {
 		// Debug specific resources
-		if reg.TypeName == "azurerm_resource_group" || reg.TypeName == "azurerm_virtual_network" {
+		if reg.TypeName == p.prefix+"resource_group" || reg.TypeName == p.prefix+"virtual_network" {
 			log.Printf("DEBUG: Processing %s (kind: %s, func: %s)", reg.TypeName, reg.Kind, reg.FuncName)
 		}
 
-		// Skip typed resources without function definitions (they use struct methods)
+		// Typed resources have no function definition (they use struct methods)
 		if reg.FuncName == "" {
-			// Create minimal resource entry for typed resources
-			parsed = append(parsed, parsedProviderResource{
-				resource: database.ProviderResource{
-					Name:        reg.TypeName,
-					DisplayName: sql.NullString{String: displayNameFromResource(reg.TypeName), Valid: true},
-					Kind:        reg.Kind,
-				},
-				attributes: []database.ProviderAttribute{},
-				source:     nil,
-			})
+			add(unresolvedResource(p.prefix, reg, database.UnresolvedTyped, ""))
 			continue
 		}
 
+		// Registrations whose schema cannot be parsed are still indexed so they show up in
+		// listings and in list_unresolved_resources instead of silently disappearing.
 		fn := funcs[reg.FuncName]
 		if fn == nil {
 			log.Printf("Warning: registry entry %s -> %s missing function definition", reg.TypeName, reg.FuncName)
+			add(unresolvedResource(p.prefix, reg, database.UnresolvedMissingFunction, ""))
 			continue
 		}
 
-		resource, err := buildParsedResource(reg, fn)
+		resource, err := buildParsedResource(p.prefix, reg, fn)
 		if err != nil {
 			log.Printf("Warning: failed to parse schema for %s: %v", reg.TypeName, err)
+			add(unresolvedResource(p.prefix, reg, database.UnresolvedParseError, fn.filePath))
 			continue
 		}
-		parsed = append(parsed, resource)
+		add(resource)
 	}
 
 	sort.Slice(parsed, func(i, j int) bool {
@@ -273,6 +489,16 @@ func (p *providerParser) Parse() []parsedProviderResource {
 	return parsed
 }
 
+// richerParse reports whether candidate carries more of a definition's schema than current, for
+// definitions registered more than once: more parsed attributes win, then a resolved definition
+// over an unresolved one. Ties keep current, so the untyped registration wins by default.
+func richerParse(candidate, current parsedProviderResource) bool {
+	if len(candidate.attributes) != len(current.attributes) {
+		return len(candidate.attributes) > len(current.attributes)
+	}
+	return !candidate.resource.UnresolvedReason.Valid && current.resource.UnresolvedReason.Valid
+}
+
 func (p *providerParser) collectResourceFunctions() map[string]*resourceFunc {
 	funcs := make(map[string]*resourceFunc)
 
@@ -299,22 +525,31 @@ func (p *providerParser) collectResourceFunctions() map[string]*resourceFunc {
 				literal:  lit,
 				decl:     fn,
 			}
+			p.symbols[file.repositoryFile.FilePath]++
 		}
 	}
 
 	return funcs
 }
 
+// collectResourceRegistrations returns the untyped registrations followed by the typed ones. Each
+// pass dedupes identical name+kind entries, but a definition registered both ways while it is
+// migrated keeps both entries: Parse resolves them and keeps the richer one (see richerParse).
 func (p *providerParser) collectResourceRegistrations() []resourceRegistration {
-	seen := make(map[string]struct{}) // dedupe only identical name+kind
 	var registrations []resourceRegistration
 
 	// Collect untyped (legacy) registrations: map[string]*pluginsdk.Resource
-	untypedRegs := p.collectUntypedRegistrations(seen)
+	untypedSeen := make(map[string]struct{})
+	untypedRegs := p.collectUntypedRegistrations(untypedSeen)
 	registrations = append(registrations, untypedRegs...)
 
 	// Collect typed (modern) registrations: []sdk.Resource
-	typedRegs := p.collectTypedRegistrations(seen)
+	typedRegs := p.collectTypedRegistrations(make(map[string]struct{}))
+	for _, reg := range typedRegs {
+		if _, exists := untypedSeen[fmt.Sprintf("%s|%s", reg.TypeName, reg.Kind)]; exists {
+			log.Printf("Warning: %s (%s) is registered both untyped and typed; keeping the registration with parsed attributes", reg.TypeName, reg.Kind)
+		}
+	}
 	registrations = append(registrations, typedRegs...)
 
 	return registrations
@@ -368,8 +603,10 @@ func (p *providerParser) collectUntypedRegistrations(seen map[string]struct{}) [
 					TypeName: name,
 					FuncName: funcName,
 					Kind:     inferRegistrationKind(funcName),
+					Source:   registrationUntyped,
 				}
 
+				p.symbols[file.repositoryFile.FilePath]++
 				key := fmt.Sprintf("%s|%s", reg.TypeName, reg.Kind)
 				if _, exists := seen[key]; exists {
 					return true
@@ -415,13 +652,14 @@ func (p *providerParser) collectTypedRegistrations(seen map[string]struct{}) []r
 					if ret, ok := stmt.(*ast.ReturnStmt); ok && len(ret.Results) > 0 {
 						if lit, ok := ret.Results[0].(*ast.CompositeLit); ok {
 							for _, elt := range lit.Elts {
-								resourceType := extractTypedResourceName(elt)
+								resourceType := extractTypedResourceName(p.prefix, elt)
 								if resourceType != "" {
 									kind := "resource"
 									if methodName == "DataSources" {
 										kind = "data_source"
 									}
 
+									p.symbols[file.repositoryFile.FilePath]++
 									key := fmt.Sprintf("%s|%s", resourceType, kind)
 									if _, exists := seen[key]; !exists {
 										seen[key] = struct{}{}
@@ -429,6 +667,7 @@ func (p *providerParser) collectTypedRegistrations(seen map[string]struct{}) []r
 											TypeName: resourceType,
 											FuncName: "",
 											Kind:     kind,
+											Source:   registrationTyped,
 										})
 									}
 								}
@@ -449,25 +688,25 @@ func (p *providerParser) collectTypedRegistrations(seen map[string]struct{}) []r
 	return registrations
 }
 
-func extractTypedResourceName(expr ast.Expr) string {
+func extractTypedResourceName(prefix string, expr ast.Expr) string {
 	// Handle CompositeLit like AvailabilitySetResource{}
 	if lit, ok := expr.(*ast.CompositeLit); ok {
 		if ident, ok := lit.Type.(*ast.Ident); ok {
-			return structNameToResourceName(ident.Name)
+			return structNameToResourceName(prefix, ident.Name)
 		}
 	}
 
 	// Handle bare identifiers
 	if ident, ok := expr.(*ast.Ident); ok {
-		return structNameToResourceName(ident.Name)
+		return structNameToResourceName(prefix, ident.Name)
 	}
 
 	return ""
 }
 
-func structNameToResourceName(structName string) string {
-	// Convert "AvailabilitySetResource" to "azurerm_availability_set"
-	// Convert "VirtualNetworkDataSource" to "azurerm_virtual_network"
+func structNameToResourceName(prefix, structName string) string {
+	// Convert "AvailabilitySetResource" to "<prefix>availability_set"
+	// Convert "VirtualNetworkDataSource" to "<prefix>virtual_network"
 
 	structName = strings.TrimSuffix(structName, "Resource")
 	structName = strings.TrimSuffix(structName, "DataSource")
@@ -488,7 +727,7 @@ func structNameToResourceName(structName string) string {
 		result.WriteRune(r)
 	}
 
-	return "azurerm_" + strings.ToLower(result.String())
+	return prefix + strings.ToLower(result.String())
 }
 
 func returnsResourceType(expr ast.Expr, fset *token.FileSet) bool {
@@ -531,13 +770,29 @@ func extractResourceLiteral(body *ast.BlockStmt) *ast.CompositeLit {
 	return nil
 }
 
-func buildParsedResource(reg resourceRegistration, fn *resourceFunc) (parsedProviderResource, error) {
+// unresolvedResource builds the minimal entry stored for a registration whose schema was not parsed.
+func unresolvedResource(prefix string, reg resourceRegistration, reason, filePath string) parsedProviderResource {
+	return parsedProviderResource{
+		resource: database.ProviderResource{
+			Name:             reg.TypeName,
+			DisplayName:      sql.NullString{String: displayNameFromResource(prefix, reg.TypeName), Valid: true},
+			Kind:             reg.Kind,
+			FilePath:         nullString(filePath),
+			RegistrationType: nullString(reg.Source),
+			UnresolvedReason: nullString(reason),
+		},
+		attributes: []database.ProviderAttribute{},
+	}
+}
+
+func buildParsedResource(prefix string, reg resourceRegistration, fn *resourceFunc) (parsedProviderResource, error) {
 	resource := database.ProviderResource{
-		Name:        reg.TypeName,
-		Kind:        reg.Kind,
-		DisplayName: nullString(displayNameFromResource(reg.TypeName)),
-		FilePath:    nullString(fn.filePath),
-		APIVersion:  nullString(extractAPIVersionFromFile(fn.file)),
+		Name:             reg.TypeName,
+		Kind:             reg.Kind,
+		DisplayName:      nullString(displayNameFromResource(prefix, reg.TypeName)),
+		FilePath:         nullString(fn.filePath),
+		APIVersion:       nullString(extractAPIVersionFromFile(fn.file)),
+		RegistrationType: nullString(reg.Source),
 	}
 
 	var attrs []database.ProviderAttribute
@@ -600,6 +855,10 @@ func parseSchemaAttributes(file providerGoFile, expr ast.Expr) []database.Provid
 
 		schema := schemaLiteral(kv.Value)
 		if schema == nil {
+			if attr, ok := commonSchemaAttribute(name, kv.Value); ok {
+				attrs = append(attrs, attr)
+				continue
+			}
 			attrs = append(attrs, database.ProviderAttribute{Name: name})
 			continue
 		}
@@ -660,6 +919,7 @@ func buildAttributeFromSchema(fset *token.FileSet, name string, schema *ast.Comp
 			attr.ElemSummary = nullString(extractElemSummary(fset, kv.Value))
 			if strings.Contains(elemText, ".Resource") {
 				attr.NestedBlock = true
+				attr.ElemSchemaJSON = nullString(database.EncodeNestedSchema(parseNestedSchema(fset, kv.Value)))
 			}
 		case "ValidateFunc", "ValidateDiagFunc":
 			attr.Validation = nullString(exprToString(fset, kv.Value))
@@ -671,6 +931,44 @@ func buildAttributeFromSchema(fset *token.FileSet, name string, schema *ast.Comp
 	return attr
 }
 
+// parseNestedSchema walks the Schema map of an inline Elem resource literal.
+// Schemas built by helper functions are left unresolved unless they are well-known helpers.
+func parseNestedSchema(fset *token.FileSet, elem ast.Expr) []database.NestedAttribute {
+	resourceLit := schemaLiteral(elem)
+	if resourceLit == nil {
+		return nil
+	}
+	schemaMap := schemaLiteral(extractSchemaExpr(resourceLit))
+	if schemaMap == nil {
+		return nil
+	}
+
+	var children []database.NestedAttribute
+	for _, elt := range schemaMap.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		name := literalStringValue(fset, kv.Key)
+		if name == "" {
+			continue
+		}
+		schema := schemaLiteral(kv.Value)
+		if schema == nil {
+			if attr, ok := commonSchemaAttribute(name, kv.Value); ok {
+				children = append(children, database.NestedAttributeFromProvider(attr))
+				continue
+			}
+			children = append(children, database.NestedAttribute{Name: name})
+			continue
+		}
+		children = append(children, database.NestedAttributeFromProvider(buildAttributeFromSchema(fset, name, schema)))
+	}
+
+	sort.Slice(children, func(i, j int) bool { return children[i].Name < children[j].Name })
+	return children
+}
+
 func schemaLiteral(expr ast.Expr) *ast.CompositeLit {
 	switch v := expr.(type) {
 	case *ast.CompositeLit:
@@ -763,6 +1061,13 @@ func snippetFromRange(file providerGoFile, start, end token.Pos) string {
 	return content[startPos.Offset:endPos.Offset]
 }
 
+func lineRange(file providerGoFile, start, end token.Pos) (int, int) {
+	if file.fset == nil || !start.IsValid() || !end.IsValid() {
+		return 0, 0
+	}
+	return file.fset.Position(start).Line, file.fset.Position(end).Line
+}
+
 func extractSchemaExpr(resourceLit *ast.CompositeLit) ast.Expr {
 	for _, elt := range resourceLit.Elts {
 		kv, ok := elt.(*ast.KeyValueExpr)
@@ -919,8 +1224,8 @@ func exprToString(fset *token.FileSet, expr ast.Expr) string {
 	return buf.String()
 }
 
-func displayNameFromResource(name string) string {
-	trimmed := strings.TrimPrefix(name, "azurerm_")
+func displayNameFromResource(prefix, name string) string {
+	trimmed := strings.TrimPrefix(name, prefix)
 	parts := strings.Split(trimmed, "_")
 	for i, part := range parts {
 		if part == "" {
@@ -971,29 +1276,76 @@ func summarizeBreakingAttributes(attrs []database.ProviderAttribute) string {
 }
 
 func extractAPIVersionFromFile(file providerGoFile) string {
-	// Parse imports to find go-azure-sdk imports with API versions
-	// Example: "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	for _, pkg := range extractSDKPackagesFromFile(file) {
+		// Only resource-manager dates without a suffix such as -preview count as the API version.
+		if pkg.Plane == "resource-manager" && len(pkg.APIVersion) == 10 {
+			return pkg.APIVersion
+		}
+	}
+	return ""
+}
 
+// extractSDKPackagesFromFile returns the go-azure-sdk API packages a file imports, in import order.
+// Example: "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+func extractSDKPackagesFromFile(file providerGoFile) []database.SDKPackage {
+	if file.file == nil {
+		return nil
+	}
+
+	var packages []database.SDKPackage
 	for _, imp := range file.file.Imports {
 		if imp.Path == nil {
 			continue
 		}
-
 		path := strings.Trim(imp.Path.Value, `"`)
-		if !strings.Contains(path, "go-azure-sdk/resource-manager") {
+		pkg, ok := parseSDKPackagePath(path)
+		if !ok {
 			continue
 		}
+		if imp.Name != nil && imp.Name.Name != "_" {
+			pkg.Alias = imp.Name.Name
+		}
+		packages = append(packages, pkg)
+	}
+	return packages
+}
 
-		// Extract version from path like: .../compute/2024-03-01/virtualmachines
-		for part := range strings.SplitSeq(path, "/") {
-			// Check if it matches YYYY-MM-DD pattern
-			if len(part) == 10 && part[4] == '-' && part[7] == '-' {
-				return part
-			}
+// parseSDKPackagePath splits a go-azure-sdk import path into plane, service, API version and the
+// sub-package below the version. Paths outside resource-manager and data-plane, such as the sdk/
+// client packages, are not API packages and report false.
+func parseSDKPackagePath(path string) (database.SDKPackage, bool) {
+	_, rest, ok := strings.Cut(path, "go-azure-sdk/")
+	if !ok {
+		return database.SDKPackage{}, false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) < 2 || (parts[0] != "resource-manager" && parts[0] != "data-plane") {
+		return database.SDKPackage{}, false
+	}
+
+	pkg := database.SDKPackage{Path: path, Plane: parts[0], Service: parts[1]}
+	for i := 2; i < len(parts); i++ {
+		if isAPIVersionSegment(parts[i]) {
+			pkg.APIVersion = parts[i]
+			pkg.Resource = strings.Join(parts[i+1:], "/")
+			break
 		}
 	}
+	return pkg, true
+}
 
-	return ""
+// isAPIVersionSegment reports whether part is a dated API version such as 2024-03-01 or
+// 2022-10-01-preview.
+func isAPIVersionSegment(part string) bool {
+	if len(part) < 10 || part[4] != '-' || part[7] != '-' || (len(part) > 10 && part[10] != '-') {
+		return false
+	}
+	for i, r := range part[:10] {
+		if i != 4 && i != 7 && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
 }
 
 // parseServiceMetadata extracts service registration metadata from registration.go files
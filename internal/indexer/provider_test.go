@@ -2,9 +2,12 @@ package indexer
 
 import (
 	"database/sql"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"reflect"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -125,7 +128,7 @@ func TestDisplayNameFromResource(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := displayNameFromResource(tt.name)
+			got := displayNameFromResource(DefaultProviderPrefix, tt.name)
 			if got != tt.want {
 				t.Errorf("displayNameFromResource(%q) = %q, want %q", tt.name, got, tt.want)
 			}
@@ -773,7 +776,7 @@ func resourceOne() *schema.Resource { return nil }` // duplicate
 		{file: f2, fset: fset, repositoryFile: database.RepositoryFile{FilePath: "two.go"}},
 	}
 
-	p := newProviderParser(files)
+	p := newProviderParser(files, DefaultProviderPrefix)
 
 	// Should have 3 unique function names (resourceOne from first file wins)
 	if len(p.funcByName) != 3 {
@@ -843,7 +846,7 @@ func TestStructNameToResourceName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.structName, func(t *testing.T) {
-			got := structNameToResourceName(tt.structName)
+			got := structNameToResourceName(DefaultProviderPrefix, tt.structName)
 			if got != tt.want {
 				t.Errorf("structNameToResourceName(%q) = %q, want %q", tt.structName, got, tt.want)
 			}
@@ -1011,3 +1014,583 @@ func TestExtractServiceNameFromPath(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildAttributeFromSchemaNestedBlock(t *testing.T) {
+	fset := token.NewFileSet()
+
+	src := `&pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"application_stack": {
+					Type:     pluginsdk.TypeList,
+					Optional: true,
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"docker_image_name": {
+								Type:     pluginsdk.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"always_on": {
+					Type:     pluginsdk.TypeBool,
+					Optional: true,
+				},
+			},
+		},
+	}`
+
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+
+	attr := buildAttributeFromSchema(fset, "site_config", schemaLiteral(expr))
+	if !attr.NestedBlock {
+		t.Fatal("expected nested block")
+	}
+
+	children := database.DecodeNestedSchema(attr.ElemSchemaJSON.String)
+	if len(children) != 2 || children[0].Name != "always_on" || children[1].Name != "application_stack" {
+		t.Fatalf("unexpected nested children: %+v", children)
+	}
+	stack := children[1]
+	if !stack.NestedBlock || len(stack.Children) != 1 || stack.Children[0].Name != "docker_image_name" {
+		t.Fatalf("expected second level nested schema, got %+v", stack)
+	}
+}
+
+func TestParseProviderRepositoryRecordsParseFailures(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/example_resource.go", "go", `
+package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_example": resourceExample(),
+	}
+}
+
+func resourceExample() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`)
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/broken_resource.go", "go", "package example\n\nfunc broken( {\n")
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	failures, err := db.GetRepositoryParseFailures(repo.Name)
+	if err != nil || len(failures) != 1 || failures[0] != "internal/services/example/broken_resource.go" {
+		t.Fatalf("expected the broken file recorded, got %v (%v)", failures, err)
+	}
+	overview, err := db.GetProviderOverview()
+	if err != nil || len(overview.ParseFailures) != 1 {
+		t.Fatalf("expected parse failures in the overview, got %+v (%v)", overview, err)
+	}
+
+	// Re-inserting the repository metadata at the start of the next sync clears the list.
+	if _, err := db.InsertRepository(repo); err != nil {
+		t.Fatalf("insert repository: %v", err)
+	}
+	if failures, err := db.GetRepositoryParseFailures(repo.Name); err != nil || len(failures) != 0 {
+		t.Fatalf("expected parse failures cleared, got %v (%v)", failures, err)
+	}
+}
+
+func TestParseProviderRepositoryRecordsRegistrationType(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	const content = `
+package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_legacy": resourceLegacy(),
+	}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ModernThingResource{},
+	}
+}
+
+func resourceLegacy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/registration.go", "go", content)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	want := map[string]string{
+		"azurerm_legacy":       "untyped",
+		"azurerm_modern_thing": "typed",
+	}
+	for name, registration := range want {
+		res, err := db.GetProviderResource(name)
+		if err != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		if res.RegistrationType.String != registration {
+			t.Fatalf("%s registration = %q, want %q", name, res.RegistrationType.String, registration)
+		}
+	}
+}
+
+func TestParseProviderRepositoryKeepsParsedAttributesOnDualRegistration(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	// Both registries list azurerm_migrating while it moves to the typed SDK.
+	const typed = `
+package example
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		MigratingResource{},
+	}
+}
+`
+	const untyped = `
+package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_migrating": resourceMigrating(),
+	}
+}
+
+func resourceMigrating() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name":     {Type: pluginsdk.TypeString, Required: true},
+			"location": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/a_registration.go", "go", typed)
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/migrating_resource.go", "go", untyped)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	resources, err := db.ListProviderResources("resource", 0)
+	if err != nil {
+		t.Fatalf("list resources: %v", err)
+	}
+	if len(resources) != 1 {
+		t.Fatalf("expected one azurerm_migrating definition, got %d", len(resources))
+	}
+	res := resources[0]
+	if res.RegistrationType.String != "untyped" || res.UnresolvedReason.Valid {
+		t.Fatalf("expected the parsed untyped registration to win, got registration=%q unresolved=%q", res.RegistrationType.String, res.UnresolvedReason.String)
+	}
+	attrs, err := db.GetProviderResourceAttributes(res.ID)
+	if err != nil {
+		t.Fatalf("get attributes: %v", err)
+	}
+	if len(attrs) != 2 {
+		t.Fatalf("expected both parsed attributes to survive, got %d", len(attrs))
+	}
+}
+
+func TestRicherParse(t *testing.T) {
+	parsed := parsedProviderResource{attributes: []database.ProviderAttribute{{Name: "name"}}}
+	empty := parsedProviderResource{}
+	typed := parsedProviderResource{resource: database.ProviderResource{UnresolvedReason: sql.NullString{String: database.UnresolvedTyped, Valid: true}}}
+
+	if !richerParse(parsed, typed) || richerParse(typed, parsed) {
+		t.Fatal("expected parsed attributes to win over a typed placeholder in either order")
+	}
+	if !richerParse(empty, typed) || richerParse(typed, empty) {
+		t.Fatal("expected a resolved definition to win over an unresolved one")
+	}
+	if richerParse(parsed, parsed) {
+		t.Fatal("expected ties to keep the current entry")
+	}
+}
+
+func TestParseProviderRepositoryUsesRepositoryPrefix(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azuread")
+
+	const content = `
+package applications
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azuread_group": groupResource(),
+	}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ApplicationRegistrationResource{},
+	}
+}
+
+func groupResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"display_name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/applications/registration.go", "go", content)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	want := map[string]string{
+		"azuread_group":                    "Group",
+		"azuread_application_registration": "Application Registration",
+	}
+	for name, displayName := range want {
+		res, err := db.GetProviderResource(name)
+		if err != nil {
+			t.Fatalf("get %s: %v", name, err)
+		}
+		if res.DisplayName.String != displayName {
+			t.Fatalf("%s display name = %q, want %q", name, res.DisplayName.String, displayName)
+		}
+	}
+	if _, err := db.GetProviderResource("azurerm_application_registration"); err == nil {
+		t.Fatal("expected no azurerm_ name for a typed azuread resource")
+	}
+}
+
+func TestProviderPrefix(t *testing.T) {
+	tests := map[string]string{
+		"terraform-provider-azurerm":            "azurerm_",
+		"hashicorp/terraform-provider-azuread":  "azuread_",
+		"terraform-provider-azurestack":         "azurestack_",
+		"example/terraform-provider-azurerm-v2": "azurerm_",
+		"my-provider":                           DefaultProviderPrefix,
+		"":                                      DefaultProviderPrefix,
+	}
+	for repo, want := range tests {
+		if got := ProviderPrefix(repo); got != want {
+			t.Errorf("ProviderPrefix(%q) = %q, want %q", repo, got, want)
+		}
+	}
+}
+
+func TestParseProviderRepositoryKeepsUnresolvedRegistrations(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	const content = `
+package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_legacy":  resourceLegacy(),
+		"azurerm_missing": resourceMissing(),
+	}
+}
+
+func (r Registration) Resources() []sdk.Resource {
+	return []sdk.Resource{
+		ModernThingResource{},
+	}
+}
+
+func resourceLegacy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/registration.go", "go", content)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	unresolved, err := db.ListUnresolvedResources("")
+	if err != nil {
+		t.Fatalf("list unresolved: %v", err)
+	}
+	got := make(map[string]string)
+	for _, r := range unresolved {
+		got[r.Name] = r.UnresolvedReason.String
+	}
+	want := map[string]string{
+		"azurerm_missing":      database.UnresolvedMissingFunction,
+		"azurerm_modern_thing": database.UnresolvedTyped,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unresolved reasons = %v, want %v", got, want)
+	}
+
+	legacy, err := db.GetProviderResource("azurerm_legacy")
+	if err != nil || legacy.UnresolvedReason.Valid {
+		t.Fatalf("expected parsed resource without a reason, got %+v err=%v", legacy, err)
+	}
+}
+
+func TestParseProviderRepositoryRecordsSourceLines(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	const content = `package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_legacy": resourceLegacy(),
+	}
+}
+
+func resourceLegacy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+			// Comments are dropped from stored snippets but must not shift recorded lines.
+			"location": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/legacy_resource.go", "go", content)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	res, err := db.GetProviderResource("azurerm_legacy")
+	if err != nil {
+		t.Fatalf("get resource: %v", err)
+	}
+	source, err := db.GetProviderResourceSource(res.ID)
+	if err != nil {
+		t.Fatalf("get source: %v", err)
+	}
+	if source.FunctionStartLine.Int64 != 9 || source.FunctionEndLine.Int64 != 17 {
+		t.Fatalf("function lines = %d-%d, want 9-17", source.FunctionStartLine.Int64, source.FunctionEndLine.Int64)
+	}
+	if source.SchemaStartLine.Int64 != 11 || source.SchemaEndLine.Int64 != 15 {
+		t.Fatalf("schema lines = %d-%d, want 11-15", source.SchemaStartLine.Int64, source.SchemaEndLine.Int64)
+	}
+
+	lines, err := source.AttributeLines()
+	if err != nil {
+		t.Fatalf("attribute lines: %v", err)
+	}
+	want := []database.SchemaAttributeLine{{Name: "name", Line: 12}, {Name: "location", Line: 14}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Fatalf("attribute lines = %+v, want %+v", lines, want)
+	}
+}
+
+func TestParseProviderRepositoryRecordsSDKPackages(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	const content = `package compute
+
+import (
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	vmss "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-10-01-preview/virtualmachinescalesets"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/pollers"
+	"github.com/hashicorp/go-azure-sdk/data-plane/keyvault/2016-10-01/secrets"
+)
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_virtual_machine": resourceVirtualMachine(),
+	}
+}
+
+func resourceVirtualMachine() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/compute/virtual_machine_resource.go", "go", content)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("parseProviderRepository: %v", err)
+	}
+
+	res, err := db.GetProviderResource("azurerm_virtual_machine")
+	if err != nil {
+		t.Fatalf("get resource: %v", err)
+	}
+	if res.APIVersion.String != "2024-03-01" {
+		t.Fatalf("api version = %q, want 2024-03-01", res.APIVersion.String)
+	}
+	source, err := db.GetProviderResourceSource(res.ID)
+	if err != nil {
+		t.Fatalf("get source: %v", err)
+	}
+	packages, recorded, err := source.ImportedSDKPackages()
+	if err != nil || !recorded {
+		t.Fatalf("sdk packages: recorded=%v err=%v", recorded, err)
+	}
+	want := []database.SDKPackage{
+		{Path: "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines", Plane: "resource-manager", Service: "compute", APIVersion: "2024-03-01", Resource: "virtualmachines"},
+		{Path: "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-10-01-preview/virtualmachinescalesets", Alias: "vmss", Plane: "resource-manager", Service: "compute", APIVersion: "2022-10-01-preview", Resource: "virtualmachinescalesets"},
+		{Path: "github.com/hashicorp/go-azure-sdk/data-plane/keyvault/2016-10-01/secrets", Plane: "data-plane", Service: "keyvault", APIVersion: "2016-10-01", Resource: "secrets"},
+	}
+	if !reflect.DeepEqual(packages, want) {
+		t.Fatalf("sdk packages = %+v, want %+v", packages, want)
+	}
+}
+
+func TestParseProviderRepositorySkipsUnchangedFiles(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	const resourceFile = `package example
+
+func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
+	return map[string]*pluginsdk.Resource{
+		"azurerm_legacy": resourceLegacy(),
+	}
+}
+
+func resourceLegacy() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+	const helperFile = `package example
+
+func normalizeName(name string) string {
+	return name
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/services/example/legacy_resource.go", "go", resourceFile)
+	helper := testutil.InsertFile(t, db, repo.ID, "internal/services/example/helpers.go", "go", helperFile)
+
+	s := &Syncer{db: db}
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("first parse: %v", err)
+	}
+
+	entry, err := db.GetParseCacheEntry("internal/services/example/legacy_resource.go")
+	if err != nil {
+		t.Fatalf("resource cache entry: %v", err)
+	}
+	if entry.ResourceCount != 1 || entry.AttributeCount != 1 || entry.SymbolCount == 0 {
+		t.Fatalf("unexpected resource cache entry: %+v", entry)
+	}
+	if entry, err := db.GetParseCacheEntry(helper.FilePath); err != nil || entry.SymbolCount != 0 {
+		t.Fatalf("expected helper cached without symbols, got %+v (%v)", entry, err)
+	}
+
+	files, err := db.GetRepositoryFiles(repo.ID)
+	if err != nil {
+		t.Fatalf("list files: %v", err)
+	}
+	goFiles, skipped, _ := s.parseGoFiles(files)
+	if skipped != 1 || len(goFiles) != 1 || goFiles[0].repositoryFile.FilePath != "internal/services/example/legacy_resource.go" {
+		t.Fatalf("expected unchanged helper to be skipped, got %d parsed and %d skipped", len(goFiles), skipped)
+	}
+
+	for i := range files {
+		if files[i].FilePath == helper.FilePath {
+			files[i].Content += "\nfunc resourceExtra() *pluginsdk.Resource { return &pluginsdk.Resource{} }\n"
+		}
+	}
+	if goFiles, skipped, _ := s.parseGoFiles(files); skipped != 0 || len(goFiles) != 2 {
+		t.Fatalf("expected changed helper to be parsed again, got %d parsed and %d skipped", len(goFiles), skipped)
+	}
+
+	if err := s.parseProviderRepository(repo.ID, GitHubRepo{Name: repo.Name}); err != nil {
+		t.Fatalf("second parse: %v", err)
+	}
+	if _, err := db.GetProviderResource("azurerm_legacy"); err != nil {
+		t.Fatalf("expected resource to survive a cached re-parse: %v", err)
+	}
+}
+
+func TestParseGoFilesConcurrentlyKeepsOrder(t *testing.T) {
+	var files []database.RepositoryFile
+	for i := range 50 {
+		name := fmt.Sprintf("file_%02d.go", i)
+		files = append(files, database.RepositoryFile{FileName: name, FilePath: "internal/" + name, Content: fmt.Sprintf("package example\n\nfunc f%d() {}\n", i)})
+	}
+	files = append(files,
+		database.RepositoryFile{FileName: "README.md", FilePath: "README.md", Content: "# docs"},
+		database.RepositoryFile{FileName: "broken.go", FilePath: "internal/broken.go", Content: "package"},
+	)
+
+	goFiles, skipped, failed := parseGoFilesConcurrently(files, nil, 8)
+	if skipped != 0 || len(goFiles) != 50 {
+		t.Fatalf("expected 50 parsed files and none skipped, got %d parsed, %d skipped", len(goFiles), skipped)
+	}
+	if len(failed) != 1 || failed[0] != "internal/broken.go" {
+		t.Fatalf("expected broken.go reported as a parse failure, got %v", failed)
+	}
+	for i, f := range goFiles {
+		if f.repositoryFile.FilePath != files[i].FilePath {
+			t.Fatalf("result %d is %s, want %s", i, f.repositoryFile.FilePath, files[i].FilePath)
+		}
+	}
+}
+
+func BenchmarkParseGoFiles(b *testing.B) {
+	var body strings.Builder
+	body.WriteString("package example\n\nfunc resourceExample() *pluginsdk.Resource {\n\treturn &pluginsdk.Resource{\n\t\tSchema: map[string]*pluginsdk.Schema{\n")
+	for i := range 200 {
+		fmt.Fprintf(&body, "\t\t\t\"attr_%d\": {Type: pluginsdk.TypeString, Optional: true, ValidateFunc: validation.StringIsNotEmpty},\n", i)
+	}
+	body.WriteString("\t\t},\n\t}\n}\n")
+
+	files := make([]database.RepositoryFile, 500)
+	for i := range files {
+		name := fmt.Sprintf("resource_%d.go", i)
+		files[i] = database.RepositoryFile{FileName: name, FilePath: "internal/" + name, Content: body.String()}
+	}
+
+	counts := []int{1}
+	if procs := runtime.GOMAXPROCS(0); procs > 1 {
+		counts = append(counts, procs)
+	}
+	for _, workers := range counts {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for b.Loop() {
+				parseGoFilesConcurrently(files, nil, workers)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -16,9 +17,28 @@ const maxReleaseHistory = 40
 
 var (
 	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\([^)]+\)`)
-	resourceNamePattern = regexp.MustCompile(`azurerm_[a-z0-9_]+`)
+	// Matches "Removed Resource: ..." headings and "the X resource has been removed" phrasing, but not
+	// "the deprecated foo property has been removed" entries that only drop a field.
+	removedResourcePattern = regexp.MustCompile(`(?i)removed (resource|data source)s?:|\b(resource|data source)s? (has|have|was|were) (been )?removed`)
 )
 
+// ResourceNamePattern matches resource names of the provider with the given prefix in
+// lowercased changelog text.
+func ResourceNamePattern(prefix string) *regexp.Regexp {
+	return regexp.MustCompile(regexp.QuoteMeta(strings.ToLower(prefix)) + `[a-z0-9_]+`)
+}
+
+// ChangeType classifies a changelog entry from its section heading and text, e.g. "new_resource",
+// "breaking_change" or "bugfix". Unrecognised sections return "".
+func ChangeType(section, text string) string {
+	return changeTypeForSection(section, text)
+}
+
+// IsResourceRemoval reports whether a changelog entry announces that a resource or data source was removed.
+func IsResourceRemoval(text string) bool {
+	return removedResourcePattern.MatchString(strings.ToLower(text))
+}
+
 type parsedRelease struct {
 	Version     string
 	Tag         string
@@ -31,7 +51,7 @@ type parsedSection struct {
 	Entries []string
 }
 
-func (s *Syncer) captureReleaseMetadata(repositoryID int64, repo GitHubRepo) error {
+func (s *Syncer) captureReleaseMetadata(ctx context.Context, repositoryID int64, repo GitHubRepo) error {
 	changelog, err := s.db.GetFile(repo.Name, "CHANGELOG.md")
 	if err != nil {
 		return err
@@ -42,11 +62,12 @@ func (s *Syncer) captureReleaseMetadata(repositoryID int64, repo GitHubRepo) err
 		return fmt.Errorf("no releases parsed from CHANGELOG.md")
 	}
 
-	tags, err := s.githubClient.listTags(repo.FullName, 5)
+	tags, err := s.githubClient.listTags(ctx, repo.FullName, s.tagPages())
 	if err != nil {
 		log.Printf("Warning: failed to fetch tags for %s: %v", repo.FullName, err)
 	}
 
+	namePattern := ResourceNamePattern(ProviderPrefix(repo.Name))
 	tagLookup := make(map[string]GitHubTag)
 	for _, tag := range tags {
 		normalized := normalizeTagName(tag.Name)
@@ -86,12 +107,110 @@ func (s *Syncer) captureReleaseMetadata(repositoryID int64, repo GitHubRepo) err
 			return fmt.Errorf("failed to persist release %s: %w", rel.Version, err)
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, namePattern)
 		if err := s.db.ReplaceReleaseEntries(releaseID, entries); err != nil {
 			return fmt.Errorf("failed to persist release entries for %s: %w", rel.Version, err)
 		}
 	}
 
+	if err := s.persistResourceLifecycle(repositoryID, collectResourceLifecycle(releases, namePattern)); err != nil {
+		return fmt.Errorf("failed to persist resource lifecycle: %w", err)
+	}
+
+	return nil
+}
+
+type lifecycleKey struct {
+	Name string
+	Kind string
+}
+
+type resourceLifecycle struct {
+	Added   string
+	Removed string
+}
+
+// collectResourceLifecycle walks releases oldest-first and records the version in which each
+// resource or data source was introduced and, if applicable, removed.
+func collectResourceLifecycle(releases []parsedRelease, namePattern *regexp.Regexp) map[lifecycleKey]*resourceLifecycle {
+	lifecycle := make(map[lifecycleKey]*resourceLifecycle)
+	lookup := func(key lifecycleKey) *resourceLifecycle {
+		entry, ok := lifecycle[key]
+		if !ok {
+			entry = &resourceLifecycle{}
+			lifecycle[key] = entry
+		}
+		return entry
+	}
+
+	for i := len(releases) - 1; i >= 0; i-- {
+		rel := releases[i]
+		for _, section := range rel.Sections {
+			if section == nil {
+				continue
+			}
+			for _, text := range section.Entries {
+				lower := strings.ToLower(text)
+				names := namePattern.FindAllString(lower, -1)
+				if len(names) == 0 {
+					continue
+				}
+
+				switch changeType := changeTypeForSection(section.Name, text); changeType {
+				case "new_resource", "new_data_source":
+					kind := "resource"
+					if changeType == "new_data_source" {
+						kind = "data_source"
+					}
+					for _, name := range names {
+						entry := lookup(lifecycleKey{Name: name, Kind: kind})
+						if entry.Added == "" || entry.Removed != "" {
+							entry.Added = rel.Version
+						}
+						entry.Removed = ""
+					}
+					continue
+				}
+
+				if removedResourcePattern.MatchString(lower) {
+					kind := "resource"
+					if strings.Contains(lower, "data source") {
+						kind = "data_source"
+					}
+					for _, name := range names {
+						lookup(lifecycleKey{Name: name, Kind: kind}).Removed = rel.Version
+					}
+				}
+			}
+		}
+	}
+
+	return lifecycle
+}
+
+func (s *Syncer) persistResourceLifecycle(repositoryID int64, lifecycle map[lifecycleKey]*resourceLifecycle) error {
+	for key, entry := range lifecycle {
+		updated, err := s.db.UpdateProviderResourceLifecycle(repositoryID, key.Name, key.Kind, entry.Added, entry.Removed)
+		if err != nil {
+			return err
+		}
+		if updated || entry.Removed == "" {
+			continue
+		}
+
+		// Removed definitions are gone from the source tree; keep a placeholder row so
+		// their availability window stays queryable.
+		if _, err := s.db.InsertProviderResource(&database.ProviderResource{
+			RepositoryID:     repositoryID,
+			Name:             key.Name,
+			Kind:             key.Kind,
+			VersionAdded:     makeNullString(entry.Added),
+			VersionRemoved:   makeNullString(entry.Removed),
+			RegistrationType: makeNullString(registrationRemoved),
+		}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -320,7 +439,7 @@ func cleanBulletText(line string) string {
 	return cleaned
 }
 
-func buildReleaseEntries(rel parsedRelease) []database.ProviderReleaseEntry {
+func buildReleaseEntries(rel parsedRelease, namePattern *regexp.Regexp) []database.ProviderReleaseEntry {
 	entries := []database.ProviderReleaseEntry{}
 	order := 0
 	for _, section := range rel.Sections {
@@ -336,7 +455,7 @@ func buildReleaseEntries(rel parsedRelease) []database.ProviderReleaseEntry {
 			if text == "" {
 				continue
 			}
-			identifier := resourceNamePattern.FindString(strings.ToLower(text))
+			identifier := namePattern.FindString(strings.ToLower(text))
 			changeType := changeTypeForSection(sectionName, text)
 			entryKey := fmt.Sprintf("%s-%s-%03d", slugify(sectionName), slugify(rel.Version), order)
 			entries = append(entries, database.ProviderReleaseEntry{
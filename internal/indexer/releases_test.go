@@ -3,6 +3,8 @@ package indexer
 import (
 	"strings"
 	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/testutil"
 )
 
 func TestNormalizeVersion(t *testing.T) {
@@ -410,7 +412,7 @@ func TestBuildReleaseEntries(t *testing.T) {
 			},
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, ResourceNamePattern(DefaultProviderPrefix))
 		if len(entries) != 2 {
 			t.Fatalf("expected 2 entries, got %d", len(entries))
 		}
@@ -450,7 +452,7 @@ func TestBuildReleaseEntries(t *testing.T) {
 			},
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, ResourceNamePattern(DefaultProviderPrefix))
 		if len(entries) != 1 {
 			t.Fatalf("expected 1 entry, got %d", len(entries))
 		}
@@ -467,7 +469,7 @@ func TestBuildReleaseEntries(t *testing.T) {
 			Sections: []*parsedSection{},
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, ResourceNamePattern(DefaultProviderPrefix))
 		if len(entries) != 0 {
 			t.Errorf("expected 0 entries for empty sections, got %d", len(entries))
 		}
@@ -483,7 +485,7 @@ func TestBuildReleaseEntries(t *testing.T) {
 			},
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, ResourceNamePattern(DefaultProviderPrefix))
 		if len(entries) != 1 {
 			t.Errorf("expected 1 entry (nil section skipped), got %d", len(entries))
 		}
@@ -498,7 +500,7 @@ func TestBuildReleaseEntries(t *testing.T) {
 			},
 		}
 
-		entries := buildReleaseEntries(rel)
+		entries := buildReleaseEntries(rel, ResourceNamePattern(DefaultProviderPrefix))
 		if len(entries) != 2 {
 			t.Fatalf("expected 2 entries, got %d", len(entries))
 		}
@@ -623,3 +625,105 @@ BUG FIXES:
 		t.Errorf("BUG FIXES section has %d entries, want 1", len(rel.Sections[2].Entries))
 	}
 }
+
+func TestCollectResourceLifecycle(t *testing.T) {
+	changelog := `## 4.0.0 (August 22, 2024)
+
+BREAKING CHANGES:
+
+* ` + "`azurerm_sql_server`" + ` - this resource has been removed
+* Data Source: ` + "`azurerm_sql_server`" + ` - this data source has been removed
+* ` + "`azurerm_storage_account`" + ` - the deprecated ` + "`allow_blob_public_access`" + ` property has been removed
+
+## 3.1.0 (June 1, 2022)
+
+FEATURES:
+
+* **New Resource:** ` + "`azurerm_mssql_server`" + `
+* **New Data Source:** ` + "`azurerm_sql_server`" + `
+
+## 3.0.0 (March 1, 2022)
+
+FEATURES:
+
+* **New Resource:** ` + "`azurerm_sql_server`" + `
+`
+	releases := parseChangelogReleases(changelog)
+	lifecycle := collectResourceLifecycle(releases, ResourceNamePattern(DefaultProviderPrefix))
+
+	check := func(name, kind, added, removed string) {
+		t.Helper()
+		entry, ok := lifecycle[lifecycleKey{Name: name, Kind: kind}]
+		if !ok {
+			t.Fatalf("expected lifecycle for %s (%s)", name, kind)
+		}
+		if entry.Added != added || entry.Removed != removed {
+			t.Fatalf("%s (%s): got added=%q removed=%q, want added=%q removed=%q", name, kind, entry.Added, entry.Removed, added, removed)
+		}
+	}
+
+	check("azurerm_sql_server", "resource", "3.0.0", "4.0.0")
+	check("azurerm_sql_server", "data_source", "3.1.0", "4.0.0")
+	check("azurerm_mssql_server", "resource", "3.1.0", "")
+
+	if _, ok := lifecycle[lifecycleKey{Name: "azurerm_storage_account", Kind: "resource"}]; ok {
+		t.Fatalf("property removals should not mark the resource as removed")
+	}
+}
+
+func TestPersistResourceLifecycle(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_mssql_server", "resource", "internal/services/mssql/mssql_server_resource.go")
+
+	s := &Syncer{db: db}
+	err := s.persistResourceLifecycle(repo.ID, map[lifecycleKey]*resourceLifecycle{
+		{Name: "azurerm_mssql_server", Kind: "resource"}:  {Added: "3.1.0"},
+		{Name: "azurerm_sql_server", Kind: "resource"}:    {Added: "3.0.0", Removed: "4.0.0"},
+		{Name: "azurerm_never_indexed", Kind: "resource"}: {Added: "3.2.0"},
+	})
+	if err != nil {
+		t.Fatalf("persistResourceLifecycle: %v", err)
+	}
+
+	existing, err := db.GetProviderResource("azurerm_mssql_server")
+	if err != nil {
+		t.Fatalf("get existing: %v", err)
+	}
+	if existing.VersionAdded.String != "3.1.0" || existing.VersionRemoved.Valid {
+		t.Fatalf("unexpected lifecycle on existing resource: %+v", existing)
+	}
+
+	removed, err := db.GetProviderResource("azurerm_sql_server")
+	if err != nil {
+		t.Fatalf("expected placeholder for removed resource: %v", err)
+	}
+	if removed.VersionRemoved.String != "4.0.0" || removed.RegistrationType.String != registrationRemoved {
+		t.Fatalf("unexpected placeholder: %+v", removed)
+	}
+
+	if _, err := db.GetProviderResource("azurerm_never_indexed"); err == nil {
+		t.Fatalf("added-only entries without a row should not create placeholders")
+	}
+
+	listed, err := db.ListProviderResources("", 0)
+	if err != nil || len(listed) != 1 || listed[0].Name != "azurerm_mssql_server" {
+		t.Fatalf("expected placeholders left out of listings, got %+v err=%v", listed, err)
+	}
+	found, err := db.SearchProviderResources("sql_server", "", 10)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	for _, r := range found {
+		if r.Name == "azurerm_sql_server" {
+			t.Fatalf("expected placeholders left out of search results, got %+v", found)
+		}
+	}
+
+	// A re-parse upserts the definition without lifecycle data; the recorded window must survive.
+	testutil.InsertResource(t, db, repo.ID, "azurerm_mssql_server", "resource", "internal/services/mssql/mssql_server_resource.go")
+	existing, err = db.GetProviderResource("azurerm_mssql_server")
+	if err != nil || existing.VersionAdded.String != "3.1.0" {
+		t.Fatalf("expected version_added kept across re-parse, got %+v err=%v", existing, err)
+	}
+}
@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// FetchFileAtRef downloads a repository file as it existed at a tag, branch or commit.
+func (s *Syncer) FetchFileAtRef(filePath, ref string) (string, error) {
+	if s.githubClient == nil {
+		return "", fmt.Errorf("github client is not initialized")
+	}
+	filePath = strings.TrimPrefix(strings.TrimSpace(filePath), "/")
+	ref = strings.TrimSpace(ref)
+	if filePath == "" || ref == "" {
+		return "", fmt.Errorf("file path and ref are required")
+	}
+
+	endpoint := s.githubClient.endpoint("repos/%s/contents/%s?ref=%s", s.fullRepositoryName(), escapePath(filePath), url.QueryEscape(ref))
+	data, err := s.githubClient.get(context.Background(), endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	var content GitHubContent
+	if err := json.Unmarshal(data, &content); err != nil {
+		return "", err
+	}
+	return s.fetchFileContent(context.Background(), content)
+}
+
+// ResourceSchemaAtRef fetches a resource implementation file at ref and parses the schema of
+// resourceName from it. Schema helpers defined in other files are not resolved.
+func (s *Syncer) ResourceSchemaAtRef(filePath, ref, resourceName string) ([]database.ProviderAttribute, error) {
+	content, err := s.FetchFileAtRef(filePath, ref)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResourceSchemaSource(filePath, content, resourceName)
+}
+
+// ParseResourceSchemaSource parses a single Go file and returns the top-level attributes of the
+// resource function defining resourceName. When the file declares several resource functions the
+// one whose name ends with the resource's CamelCase name is used.
+func ParseResourceSchemaSource(filePath, content, resourceName string) ([]database.ProviderAttribute, error) {
+	goFile, err := parseGoFile(database.RepositoryFile{FilePath: filePath, Content: content})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filePath, err)
+	}
+
+	prefix := resourceNamePrefix(resourceName)
+	parser := newProviderParser([]providerGoFile{goFile}, prefix)
+	goFile.parser = parser
+	funcs := parser.collectResourceFunctions()
+	fn := selectResourceFunc(funcs, strings.TrimPrefix(resourceName, prefix))
+	if fn == nil {
+		return nil, fmt.Errorf("no schema function for %s found in %s", resourceName, filePath)
+	}
+	fn.file.parser = parser
+
+	parsed, err := buildParsedResource(prefix, resourceRegistration{TypeName: resourceName, FuncName: fn.name}, fn)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(parsed.attributes, func(i, j int) bool { return parsed.attributes[i].Name < parsed.attributes[j].Name })
+	return parsed.attributes, nil
+}
+
+// selectResourceFunc picks the function whose name ends with the CamelCase form of shortName,
+// the resource name without its provider prefix.
+func selectResourceFunc(funcs map[string]*resourceFunc, shortName string) *resourceFunc {
+	if len(funcs) == 1 {
+		for _, fn := range funcs {
+			return fn
+		}
+	}
+
+	suffix := strings.ReplaceAll(shortName, "_", "")
+	names := make([]string, 0, len(funcs))
+	for name := range funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.HasSuffix(strings.ToLower(name), suffix) {
+			return funcs[name]
+		}
+	}
+	return nil
+}
+
+// resourceNamePrefix returns the provider prefix of a full resource name: "azuread_application"
+// yields "azuread_".
+func resourceNamePrefix(resourceName string) string {
+	if i := strings.Index(resourceName, "_"); i >= 0 {
+		return resourceName[:i+1]
+	}
+	return ""
+}
+
+func escapePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,80 @@
+package indexer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const schemaRefSource = `package network
+
+func resourceVirtualNetwork() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {Type: pluginsdk.TypeString, Required: true, ForceNew: true},
+			"dns_servers": {Type: pluginsdk.TypeList, Optional: true},
+		},
+	}
+}
+
+func resourceVirtualNetworkPeering() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"remote_virtual_network_id": {Type: pluginsdk.TypeString, Required: true},
+		},
+	}
+}
+`
+
+func TestParseResourceSchemaSourceSelectsMatchingFunction(t *testing.T) {
+	attrs, err := ParseResourceSchemaSource("virtual_network_resource.go", schemaRefSource, "azurerm_virtual_network")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(attrs) != 2 || attrs[0].Name != "dns_servers" || attrs[1].Name != "name" || !attrs[1].ForceNew {
+		t.Fatalf("unexpected attributes: %+v", attrs)
+	}
+
+	if _, err := ParseResourceSchemaSource("virtual_network_resource.go", schemaRefSource, "azurerm_subnet"); err == nil {
+		t.Fatalf("expected error when no function matches")
+	}
+}
+
+func TestResourceSchemaAtRefFetchesContentsAtRef(t *testing.T) {
+	var gotURL string
+	body, _ := json.Marshal(GitHubContent{
+		Name:    "virtual_network_resource.go",
+		Content: base64.StdEncoding.EncodeToString([]byte(schemaRefSource)),
+	})
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(string(body))),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+	}
+	s := &Syncer{githubClient: client, org: "hashicorp", repo: "terraform-provider-azurerm"}
+
+	attrs, err := s.ResourceSchemaAtRef("internal/services/network/virtual_network_resource.go", "v4.50.0", "azurerm_virtual_network_peering")
+	if err != nil {
+		t.Fatalf("schema at ref: %v", err)
+	}
+	if len(attrs) != 1 || attrs[0].Name != "remote_virtual_network_id" {
+		t.Fatalf("unexpected attributes: %+v", attrs)
+	}
+	want := "https://api.github.com/repos/hashicorp/terraform-provider-azurerm/contents/internal/services/network/virtual_network_resource.go?ref=v4.50.0"
+	if gotURL != want {
+		t.Fatalf("expected %s, got %s", want, gotURL)
+	}
+}
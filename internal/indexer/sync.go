@@ -5,6 +5,7 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -14,6 +15,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,9 +30,27 @@ type Syncer struct {
 	org          string
 	repo         string
 	workerCount  int
+	parseWorkers int // AST parsing pool size; 0 uses one worker per CPU
+	maxTagPages  int
+	ref          string
+	excludeTests bool
+	profile      bool
 }
 
-const defaultWorkerCount = 4
+// DefaultWorkerCount is how many sync workers run concurrently when none is configured.
+const DefaultWorkerCount = 4
+
+// defaultMaxTagPages is how many pages of 100 tags are fetched when resolving release commits.
+const defaultMaxTagPages = 5
+
+// DefaultCacheTTL is how long GitHub API responses are reused before being fetched again.
+const DefaultCacheTTL = 10 * time.Minute
+
+// DefaultGitHubBaseURL is the public GitHub REST API endpoint used when no base URL is configured.
+const DefaultGitHubBaseURL = "https://api.github.com"
+
+// DefaultMaxArchiveBytes caps a downloaded repository tarball when no limit is configured.
+const DefaultMaxArchiveBytes int64 = 1 << 30
 
 type GitHubRepo struct {
 	Name        string `json:"name"`
@@ -65,24 +85,42 @@ type GitHubCompareResult struct {
 }
 
 type GitHubCompareFile struct {
-	Filename string `json:"filename"`
-	Status   string `json:"status"`
-	Patch    string `json:"patch"`
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Patch            string `json:"patch"`
 }
 
 type GitHubClient struct {
 	httpClient *http.Client
 	cache      map[string]CacheEntry
 	cacheMutex sync.RWMutex
+	cacheTTL   time.Duration
 	rateLimit  *RateLimiter
+	rateWait   time.Duration // how long a request blocks for the rate limit to refill; 0 fails at once
 	token      string
+	baseURL    string
+
+	maxArchiveBytes int64 // 0 applies DefaultMaxArchiveBytes
 }
 
 type CacheEntry struct {
 	Data      any
+	CachedAt  time.Time
 	ExpiresAt time.Time
 }
 
+// CacheStats summarises the in-memory GitHub response cache.
+type CacheStats struct {
+	Entries int
+	Expired int
+	Bytes   int
+	Oldest  time.Time // zero when the cache is empty
+	TTL     time.Duration
+}
+
 type RateLimiter struct {
 	tokens    int
 	maxTokens int
@@ -97,16 +135,74 @@ type SyncProgress struct {
 	CurrentRepo    string
 	Errors         []string
 	UpdatedRepos   []string
+	ParseFailures  []string     // Go files skipped because they failed to parse
+	Timings        *SyncTimings // per-stage durations; nil unless profiling is enabled
+}
+
+// SyncTimings is how long a profiled sync spent in each stage, summed over repositories. Workers
+// run concurrently, so the sum can exceed the wall-clock duration of the sync.
+type SyncTimings struct {
+	Download    time.Duration // fetching repository archives from GitHub
+	FileInserts time.Duration // extracting archives and storing their files
+	Parse       time.Duration // parsing provider schemas into the index
+	Releases    time.Duration // changelog and tag metadata
+}
+
+func (t *SyncTimings) add(other SyncTimings) {
+	t.Download += other.Download
+	t.FileInserts += other.FileInserts
+	t.Parse += other.Parse
+	t.Releases += other.Releases
+}
+
+func (t SyncTimings) String() string {
+	return fmt.Sprintf("download %s, file inserts %s, parse %s, releases %s",
+		t.Download.Round(time.Millisecond), t.FileInserts.Round(time.Millisecond), t.Parse.Round(time.Millisecond), t.Releases.Round(time.Millisecond))
+}
+
+type progressReporterKey struct{}
+
+// WithProgressReporter returns a context under which SyncAll and SyncUpdates call report with a copy
+// of their progress each time it changes, so callers can show a sync while it is still running.
+func WithProgressReporter(ctx context.Context, report func(SyncProgress)) context.Context {
+	return context.WithValue(ctx, progressReporterKey{}, report)
+}
+
+// reportProgress hands a copy of progress to the context's reporter, if any. Callers that share
+// progress between workers must hold their lock.
+func reportProgress(ctx context.Context, progress *SyncProgress) {
+	report, _ := ctx.Value(progressReporterKey{}).(func(SyncProgress))
+	if report == nil {
+		return
+	}
+	snapshot := *progress
+	snapshot.Errors = slices.Clone(progress.Errors)
+	snapshot.UpdatedRepos = slices.Clone(progress.UpdatedRepos)
+	snapshot.ParseFailures = slices.Clone(progress.ParseFailures)
+	if progress.Timings != nil {
+		timings := *progress.Timings
+		snapshot.Timings = &timings
+	}
+	report(snapshot)
 }
 
 var ErrRepoContentUnavailable = errors.New("repository content unavailable")
 
+// ErrArchiveTooLarge is returned when a repository tarball exceeds the configured size limit.
+var ErrArchiveTooLarge = errors.New("repository archive too large")
+
+// ErrRateLimitExceeded is returned when the GitHub request budget is exhausted and the client may
+// not wait, or not long enough, for it to refill.
+var ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
 func NewSyncer(db *database.DB, token string, org string, repo string) *Syncer {
 	client := &GitHubClient{
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 		cache:      make(map[string]CacheEntry),
+		cacheTTL:   DefaultCacheTTL,
 		rateLimit:  &RateLimiter{tokens: 60, maxTokens: 60, refillAt: time.Now().Add(time.Hour)},
 		token:      token,
+		baseURL:    DefaultGitHubBaseURL,
 	}
 
 	if token != "" {
@@ -119,8 +215,130 @@ func NewSyncer(db *database.DB, token string, org string, repo string) *Syncer {
 		githubClient: client,
 		org:          org,
 		repo:         repo,
-		workerCount:  defaultWorkerCount,
+		workerCount:  DefaultWorkerCount,
+		maxTagPages:  defaultMaxTagPages,
+	}
+}
+
+// SetGitHubBaseURL points the GitHub client at another API root, such as a GitHub Enterprise
+// Server instance (https://ghes.example.com/api/v3). An empty value restores the public API.
+func (s *Syncer) SetGitHubBaseURL(baseURL string) {
+	if s.githubClient != nil {
+		s.githubClient.baseURL = normalizeGitHubBaseURL(baseURL)
+	}
+}
+
+// SetWorkerCount sets how many sync workers run concurrently and how many goroutines parse Go
+// files; values below 1 restore the defaults (DefaultWorkerCount for the queue, one parser per
+// CPU). The queue count is still capped by the GitHub rate-limit budget and the amount of queued work.
+func (s *Syncer) SetWorkerCount(workers int) {
+	if workers < 1 {
+		s.workerCount = DefaultWorkerCount
+		s.parseWorkers = 0
+		return
+	}
+	s.workerCount = workers
+	s.parseWorkers = workers
+}
+
+// SetMaxTagPages sets how many pages of 100 tags are fetched from GitHub; values below 1 restore the default.
+func (s *Syncer) SetMaxTagPages(pages int) {
+	if pages < 1 {
+		pages = defaultMaxTagPages
+	}
+	s.maxTagPages = pages
+}
+
+// SetMaxArchiveBytes caps how many bytes a repository tarball download may read before the sync
+// fails; values of zero or below restore the default.
+func (s *Syncer) SetMaxArchiveBytes(limit int64) {
+	if s.githubClient == nil {
+		return
+	}
+	if limit <= 0 {
+		limit = DefaultMaxArchiveBytes
+	}
+	s.githubClient.maxArchiveBytes = limit
+}
+
+// SetRateWait lets GitHub requests block for up to wait when the rate-limit budget is exhausted,
+// so a large sync slows down instead of failing. Zero or below restores failing immediately.
+func (s *Syncer) SetRateWait(wait time.Duration) {
+	if s.githubClient == nil {
+		return
+	}
+	s.githubClient.rateWait = max(wait, 0)
+}
+
+// SetCacheTTL sets how long GitHub API responses are cached; values of zero or below restore the default.
+func (s *Syncer) SetCacheTTL(ttl time.Duration) {
+	if s.githubClient == nil {
+		return
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	s.githubClient.cacheTTL = ttl
+}
+
+// ClearCache drops every cached GitHub response so the next request fetches fresh data. It
+// returns how many entries were removed.
+func (s *Syncer) ClearCache() int {
+	if s.githubClient == nil {
+		return 0
+	}
+	return s.githubClient.clearCache()
+}
+
+// CacheStats reports the size and age of the GitHub response cache.
+func (s *Syncer) CacheStats() CacheStats {
+	if s.githubClient == nil {
+		return CacheStats{TTL: DefaultCacheTTL}
+	}
+	return s.githubClient.cacheStats(time.Now())
+}
+
+// SetIncludeTests controls whether *_test.go files are stored during syncs. Excluding them
+// shrinks the index but leaves list_resource_tests with nothing to report.
+func (s *Syncer) SetIncludeTests(include bool) {
+	s.excludeTests = !include
+}
+
+// SetProfile records how long each sync stage takes. The breakdown is logged when a sync finishes
+// and attached to its progress as Timings.
+func (s *Syncer) SetProfile(enabled bool) {
+	s.profile = enabled
+}
+
+// SetRef pins syncs to a tag, branch or commit instead of the default branch. When set, the
+// parsed schema of every definition is also recorded as a snapshot for that version.
+func (s *Syncer) SetRef(ref string) {
+	s.ref = strings.TrimSpace(ref)
+}
+
+// snapshotVersion maps the configured ref to the release version used for schema snapshots:
+// tags such as v4.52.0 become 4.52.0, anything else is kept verbatim.
+func (s *Syncer) snapshotVersion() string {
+	ref := s.ref
+	if len(ref) > 1 && (ref[0] == 'v' || ref[0] == 'V') && ref[1] >= '0' && ref[1] <= '9' {
+		return ref[1:]
+	}
+	return ref
+}
+
+func (s *Syncer) tagPages() int {
+	if s.maxTagPages < 1 {
+		return defaultMaxTagPages
 	}
+	return s.maxTagPages
+}
+
+func normalizeGitHubBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return DefaultGitHubBaseURL
+	}
+	return baseURL
 }
 
 func (s *Syncer) workerCountFor(total int) int {
@@ -133,7 +351,7 @@ func (s *Syncer) workerCountFor(total int) int {
 
 	count := s.workerCount
 	if count <= 0 {
-		count = defaultWorkerCount
+		count = DefaultWorkerCount
 	}
 
 	if s.githubClient != nil && s.githubClient.rateLimit != nil && s.githubClient.rateLimit.maxTokens > 0 && count > s.githubClient.rateLimit.maxTokens {
@@ -165,41 +383,56 @@ func (s *Syncer) CompareTags(baseTag, headTag string) (*GitHubCompareResult, err
 	if s.githubClient == nil {
 		return nil, fmt.Errorf("github client is not initialized")
 	}
-	return s.githubClient.compare(s.fullRepositoryName(), baseTag, headTag)
+	return s.githubClient.compare(context.Background(), s.fullRepositoryName(), baseTag, headTag)
 }
 
-func (s *Syncer) SyncAll() (*SyncProgress, error) {
-	progress := &SyncProgress{}
+// ListTags returns the repository's tags, most recent first, up to the configured page depth.
+func (s *Syncer) ListTags() ([]GitHubTag, error) {
+	if s.githubClient == nil {
+		return nil, fmt.Errorf("github client is not initialized")
+	}
+	return s.githubClient.listTags(context.Background(), s.fullRepositoryName(), s.tagPages())
+}
+
+// SyncAll re-indexes every configured repository. Cancelling ctx aborts in-flight GitHub downloads
+// and stops repositories that have not started yet.
+func (s *Syncer) SyncAll(ctx context.Context) (*SyncProgress, error) {
+	progress := s.newProgress()
 
 	log.Println("Fetching repositories from GitHub...")
-	repos, err := s.fetchRepositories()
+	repos, err := s.fetchRepositories(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
 	progress.TotalRepos = len(repos)
 	log.Printf("Found %d repositories", len(repos))
+	reportProgress(ctx, progress)
 
-	s.processRepoQueue(repos, progress, nil)
+	s.processRepoQueue(ctx, repos, progress, nil)
 
 	log.Printf("Sync completed: %d/%d repositories synced successfully",
 		progress.ProcessedRepos-len(progress.Errors), progress.TotalRepos)
+	logTimings(progress)
 
 	return progress, nil
 }
 
-func (s *Syncer) SyncUpdates() (*SyncProgress, error) {
-	progress := &SyncProgress{}
+// SyncUpdates re-indexes repositories whose GitHub metadata changed since the last sync. Cancelling
+// ctx behaves as for SyncAll.
+func (s *Syncer) SyncUpdates(ctx context.Context) (*SyncProgress, error) {
+	progress := s.newProgress()
 
 	s.githubClient.clearCache()
 	log.Println("Fetching repositories from GitHub (cache cleared)...")
-	repos, err := s.fetchRepositories()
+	repos, err := s.fetchRepositories(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
 	progress.TotalRepos = len(repos)
 	log.Printf("Found %d repositories", len(repos))
+	reportProgress(ctx, progress)
 
 	reposToSync := make([]GitHubRepo, 0, len(repos))
 
@@ -223,6 +456,7 @@ func (s *Syncer) SyncUpdates() (*SyncProgress, error) {
 			log.Printf("Skipping %s (already up-to-date)", repo.Name)
 			progress.SkippedRepos++
 			progress.ProcessedRepos++
+			reportProgress(ctx, progress)
 			continue
 		}
 
@@ -234,17 +468,32 @@ func (s *Syncer) SyncUpdates() (*SyncProgress, error) {
 		p.UpdatedRepos = append(p.UpdatedRepos, repo.Name)
 	}
 
-	s.processRepoQueue(reposToSync, progress, onSuccess)
+	s.processRepoQueue(ctx, reposToSync, progress, onSuccess)
 
 	syncedCount := len(progress.UpdatedRepos)
 
 	log.Printf("Sync completed: %d/%d repositories synced, %d skipped (up-to-date), %d errors",
 		syncedCount, progress.TotalRepos, progress.SkippedRepos, len(progress.Errors))
+	logTimings(progress)
 
 	return progress, nil
 }
 
-func (s *Syncer) processRepoQueue(repos []GitHubRepo, progress *SyncProgress, onSuccess func(*SyncProgress, GitHubRepo)) {
+func (s *Syncer) newProgress() *SyncProgress {
+	progress := &SyncProgress{}
+	if s.profile {
+		progress.Timings = &SyncTimings{}
+	}
+	return progress
+}
+
+func logTimings(progress *SyncProgress) {
+	if progress.Timings != nil {
+		log.Printf("Sync timings: %s", progress.Timings)
+	}
+}
+
+func (s *Syncer) processRepoQueue(ctx context.Context, repos []GitHubRepo, progress *SyncProgress, onSuccess func(*SyncProgress, GitHubRepo)) {
 	if len(repos) == 0 {
 		return
 	}
@@ -260,9 +509,17 @@ func (s *Syncer) processRepoQueue(repos []GitHubRepo, progress *SyncProgress, on
 
 		mu.Lock()
 		progress.CurrentRepo = repo.Name
+		reportProgress(ctx, progress)
 		mu.Unlock()
 
-		err := s.syncRepository(repo)
+		var timings SyncTimings
+		err := s.syncRepository(ctx, repo, &timings)
+		if progress.Timings != nil {
+			log.Printf("Timings for %s: %s", repo.Name, timings)
+			mu.Lock()
+			progress.Timings.add(timings)
+			mu.Unlock()
+		}
 		if err != nil {
 			errMsg := fmt.Sprintf("Failed to sync %s: %v", repo.Name, err)
 			log.Println(errMsg)
@@ -270,16 +527,24 @@ func (s *Syncer) processRepoQueue(repos []GitHubRepo, progress *SyncProgress, on
 			progress.Errors = append(progress.Errors, errMsg)
 			progress.ProcessedRepos++
 			progress.CurrentRepo = repo.Name
+			reportProgress(ctx, progress)
 			mu.Unlock()
 			return
 		}
 
+		failures, err := s.db.GetRepositoryParseFailures(repo.Name)
+		if err != nil {
+			log.Printf("Warning: failed to load parse failures for %s: %v", repo.Name, err)
+		}
+
 		mu.Lock()
 		progress.ProcessedRepos++
 		progress.CurrentRepo = repo.Name
+		progress.ParseFailures = append(progress.ParseFailures, failures...)
 		if onSuccess != nil {
 			onSuccess(progress, repo)
 		}
+		reportProgress(ctx, progress)
 		mu.Unlock()
 	}
 
@@ -309,22 +574,22 @@ func (s *Syncer) processRepoQueue(repos []GitHubRepo, progress *SyncProgress, on
 	wg.Wait()
 }
 
-func (s *Syncer) fetchRepositories() ([]GitHubRepo, error) {
-	repo, err := s.fetchRepositoryByName(s.repo)
+func (s *Syncer) fetchRepositories(ctx context.Context) ([]GitHubRepo, error) {
+	repo, err := s.fetchRepositoryByName(ctx, s.repo)
 	if err != nil {
 		return nil, err
 	}
 	return []GitHubRepo{repo}, nil
 }
 
-func (s *Syncer) fetchRepositoryByName(name string) (GitHubRepo, error) {
+func (s *Syncer) fetchRepositoryByName(ctx context.Context, name string) (GitHubRepo, error) {
 	target := name
 	if !strings.Contains(name, "/") && s.org != "" {
 		target = fmt.Sprintf("%s/%s", s.org, name)
 	}
 
-	url := fmt.Sprintf("https://api.github.com/repos/%s", target)
-	data, err := s.githubClient.get(url)
+	url := s.githubClient.endpoint("repos/%s", target)
+	data, err := s.githubClient.get(ctx, url)
 	if err != nil {
 		return GitHubRepo{}, err
 	}
@@ -347,7 +612,13 @@ func (s *Syncer) fetchRepositoryByName(name string) (GitHubRepo, error) {
 	return repo, nil
 }
 
-func (s *Syncer) syncRepository(repo GitHubRepo) error {
+// syncRepository re-indexes one repository, adding the time spent in each stage to timings.
+func (s *Syncer) syncRepository(ctx context.Context, repo GitHubRepo, timings *SyncTimings) error {
+	// Checked before any existing data is cleared so a cancelled sync leaves the index intact.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	repositoryID, err := s.insertRepositoryMetadata(repo)
 	if err != nil {
 		return err
@@ -357,24 +628,28 @@ func (s *Syncer) syncRepository(repo GitHubRepo) error {
 		log.Printf("Warning: failed to clear old data for %s: %v", repo.Name, err)
 	}
 
-	if err := s.syncReadme(repositoryID, repo); err != nil {
+	if err := s.syncReadme(ctx, repositoryID, repo); err != nil {
 		log.Printf("Warning: failed to fetch README for %s: %v", repo.Name, err)
 	}
 
-	if err := s.syncRepositoryContent(repositoryID, repo); err != nil {
+	if err := s.syncRepositoryContent(ctx, repositoryID, repo, timings); err != nil {
 		if errors.Is(err, ErrRepoContentUnavailable) {
 			return s.handleUnavailableRepo(repositoryID, repo.Name)
 		}
 		return fmt.Errorf("failed to sync files: %w", err)
 	}
 
+	start := time.Now()
 	if err := s.parseProviderRepository(repositoryID, repo); err != nil {
 		log.Printf("Warning: failed to parse provider resources for %s: %v", repo.Name, err)
 	}
+	timings.Parse += time.Since(start)
 
-	if err := s.captureReleaseMetadata(repositoryID, repo); err != nil {
+	start = time.Now()
+	if err := s.captureReleaseMetadata(ctx, repositoryID, repo); err != nil {
 		log.Printf("Warning: failed to ingest release metadata for %s: %v", repo.Name, err)
 	}
+	timings.Releases += time.Since(start)
 
 	if err := s.persistRepositoryTags(repositoryID); err != nil {
 		log.Printf("Warning: failed to persist tags for %s: %v", repo.Name, err)
@@ -412,8 +687,8 @@ func (s *Syncer) clearExistingRepositoryData(repositoryID int64) error {
 	return nil
 }
 
-func (s *Syncer) syncReadme(repositoryID int64, repo GitHubRepo) error {
-	readme, err := s.fetchReadme(repo.FullName)
+func (s *Syncer) syncReadme(ctx context.Context, repositoryID int64, repo GitHubRepo) error {
+	readme, err := s.fetchReadme(ctx, repo.FullName)
 	if err != nil {
 		return err
 	}
@@ -432,8 +707,8 @@ func (s *Syncer) syncReadme(repositoryID int64, repo GitHubRepo) error {
 	return err
 }
 
-func (s *Syncer) syncRepositoryContent(repositoryID int64, repo GitHubRepo) error {
-	return s.syncRepositoryFromArchive(repositoryID, repo)
+func (s *Syncer) syncRepositoryContent(ctx context.Context, repositoryID int64, repo GitHubRepo, timings *SyncTimings) error {
+	return s.syncRepositoryFromArchive(ctx, repositoryID, repo, timings)
 }
 
 func (s *Syncer) handleUnavailableRepo(repositoryID int64, repoName string) error {
@@ -454,9 +729,14 @@ func (s *Syncer) persistRepositoryAliases(repositoryID int64) error {
 	return nil
 }
 
-func (s *Syncer) syncRepositoryFromArchive(repositoryID int64, repo GitHubRepo) error {
-	archiveURL := fmt.Sprintf("https://api.github.com/repos/%s/tarball", repo.FullName)
-	data, err := s.githubClient.getArchive(archiveURL)
+func (s *Syncer) syncRepositoryFromArchive(ctx context.Context, repositoryID int64, repo GitHubRepo, timings *SyncTimings) error {
+	start := time.Now()
+	archiveURL := s.githubClient.endpoint("repos/%s/tarball", repo.FullName)
+	if s.ref != "" {
+		archiveURL = s.githubClient.endpoint("repos/%s/tarball/%s", repo.FullName, url.PathEscape(s.ref))
+	}
+	data, err := s.githubClient.getArchive(ctx, archiveURL)
+	timings.Download += time.Since(start)
 	if err != nil {
 		if errors.Is(err, ErrRepoContentUnavailable) {
 			return ErrRepoContentUnavailable
@@ -464,6 +744,8 @@ func (s *Syncer) syncRepositoryFromArchive(repositoryID int64, repo GitHubRepo)
 		return err
 	}
 
+	start = time.Now()
+	defer func() { timings.FileInserts += time.Since(start) }()
 	tarReader, err := openTarArchive(data)
 	if err != nil {
 		return err
@@ -498,6 +780,9 @@ func (s *Syncer) processArchiveEntries(tarReader *tar.Reader, repositoryID int64
 		if relativePath == "" || shouldSkipPath(relativePath) {
 			continue
 		}
+		if s.excludeTests && strings.HasSuffix(relativePath, "_test.go") {
+			continue
+		}
 
 		contentBytes, err := io.ReadAll(tarReader)
 		if err != nil {
@@ -562,9 +847,12 @@ func isRegularFile(typeFlag byte) bool {
 	return typeFlag == tar.TypeReg
 }
 
-func (s *Syncer) fetchReadme(repoFullName string) (string, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/readme", repoFullName)
-	data, err := s.githubClient.get(url)
+func (s *Syncer) fetchReadme(ctx context.Context, repoFullName string) (string, error) {
+	endpoint := s.githubClient.endpoint("repos/%s/readme", repoFullName)
+	if s.ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(s.ref)
+	}
+	data, err := s.githubClient.get(ctx, endpoint)
 	if err != nil {
 		return "", err
 	}
@@ -574,12 +862,12 @@ func (s *Syncer) fetchReadme(repoFullName string) (string, error) {
 		return "", err
 	}
 
-	return s.fetchFileContent(content)
+	return s.fetchFileContent(ctx, content)
 }
 
-func (s *Syncer) fetchFileContent(content GitHubContent) (string, error) {
+func (s *Syncer) fetchFileContent(ctx context.Context, content GitHubContent) (string, error) {
 	if content.DownloadURL != "" {
-		data, err := s.githubClient.get(content.DownloadURL)
+		data, err := s.githubClient.get(ctx, content.DownloadURL)
 		if err != nil {
 			return "", err
 		}
@@ -628,13 +916,81 @@ func (rl *RateLimiter) acquire() bool {
 	return false
 }
 
-func (gc *GitHubClient) clearCache() {
+// wait takes a token, blocking for up to maxWait while the budget is exhausted. Waiting stops at
+// refillAt, when tokens come back; it fails at once when maxWait is zero or the refill lies beyond
+// it, and returns the context's error when ctx is done first.
+func (rl *RateLimiter) wait(ctx context.Context, maxWait time.Duration) error {
+	if rl.acquire() {
+		return nil
+	}
+	if maxWait <= 0 {
+		return ErrRateLimitExceeded
+	}
+
+	deadline := time.Now().Add(maxWait)
+	for {
+		rl.mutex.Lock()
+		refillAt := rl.refillAt
+		rl.mutex.Unlock()
+
+		if refillAt.After(deadline) {
+			return fmt.Errorf("%w: tokens refill at %s, beyond the %s wait limit", ErrRateLimitExceeded, refillAt.Format(time.RFC3339), maxWait)
+		}
+
+		timer := time.NewTimer(max(time.Until(refillAt), time.Millisecond))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if rl.acquire() {
+			return nil
+		}
+	}
+}
+
+// endpoint builds an API URL relative to the configured base URL.
+func (gc *GitHubClient) endpoint(format string, args ...any) string {
+	return normalizeGitHubBaseURL(gc.baseURL) + "/" + fmt.Sprintf(format, args...)
+}
+
+func (gc *GitHubClient) clearCache() int {
 	gc.cacheMutex.Lock()
+	defer gc.cacheMutex.Unlock()
+	cleared := len(gc.cache)
 	gc.cache = make(map[string]CacheEntry)
-	gc.cacheMutex.Unlock()
+	return cleared
+}
+
+func (gc *GitHubClient) cacheStats(now time.Time) CacheStats {
+	gc.cacheMutex.RLock()
+	defer gc.cacheMutex.RUnlock()
+
+	stats := CacheStats{Entries: len(gc.cache), TTL: gc.ttl()}
+	for _, entry := range gc.cache {
+		if data, ok := entry.Data.([]byte); ok {
+			stats.Bytes += len(data)
+		}
+		if !now.Before(entry.ExpiresAt) {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || entry.CachedAt.Before(stats.Oldest) {
+			stats.Oldest = entry.CachedAt
+		}
+	}
+	return stats
 }
 
-func (gc *GitHubClient) get(url string) ([]byte, error) {
+func (gc *GitHubClient) ttl() time.Duration {
+	if gc.cacheTTL <= 0 {
+		return DefaultCacheTTL
+	}
+	return gc.cacheTTL
+}
+
+func (gc *GitHubClient) get(ctx context.Context, url string) ([]byte, error) {
 	gc.cacheMutex.RLock()
 	if entry, exists := gc.cache[url]; exists && time.Now().Before(entry.ExpiresAt) {
 		gc.cacheMutex.RUnlock()
@@ -644,11 +1000,11 @@ func (gc *GitHubClient) get(url string) ([]byte, error) {
 	}
 	gc.cacheMutex.RUnlock()
 
-	if !gc.rateLimit.acquire() {
-		return nil, fmt.Errorf("rate limit exceeded")
+	if err := gc.rateLimit.wait(ctx, gc.rateWait); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -674,24 +1030,26 @@ func (gc *GitHubClient) get(url string) ([]byte, error) {
 		return nil, err
 	}
 
+	now := time.Now()
 	gc.cacheMutex.Lock()
 	gc.cache[url] = CacheEntry{
 		Data:      data,
-		ExpiresAt: time.Now().Add(10 * time.Minute),
+		CachedAt:  now,
+		ExpiresAt: now.Add(gc.ttl()),
 	}
 	gc.cacheMutex.Unlock()
 
 	return data, nil
 }
 
-func (gc *GitHubClient) listTags(repoFullName string, maxPages int) ([]GitHubTag, error) {
+func (gc *GitHubClient) listTags(ctx context.Context, repoFullName string, maxPages int) ([]GitHubTag, error) {
 	if maxPages <= 0 {
 		maxPages = 1
 	}
 	var tags []GitHubTag
 	for page := 1; page <= maxPages; page++ {
-		endpoint := fmt.Sprintf("https://api.github.com/repos/%s/tags?per_page=100&page=%d", repoFullName, page)
-		data, err := gc.get(endpoint)
+		endpoint := gc.endpoint("repos/%s/tags?per_page=100&page=%d", repoFullName, page)
+		data, err := gc.get(ctx, endpoint)
 		if err != nil {
 			return nil, err
 		}
@@ -707,19 +1065,19 @@ func (gc *GitHubClient) listTags(repoFullName string, maxPages int) ([]GitHubTag
 	return tags, nil
 }
 
-func (gc *GitHubClient) compare(repoFullName, base, head string) (*GitHubCompareResult, error) {
+func (gc *GitHubClient) compare(ctx context.Context, repoFullName, base, head string) (*GitHubCompareResult, error) {
 	base = strings.TrimSpace(base)
 	head = strings.TrimSpace(head)
 	if base == "" || head == "" {
 		return nil, fmt.Errorf("base and head tags are required")
 	}
-	compareURL := fmt.Sprintf(
-		"https://api.github.com/repos/%s/compare/%s...%s",
+	compareURL := gc.endpoint(
+		"repos/%s/compare/%s...%s",
 		repoFullName,
 		url.PathEscape(base),
 		url.PathEscape(head),
 	)
-	data, err := gc.get(compareURL)
+	data, err := gc.get(ctx, compareURL)
 	if err != nil {
 		return nil, err
 	}
@@ -730,12 +1088,12 @@ func (gc *GitHubClient) compare(repoFullName, base, head string) (*GitHubCompare
 	return &result, nil
 }
 
-func (gc *GitHubClient) getArchive(url string) ([]byte, error) {
-	if !gc.rateLimit.acquire() {
-		return nil, fmt.Errorf("rate limit exceeded")
+func (gc *GitHubClient) getArchive(ctx context.Context, url string) ([]byte, error) {
+	if err := gc.rateLimit.wait(ctx, gc.rateWait); err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -760,5 +1118,21 @@ func (gc *GitHubClient) getArchive(url string) ([]byte, error) {
 		return nil, fmt.Errorf("GitHub API error: %d", resp.StatusCode)
 	}
 
-	return io.ReadAll(resp.Body)
+	limit := gc.maxArchiveBytes
+	if limit <= 0 {
+		limit = DefaultMaxArchiveBytes
+	}
+	if resp.ContentLength > limit {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrArchiveTooLarge, resp.ContentLength, limit)
+	}
+
+	// Read one byte past the limit so a body of exactly limit bytes is still accepted.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than the %d byte limit", ErrArchiveTooLarge, limit)
+	}
+	return data, nil
 }
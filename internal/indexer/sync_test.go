@@ -4,10 +4,14 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"sync"
 	"testing"
@@ -38,6 +42,16 @@ func TestWorkerCountFor(t *testing.T) {
 	if got := limiting.workerCountFor(10); got != 2 {
 		t.Fatalf("expected rate-limit cap to 2, got %d", got)
 	}
+
+	configured := &Syncer{}
+	configured.SetWorkerCount(8)
+	if got := configured.workerCountFor(20); got != 8 || configured.parseWorkers != 8 {
+		t.Fatalf("expected configured worker count 8 for queue and parsing, got %d and %d", got, configured.parseWorkers)
+	}
+	configured.SetWorkerCount(0)
+	if got := configured.workerCountFor(20); got != DefaultWorkerCount || configured.parseWorkers != 0 {
+		t.Fatalf("expected default worker counts, got %d and %d", got, configured.parseWorkers)
+	}
 }
 
 func TestCompareTagsNilClient(t *testing.T) {
@@ -83,6 +97,116 @@ func TestCompareTagsUsesHTTPClient(t *testing.T) {
 	}
 }
 
+func TestGetWaitsForRateLimitRefill(t *testing.T) {
+	calls := 0
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`[]`)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 0, maxTokens: 1, refillAt: time.Now().Add(50 * time.Millisecond)},
+	}
+
+	if _, err := client.get(context.Background(), "https://api.github.com/a"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected immediate rate limit error without a wait, got %v", err)
+	}
+
+	client.rateWait = time.Second
+	start := time.Now()
+	if _, err := client.get(context.Background(), "https://api.github.com/a"); err != nil {
+		t.Fatalf("expected request to succeed after the refill, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Fatalf("expected request to wait for the refill, returned after %s", elapsed)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one HTTP call, got %d", calls)
+	}
+
+	// The budget is spent again and refills in an hour, beyond the wait limit.
+	if _, err := client.get(context.Background(), "https://api.github.com/b"); !errors.Is(err, ErrRateLimitExceeded) {
+		t.Fatalf("expected rate limit error when the refill is beyond the wait, got %v", err)
+	}
+}
+
+func TestRateLimiterWaitHonorsContext(t *testing.T) {
+	rl := &RateLimiter{tokens: 0, maxTokens: 1, refillAt: time.Now().Add(time.Minute)}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := rl.wait(ctx, time.Hour); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context error while waiting, got %v", err)
+	}
+}
+
+func TestCompareTagsHonorsBaseURL(t *testing.T) {
+	var gotURL string
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"files":[]}`)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+	}
+
+	s := &Syncer{githubClient: client, org: "internal", repo: "terraform-provider-azurerm"}
+	s.SetGitHubBaseURL("https://ghes.example.com/api/v3/")
+
+	if _, err := s.CompareTags("v1.0.0", "v1.1.0"); err != nil {
+		t.Fatalf("compare tags unexpected error: %v", err)
+	}
+	want := "https://ghes.example.com/api/v3/repos/internal/terraform-provider-azurerm/compare/v1.0.0...v1.1.0"
+	if gotURL != want {
+		t.Fatalf("expected %s, got %s", want, gotURL)
+	}
+}
+
+func TestFetchRepositoryByNameHonorsBaseURL(t *testing.T) {
+	var gotURL string
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				gotURL = req.URL.String()
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"name":"terraform-provider-azurerm","full_name":"internal/terraform-provider-azurerm","size":1024}`)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+	}
+
+	s := &Syncer{githubClient: client, org: "internal"}
+	s.SetGitHubBaseURL("https://ghes.example.com/api/v3")
+
+	repo, err := s.fetchRepositoryByName(context.Background(), "terraform-provider-azurerm")
+	if err != nil {
+		t.Fatalf("fetchRepositoryByName: %v", err)
+	}
+	if want := "https://ghes.example.com/api/v3/repos/internal/terraform-provider-azurerm"; gotURL != want {
+		t.Fatalf("expected %s, got %s", want, gotURL)
+	}
+	if repo.FullName != "internal/terraform-provider-azurerm" {
+		t.Fatalf("unexpected repo: %+v", repo)
+	}
+}
+
 func TestGitHubClientGetCaches(t *testing.T) {
 	count := 0
 	client := &GitHubClient{
@@ -100,11 +224,11 @@ func TestGitHubClientGetCaches(t *testing.T) {
 		rateLimit: &RateLimiter{tokens: 2, maxTokens: 2, refillAt: time.Now().Add(time.Hour)},
 	}
 
-	data1, err := client.get("https://example.com/data")
+	data1, err := client.get(context.Background(), "https://example.com/data")
 	if err != nil {
 		t.Fatalf("get first call: %v", err)
 	}
-	data2, err := client.get("https://example.com/data")
+	data2, err := client.get(context.Background(), "https://example.com/data")
 	if err != nil {
 		t.Fatalf("get second call: %v", err)
 	}
@@ -131,7 +255,7 @@ func TestGitHubClientGetHandlesNonOK(t *testing.T) {
 		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
 	}
 
-	if _, err := client.get("https://example.com/denied"); err == nil {
+	if _, err := client.get(context.Background(), "https://example.com/denied"); err == nil {
 		t.Fatalf("expected error on non-200 response")
 	}
 }
@@ -212,8 +336,11 @@ func TestNewSyncer(t *testing.T) {
 		if s.repo != "terraform-provider-azurerm" {
 			t.Errorf("expected repo to be terraform-provider-azurerm, got %s", s.repo)
 		}
-		if s.workerCount != defaultWorkerCount {
-			t.Errorf("expected worker count %d, got %d", defaultWorkerCount, s.workerCount)
+		if s.workerCount != DefaultWorkerCount {
+			t.Errorf("expected worker count %d, got %d", DefaultWorkerCount, s.workerCount)
+		}
+		if s.githubClient.baseURL != DefaultGitHubBaseURL {
+			t.Errorf("expected default base URL, got %s", s.githubClient.baseURL)
 		}
 	})
 
@@ -484,13 +611,57 @@ func TestGitHubClientClearCache(t *testing.T) {
 		t.Fatalf("expected 2 cache entries initially, got %d", len(client.cache))
 	}
 
-	client.clearCache()
+	if cleared := client.clearCache(); cleared != 2 {
+		t.Errorf("expected 2 entries reported cleared, got %d", cleared)
+	}
 
 	if len(client.cache) != 0 {
 		t.Errorf("expected 0 cache entries after clear, got %d", len(client.cache))
 	}
 }
 
+func TestSyncerCacheTTLAndStats(t *testing.T) {
+	count := 0
+	s := NewSyncer(nil, "", "hashicorp", "terraform-provider-azurerm")
+	s.githubClient.httpClient = &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			count++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("data")),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+	s.SetCacheTTL(time.Nanosecond)
+
+	for range 2 {
+		if _, err := s.githubClient.get(context.Background(), "https://example.com/data"); err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if count != 2 {
+		t.Fatalf("expected expired entry to be refetched, got %d requests", count)
+	}
+
+	stats := s.CacheStats()
+	if stats.Entries != 1 || stats.Expired != 1 || stats.Bytes != 4 || stats.TTL != time.Nanosecond || stats.Oldest.IsZero() {
+		t.Fatalf("unexpected cache stats: %+v", stats)
+	}
+
+	s.SetCacheTTL(0)
+	if got := s.CacheStats().TTL; got != DefaultCacheTTL {
+		t.Fatalf("expected default TTL restored, got %s", got)
+	}
+	if cleared := s.ClearCache(); cleared != 1 {
+		t.Fatalf("expected 1 entry cleared, got %d", cleared)
+	}
+	if stats := s.CacheStats(); stats.Entries != 0 || !stats.Oldest.IsZero() {
+		t.Fatalf("expected empty cache after clear, got %+v", stats)
+	}
+}
+
 func TestGitHubClientListTags(t *testing.T) {
 	page := 0
 	client := &GitHubClient{
@@ -516,7 +687,7 @@ func TestGitHubClientListTags(t *testing.T) {
 		rateLimit: &RateLimiter{tokens: 10, maxTokens: 10, refillAt: time.Now().Add(time.Hour)},
 	}
 
-	tags, err := client.listTags("hashicorp/terraform-provider-azurerm", 2)
+	tags, err := client.listTags(context.Background(), "hashicorp/terraform-provider-azurerm", 2)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -526,23 +697,62 @@ func TestGitHubClientListTags(t *testing.T) {
 	}
 }
 
+func TestSyncerListTagsHonorsMaxTagPages(t *testing.T) {
+	var requested []string
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requested = append(requested, req.URL.Query().Get("page"))
+				tags := make([]GitHubTag, 100)
+				for i := range tags {
+					tags[i].Name = fmt.Sprintf("v%s.%d.0", req.URL.Query().Get("page"), i)
+				}
+				body, _ := json.Marshal(tags)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewReader(body)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 10, maxTokens: 10, refillAt: time.Now().Add(time.Hour)},
+	}
+
+	s := &Syncer{githubClient: client, org: "hashicorp", repo: "terraform-provider-azurerm"}
+	s.SetMaxTagPages(3)
+
+	tags, err := s.ListTags()
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if len(tags) != 300 || len(requested) != 3 {
+		t.Fatalf("expected 3 pages (300 tags), got %d tags over %v", len(tags), requested)
+	}
+
+	s.SetMaxTagPages(0)
+	if got := s.tagPages(); got != defaultMaxTagPages {
+		t.Fatalf("expected default page depth, got %d", got)
+	}
+}
+
 func TestGitHubClientCompareEmptyTags(t *testing.T) {
 	client := &GitHubClient{
 		cache:     make(map[string]CacheEntry),
 		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
 	}
 
-	_, err := client.compare("repo", "", "v1.0.0")
+	_, err := client.compare(context.Background(), "repo", "", "v1.0.0")
 	if err == nil {
 		t.Fatal("expected error for empty base tag")
 	}
 
-	_, err = client.compare("repo", "v1.0.0", "")
+	_, err = client.compare(context.Background(), "repo", "v1.0.0", "")
 	if err == nil {
 		t.Fatal("expected error for empty head tag")
 	}
 
-	_, err = client.compare("repo", "  ", "  ")
+	_, err = client.compare(context.Background(), "repo", "  ", "  ")
 	if err == nil {
 		t.Fatal("expected error for whitespace-only tags")
 	}
@@ -563,7 +773,7 @@ func TestGitHubClientGetArchiveRateLimit(t *testing.T) {
 		rateLimit: &RateLimiter{tokens: 0, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
 	}
 
-	_, err := client.getArchive("https://api.github.com/repos/test/test/tarball")
+	_, err := client.getArchive(context.Background(), "https://api.github.com/repos/test/test/tarball")
 	if err == nil {
 		t.Fatal("expected rate limit error")
 	}
@@ -589,7 +799,7 @@ func TestFetchReadmeDecodesContent(t *testing.T) {
 	}
 
 	s := &Syncer{githubClient: client}
-	readme, err := s.fetchReadme("hashicorp/terraform-provider-azurerm")
+	readme, err := s.fetchReadme(context.Background(), "hashicorp/terraform-provider-azurerm")
 	if err != nil {
 		t.Fatalf("fetchReadme: %v", err)
 	}
@@ -598,6 +808,46 @@ func TestFetchReadmeDecodesContent(t *testing.T) {
 	}
 }
 
+func TestSyncerRefSnapshotVersion(t *testing.T) {
+	var requested string
+	client := &GitHubClient{
+		httpClient: &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				requested = req.URL.RawQuery
+				body := `{"content":"` + base64.StdEncoding.EncodeToString([]byte("README")) + `"}`
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(body)),
+					Header:     make(http.Header),
+				}, nil
+			}),
+		},
+		cache:     make(map[string]CacheEntry),
+		rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+	}
+
+	s := &Syncer{githubClient: client}
+	if got := s.snapshotVersion(); got != "" {
+		t.Fatalf("expected no snapshot version without a ref, got %q", got)
+	}
+
+	s.SetRef(" v4.52.0 ")
+	if got := s.snapshotVersion(); got != "4.52.0" {
+		t.Fatalf("expected tag to map to 4.52.0, got %q", got)
+	}
+	if _, err := s.fetchReadme(context.Background(), "hashicorp/terraform-provider-azurerm"); err != nil {
+		t.Fatalf("fetchReadme: %v", err)
+	}
+	if requested != "ref=v4.52.0" {
+		t.Fatalf("expected README fetched at the ref, got query %q", requested)
+	}
+
+	s.SetRef("main")
+	if got := s.snapshotVersion(); got != "main" {
+		t.Fatalf("expected branch name kept verbatim, got %q", got)
+	}
+}
+
 func TestGitHubClientGetArchiveHTTPErrors(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -626,7 +876,7 @@ func TestGitHubClientGetArchiveHTTPErrors(t *testing.T) {
 				rateLimit: &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
 			}
 
-			_, err := client.getArchive("https://api.github.com/repos/test/test/tarball")
+			_, err := client.getArchive(context.Background(), "https://api.github.com/repos/test/test/tarball")
 			if err == nil {
 				t.Fatal("expected error")
 			}
@@ -637,6 +887,97 @@ func TestGitHubClientGetArchiveHTTPErrors(t *testing.T) {
 	}
 }
 
+func TestGitHubClientGetArchiveSizeLimit(t *testing.T) {
+	newClient := func(body string, contentLength int64) *GitHubClient {
+		return &GitHubClient{
+			httpClient: &http.Client{
+				Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode:    http.StatusOK,
+						Body:          io.NopCloser(strings.NewReader(body)),
+						ContentLength: contentLength,
+						Header:        make(http.Header),
+					}, nil
+				}),
+			},
+			cache:           make(map[string]CacheEntry),
+			rateLimit:       &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+			maxArchiveBytes: 16,
+		}
+	}
+	const url = "https://api.github.com/repos/test/test/tarball"
+
+	oversized := strings.Repeat("x", 64)
+	if _, err := newClient(oversized, -1).getArchive(context.Background(), url); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Fatalf("expected an oversized streamed body to be rejected, got %v", err)
+	}
+	if _, err := newClient(oversized, int64(len(oversized))).getArchive(context.Background(), url); !errors.Is(err, ErrArchiveTooLarge) || !strings.Contains(err.Error(), "64 bytes") {
+		t.Fatalf("expected the declared Content-Length to be rejected up front, got %v", err)
+	}
+
+	exact := strings.Repeat("x", 16)
+	data, err := newClient(exact, -1).getArchive(context.Background(), url)
+	if err != nil || string(data) != exact {
+		t.Fatalf("expected a body at the limit to be accepted, got %q err=%v", data, err)
+	}
+}
+
+func TestGitHubClientGetArchiveCancelledMidDownload(t *testing.T) {
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("partial archive"))
+		w.(http.Flusher).Flush()
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := &GitHubClient{
+		httpClient: srv.Client(),
+		cache:      make(map[string]CacheEntry),
+		rateLimit:  &RateLimiter{tokens: 1, maxTokens: 1, refillAt: time.Now().Add(time.Hour)},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.getArchive(ctx, srv.URL+"/repos/test/test/tarball")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("download was not cancelled")
+	}
+}
+
+func TestSyncRepositoryCancelledKeepsExistingData(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertFile(t, db, repo.ID, "main.go", "go", "package main")
+
+	s := NewSyncer(db, "", "hashicorp", "terraform-provider-azurerm")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := s.syncRepository(ctx, GitHubRepo{Name: repo.Name, FullName: "hashicorp/terraform-provider-azurerm"}, &SyncTimings{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := db.GetFile(repo.Name, "main.go"); err != nil {
+		t.Fatalf("expected indexed files to survive a cancelled sync: %v", err)
+	}
+}
+
 func TestProcessArchiveEntriesInsertsFiles(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -690,6 +1031,61 @@ func TestProcessArchiveEntriesInsertsFiles(t *testing.T) {
 	}
 }
 
+func TestProcessArchiveEntriesExcludesTestFiles(t *testing.T) {
+	buf := new(bytes.Buffer)
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range map[string]string{
+		"root/internal/example/resource.go":      "package example",
+		"root/internal/example/resource_test.go": "package example_test",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("write content: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar writer: %v", err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatalf("close gzip writer: %v", err)
+	}
+
+	for _, include := range []bool{true, false} {
+		db := testutil.NewTestDB(t)
+		repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+		tarReader, err := openTarArchive(buf.Bytes())
+		if err != nil {
+			t.Fatalf("open archive: %v", err)
+		}
+		s := &Syncer{db: db}
+		s.SetIncludeTests(include)
+		if err := s.processArchiveEntries(tarReader, repo.ID); err != nil {
+			t.Fatalf("process archive: %v", err)
+		}
+
+		files, err := db.GetRepositoryFiles(repo.ID)
+		if err != nil {
+			t.Fatalf("get files: %v", err)
+		}
+		want := 1
+		if include {
+			want = 2
+		}
+		if len(files) != want {
+			t.Fatalf("include=%v: expected %d files, got %d", include, want, len(files))
+		}
+		for _, f := range files {
+			if !include && strings.HasSuffix(f.FilePath, "_test.go") {
+				t.Fatalf("expected %s to be excluded", f.FilePath)
+			}
+		}
+	}
+}
+
 func TestProcessRepoQueueConcurrent(t *testing.T) {
 	s := &testSyncer{
 		Syncer: &Syncer{workerCount: 3},
@@ -788,15 +1184,19 @@ var customDiff = func() {}
 		org:          "hashicorp",
 		repo:         "terraform-provider-azurerm",
 		workerCount:  1,
+		profile:      true,
 	}
 
-	progress, err := s.SyncAll()
+	progress, err := s.SyncAll(context.Background())
 	if err != nil {
 		t.Fatalf("SyncAll error: %v", err)
 	}
 	if progress.TotalRepos != 1 || progress.ProcessedRepos != 1 || len(progress.Errors) != 0 {
 		t.Fatalf("unexpected progress: %+v", progress)
 	}
+	if progress.Timings == nil {
+		t.Fatalf("expected timings on a profiled sync")
+	}
 
 	repo, err := db.GetRepository("terraform-provider-azurerm")
 	if err != nil {
@@ -852,13 +1252,69 @@ func TestSyncUpdatesSkipsUpToDateRepo(t *testing.T) {
 		workerCount:  1,
 	}
 
-	progress, err := s.SyncUpdates()
+	progress, err := s.SyncUpdates(context.Background())
 	if err != nil {
 		t.Fatalf("SyncUpdates error: %v", err)
 	}
 	if progress.SkippedRepos != 1 || len(progress.UpdatedRepos) != 0 {
 		t.Fatalf("expected repo to be skipped, progress: %+v", progress)
 	}
+	if progress.Timings != nil {
+		t.Fatalf("expected no timings without profiling, got %+v", progress.Timings)
+	}
+}
+
+func TestSyncUpdatesReportsProgress(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	repo.LastUpdated = "2024-01-01T00:00:00Z"
+	_, _ = db.InsertRepository(repo)
+
+	repoJSON := `{"name":"terraform-provider-azurerm","full_name":"hashicorp/terraform-provider-azurerm","description":"desc","updated_at":"2024-01-01T00:00:00Z","html_url":"https://github.com/hashicorp/terraform-provider-azurerm","private":false,"archived":false,"size":1}`
+	client := newFakeGitHubClient(t, map[string][]byte{
+		"https://api.github.com/repos/hashicorp/terraform-provider-azurerm": []byte(repoJSON),
+	}, nil)
+
+	s := &Syncer{
+		db:           db,
+		githubClient: client,
+		org:          "hashicorp",
+		repo:         "terraform-provider-azurerm",
+		workerCount:  1,
+	}
+
+	var reports []SyncProgress
+	ctx := WithProgressReporter(context.Background(), func(p SyncProgress) {
+		reports = append(reports, p)
+	})
+	if _, err := s.SyncUpdates(ctx); err != nil {
+		t.Fatalf("SyncUpdates error: %v", err)
+	}
+
+	if len(reports) != 2 {
+		t.Fatalf("expected reports after listing and after the skip, got %+v", reports)
+	}
+	if reports[0].TotalRepos != 1 || reports[0].ProcessedRepos != 0 {
+		t.Fatalf("expected first report before any repository was processed, got %+v", reports[0])
+	}
+	last := reports[1]
+	if last.ProcessedRepos != 1 || last.SkippedRepos != 1 || last.CurrentRepo != "terraform-provider-azurerm" {
+		t.Fatalf("unexpected final report: %+v", last)
+	}
+}
+
+func TestSyncTimingsAdd(t *testing.T) {
+	var total SyncTimings
+	total.add(SyncTimings{Download: time.Second, Parse: 2 * time.Second})
+	total.add(SyncTimings{Download: time.Second, FileInserts: 500 * time.Millisecond, Releases: time.Second})
+
+	want := SyncTimings{Download: 2 * time.Second, FileInserts: 500 * time.Millisecond, Parse: 2 * time.Second, Releases: time.Second}
+	if total != want {
+		t.Fatalf("add = %+v, want %+v", total, want)
+	}
+	if got := total.String(); got != "download 2s, file inserts 500ms, parse 2s, releases 1s" {
+		t.Fatalf("unexpected breakdown: %s", got)
+	}
 }
 
 func buildTestArchive(t *testing.T, files map[string]string) []byte {
@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Description style rules reported by check_description_style.
+const (
+	styleMissing        = "missing"
+	styleCapitalization = "capitalization"
+	styleTrailingPeriod = "trailing_period"
+	styleWhitespace     = "whitespace"
+	styleEnumValues     = "enum_values"
+)
+
+var styleRuleHints = map[string]string{
+	styleMissing:        "description is empty",
+	styleCapitalization: "should start with a capital letter",
+	styleTrailingPeriod: "should end with a period",
+	styleWhitespace:     "has leading/trailing or repeated whitespace",
+	styleEnumValues:     "should list the valid values accepted by its validation",
+}
+
+// descriptionStyleIssues applies the provider's description conventions; enumValues are the
+// values accepted by the attribute's StringInSlice validation, if any.
+func descriptionStyleIssues(desc string, enumValues []string, includeMissing bool) []string {
+	trimmed := strings.TrimSpace(desc)
+	if trimmed == "" {
+		if includeMissing {
+			return []string{styleMissing}
+		}
+		return nil
+	}
+
+	var issues []string
+	if first := []rune(trimmed)[0]; unicode.IsLower(first) {
+		issues = append(issues, styleCapitalization)
+	}
+	if !strings.HasSuffix(strings.TrimRight(trimmed, ")`'\""), ".") {
+		issues = append(issues, styleTrailingPeriod)
+	}
+	if trimmed != desc || strings.Contains(trimmed, "  ") {
+		issues = append(issues, styleWhitespace)
+	}
+	if len(enumValues) > 0 && !mentionsAnyValue(trimmed, enumValues) {
+		issues = append(issues, styleEnumValues)
+	}
+	return issues
+}
+
+func mentionsAnyValue(desc string, values []string) bool {
+	lower := strings.ToLower(desc)
+	for _, value := range values {
+		if value != "" && strings.Contains(lower, strings.ToLower(value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// logLevels lists the MCP (syslog) severities from least to most severe.
+var logLevels = []string{"debug", "info", "notice", "warning", "error", "critical", "alert", "emergency"}
+
+const loggerName = "aztfmcp"
+
+var errNoWriter = errors.New("no writer configured")
+
+// logPrefixPattern matches the standard logger's optional date and time prefix.
+var logPrefixPattern = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d+)? )?`)
+
+// protocolTracePattern matches the Received/Sent lines that echo every JSON-RPC frame.
+var protocolTracePattern = regexp.MustCompile(logPrefixPattern.String() + `(Received|Sent): `)
+
+// logLinePrefixes maps the leading marker of a log message to its severity; messages without one
+// are info.
+var logLinePrefixes = []struct {
+	prefix string
+	level  string
+}{
+	{"debug:", "debug"},
+	{"received:", "debug"},
+	{"sent:", "debug"},
+	{"handling method:", "debug"},
+	{"warning:", "warning"},
+	{"error:", "error"},
+}
+
+func logLevelRank(level string) int {
+	for i, l := range logLevels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// classifyLogLine infers the severity of a line written through the standard logger from the
+// marker it starts with, so words such as "failed" inside a message do not change its level.
+func classifyLogLine(line string) string {
+	message := strings.ToLower(logPrefixPattern.ReplaceAllString(line, ""))
+	for _, p := range logLinePrefixes {
+		if strings.HasPrefix(message, p.prefix) {
+			return p.level
+		}
+	}
+	return "info"
+}
+
+// clientLogWriter tees standard log output to the client as notifications/message
+// once the client has opted in via logging/setLevel.
+type clientLogWriter struct {
+	server *Server
+	next   io.Writer
+}
+
+func (w *clientLogWriter) Write(p []byte) (int, error) {
+	n, err := w.next.Write(p)
+	w.server.forwardLog(string(p))
+	return n, err
+}
+
+func (s *Server) handleSetLogLevel(msg Message) {
+	params, err := UnmarshalArgs[struct {
+		Level string `json:"level"`
+	}](msg.Params)
+	level := strings.ToLower(strings.TrimSpace(params.Level))
+	if err != nil || logLevelRank(level) < 0 {
+		s.sendError(-32602, fmt.Sprintf("Invalid params: unknown log level %q", params.Level), msg.ID)
+		return
+	}
+
+	s.logLevel.Store(int32(logLevelRank(level) + 1))
+	s.sendResponse(Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+		Result:  map[string]any{},
+	})
+}
+
+// forwardLog must not log itself: it runs inside the standard logger's Write. Protocol trace
+// lines are never forwarded: they carry whole frames, including every tool response.
+func (s *Server) forwardLog(line string) {
+	threshold := int(s.logLevel.Load()) - 1
+	if threshold < 0 {
+		return
+	}
+
+	line = strings.TrimRight(line, "\n")
+	if protocolTracePattern.MatchString(line) {
+		return
+	}
+	level := classifyLogLine(line)
+	if logLevelRank(level) < threshold {
+		return
+	}
+
+	s.sendNotification("notifications/message", map[string]any{
+		"level":  level,
+		"logger": loggerName,
+		"data":   line,
+	})
+}
+
+func (s *Server) sendNotification(method string, params any) {
+	data, err := json.Marshal(Message{JSONRPC: "2.0", Method: method, Params: params})
+	if err != nil {
+		return
+	}
+	s.writeLine(data)
+}
+
+// writeLine writes a single JSON-RPC frame, serialising writers so that responses
+// and notifications never interleave mid-line.
+func (s *Server) writeLine(data []byte) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	if s.writer == nil {
+		return errNoWriter
+	}
+	_, err := fmt.Fprintln(s.writer, string(data))
+	return err
+}
@@ -0,0 +1,90 @@
+package mcp
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHandleSetLogLevelForwardsLogs(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewServer("test.db", "", "org", "repo")
+	s.writer = &buf
+
+	s.forwardLog("Warning: dropped before opt-in\n")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no notifications before logging/setLevel, got %s", buf.String())
+	}
+
+	s.handleMessage(Message{JSONRPC: "2.0", Method: "logging/setLevel", ID: 1, Params: map[string]any{"level": "warning"}})
+	resp := decodeMessage(t, buf.String())
+	if resp.Error != nil || resp.Result == nil {
+		t.Fatalf("expected empty result, got %+v", resp)
+	}
+
+	buf.Reset()
+	s.forwardLog("Syncing repository: terraform-provider-azurerm (1/1)\n")
+	if buf.Len() != 0 {
+		t.Fatalf("expected info line to be filtered, got %s", buf.String())
+	}
+
+	s.forwardLog("Warning: failed to fetch README\n")
+	note := decodeMessage(t, buf.String())
+	if note.Method != "notifications/message" || note.ID != nil {
+		t.Fatalf("expected notification, got %+v", note)
+	}
+	params := note.Params.(map[string]any)
+	if params["level"] != "warning" || !strings.Contains(params["data"].(string), "failed to fetch README") {
+		t.Fatalf("unexpected notification params: %#v", params)
+	}
+}
+
+func TestForwardLogSkipsProtocolTrace(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewServer("test.db", "", "org", "repo")
+	s.writer = &buf
+	s.logLevel.Store(int32(logLevelRank("debug") + 1))
+
+	s.forwardLog(`2026/10/17 12:00:00 Sent: {"jsonrpc":"2.0","id":1,"result":{}}` + "\n")
+	s.forwardLog(`Received: {"jsonrpc":"2.0","method":"tools/list","id":2}` + "\n")
+	if buf.Len() != 0 {
+		t.Fatalf("expected protocol trace lines to stay local, got %s", buf.String())
+	}
+
+	s.forwardLog("2026/10/17 12:00:01 Handling method: tools/list\n")
+	note := decodeMessage(t, buf.String())
+	if params := note.Params.(map[string]any); params["level"] != "debug" {
+		t.Fatalf("expected other debug lines forwarded, got %#v", params)
+	}
+}
+
+func TestHandleSetLogLevelInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	s := NewServer("test.db", "", "org", "repo")
+	s.writer = &buf
+
+	s.handleMessage(Message{JSONRPC: "2.0", Method: "logging/setLevel", ID: 1, Params: map[string]any{"level": "verbose"}})
+	resp := decodeMessage(t, buf.String())
+	if resp.Error == nil || resp.Error.Code != -32602 {
+		t.Fatalf("expected invalid params error, got %+v", resp)
+	}
+}
+
+func TestClassifyLogLine(t *testing.T) {
+	cases := map[string]string{
+		"Received: {}":                                        "debug",
+		"Debug: compared 10 resources":                        "debug",
+		"2026/10/17 12:00:01 DEBUG: matched 3 files":          "debug",
+		"Warning: failed to parse Go file":                    "warning",
+		"Error: failed to write response: EOF":                "error",
+		"2026/10/17 12:00:01 Error: failed to close database": "error",
+		"Sync completed: 1/1 repositories":                    "info",
+		"Skipping release 1.0.0: compare failed":              "info",
+		"Tool call: get_error_codes":                          "info",
+	}
+	for line, want := range cases {
+		if got := classifyLogLine(line); got != want {
+			t.Fatalf("classifyLogLine(%q) = %s, want %s", line, got, want)
+		}
+	}
+}
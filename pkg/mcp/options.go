@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+// Option customises a Server created by NewServer.
+type Option func(*Server)
+
+// WithDescriptionMaxChars sets the default truncation length for attribute descriptions in
+// schema and search tables. Zero disables truncation.
+func WithDescriptionMaxChars(n int) Option {
+	return func(s *Server) {
+		if n > 0 {
+			s.descMaxChars = n
+		}
+	}
+}
+
+// WithGitHubBaseURL points repository syncs at another GitHub API root, such as a
+// GitHub Enterprise Server instance. Empty keeps the public api.github.com endpoint.
+func WithGitHubBaseURL(baseURL string) Option {
+	return func(s *Server) {
+		s.githubBaseURL = baseURL
+	}
+}
+
+// WithToolTimeout sets the default deadline for a single tools/call. Long-running tools such as
+// sync_updates_provider keep their larger built-in deadline when it exceeds this value.
+func WithToolTimeout(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.toolTimeout = d
+		}
+	}
+}
+
+// WithMaxTagPages sets how many pages of 100 tags are fetched from GitHub for release
+// metadata and list_tags. Values below 1 keep the syncer default.
+func WithMaxTagPages(pages int) Option {
+	return func(s *Server) {
+		s.maxTagPages = pages
+	}
+}
+
+// WithWorkers sets how many sync workers run concurrently and how many goroutines parse Go
+// files. Values below 1 keep the syncer defaults; the queue count is still capped by the GitHub
+// rate-limit budget.
+func WithWorkers(n int) Option {
+	return func(s *Server) {
+		s.workers = n
+	}
+}
+
+// WithDBMode selects how the index is opened. database.ModeReadOnly serves a pre-built index
+// without writing to it and disables the sync and backfill tools.
+func WithDBMode(mode database.Mode) Option {
+	return func(s *Server) {
+		s.dbMode = mode
+	}
+}
+
+// WithRef pins syncs to a tag, branch or commit. Syncing a release tag also records a schema
+// snapshot for that version, queryable through get_resource_schema's version argument.
+func WithRef(ref string) Option {
+	return func(s *Server) {
+		s.ref = ref
+	}
+}
+
+// WithAutoRepair moves a corrupt index aside and creates a fresh one instead of failing every
+// tool call. It has no effect in read-only mode.
+func WithAutoRepair(enabled bool) Option {
+	return func(s *Server) {
+		s.autoRepair = enabled
+	}
+}
+
+// WithCacheTTL sets how long GitHub API responses are reused before being fetched again.
+// Zero keeps the syncer default of ten minutes.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *Server) {
+		s.cacheTTL = ttl
+	}
+}
+
+// WithRateWait lets sync requests block for up to wait for the GitHub rate limit to refill
+// instead of failing once the budget is exhausted. Zero keeps failing immediately.
+func WithRateWait(wait time.Duration) Option {
+	return func(s *Server) {
+		s.rateWait = wait
+	}
+}
+
+// WithServerInfo sets the name and version reported in the initialize response's serverInfo.
+// Empty values keep the defaults.
+func WithServerInfo(name, version string) Option {
+	return func(s *Server) {
+		s.serverName = name
+		s.serverVersion = version
+	}
+}
+
+// WithIncludeTests controls whether *_test.go files are indexed during syncs. Disabling it
+// shrinks the database; list_resource_tests then reports that tests were not indexed.
+func WithIncludeTests(enabled bool) Option {
+	return func(s *Server) {
+		s.excludeTests = !enabled
+	}
+}
+
+// WithStyle sets how tool output is presented. The formatters build markdown by default, or plain
+// text with aligned columns instead of tables for clients that show raw text.
+func WithStyle(style formatter.Style) Option {
+	return func(s *Server) {
+		s.style = style
+	}
+}
+
+// WithPollInterval fixes the "check again in N seconds" hint sync_status gives for running jobs.
+// Zero keeps the adaptive hint, which follows the duration of the previous job of the same type.
+func WithPollInterval(d time.Duration) Option {
+	return func(s *Server) {
+		if d > 0 {
+			s.pollInterval = d
+		}
+	}
+}
+
+// WithProfile records how long each sync stage takes (download, file inserts, parse, releases).
+// The breakdown is logged and shown in completed sync results.
+func WithProfile(enabled bool) Option {
+	return func(s *Server) {
+		s.profile = enabled
+	}
+}
+
+// WithMaxArchiveBytes caps the size of a downloaded repository tarball; zero keeps the indexer default.
+func WithMaxArchiveBytes(limit int64) Option {
+	return func(s *Server) {
+		if limit > 0 {
+			s.maxArchiveBytes = limit
+		}
+	}
+}
+
+// descriptionLimit resolves a per-call desc_max_chars value against the server default:
+// positive values override it, negative values disable truncation.
+func (s *Server) descriptionLimit(requested int) int {
+	switch {
+	case requested > 0:
+		return requested
+	case requested < 0:
+		return 0
+	default:
+		return s.descMaxChars
+	}
+}
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/printer"
@@ -9,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/dkooll/aztfmcp/internal/formatter"
 )
@@ -73,6 +75,91 @@ func extractMarkdownSection(content, section string) (string, bool) {
 	return strings.TrimSpace(content), false
 }
 
+// attributeDocParagraphs returns the documentation bullets ("* `name` - ...") describing the last
+// segment of path, continuation lines included. Bullets following an "A `block` block supports"
+// intro belong to that block, so a nested path only matches bullets under its parent block; when
+// nothing matches that way every bullet for the name is returned.
+func attributeDocParagraphs(content, path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, ".") {
+		segment = strings.TrimSpace(segment)
+		if _, err := strconv.Atoi(segment); err == nil || segment == "" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return nil
+	}
+	leaf := segments[len(segments)-1]
+	parent := ""
+	if len(segments) > 1 {
+		parent = segments[len(segments)-2]
+	}
+
+	type docBullet struct {
+		block string
+		text  string
+	}
+	var bullets []docBullet
+	var current *docBullet
+	block := ""
+	flush := func() {
+		if current != nil {
+			bullets = append(bullets, *current)
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			block = ""
+		case strings.HasPrefix(trimmed, "* `") || strings.HasPrefix(trimmed, "- `"):
+			flush()
+			if backtickName(trimmed[2:]) == leaf {
+				current = &docBullet{block: block, text: trimmed}
+			}
+		case strings.HasPrefix(trimmed, "A `") || strings.HasPrefix(trimmed, "An `"):
+			flush()
+			if name := backtickName(trimmed[strings.Index(trimmed, "`"):]); name != "" && strings.Contains(trimmed, "block") {
+				block = name
+			}
+		case current != nil:
+			current.text += " " + trimmed
+		}
+	}
+	flush()
+
+	var scoped, all []string
+	for _, bullet := range bullets {
+		all = append(all, bullet.text)
+		if bullet.block == parent {
+			scoped = append(scoped, bullet.text)
+		}
+	}
+	if len(scoped) > 0 {
+		return scoped
+	}
+	return all
+}
+
+// backtickName returns the identifier enclosed by the leading backticks of s.
+func backtickName(s string) string {
+	if !strings.HasPrefix(s, "`") {
+		return ""
+	}
+	end := strings.Index(s[1:], "`")
+	if end < 0 {
+		return ""
+	}
+	return s[1 : end+1]
+}
+
 func toCamelCase(name string) string {
 	if name == "" {
 		return ""
@@ -123,13 +210,56 @@ func parseTestFunctions(source string, prefixes []string) []string {
 	return tests
 }
 
-func parseFeatureFlags(source string) []formatter.FeatureFlagInfo {
+// extractFunctionSource returns the source of the top-level function called name, doc comment
+// included, along with its 1-based start and end lines.
+func extractFunctionSource(source, name string) (string, int, int, bool) {
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, "", source, parser.ParseComments)
 	if err != nil {
-		return nil
+		return "", 0, 0, false
+	}
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name == nil || fn.Recv != nil || fn.Name.Name != name {
+			continue
+		}
+		start := fn.Pos()
+		if fn.Doc != nil {
+			start = fn.Doc.Pos()
+		}
+		startPos := fset.Position(start)
+		endPos := fset.Position(fn.End())
+		return source[startPos.Offset:endPos.Offset], startPos.Line, endPos.Line, true
+	}
+	return "", 0, 0, false
+}
+
+// featureRootTypes are the struct types that model the provider's nested features block, in
+// order of preference.
+var featureRootTypes = []string{"UserFeatures", "Features"}
+
+// parseFeatureFlags extracts feature flags from the given Go sources: entries of a flat
+// `var Features = map[string]...` table, and the boolean fields of the nested features block
+// struct (e.g. UserFeatures.KeyVault.PurgeSoftDeleteOnDestroy), with defaults taken from the
+// function returning that struct. Sources that fail to parse are skipped.
+func parseFeatureFlags(sources ...string) []formatter.FeatureFlagInfo {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, source := range sources {
+		if file, err := parser.ParseFile(fset, "", source, parser.ParseComments); err == nil {
+			files = append(files, file)
+		}
+	}
+
+	var infos []formatter.FeatureFlagInfo
+	for _, file := range files {
+		infos = append(infos, parseFeatureFlagTable(fset, file)...)
 	}
+	return append(infos, parseFeatureBlocks(fset, files)...)
+}
 
+func parseFeatureFlagTable(fset *token.FileSet, file *ast.File) []formatter.FeatureFlagInfo {
 	var infos []formatter.FeatureFlagInfo
 	for _, decl := range file.Decls {
 		gen, ok := decl.(*ast.GenDecl)
@@ -187,6 +317,111 @@ func parseFeatureFlags(source string) []formatter.FeatureFlagInfo {
 	return infos
 }
 
+// parseFeatureBlocks walks the features block struct: struct-typed fields become blocks, named
+// in snake_case as in Terraform configuration, and their boolean fields become flags.
+func parseFeatureBlocks(fset *token.FileSet, files []*ast.File) []formatter.FeatureFlagInfo {
+	structs := make(map[string]*ast.StructType)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+
+	for _, root := range featureRootTypes {
+		if st, ok := structs[root]; ok {
+			var infos []formatter.FeatureFlagInfo
+			collectFeatureBlock(fset, structs, st, "", featureDefaults(files, root), &infos)
+			return infos
+		}
+	}
+	return nil
+}
+
+func collectFeatureBlock(fset *token.FileSet, structs map[string]*ast.StructType, st *ast.StructType, block string, defaults *ast.CompositeLit, infos *[]formatter.FeatureFlagInfo) {
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			value := compositeField(defaults, name.Name)
+			typeName := identName(field.Type)
+			if nested, ok := structs[typeName]; ok {
+				child := featureFieldName(name.Name)
+				if block != "" {
+					child = block + "." + child
+				}
+				collectFeatureBlock(fset, structs, nested, child, compositeLiteral(value), infos)
+				continue
+			}
+			if typeName != "bool" || block == "" {
+				continue
+			}
+
+			info := formatter.FeatureFlagInfo{
+				Block:       block,
+				Key:         featureFieldName(name.Name),
+				Description: strings.TrimSpace(field.Doc.Text() + field.Comment.Text()),
+			}
+			if value != nil {
+				info.Default = exprString(fset, value)
+			}
+			*infos = append(*infos, info)
+		}
+	}
+}
+
+// featureDefaults returns the composite literal of typeName returned by a function such as
+// features.Default(), or nil when no function builds one.
+func featureDefaults(files []*ast.File, typeName string) *ast.CompositeLit {
+	var found *ast.CompositeLit
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			ret, ok := n.(*ast.ReturnStmt)
+			if !ok || found != nil || len(ret.Results) != 1 {
+				return found == nil
+			}
+			if lit := compositeLiteral(ret.Results[0]); lit != nil && identName(lit.Type) == typeName {
+				found = lit
+			}
+			return found == nil
+		})
+	}
+	return found
+}
+
+// compositeField returns the value assigned to field in lit, or nil when it is not set.
+func compositeField(lit *ast.CompositeLit, field string) ast.Expr {
+	if lit == nil {
+		return nil
+	}
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok && identName(kv.Key) == field {
+			return kv.Value
+		}
+	}
+	return nil
+}
+
+// featureFieldName converts a Go field name such as "PurgeSoftDeleteOnDestroy" or "HCICluster"
+// into its configuration name ("purge_soft_delete_on_destroy", "hci_cluster").
+func featureFieldName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
 func parseResourceBehaviors(schemaSnippet string) formatter.ResourceBehaviorInfo {
 	info := formatter.ResourceBehaviorInfo{}
 	if strings.TrimSpace(schemaSnippet) == "" {
@@ -326,3 +561,103 @@ func stringList(expr ast.Expr, fset *token.FileSet) []string {
 	}
 	return items
 }
+
+// resourceNotFound reports an unknown resource, suggesting the closest indexed names when there are any.
+func (s *Server) resourceNotFound(name string) map[string]any {
+	msg := fmt.Sprintf("Resource '%s' not found", name)
+	resources, err := s.db.ListProviderResources("", 0)
+	if err != nil || len(resources) == 0 {
+		return ErrorResponse(msg)
+	}
+
+	names := make([]string, 0, len(resources))
+	for _, r := range resources {
+		names = append(names, r.Name)
+	}
+	if suggestions := closestResourceNames(name, s.providerPrefix(), names, 5); len(suggestions) > 0 {
+		msg += ". Did you mean: " + strings.Join(suggestions, ", ") + "?"
+	}
+	return ErrorResponse(msg)
+}
+
+// closestResourceNames ranks names by edit distance between their prefix-less forms and the input,
+// keeping only reasonably close matches or names that contain the input outright.
+func closestResourceNames(input, prefix string, names []string, limit int) []string {
+	key := aliasKey(input, prefix)
+	if key == "" {
+		return nil
+	}
+
+	type candidate struct {
+		name  string
+		score int
+	}
+	seen := make(map[string]bool)
+	var candidates []candidate
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		suffix := name
+		if idx := strings.Index(name, "_"); idx >= 0 {
+			suffix = name[idx+1:]
+		}
+		score := levenshtein(key, suffix)
+		if strings.Contains(suffix, key) {
+			score = min(score, len(suffix)-len(key))
+		}
+		if score > max(2, len(key)/3) && !strings.Contains(suffix, key) {
+			continue
+		}
+		candidates = append(candidates, candidate{name: name, score: score})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score < candidates[j].score
+		}
+		return candidates[i].name < candidates[j].name
+	})
+
+	var out []string
+	for _, c := range candidates {
+		if len(out) >= limit {
+			break
+		}
+		out = append(out, c.name)
+	}
+	return out
+}
+
+// aliasKey lowercases input, drops a leading provider prefix and folds spaces and hyphens into underscores.
+func aliasKey(input, prefix string) string {
+	fields := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-' || r == '\t'
+	})
+	if len(fields) > 1 && fields[0] == strings.TrimSuffix(prefix, "_") {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, "_")
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+// nestedSchemaTree converts top-level attributes into a tree that includes stored nested block children.
+func nestedSchemaTree(attrs []database.ProviderAttribute) []database.NestedAttribute {
+	tree := make([]database.NestedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		tree = append(tree, database.NestedAttributeFromProvider(attr))
+	}
+	return tree
+}
+
+func nestedAttributeNames(level []database.NestedAttribute) []string {
+	names := make([]string, 0, len(level))
+	for _, attr := range level {
+		names = append(names, attr.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolveAttributePath walks a dotted path (e.g. site_config.0.application_stack.0.docker_image)
+// through the schema tree, stopping at the first segment that cannot be resolved.
+func resolveAttributePath(tree []database.NestedAttribute, path string) formatter.AttributePathResolution {
+	result := formatter.AttributePathResolution{Path: path, Valid: true}
+
+	level := tree
+	var current *database.NestedAttribute
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		segment = strings.TrimSpace(segment)
+
+		if _, err := strconv.Atoi(segment); err == nil {
+			if current == nil || !current.NestedBlock {
+				return failPathSegment(result, i, segment, "index segments are only valid after a nested block", nestedAttributeNames(level))
+			}
+			result.Resolved = append(result.Resolved, segment)
+			continue
+		}
+
+		if current != nil {
+			if !current.NestedBlock {
+				return failPathSegment(result, i, segment, "'"+current.Name+"' is not a nested block", nil)
+			}
+			if len(current.Children) == 0 {
+				return failPathSegment(result, i, segment, "nested schema for '"+current.Name+"' is not available (built by a helper function)", nil)
+			}
+		}
+
+		var next *database.NestedAttribute
+		for idx := range level {
+			if level[idx].Name == segment {
+				next = &level[idx]
+				break
+			}
+		}
+		if next == nil {
+			return failPathSegment(result, i, segment, "attribute not found at this level", nestedAttributeNames(level))
+		}
+
+		current = next
+		level = next.Children
+		result.Resolved = append(result.Resolved, segment)
+	}
+
+	result.Leaf = current
+	return result
+}
+
+func failPathSegment(result formatter.AttributePathResolution, index int, segment, reason string, alternatives []string) formatter.AttributePathResolution {
+	result.Valid = false
+	result.FailedIndex = index
+	result.FailedSegment = segment
+	result.Reason = reason
+	result.Alternatives = alternatives
+	return result
+}
@@ -10,9 +10,12 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"path"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dkooll/aztfmcp/internal/database"
@@ -41,32 +44,71 @@ type ToolCallParams struct {
 }
 
 type Syncer interface {
-	SyncAll() (*indexer.SyncProgress, error)
-	SyncUpdates() (*indexer.SyncProgress, error)
+	SyncAll(ctx context.Context) (*indexer.SyncProgress, error)
+	SyncUpdates(ctx context.Context) (*indexer.SyncProgress, error)
 	CompareTags(baseTag, headTag string) (*indexer.GitHubCompareResult, error)
+	ListTags() ([]indexer.GitHubTag, error)
+	ResourceSchemaAtRef(filePath, ref, resourceName string) ([]database.ProviderAttribute, error)
+	ClearCache() int
+	CacheStats() indexer.CacheStats
 }
 
 type Server struct {
-	db        *database.DB
-	syncer    Syncer
-	writer    io.Writer
-	jobs      map[string]*SyncJob
-	jobsMutex sync.RWMutex
-	dbPath    string
-	token     string
-	org       string
-	repo      string
-	dbMutex   sync.Mutex
-}
-
-func NewServer(dbPath, token, org, repo string) *Server {
-	return &Server{
+	db         *database.DB
+	syncer     Syncer
+	writer     io.Writer
+	writeMutex sync.Mutex
+	logLevel   atomic.Int32 // client log threshold rank+1; 0 until logging/setLevel
+	jobs       map[string]*SyncJob
+	jobsMutex  sync.RWMutex
+	runCtx     context.Context // cancelled when Run returns; parent of background sync jobs
+	jobRunners sync.WaitGroup  // running sync job goroutines, awaited at shutdown
+	closing    bool            // set at shutdown; no new sync jobs start afterwards
+	dbPath     string
+	token      string
+	org        string
+	repo       string
+	dbMutex    sync.Mutex
+
+	inflight    sync.WaitGroup
+	handlers    sync.WaitGroup // tool handler goroutines, including ones abandoned after a timeout
+	toolTimeout time.Duration
+
+	descMaxChars  int
+	githubBaseURL string
+	maxTagPages   int
+	workers       int
+	dbMode        database.Mode
+	ref           string
+	autoRepair    bool
+	cacheTTL      time.Duration
+	excludeTests  bool
+	style         formatter.Style
+	pollInterval  time.Duration
+	profile       bool
+
+	maxArchiveBytes int64
+	rateWait        time.Duration
+	serverName      string
+	serverVersion   string
+
+	attributeNames attributeNameIndex
+}
+
+func NewServer(dbPath, token, org, repo string, opts ...Option) *Server {
+	s := &Server{
 		dbPath: dbPath,
 		token:  token,
 		org:    org,
 		repo:   repo,
 		jobs:   make(map[string]*SyncJob),
+
+		toolTimeout: defaultToolTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *Server) repoShortName() string {
@@ -118,7 +160,7 @@ type SyncJob struct {
 	Status      string
 	StartedAt   time.Time
 	CompletedAt *time.Time
-	Progress    *indexer.SyncProgress
+	Progress    *indexer.SyncProgress // live counts while running, the final tally once completed
 	Error       string
 }
 
@@ -131,28 +173,100 @@ func (s *Server) ensureDB() error {
 	}
 
 	log.Printf("Initializing database at: %s", s.dbPath)
-	db, err := database.New(s.dbPath)
+	db, err := database.NewWithMode(s.dbPath, s.dbMode)
+	if errors.Is(err, database.ErrCorrupt) {
+		db, err = s.recoverCorruptDB(err)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 
 	s.db = db
-	s.syncer = indexer.NewSyncer(db, s.token, s.org, s.repo)
+	syncer := indexer.NewSyncer(db, s.token, s.org, s.repo)
+	syncer.SetGitHubBaseURL(s.githubBaseURL)
+	syncer.SetMaxTagPages(s.maxTagPages)
+	syncer.SetWorkerCount(s.workers)
+	syncer.SetRef(s.ref)
+	syncer.SetCacheTTL(s.cacheTTL)
+	syncer.SetIncludeTests(!s.excludeTests)
+	syncer.SetProfile(s.profile)
+	syncer.SetMaxArchiveBytes(s.maxArchiveBytes)
+	syncer.SetRateWait(s.rateWait)
+	s.syncer = syncer
 	log.Println("Database initialized successfully")
 
 	return nil
 }
 
+// recoverCorruptDB handles an index that failed to open as corrupt. With auto-repair enabled (and
+// a writable index) the bad file is moved aside and a fresh database is created; otherwise the
+// error explains how to rebuild it.
+func (s *Server) recoverCorruptDB(openErr error) (*database.DB, error) {
+	if !s.autoRepair || s.dbMode == database.ModeReadOnly {
+		return nil, fmt.Errorf("%w. Delete %s and run sync_provider to rebuild the index, or restart with --auto-repair to move it aside automatically", openErr, s.dbPath)
+	}
+
+	backup, err := database.MoveAside(s.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w; auto-repair failed: %w", openErr, err)
+	}
+	log.Printf("Warning: database %s was corrupt; moved it to %s and created a new index (run sync_provider to repopulate)", s.dbPath, backup)
+	return database.NewWithMode(s.dbPath, s.dbMode)
+}
+
 func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.writeMutex.Lock()
 	s.writer = w
-	scanner := bufio.NewScanner(r)
+	s.writeMutex.Unlock()
+
+	prevLogOutput := log.Writer()
+	log.SetOutput(&clientLogWriter{server: s, next: prevLogOutput})
+	defer log.SetOutput(prevLogOutput)
+
+	// Registered first so it runs last: sync jobs have seen the cancelled context by then.
+	defer s.shutdown()
+
+	// Cancelling on return stops background syncs and in-flight GitHub downloads at shutdown.
+	// It is deferred before the inflight wait so pending tool calls finish with a live context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.jobsMutex.Lock()
+	s.runCtx = ctx
+	s.jobsMutex.Unlock()
+	defer s.inflight.Wait()
 
-	for scanner.Scan() {
-		if err := ctx.Err(); err != nil {
-			return err
+	// Lines are read off the loop so a cancelled context stops the server even while stdin is idle.
+	lines := make(chan string)
+	scanErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	for {
+		var raw string
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case text, ok := <-lines:
+			if !ok {
+				if err := <-scanErr; err != nil {
+					return fmt.Errorf("scanner error: %w", err)
+				}
+				return nil
+			}
+			raw = text
 		}
 
-		line := strings.TrimSpace(scanner.Text())
+		line := strings.TrimSpace(raw)
 		if line == "" {
 			continue
 		}
@@ -161,19 +275,57 @@ func (s *Server) Run(ctx context.Context, r io.Reader, w io.Writer) error {
 
 		var msg Message
 		if err := json.Unmarshal([]byte(line), &msg); err != nil {
-			log.Printf("Failed to parse message: %v", err)
+			log.Printf("Error: failed to parse message: %v", err)
 			s.sendError(-32700, "Parse error", nil)
 			continue
 		}
 
+		// Tool calls run off the read loop so a slow handler cannot block other requests.
+		if msg.Method == "tools/call" {
+			s.inflight.Go(func() {
+				s.handleToolsCall(ctx, msg)
+			})
+			continue
+		}
+
 		s.handleMessage(msg)
 	}
+}
+
+// shutdownGracePeriod bounds how long shutdown waits for cancelled sync jobs and tool handlers
+// before closing the database.
+const shutdownGracePeriod = 10 * time.Second
 
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("scanner error: %w", err)
+// shutdown stops new sync jobs from starting, gives running ones and tool handlers that outlived
+// their timeout shutdownGracePeriod to return after their context was cancelled, and then closes
+// the database so its WAL is checkpointed. The db and syncer fields stay set: handlers read them
+// without dbMutex, so a handler still running past the grace period gets "database is closed"
+// errors rather than a racing nil pointer.
+func (s *Server) shutdown() {
+	s.jobsMutex.Lock()
+	s.closing = true
+	s.jobsMutex.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.jobRunners.Wait()
+		s.handlers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(shutdownGracePeriod):
+		log.Printf("Sync jobs or tool handlers still running after %s; closing the database anyway", shutdownGracePeriod)
 	}
 
-	return nil
+	s.dbMutex.Lock()
+	defer s.dbMutex.Unlock()
+	if s.db == nil {
+		return
+	}
+	if err := s.db.Close(); err != nil {
+		log.Printf("Error: failed to close database: %v", err)
+	}
 }
 
 func (s *Server) handleMessage(msg Message) {
@@ -188,7 +340,9 @@ func (s *Server) handleMessage(msg Message) {
 	case "tools/list":
 		s.handleToolsList(msg)
 	case "tools/call":
-		s.handleToolsCall(msg)
+		s.handleToolsCall(context.Background(), msg)
+	case "logging/setLevel":
+		s.handleSetLogLevel(msg)
 	case "notifications/cancelled":
 		log.Println("Request cancelled")
 		return
@@ -197,18 +351,47 @@ func (s *Server) handleMessage(msg Message) {
 	}
 }
 
+// Default serverInfo reported by initialize; see WithServerInfo.
+const (
+	DefaultServerName    = "az-cn-azurerm"
+	DefaultServerVersion = "1.0.0"
+)
+
+// supportedProtocolVersions lists the MCP revisions this server speaks, newest first. 2025-03-26
+// is left out because it requires accepting JSON-RPC batches, which the server does not.
+var supportedProtocolVersions = []string{"2025-06-18", "2024-11-05"}
+
+// negotiateProtocolVersion echoes the client's requested revision when it is supported and
+// otherwise offers the newest supported one, leaving the client to disconnect if it cannot use it.
+func negotiateProtocolVersion(requested string) string {
+	if slices.Contains(supportedProtocolVersions, requested) {
+		return requested
+	}
+	return supportedProtocolVersions[0]
+}
+
 func (s *Server) handleInitialize(msg Message) {
+	params, err := UnmarshalArgs[struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}](msg.Params)
+	if err != nil {
+		log.Printf("Ignoring malformed initialize params: %v", err)
+	}
+	version := negotiateProtocolVersion(strings.TrimSpace(params.ProtocolVersion))
+	log.Printf("Negotiated protocol version %s (client requested %q)", version, params.ProtocolVersion)
+
 	response := Message{
 		JSONRPC: "2.0",
 		ID:      msg.ID,
 		Result: map[string]any{
-			"protocolVersion": "2024-11-05",
+			"protocolVersion": version,
 			"serverInfo": map[string]any{
-				"name":    "az-cn-azurerm",
-				"version": "1.0.0",
+				"name":    ifEmpty(s.serverName, DefaultServerName),
+				"version": ifEmpty(s.serverVersion, DefaultServerVersion),
 			},
 			"capabilities": map[string]any{
-				"tools": map[string]any{},
+				"tools":   map[string]any{},
+				"logging": map[string]any{},
 			},
 		},
 	}
@@ -235,7 +418,7 @@ func (s *Server) handleToolsList(msg Message) {
 		},
 		{
 			"name":        "sync_status",
-			"description": "Show status for running or completed sync jobs",
+			"description": "Show status for running or completed sync jobs, including live progress and when to check a running job again",
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -305,19 +488,45 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "string",
 						"description": "Target version (e.g. 4.48.0 or v4.48.0)",
 					},
+					"force": map[string]any{
+						"type":        "boolean",
+						"description": "Replace a release already stored by a full sync, discarding its comparison URL and commit metadata (default false)",
+					},
 				},
 				"required": []string{"version"},
 			},
 		},
+		{
+			"name":        "backfill_releases",
+			"description": "Parse and store every CHANGELOG release from a given version up to the newest, filling release history for summaries and breaking-change scans in one call",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"since": map[string]any{
+						"type":        "string",
+						"description": "Oldest version to backfill (e.g. 4.30.0 or v4.30.0)",
+					},
+					"force": map[string]any{
+						"type":        "boolean",
+						"description": "Replace releases already stored by a full sync, discarding their comparison URLs and commit metadata (default false)",
+					},
+				},
+				"required": []string{"since"},
+			},
+		},
 		{
 			"name":        "list_resources",
-			"description": "List parsed AzureRM resources and data sources (from Go schemas)",
+			"description": "List parsed AzureRM resources, data sources, actions, list resources and ephemeral resources (from Go schemas)",
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
 					"kind": map[string]any{
 						"type":        "string",
-						"description": "Optional filter: resource | data_source",
+						"description": "Optional filter: resource | data_source | action | list | ephemeral",
+					},
+					"category": map[string]any{
+						"type":        "string",
+						"description": "Optional Terraform registry website category of the resource's service (e.g., Container, Database)",
 					},
 					"compact": map[string]any{
 						"type":        "boolean",
@@ -338,7 +547,7 @@ func (s *Server) handleToolsList(msg Message) {
 				"properties": map[string]any{
 					"query": map[string]any{
 						"type":        "string",
-						"description": "Search query (supports boolean operators)",
+						"description": "Search query; uppercase AND, OR, NOT and parentheses combine terms (e.g., network OR subnet)",
 					},
 					"compact": map[string]any{
 						"type":        "boolean",
@@ -348,6 +557,14 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "number",
 						"description": "Optional result cap (default 10)",
 					},
+					"count_only": map[string]any{
+						"type":        "boolean",
+						"description": "Return only the number of matching resources",
+					},
+					"kind": map[string]any{
+						"type":        "string",
+						"description": "Optional filter: resource | data_source | action | list | ephemeral",
+					},
 				},
 				"required": []string{"query"},
 			},
@@ -360,7 +577,7 @@ func (s *Server) handleToolsList(msg Message) {
 				"properties": map[string]any{
 					"name": map[string]any{
 						"type":        "string",
-						"description": "Resource or data source name (e.g., azurerm_virtual_network)",
+						"description": "Resource or data source name (e.g., azurerm_virtual_network); display names such as 'Virtual Network' also resolve",
 					},
 					"attributes": map[string]any{
 						"type":        "array",
@@ -380,6 +597,10 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "boolean",
 						"description": "Only include nested block definitions",
 					},
+					"single_blocks_only": map[string]any{
+						"type":        "boolean",
+						"description": "Only include MaxItems: 1 nested blocks, which are written as a single object rather than a repeatable list",
+					},
 					"max_rows": map[string]any{
 						"type":        "number",
 						"description": "Limit the number of attributes returned (default 50, use -1 for all)",
@@ -388,6 +609,22 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "boolean",
 						"description": "Emit a compact bullet list instead of the full table",
 					},
+					"desc_max_chars": map[string]any{
+						"type":        "number",
+						"description": "Truncate descriptions to this many characters (default: server setting, -1 for full text)",
+					},
+					"version": map[string]any{
+						"type":        "string",
+						"description": "Show the schema snapshot recorded for this release (e.g., 4.52.0) instead of the current one",
+					},
+					"section": map[string]any{
+						"type":        "string",
+						"description": "Which attributes to show: 'arguments' (required/optional, for writing config), 'attributes' (computed-only outputs) or 'all' (default)",
+					},
+					"compare_with": map[string]any{
+						"type":        "string",
+						"description": "Instead of the schema, diff against the same-named 'data_source' (for a resource) or 'resource' (for a data source): attributes on one side only and flag differences",
+					},
 				},
 				"required": []string{"name"},
 			},
@@ -413,6 +650,13 @@ func (s *Server) handleToolsList(msg Message) {
 							"type": "string",
 						},
 					},
+					"exclude_flags": map[string]any{
+						"type":        "array",
+						"description": "Attributes must include none of the listed flags, e.g. flags=[optional] with exclude_flags=[computed] for optional but not computed",
+						"items": map[string]any{
+							"type": "string",
+						},
+					},
 					"conflicts_with": map[string]any{
 						"type":        "string",
 						"description": "Only show attributes that conflict with this name",
@@ -429,6 +673,14 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "number",
 						"description": "Maximum number of matches (default 20)",
 					},
+					"desc_max_chars": map[string]any{
+						"type":        "number",
+						"description": "Truncate descriptions to this many characters (default: server setting, -1 for full text)",
+					},
+					"count_only": map[string]any{
+						"type":        "boolean",
+						"description": "Return only the number of matching attributes",
+					},
 				},
 			},
 		},
@@ -444,7 +696,7 @@ func (s *Server) handleToolsList(msg Message) {
 					},
 					"section": map[string]any{
 						"type":        "string",
-						"description": "Snippet to return: schema | function (default schema)",
+						"description": "Snippet to return: schema | function | attributes (default schema). attributes lists each top-level schema field with its source line",
 					},
 					"max_lines": map[string]any{
 						"type":        "number",
@@ -482,6 +734,10 @@ func (s *Server) handleToolsList(msg Message) {
 			"inputSchema": map[string]any{
 				"type": "object",
 				"properties": map[string]any{
+					"repository": map[string]any{
+						"type":        "string",
+						"description": "Indexed repository to read from (defaults to the configured provider repository)",
+					},
 					"file_path": map[string]any{
 						"type":        "string",
 						"description": "Relative path (e.g., internal/services/network/virtual_network_resource.go)",
@@ -494,10 +750,22 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "number",
 						"description": "Optional ending line number (inclusive, 0 for default window, -1 for full file)",
 					},
+					"page": map[string]any{
+						"type":        "number",
+						"description": "Optional 1-based page to return instead of a start_line/end_line window",
+					},
+					"page_size": map[string]any{
+						"type":        "number",
+						"description": "Lines per page when page is set (default 200, at most 2000 unless force is set)",
+					},
 					"summary": map[string]any{
 						"type":        "boolean",
 						"description": "Only return file metadata and line window info, omit content",
 					},
+					"force": map[string]any{
+						"type":        "boolean",
+						"description": "Return open-ended windows and pages longer than 2000 lines instead of rejecting or capping them",
+					},
 				},
 				"required": []string{"file_path"},
 			},
@@ -534,9 +802,27 @@ func (s *Server) handleToolsList(msg Message) {
 				"required": []string{"name"},
 			},
 		},
+		{
+			"name":        "get_resource_test",
+			"description": "Return the full source of an acceptance test covering a provider resource or data source",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_virtual_network)",
+					},
+					"test_name": map[string]any{
+						"type":        "string",
+						"description": "Test function name as reported by list_resource_tests (e.g., TestAccAzureRMVirtualNetwork_basic)",
+					},
+				},
+				"required": []string{"resource_name", "test_name"},
+			},
+		},
 		{
 			"name":        "list_feature_flags",
-			"description": "Enumerate provider feature flags defined in internal/features/config/features.go",
+			"description": "Enumerate provider feature flags: the flag table in internal/features/config/features.go and the nested features {} block with its sub-blocks (e.g. key_vault, virtual_machine), boolean settings and defaults",
 			"inputSchema": map[string]any{
 				"type":       "object",
 				"properties": map[string]any{},
@@ -560,6 +846,10 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "number",
 						"description": "Maximum number of matches (default 20)",
 					},
+					"desc_max_chars": map[string]any{
+						"type":        "number",
+						"description": "Truncate descriptions to this many characters (default: server setting, -1 for full text)",
+					},
 				},
 			},
 		},
@@ -627,6 +917,10 @@ func (s *Server) handleToolsList(msg Message) {
 						"type":        "number",
 						"description": "Maximum attribute names to list per section (default 30, use -1 for all)",
 					},
+					"only_differences": map[string]any{
+						"type":        "boolean",
+						"description": "Omit the shared attribute list and report only attributes unique to either side plus shared attributes whose type, mode or ForceNew flag differs",
+					},
 				},
 				"required": []string{"resource_a", "resource_b"},
 			},
@@ -647,7 +941,7 @@ func (s *Server) handleToolsList(msg Message) {
 					},
 					"limit": map[string]any{
 						"type":        "number",
-						"description": "Maximum number of results (default 5)",
+						"description": "Maximum number of results (default 5, at most 50)",
 					},
 				},
 				"required": []string{"resource_name"},
@@ -703,19 +997,953 @@ func (s *Server) handleToolsList(msg Message) {
 				"required": []string{"resource_name", "attribute_name"},
 			},
 		},
-	}
-
-	response := Message{
-		JSONRPC: "2.0",
-		ID:      msg.ID,
-		Result: map[string]any{
-			"tools": tools,
+		{
+			"name":        "validation_categories",
+			"description": "Group attributes by validation category (string length, regex, enum, numeric range, CIDR/IP, UUID) with counts and examples",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Limit the analysis to a single resource",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Limit the analysis to resources with this prefix (e.g., azurerm_storage_)",
+					},
+					"examples": map[string]any{
+						"type":        "number",
+						"description": "Maximum example attributes per category (default 5)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_allowed_values",
+			"description": "List the allowed values of an attribute validated with StringInSlice (e.g., sku or tier enums)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource name (e.g., azurerm_storage_account)",
+					},
+					"attribute_name": map[string]any{
+						"type":        "string",
+						"description": "Attribute name (e.g., account_tier)",
+					},
+				},
+				"required": []string{"resource_name", "attribute_name"},
+			},
+		},
+		{
+			"name":        "validate_attribute_path",
+			"description": "Validate a dotted attribute path against the nested schema and report the first segment that fails to resolve",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource name (e.g., azurerm_linux_web_app)",
+					},
+					"path": map[string]any{
+						"type":        "string",
+						"description": "Dotted attribute path (e.g., site_config.0.application_stack.0.docker_image_name)",
+					},
+				},
+				"required": []string{"resource_name", "path"},
+			},
+		},
+		{
+			"name":        "get_resources_schema",
+			"description": "Fetch schemas for several resources/data sources in one call (up to 20 names)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"names": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Resource or data source names (e.g., [\"azurerm_linux_virtual_machine\", \"azurerm_windows_virtual_machine\"])",
+					},
+					"compact": map[string]any{
+						"type":        "boolean",
+						"description": "Emit compact bullet lists instead of full tables",
+					},
+					"max_rows": map[string]any{
+						"type":        "number",
+						"description": "Limit attributes per resource (default 50, use -1 for all)",
+					},
+					"desc_max_chars": map[string]any{
+						"type":        "number",
+						"description": "Truncate descriptions to this many characters (default: server setting, -1 for full text)",
+					},
+				},
+				"required": []string{"names"},
+			},
+		},
+		{
+			"name":        "find_attributes_with_value",
+			"description": "Find attributes whose StringInSlice enum accepts a given value (e.g., Premium)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"value": map[string]any{
+						"type":        "string",
+						"description": "Enum value to look for (exact match unless the validation ignores case)",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix filter (e.g., azurerm_storage_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of matches (default 20)",
+					},
+				},
+				"required": []string{"value"},
+			},
+		},
+		{
+			"name":        "provider_overview",
+			"description": "Summarize provider registrations: typed vs untyped counts, services, definitions whose schema could not be resolved, and Go files that failed to parse in the last sync",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum unresolved definitions to list (default 25, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_attribute",
+			"description": "Show a single attribute with its full description, flags, and validation",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+					"attribute_name": map[string]any{
+						"type":        "string",
+						"description": "Attribute name or dotted nested path (e.g., network_rules.0.bypass)",
+					},
+				},
+				"required": []string{"resource_name", "attribute_name"},
+			},
+		},
+		{
+			"name":        "find_global_resources",
+			"description": "Find resources without a required location attribute (heuristic for global or subscription-scoped resources)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix filter (e.g., azurerm_dns_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum resources listed per section (default 50, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "list_resource_lifecycle",
+			"description": "Show the provider version each resource/data source was added in and, if applicable, removed in (from the changelog)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix filter (e.g., azurerm_app_service)",
+					},
+					"removed_only": map[string]any{
+						"type":        "boolean",
+						"description": "Only list definitions that have been removed",
+					},
+				},
+			},
 		},
+		{
+			"name":        "generate_docs_stub",
+			"description": "Generate a Markdown docs skeleton (Arguments and Attributes Reference) from a resource's parsed schema",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+		{
+			"name":        "list_tags",
+			"description": "List the most recent repository tags with their commit SHAs (valid inputs for release diffing)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of tags to return (default 20, use -1 for all fetched tags)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "check_description_style",
+			"description": "Lint attribute descriptions against provider conventions (capitalised, ends with a period, lists enum values)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Check a single resource or data source (e.g., azurerm_storage_account)",
+					},
+					"service": map[string]any{
+						"type":        "string",
+						"description": "Check every resource registered by a service (e.g., Storage)",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Check resources starting with this prefix (e.g., azurerm_storage_)",
+					},
+					"include_missing": map[string]any{
+						"type":        "boolean",
+						"description": "Also report attributes without any description",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum violations listed (default 50, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "diff_file",
+			"description": "Show the GitHub diff for a single file between two tags",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"file_path": map[string]any{
+						"type":        "string",
+						"description": "Repository-relative file path (e.g., internal/services/storage/storage_account_resource.go)",
+					},
+					"base_tag": map[string]any{
+						"type":        "string",
+						"description": "Older tag (e.g., v4.51.0)",
+					},
+					"head_tag": map[string]any{
+						"type":        "string",
+						"description": "Newer tag (e.g., v4.52.0)",
+					},
+					"max_lines": map[string]any{
+						"type":        "number",
+						"description": "Maximum diff lines to return (default 200, use -1 for all)",
+					},
+				},
+				"required": []string{"file_path", "base_tag", "head_tag"},
+			},
+		},
+		{
+			"name":        "recently_added_properties",
+			"description": "List resource attributes added in recent releases, parsed from \"support for the `x` property\" changelog entries",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"releases": map[string]any{
+						"type":        "number",
+						"description": "Number of most recent releases to scan (default 5, use -1 for all indexed releases)",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Only include resources starting with this prefix (e.g., azurerm_kubernetes_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum additions listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "attribute_usage",
+			"description": "Count how many resources and data sources declare a top-level attribute name (e.g., tags, identity)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Attribute name, matched as a case-insensitive substring unless exact is set",
+					},
+					"exact": map[string]any{
+						"type":        "boolean",
+						"description": "Match the attribute name exactly",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum attribute names listed (default 20, use -1 for all)",
+					},
+					"include_resources": map[string]any{
+						"type":        "boolean",
+						"description": "List the definitions declaring each attribute",
+					},
+					"resource_limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum definitions listed per attribute when include_resources is set (default 10, use -1 for all)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+		{
+			"name":        "scan_breaking_changes",
+			"description": "Diff the schemas of resources mentioned in the changelog between two versions and report attributes that became ForceNew, lost Optional, were removed, or were added as required",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from_version": map[string]any{
+						"type":        "string",
+						"description": "Version currently in use (e.g., 4.40.0)",
+					},
+					"to_version": map[string]any{
+						"type":        "string",
+						"description": "Version being upgraded to (e.g., 4.52.0)",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Only scan resources starting with this prefix (e.g., azurerm_kubernetes_)",
+					},
+					"max_resources": map[string]any{
+						"type":        "number",
+						"description": "Maximum resources fetched and diffed (default 25, use -1 for all); each costs two GitHub requests",
+					},
+				},
+				"required": []string{"from_version", "to_version"},
+			},
+		},
+		{
+			"name":        "attribute_introduced_in",
+			"description": "Find the earliest release an attribute appeared in, using recorded schema snapshots and falling back to changelog entries",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_kubernetes_cluster)",
+					},
+					"attribute_name": map[string]any{
+						"type":        "string",
+						"description": "Attribute name or dotted path (e.g., default_node_pool.0.zones)",
+					},
+				},
+				"required": []string{"resource_name", "attribute_name"},
+			},
+		},
+		{
+			"name":        "find_unvalidated_attributes",
+			"description": "List configurable string and integer attributes that have no ValidateFunc, for validation audits across the provider or a service",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Only include resources starting with this prefix (e.g., azurerm_storage_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum attributes listed (default 50, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_source_url",
+			"description": "Return a GitHub link to the file and function implementing a resource or data source, pinned to --ref when set",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_virtual_network)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "find_resources_with_custom_diff",
+			"description": "List resources whose schema declares a CustomizeDiff function (plan-time logic worth reviewing during upgrades)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix filter (e.g., azurerm_kubernetes_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum resources listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "find_non_importable_resources",
+			"description": "List resources that declare no Importer and therefore cannot be imported into Terraform state",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix filter (e.g., azurerm_monitor_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum resources listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "clear_github_cache",
+			"description": "Drop cached GitHub API responses so the next sync or lookup fetches fresh data (cache size and age are shown by provider_overview)",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		{
+			"name":        "get_parse_cache_status",
+			"description": "Report parse cache totals (files, resources, attributes, last parse time) and cached files whose content hash no longer matches the index; useful when a resource is missing after a sync",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum stale files listed (default 50, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_deprecations",
+			"description": "List deprecated resources/data sources and deprecated attributes with their replacement guidance, as a migration checklist for provider upgrades",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Optional resource or data source to inspect (e.g., azurerm_kubernetes_cluster)",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix when resource_name is not given (e.g., azurerm_storage_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum entries listed per section (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "explain_attribute",
+			"description": "Explain one attribute in a single view: schema flags, type and validation, constraints such as allowed values and defaults, and the matching paragraph from the resource documentation",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+					"attribute_name": map[string]any{
+						"type":        "string",
+						"description": "Attribute name or dotted path to a nested attribute (e.g., network_rules.default_action)",
+					},
+				},
+				"required": []string{"resource_name", "attribute_name"},
+			},
+		},
+		{
+			"name":        "list_unresolved_resources",
+			"description": "List resources and data sources that are registered but have no parsed schema attributes, with the reason (typed resource, missing function, parse error) to expose coverage gaps",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix (e.g., azurerm_network_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum definitions listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_registration_info",
+			"description": "Show the service registration behind a resource: service name, website categories and the associated GitHub label, with links for finding or filing issues",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "locate_resource",
+			"description": "Show where a resource lives: its implementation file and function, the service registration file that lists it, and its documentation page",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "get_attribute_tree",
+			"description": "Render a resource's full schema as an indented outline of nested blocks and their attributes, e.g. site_config/ application_stack/ docker_image (Optional, String)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_linux_web_app)",
+					},
+					"max_depth": map[string]any{
+						"type":        "number",
+						"description": "Deepest block level expanded; deeper blocks are collapsed to a child count (default 6, use -1 for no limit)",
+					},
+					"max_lines": map[string]any{
+						"type":        "number",
+						"description": "Maximum outline lines returned (default 400, use -1 for all)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "get_release_file_stats",
+			"description": "Summarize which areas of the provider a release touched: added, modified, removed and renamed file counts grouped by service directory, compared against the previous tag",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"version": map[string]any{
+						"type":        "string",
+						"description": "Release version or tag (e.g., 4.52.0 or v4.52.0)",
+					},
+				},
+				"required": []string{"version"},
+			},
+		},
+		{
+			"name":        "search_attribute_descriptions",
+			"description": "Find attributes by what they do: full-text search over attribute names and descriptions, ranked by relevance (e.g., 'minimum TLS version')",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Words describing the attribute; any word may match and rows matching more words rank higher. Quote phrases or use AND/OR/NOT for precise queries",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Only include resources starting with this prefix (e.g., azurerm_storage_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of matches (default 20, -1 for all)",
+					},
+					"desc_max_chars": map[string]any{
+						"type":        "number",
+						"description": "Truncate descriptions to this many characters (default: server setting, -1 for full text)",
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+		{
+			"name":        "get_indexed_version",
+			"description": "Report which provider version the index reflects: the latest indexed release, the synced ref and when the repository was synced",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		{
+			"name":        "lint_constraint_groups",
+			"description": "Check a resource's ExactlyOneOf, AtLeastOneOf and ConflictsWith declarations for consistency: unreciprocated or mismatched groups, references to attributes that do not exist and ConflictsWith entries overlapping an ExactlyOneOf group",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "find_resources_without_timeouts",
+			"description": "List resources and data sources whose schema declares no Timeouts block, so slow Azure operations fall back to the SDK default deadlines",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix (e.g., azurerm_network_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum definitions listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "validate_config",
+			"description": "Check an HCL resource or data block against the indexed schema: unknown arguments, missing required arguments, computed-only fields that cannot be set and arguments written as blocks (or the reverse). Values are not evaluated",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"hcl": map[string]any{
+						"type":        "string",
+						"description": "HCL to check: a resource or data block, or just its arguments when resource_name is given",
+					},
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account); optional when hcl holds a single resource block",
+					},
+				},
+				"required": []string{"hcl"},
+			},
+		},
+		{
+			"name":        "get_constraints_diagram",
+			"description": "Draw a resource's ConflictsWith, ExactlyOneOf and AtLeastOneOf relationships as a Mermaid flowchart that MCP clients can render",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_key_vault_key)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "search_resources_by_attribute_combination",
+			"description": "Find resources and data sources whose schema declares all of the given top-level attributes, e.g. both tags and an identity block",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"attributes": map[string]any{
+						"type":        "array",
+						"description": "Top-level attribute names that must all be present (1-10, e.g., [\"tags\", \"identity\"])",
+						"items": map[string]any{
+							"type": "string",
+						},
+					},
+					"kind": map[string]any{
+						"type":        "string",
+						"description": "Optional filter: resource | data_source | action | list | ephemeral",
+					},
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix (e.g., azurerm_network_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum definitions listed (default 50, at most 500)",
+					},
+				},
+				"required": []string{"attributes"},
+			},
+		},
+		{
+			"name":        "get_provider_readme",
+			"description": "Return the provider repository's README as stored at the last sync, for a provider-level overview",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"max_chars": map[string]any{
+						"type":        "number",
+						"description": "Maximum characters returned (default 8000, use -1 for the full README)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "list_doc_guides",
+			"description": "List the long-form guides under website/docs/guides/ (authentication, upgrade guides, feature flags) with their titles and descriptions",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		{
+			"name":        "find_shadowed_attributes",
+			"description": "Find attributes inside nested blocks that share a name with a top-level attribute of the same resource, showing where the two declarations behave differently",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g. azurerm_kubernetes_cluster)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "whats_new_for_resource",
+			"description": "Show what changed for a resource in the latest release that mentions it: the matching changelog entries and the diff of its source file",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g. azurerm_kubernetes_cluster)",
+					},
+					"max_context_lines": map[string]any{
+						"type":        "integer",
+						"description": "Optional limit for diff lines (default 24)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "get_attribute_conflicts_transitive",
+			"description": "Follow ConflictsWith, ExactlyOneOf, AtLeastOneOf and RequiredWith chains from one attribute and return every attribute they connect, with the constraint linking each pair",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g. azurerm_storage_account)",
+					},
+					"attribute_name": map[string]any{
+						"type":        "string",
+						"description": "Starting attribute; nested attributes use dotted paths (e.g. network_rules.default_action)",
+					},
+					"max_depth": map[string]any{
+						"type":        "integer",
+						"description": "Maximum hops to follow from the starting attribute (default 5, max 10)",
+					},
+				},
+				"required": []string{"resource_name", "attribute_name"},
+			},
+		},
+		{
+			"name":        "find_similar_attributes",
+			"description": "Find the equivalents of one resource's attribute in another resource, matching by exact name, then normalized name, then description similarity",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_a": map[string]any{
+						"type":        "string",
+						"description": "Resource that has the attribute (e.g., azurerm_storage_account)",
+					},
+					"attribute": map[string]any{
+						"type":        "string",
+						"description": "Attribute path on resource_a (e.g., public_network_access_enabled or network_rules.default_action)",
+					},
+					"resource_b": map[string]any{
+						"type":        "string",
+						"description": "Resource to search for equivalents",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of candidates (default 10, at most 50)",
+					},
+				},
+				"required": []string{"resource_a", "attribute", "resource_b"},
+			},
+		},
+		{
+			"name":        "get_provider_diff_summary",
+			"description": "Summarize what changes when upgrading the provider: new resources, removed resources, breaking changes and deprecations from the changelog entries between two versions",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"version": map[string]any{
+						"type":        "string",
+						"description": "Target version to upgrade to (e.g., 4.50.0). Without from_version, the summary covers this version up to the indexed release",
+					},
+					"from_version": map[string]any{
+						"type":        "string",
+						"description": "Version currently in use; when set, the summary covers from_version up to version",
+					},
+					"max_items": map[string]any{
+						"type":        "number",
+						"description": "Maximum entries listed per category (default 25, -1 for all)",
+					},
+				},
+				"required": []string{"version"},
+			},
+		},
+		{
+			"name":        "export_tf_schema",
+			"description": "Export a resource's parsed schema as a `terraform providers schema -json` document (block attributes, block_types, types and required/optional/computed flags)",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_storage_account)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+		{
+			"name":        "list_providers",
+			"description": "List the indexed provider repositories with resource and data source counts and when each was last synced",
+			"inputSchema": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		{
+			"name":        "find_deprecation_replacements",
+			"description": "Extract old->new migration mappings from deprecation messages of resources and attributes, e.g. renamed attributes or superseding resources",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix to scope the scan (e.g., azurerm_storage_)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum mappings listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "find_resources_missing_common_args",
+			"description": "Audit resources that lack standard arguments such as resource_group_name and location, noting whether each gap is likely a parse miss or an intentional child resource",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_prefix": map[string]any{
+						"type":        "string",
+						"description": "Optional resource name prefix (e.g., azurerm_network_)",
+					},
+					"kind": map[string]any{
+						"type":        "string",
+						"description": "Definition kind to audit: resource (default) or data_source",
+					},
+					"arguments": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Expected top-level arguments (default resource_group_name and location)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum definitions listed (default 100, use -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "list_directory",
+			"description": "List the indexed files under a directory with their sizes, without content, to navigate the repository before fetching files",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"repository": map[string]any{
+						"type":        "string",
+						"description": "Indexed repository to browse (defaults to the configured provider repository)",
+					},
+					"path_prefix": map[string]any{
+						"type":        "string",
+						"description": "Directory to list (e.g., internal/services/network); empty for the repository root",
+					},
+					"depth": map[string]any{
+						"type":        "number",
+						"description": "Directory levels to expand; deeper files are collapsed into their subdirectory (default 1, -1 for the whole subtree)",
+					},
+					"limit": map[string]any{
+						"type":        "number",
+						"description": "Maximum entries to return (default 200, -1 for all)",
+					},
+				},
+			},
+		},
+		{
+			"name":        "get_sdk_clients",
+			"description": "List the go-azure-sdk packages a resource's implementation file imports: service, API version and sub-resource, showing which Azure API it talks to",
+			"inputSchema": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"resource_name": map[string]any{
+						"type":        "string",
+						"description": "Resource or data source name (e.g., azurerm_linux_virtual_machine)",
+					},
+				},
+				"required": []string{"resource_name"},
+			},
+		},
+	}
+
+	response := Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+		Result: map[string]any{
+			"tools": tools,
+		},
+	}
+	s.sendResponse(response)
+}
+
+const defaultToolTimeout = 2 * time.Minute
+
+// longRunningToolTimeouts raises the deadline for tools that routinely outlast the default.
+var longRunningToolTimeouts = map[string]time.Duration{
+	"sync_updates_provider": 30 * time.Minute,
+	"scan_breaking_changes": 10 * time.Minute,
+}
+
+// writeTools modify the index and are rejected when it is opened read-only.
+var writeTools = map[string]bool{
+	"sync_provider":         true,
+	"sync_updates_provider": true,
+	"backfill_release":      true,
+	"backfill_releases":     true,
+}
+
+func (s *Server) timeoutFor(tool string) time.Duration {
+	timeout := s.toolTimeout
+	if timeout <= 0 {
+		timeout = defaultToolTimeout
 	}
-	s.sendResponse(response)
+	if override, ok := longRunningToolTimeouts[tool]; ok && override > timeout {
+		return override
+	}
+	return timeout
 }
 
-func (s *Server) handleToolsCall(msg Message) {
+func (s *Server) handleToolsCall(ctx context.Context, msg Message) {
 	paramsBytes, err := json.Marshal(msg.Params)
 	if err != nil {
 		s.sendError(-32602, "Invalid params", msg.ID)
@@ -730,69 +1958,217 @@ func (s *Server) handleToolsCall(msg Message) {
 
 	log.Printf("Tool call: %s", params.Name)
 
-	var result any
-	switch params.Name {
+	timeout := s.timeoutFor(params.Name)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type toolOutcome struct {
+		result map[string]any
+		found  bool
+	}
+	// Buffered so an abandoned handler can still finish and exit after a timeout; shutdown waits
+	// for it through s.handlers before closing the database it may still be using.
+	done := make(chan toolOutcome, 1)
+	s.handlers.Go(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Error: tool %s panicked: %v", params.Name, r)
+				done <- toolOutcome{result: ErrorResponse(fmt.Sprintf("Tool '%s' failed: %v", params.Name, r)), found: true}
+			}
+		}()
+		result, found := s.dispatchTool(ctx, params.Name, params.Arguments)
+		done <- toolOutcome{result: result, found: found}
+	})
+
+	var result map[string]any
+	select {
+	case outcome := <-done:
+		if !outcome.found {
+			s.sendError(-32601, "Tool not found", msg.ID)
+			return
+		}
+		result = outcome.result
+	case <-ctx.Done():
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			log.Printf("Tool %s abandoned: %v", params.Name, ctx.Err())
+			return
+		}
+		log.Printf("Warning: tool %s timed out after %s", params.Name, timeout)
+		result = ErrorResponse(fmt.Sprintf("Tool '%s' timed out after %s", params.Name, timeout))
+	}
+
+	response := Message{
+		JSONRPC: "2.0",
+		ID:      msg.ID,
+		Result:  result,
+	}
+	s.sendResponse(response)
+}
+
+// dispatchTool runs the named tool handler; found is false for unknown tools.
+func (s *Server) dispatchTool(ctx context.Context, name string, args any) (result map[string]any, found bool) {
+	if s.dbMode == database.ModeReadOnly && writeTools[name] {
+		return ErrorResponse(fmt.Sprintf("Tool '%s' is unavailable: the database is opened read-only (--db-mode readonly)", name)), true
+	}
+
+	switch name {
 	case "sync_provider":
-		result = s.handleSyncProvider()
+		return s.handleSyncProvider(), true
 	case "sync_updates_provider":
-		result = s.handleSyncProviderUpdates()
+		return s.handleSyncProviderUpdates(ctx), true
 	case "sync_status":
-		result = s.handleSyncStatus(params.Arguments)
+		return s.handleSyncStatus(args), true
 	case "get_release_summary":
-		result = s.handleGetReleaseSummary(params.Arguments)
+		return s.handleGetReleaseSummary(args), true
 	case "get_release_snippet":
-		result = s.handleGetReleaseSnippet(params.Arguments)
+		return s.handleGetReleaseSnippet(args), true
 	case "backfill_release":
-		result = s.handleBackfillRelease(params.Arguments)
+		return s.handleBackfillRelease(args), true
+	case "backfill_releases":
+		return s.handleBackfillReleases(args), true
 	case "list_resources":
-		result = s.handleListResources(params.Arguments)
+		return s.handleListResources(args), true
 	case "search_resources":
-		result = s.handleSearchResources(params.Arguments)
+		return s.handleSearchResources(args), true
 	case "get_resource_schema":
-		result = s.handleGetResourceSchema(params.Arguments)
+		return s.handleGetResourceSchema(args), true
 	case "search_resource_attributes":
-		result = s.handleSearchResourceAttributes(params.Arguments)
+		return s.handleSearchResourceAttributes(args), true
 	case "get_schema_source":
-		result = s.handleGetSchemaSource(params.Arguments)
+		return s.handleGetSchemaSource(args), true
 	case "search_code":
-		result = s.handleSearchCode(params.Arguments)
+		return s.handleSearchCode(args), true
 	case "get_file_content":
-		result = s.handleGetFileContent(params.Arguments)
+		return s.handleGetFileContent(args), true
 	case "get_resource_docs":
-		result = s.handleGetResourceDocs(params.Arguments)
+		return s.handleGetResourceDocs(args), true
 	case "list_resource_tests":
-		result = s.handleListResourceTests(params.Arguments)
+		return s.handleListResourceTests(args), true
+	case "get_resource_test":
+		return s.handleGetResourceTest(args), true
 	case "list_feature_flags":
-		result = s.handleListFeatureFlags()
+		return s.handleListFeatureFlags(), true
 	case "search_validations":
-		result = s.handleSearchValidations(params.Arguments)
+		return s.handleSearchValidations(args), true
 	case "get_resource_behaviors":
-		result = s.handleGetResourceBehaviors(params.Arguments)
+		return s.handleGetResourceBehaviors(args), true
 	case "get_example":
-		result = s.handleGetExample(params.Arguments)
+		return s.handleGetExample(args), true
 	case "analyze_update_behavior":
-		result = s.handleAnalyzeUpdateBehavior(params.Arguments)
+		return s.handleAnalyzeUpdateBehavior(args), true
 	case "compare_resources":
-		result = s.handleCompareResources(params.Arguments)
+		return s.handleCompareResources(args), true
 	case "find_similar_resources":
-		result = s.handleFindSimilarResources(params.Arguments)
+		return s.handleFindSimilarResources(args), true
 	case "explain_breaking_change":
-		result = s.handleExplainBreakingChange(params.Arguments)
+		return s.handleExplainBreakingChange(args), true
 	case "suggest_validation_improvements":
-		result = s.handleSuggestValidationImprovements(params.Arguments)
+		return s.handleSuggestValidationImprovements(args), true
 	case "trace_attribute_dependencies":
-		result = s.handleTraceAttributeDependencies(params.Arguments)
+		return s.handleTraceAttributeDependencies(args), true
+	case "validation_categories":
+		return s.handleValidationCategories(args), true
+	case "get_allowed_values":
+		return s.handleGetAllowedValues(args), true
+	case "validate_attribute_path":
+		return s.handleValidateAttributePath(args), true
+	case "get_resources_schema":
+		return s.handleGetResourcesSchema(args), true
+	case "find_attributes_with_value":
+		return s.handleFindAttributesWithValue(args), true
+	case "provider_overview":
+		return s.handleProviderOverview(args), true
+	case "get_attribute":
+		return s.handleGetAttribute(args), true
+	case "find_global_resources":
+		return s.handleFindGlobalResources(args), true
+	case "list_resource_lifecycle":
+		return s.handleListResourceLifecycle(args), true
+	case "generate_docs_stub":
+		return s.handleGenerateDocsStub(args), true
+	case "list_tags":
+		return s.handleListTags(args), true
+	case "check_description_style":
+		return s.handleCheckDescriptionStyle(args), true
+	case "diff_file":
+		return s.handleDiffFile(args), true
+	case "recently_added_properties":
+		return s.handleRecentlyAddedProperties(args), true
+	case "attribute_usage":
+		return s.handleAttributeUsage(args), true
+	case "scan_breaking_changes":
+		return s.handleScanBreakingChanges(args), true
+	case "attribute_introduced_in":
+		return s.handleAttributeIntroducedIn(args), true
+	case "find_unvalidated_attributes":
+		return s.handleFindUnvalidatedAttributes(args), true
+	case "get_source_url":
+		return s.handleGetSourceURL(args), true
+	case "find_resources_with_custom_diff":
+		return s.handleFindResourcesWithCustomDiff(args), true
+	case "find_non_importable_resources":
+		return s.handleFindNonImportableResources(args), true
+	case "clear_github_cache":
+		return s.handleClearGitHubCache(), true
+	case "get_parse_cache_status":
+		return s.handleGetParseCacheStatus(args), true
+	case "get_deprecations":
+		return s.handleGetDeprecations(args), true
+	case "explain_attribute":
+		return s.handleExplainAttribute(args), true
+	case "list_unresolved_resources":
+		return s.handleListUnresolvedResources(args), true
+	case "get_registration_info":
+		return s.handleGetRegistrationInfo(args), true
+	case "locate_resource":
+		return s.handleLocateResource(args), true
+	case "get_attribute_tree":
+		return s.handleGetAttributeTree(args), true
+	case "get_release_file_stats":
+		return s.handleGetReleaseFileStats(args), true
+	case "search_attribute_descriptions":
+		return s.handleSearchAttributeDescriptions(args), true
+	case "get_indexed_version":
+		return s.handleGetIndexedVersion(), true
+	case "lint_constraint_groups":
+		return s.handleLintConstraintGroups(args), true
+	case "find_resources_without_timeouts":
+		return s.handleFindResourcesWithoutTimeouts(args), true
+	case "validate_config":
+		return s.handleValidateConfig(args), true
+	case "get_constraints_diagram":
+		return s.handleGetConstraintsDiagram(args), true
+	case "search_resources_by_attribute_combination":
+		return s.handleSearchResourcesByAttributeCombination(args), true
+	case "get_provider_readme":
+		return s.handleGetProviderReadme(args), true
+	case "list_doc_guides":
+		return s.handleListDocGuides(), true
+	case "find_shadowed_attributes":
+		return s.handleFindShadowedAttributes(args), true
+	case "whats_new_for_resource":
+		return s.handleWhatsNewForResource(args), true
+	case "get_attribute_conflicts_transitive":
+		return s.handleGetAttributeConflictsTransitive(args), true
+	case "find_similar_attributes":
+		return s.handleFindSimilarAttributes(args), true
+	case "get_provider_diff_summary":
+		return s.handleGetProviderDiffSummary(args), true
+	case "export_tf_schema":
+		return s.handleExportTFSchema(args), true
+	case "list_providers":
+		return s.handleListProviders(), true
+	case "find_deprecation_replacements":
+		return s.handleFindDeprecationReplacements(args), true
+	case "find_resources_missing_common_args":
+		return s.handleFindResourcesMissingCommonArgs(args), true
+	case "list_directory":
+		return s.handleListDirectory(args), true
+	case "get_sdk_clients":
+		return s.handleGetSDKClients(args), true
 	default:
-		s.sendError(-32601, "Tool not found", msg.ID)
-		return
+		return nil, false
 	}
-
-	response := Message{
-		JSONRPC: "2.0",
-		ID:      msg.ID,
-		Result:  result,
-	}
-	s.sendResponse(response)
 }
 
 func (s *Server) handleSyncProvider() map[string]any {
@@ -800,40 +2176,42 @@ func (s *Server) handleSyncProvider() map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
 	}
 
-	job := s.startSyncJob("full_sync", func() (*indexer.SyncProgress, error) {
+	job := s.startSyncJob("full_sync", func(ctx context.Context) (*indexer.SyncProgress, error) {
 		log.Println("Starting full repository sync (async job)...")
-		return s.syncer.SyncAll()
+		return s.syncer.SyncAll(ctx)
 	})
 
 	return map[string]any{
 		"content": []map[string]any{
 			{
 				"type": "text",
-				"text": fmt.Sprintf("Full sync started.\nJob ID: %s\nUse `sync_status` with this job ID to monitor progress.", job.ID),
+				"text": formatter.SyncStarted(s.style, job.ID),
 			},
 		},
 	}
 }
 
-func (s *Server) handleSyncProviderUpdates() map[string]any {
+func (s *Server) handleSyncProviderUpdates(ctx context.Context) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
 	}
 
 	log.Println("Starting incremental repository sync (updates only)...")
 
-	progress, err := s.syncer.SyncUpdates()
+	progress, err := s.syncer.SyncUpdates(ctx)
 	if err != nil {
 		return ErrorResponse(fmt.Sprintf("Sync failed: %v", err))
 	}
 
 	text := formatter.IncrementalSyncProgress(
+		s.style,
 		progress.TotalRepos,
 		len(progress.UpdatedRepos),
 		progress.SkippedRepos,
 		progress.UpdatedRepos,
 		progress.Errors,
 	)
+	text += formatter.SyncTimings(s.style, progress.Timings)
 
 	if summary := s.releaseSummaryIfUpdated(progress.UpdatedRepos); summary != "" {
 		if strings.TrimSpace(text) != "" {
@@ -869,27 +2247,41 @@ func (s *Server) handleSyncStatus(args any) map[string]any {
 	return SuccessResponse(text)
 }
 
+func (s *Server) handleClearGitHubCache() map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	cleared := s.syncer.ClearCache()
+	return SuccessResponse(formatter.GitHubCacheCleared(cleared))
+}
+
+// registrationKinds are the definition kinds the indexer records, in the order tools list them.
+var registrationKinds = []string{"resource", "data_source", "action", "list", "ephemeral"}
+
 func (s *Server) handleListResources(args any) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
 	}
 
 	params, err := UnmarshalArgs[struct {
-		Kind    string `json:"kind"`
-		Limit   int    `json:"limit"`
-		Compact bool   `json:"compact"`
+		Kind     string `json:"kind"`
+		Category string `json:"category"`
+		Limit    int    `json:"limit"`
+		Compact  bool   `json:"compact"`
 	}](args)
 	if err != nil {
 		params = struct {
-			Kind    string `json:"kind"`
-			Limit   int    `json:"limit"`
-			Compact bool   `json:"compact"`
+			Kind     string `json:"kind"`
+			Category string `json:"category"`
+			Limit    int    `json:"limit"`
+			Compact  bool   `json:"compact"`
 		}{}
 	}
 
 	kind := strings.TrimSpace(strings.ToLower(params.Kind))
-	if kind != "" && kind != "resource" && kind != "data_source" {
-		return ErrorResponse("kind must be 'resource' or 'data_source'")
+	if kind != "" && !slices.Contains(registrationKinds, kind) {
+		return ErrorResponse("kind must be one of: " + strings.Join(registrationKinds, ", "))
 	}
 
 	limit := params.Limit
@@ -899,12 +2291,31 @@ func (s *Server) handleListResources(args any) map[string]any {
 		limit = 0 // negative keeps legacy “no limit” behavior
 	}
 
-	resources, err := s.db.ListProviderResources(kind, limit)
-	if err != nil {
-		return ErrorResponse(fmt.Sprintf("Failed to load provider resources: %v", err))
+	var resources []database.ProviderResource
+	if category := strings.TrimSpace(params.Category); category != "" {
+		categories, err := s.db.ListWebsiteCategories()
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load website categories: %v", err))
+		}
+		known := slices.ContainsFunc(categories, func(c string) bool { return strings.EqualFold(c, category) })
+		if !known {
+			if len(categories) == 0 {
+				return ErrorResponse("No website categories are indexed yet. Try running sync_provider.")
+			}
+			return ErrorResponse(fmt.Sprintf("Unknown category '%s'. Available categories: %s", category, strings.Join(categories, ", ")))
+		}
+		resources, err = s.db.ListProviderResourcesByCategory(category, kind, limit)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load provider resources: %v", err))
+		}
+	} else {
+		resources, err = s.db.ListProviderResources(kind, limit)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load provider resources: %v", err))
+		}
 	}
 
-	text := formatter.ProviderResourceList(resources)
+	text := formatter.ProviderResourceList(s.style, resources)
 	if params.Compact {
 		text = formatter.ProviderResourceListCompact(resources)
 	}
@@ -917,24 +2328,39 @@ func (s *Server) handleSearchResources(args any) map[string]any {
 	}
 
 	params, err := UnmarshalArgs[struct {
-		Query   string `json:"query"`
-		Limit   int    `json:"limit"`
-		Compact bool   `json:"compact"`
+		Query     string `json:"query"`
+		Limit     int    `json:"limit"`
+		Compact   bool   `json:"compact"`
+		CountOnly bool   `json:"count_only"`
+		Kind      string `json:"kind"`
 	}](args)
 	if err != nil || strings.TrimSpace(params.Query) == "" {
 		return ErrorResponse("query is required")
 	}
 
+	kind := strings.TrimSpace(strings.ToLower(params.Kind))
+	if kind != "" && !slices.Contains(registrationKinds, kind) {
+		return ErrorResponse("kind must be one of: " + strings.Join(registrationKinds, ", "))
+	}
+
+	if params.CountOnly {
+		count, err := s.db.CountProviderResources(params.Query, kind)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Search failed: %v", err))
+		}
+		return SuccessResponse(fmt.Sprintf("%d resources match '%s'", count, params.Query))
+	}
+
 	if params.Limit == 0 {
 		params.Limit = 10
 	}
 
-	resources, err := s.db.SearchProviderResources(params.Query, params.Limit)
+	resources, err := s.db.SearchProviderResources(params.Query, kind, params.Limit)
 	if err != nil {
 		return ErrorResponse(fmt.Sprintf("Search failed: %v", err))
 	}
 
-	text := formatter.ProviderResourceList(resources)
+	text := formatter.ProviderResourceList(s.style, resources)
 	if params.Compact {
 		text = formatter.ProviderResourceListCompact(resources)
 	}
@@ -947,17 +2373,30 @@ func (s *Server) handleGetResourceSchema(args any) map[string]any {
 	}
 
 	params, err := UnmarshalArgs[struct {
-		Name       string   `json:"name"`
-		Attributes []string `json:"attributes"`
-		Flags      []string `json:"flags"`
-		NestedOnly bool     `json:"nested_only"`
-		MaxRows    int      `json:"max_rows"`
-		Compact    bool     `json:"compact"`
+		Name         string   `json:"name"`
+		Attributes   []string `json:"attributes"`
+		Flags        []string `json:"flags"`
+		NestedOnly   bool     `json:"nested_only"`
+		SingleBlocks bool     `json:"single_blocks_only"`
+		MaxRows      int      `json:"max_rows"`
+		Compact      bool     `json:"compact"`
+		DescMaxChars int      `json:"desc_max_chars"`
+		Version      string   `json:"version"`
+		Section      string   `json:"section"`
+		CompareWith  string   `json:"compare_with"`
 	}](args)
 	if err != nil || strings.TrimSpace(params.Name) == "" {
 		return ErrorResponse("name is required")
 	}
 
+	section := strings.ToLower(strings.TrimSpace(params.Section))
+	if section == "" {
+		section = schemaSectionAll
+	}
+	if section != schemaSectionAll && section != schemaSectionArguments && section != schemaSectionAttributes {
+		return ErrorResponse("section must be 'arguments', 'attributes' or 'all'")
+	}
+
 	if params.MaxRows == 0 {
 		params.MaxRows = 50 // default cap for readability
 	} else if params.MaxRows < 0 {
@@ -967,12 +2406,31 @@ func (s *Server) handleGetResourceSchema(args any) map[string]any {
 	resourceName := strings.TrimSpace(params.Name)
 	resource, err := s.db.GetProviderResource(resourceName)
 	if err != nil {
-		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", resourceName))
+		return s.resourceNotFound(resourceName)
 	}
 
-	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
-	if err != nil {
-		return ErrorResponse(fmt.Sprintf("Failed to load schema for %s: %v", resourceName, err))
+	if compareWith := strings.ToLower(strings.TrimSpace(params.CompareWith)); compareWith != "" {
+		return s.compareWithSibling(resource, compareWith, params.MaxRows)
+	}
+
+	var attrs []database.ProviderAttribute
+	var schemaVersion string
+	if version := strings.TrimPrefix(strings.TrimSpace(params.Version), "v"); version != "" {
+		attrs, err = s.db.GetResourceAttributesAtVersion(resource.ID, version)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse(fmt.Sprintf("No schema snapshot of %s recorded for v%s. Start the server with --ref v%s and run sync_provider to record one.", resource.Name, version, version))
+		}
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load v%s schema for %s: %v", version, resource.Name, err))
+		}
+		// The stored breaking-change summary describes the current schema, not the snapshot.
+		resource.BreakingChanges = sql.NullString{}
+		schemaVersion = version
+	} else {
+		attrs, err = s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load schema for %s: %v", resource.Name, err))
+		}
 	}
 
 	filtered, summary := filterProviderAttributes(
@@ -980,16 +2438,22 @@ func (s *Server) handleGetResourceSchema(args any) map[string]any {
 		params.Attributes,
 		params.Flags,
 		params.NestedOnly,
+		params.SingleBlocks,
+		section,
 		params.MaxRows,
 	)
 
 	opts := formatter.SchemaRenderOptions{
 		FilterSummary: summary,
 		Compact:       params.Compact,
-		Filtered:      len(params.Attributes) > 0 || len(params.Flags) > 0 || params.NestedOnly || params.MaxRows > 0,
+		Filtered:      len(params.Attributes) > 0 || len(params.Flags) > 0 || params.NestedOnly || params.SingleBlocks || section != schemaSectionAll || params.MaxRows > 0,
+		DescMaxChars:  s.descriptionLimit(params.DescMaxChars),
+		SchemaVersion: schemaVersion,
 	}
+	src, _ := s.db.GetProviderResourceSource(resource.ID) // nil when no source was stored
+	opts.SupportsImport = resourceSupportsImport(resource, src)
 
-	text := formatter.ProviderResourceDetail(resource, filtered, opts)
+	text := formatter.ProviderResourceDetail(s.style, resource, filtered, opts)
 	return SuccessResponse(text)
 }
 
@@ -1002,10 +2466,13 @@ func (s *Server) handleSearchResourceAttributes(args any) map[string]any {
 		NameContains     string   `json:"name_contains"`
 		ResourcePrefix   string   `json:"resource_prefix"`
 		Flags            []string `json:"flags"`
+		ExcludeFlags     []string `json:"exclude_flags"`
 		ConflictsWith    string   `json:"conflicts_with"`
 		DescriptionQuery string   `json:"description_query"`
 		Compact          bool     `json:"compact"`
 		Limit            int      `json:"limit"`
+		DescMaxChars     int      `json:"desc_max_chars"`
+		CountOnly        bool     `json:"count_only"`
 	}](args)
 	if err != nil {
 		return ErrorResponse("Error: invalid filter parameters")
@@ -1017,26 +2484,78 @@ func (s *Server) handleSearchResourceAttributes(args any) map[string]any {
 		params.Limit = 0 // no limit
 	}
 
-	results, err := s.db.SearchProviderAttributes(database.AttributeSearchFilters{
+	filters := database.AttributeSearchFilters{
 		NameContains:     strings.TrimSpace(params.NameContains),
 		ResourcePrefix:   strings.TrimSpace(params.ResourcePrefix),
 		Flags:            normalizeFilters(params.Flags),
+		NotFlags:         normalizeFilters(params.ExcludeFlags),
 		ConflictsWith:    strings.TrimSpace(params.ConflictsWith),
 		DescriptionQuery: strings.TrimSpace(params.DescriptionQuery),
 		Limit:            params.Limit,
-	})
+	}
+
+	if params.CountOnly {
+		count, err := s.db.CountProviderAttributes(filters)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Attribute search failed: %v", err))
+		}
+		return SuccessResponse(fmt.Sprintf("%d attributes match the filters", count))
+	}
+
+	results, err := s.db.SearchProviderAttributes(filters)
 	if err != nil {
 		return ErrorResponse(fmt.Sprintf("Attribute search failed: %v", err))
 	}
 
-	text := formatter.ProviderAttributeSearch(results)
+	text := formatter.ProviderAttributeSearch(s.style, results, s.descriptionLimit(params.DescMaxChars))
 	if params.Compact {
 		text = formatter.ProviderAttributeSearchCompact(results)
 	}
 	return SuccessResponse(text)
 }
 
-func filterProviderAttributes(attrs []database.ProviderAttribute, nameFilters, flagFilters []string, nestedOnly bool, maxRows int) ([]database.ProviderAttribute, string) {
+func (s *Server) handleSearchAttributeDescriptions(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Query          string `json:"query"`
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+		DescMaxChars   int    `json:"desc_max_chars"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	query := strings.TrimSpace(params.Query)
+	if query == "" {
+		return ErrorResponse("query is required")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 20
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	results, err := s.db.SearchProviderAttributeDescriptions(query, strings.TrimSpace(params.ResourcePrefix), limit)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Attribute description search failed: %v", err))
+	}
+
+	return SuccessResponse(formatter.AttributeDescriptionSearch(s.style, query, results, s.descriptionLimit(params.DescMaxChars)))
+}
+
+// Schema sections accepted by get_resource_schema, mirroring the docs' Arguments and Attributes Reference split.
+const (
+	schemaSectionAll        = "all"
+	schemaSectionArguments  = "arguments"  // required or optional attributes, set in configuration
+	schemaSectionAttributes = "attributes" // computed-only attributes, exported to state
+)
+
+func filterProviderAttributes(attrs []database.ProviderAttribute, nameFilters, flagFilters []string, nestedOnly, singleBlocksOnly bool, section string, maxRows int) ([]database.ProviderAttribute, string) {
 	cleanNames := normalizeFilters(nameFilters)
 	cleanFlags := normalizeFilters(flagFilters)
 	nameMatchers := toLower(cleanNames)
@@ -1047,6 +2566,13 @@ func filterProviderAttributes(attrs []database.ProviderAttribute, nameFilters, f
 		if nestedOnly && !attr.NestedBlock {
 			continue
 		}
+		if singleBlocksOnly && !isSingleBlock(attr) {
+			continue
+		}
+		computedOnly := attr.Computed && !attr.Optional && !attr.Required
+		if (section == schemaSectionArguments && computedOnly) || (section == schemaSectionAttributes && !computedOnly) {
+			continue
+		}
 		if len(nameMatchers) > 0 && !attributeNameMatch(attr.Name, nameMatchers) {
 			continue
 		}
@@ -1069,6 +2595,12 @@ func filterProviderAttributes(attrs []database.ProviderAttribute, nameFilters, f
 	if nestedOnly {
 		summary = append(summary, "nested_only")
 	}
+	if singleBlocksOnly {
+		summary = append(summary, "single_blocks_only")
+	}
+	if section != "" && section != schemaSectionAll {
+		summary = append(summary, "section="+section)
+	}
 	if maxRows > 0 {
 		summary = append(summary, fmt.Sprintf("max_rows=%d", maxRows))
 	}
@@ -1076,6 +2608,11 @@ func filterProviderAttributes(attrs []database.ProviderAttribute, nameFilters, f
 	return filtered, strings.Join(summary, ", ")
 }
 
+// isSingleBlock reports whether attr is a MaxItems: 1 nested block, configured as one object.
+func isSingleBlock(attr database.ProviderAttribute) bool {
+	return attr.NestedBlock && attr.MaxItems.Valid && attr.MaxItems.Int64 == 1
+}
+
 func (s *Server) handleGetSchemaSource(args any) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -1094,8 +2631,8 @@ func (s *Server) handleGetSchemaSource(args any) map[string]any {
 	if section == "" {
 		section = "schema"
 	}
-	if section != "schema" && section != "function" {
-		return ErrorResponse("section must be 'schema' or 'function'")
+	if section != "schema" && section != "function" && section != "attributes" {
+		return ErrorResponse("section must be 'schema', 'function' or 'attributes'")
 	}
 
 	resource, err := s.db.GetProviderResource(strings.TrimSpace(params.Name))
@@ -1108,6 +2645,10 @@ func (s *Server) handleGetSchemaSource(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Source snippet for '%s' not available yet. Try running sync_provider.", params.Name))
 	}
 
+	if section == "attributes" {
+		return s.schemaAttributeLinesResponse(resource, src)
+	}
+
 	snippet := ""
 	switch section {
 	case "function":
@@ -1124,6 +2665,11 @@ func (s *Server) handleGetSchemaSource(args any) map[string]any {
 		section = "function"
 	}
 
+	startLine, endLine := int(src.SchemaStartLine.Int64), int(src.SchemaEndLine.Int64)
+	if section == "function" {
+		startLine, endLine = int(src.FunctionStartLine.Int64), int(src.FunctionEndLine.Int64)
+	}
+
 	snippet, truncated := trimSnippet(snippet, params.MaxLines)
 	filePath := src.FilePath.String
 	if filePath == "" && resource.FilePath.Valid {
@@ -1131,10 +2677,28 @@ func (s *Server) handleGetSchemaSource(args any) map[string]any {
 	}
 
 	functionName := src.FunctionName.String
-	text := formatter.ProviderSchemaSource(resource.Name, section, filePath, functionName, snippet, truncated)
+	text := formatter.ProviderSchemaSource(s.style, resource.Name, section, filePath, functionName, snippet, startLine, endLine, truncated)
 	return SuccessResponse(text)
 }
 
+// schemaAttributeLinesResponse renders the per-attribute declaration lines recorded at index time.
+// Schemas assembled by helper functions have no literal keys, so no lines exist for them.
+func (s *Server) schemaAttributeLinesResponse(resource *database.ProviderResource, src *database.ProviderResourceSource) map[string]any {
+	lines, err := src.AttributeLines()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to decode attribute lines for '%s': %v", resource.Name, err))
+	}
+	if len(lines) == 0 {
+		return ErrorResponse(fmt.Sprintf("Attribute source lines for '%s' are not available: the schema is not declared as a map literal or was indexed before line tracking. Try running sync_provider or use section 'schema'.", resource.Name))
+	}
+
+	filePath := src.FilePath.String
+	if filePath == "" && resource.FilePath.Valid {
+		filePath = resource.FilePath.String
+	}
+	return SuccessResponse(formatter.SchemaAttributeLines(s.style, resource.Name, filePath, src.FunctionName.String, lines))
+}
+
 func trimSnippet(snippet string, maxLines int) (string, bool) {
 	if maxLines <= 0 {
 		return snippet, false
@@ -1244,10 +2808,17 @@ func (s *Server) handleSearchCode(args any) map[string]any {
 		return "unknown"
 	}
 
-	text := formatter.CodeSearchResults(searchArgs.Query, merged, getRepositoryName)
+	text := formatter.CodeSearchResults(s.style, searchArgs.Query, merged, getRepositoryName)
 	return SuccessResponse(text)
 }
 
+// Line limits for get_file_content: the window returned when none is requested, and the longest
+// open-ended window served without force.
+const (
+	defaultFileWindow = 200
+	fullFileLineLimit = 2000
+)
+
 func (s *Server) handleGetFileContent(args any) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -1258,42 +2829,36 @@ func (s *Server) handleGetFileContent(args any) map[string]any {
 		FilePath   string `json:"file_path"`
 		StartLine  int    `json:"start_line"`
 		EndLine    int    `json:"end_line"`
+		Page       int    `json:"page"`
+		PageSize   int    `json:"page_size"`
 		Summary    bool   `json:"summary"`
+		Force      bool   `json:"force"`
 	}](args)
 	if err != nil {
 		return ErrorResponse("Error: Invalid parameters")
 	}
 
-	repoName := strings.TrimSpace(fileArgs.Repository)
-	if repoName == "" {
-		repoName = s.repo
-	}
-
+	repoName := ifEmpty(strings.TrimSpace(fileArgs.Repository), s.repoShortName())
 	repo, err := s.resolveRepository(repoName)
 	if err != nil {
-		repositories, listErr := s.db.ListRepositories()
-		if listErr == nil && len(repositories) > 0 {
-			repo = &repositories[0]
-		} else {
-			target := repoName
-			if strings.TrimSpace(target) == "" {
-				target = "(not specified)"
-			}
-			return ErrorResponse(fmt.Sprintf("Repository '%s' not found", target))
-		}
+		return s.repositoryNotFound(repoName)
 	}
 	file, err := s.db.GetFile(repo.Name, fileArgs.FilePath)
 	if err != nil {
 		return ErrorResponse(fmt.Sprintf("File '%s' not found in repository '%s'", fileArgs.FilePath, repo.Name))
 	}
 
+	if fileArgs.Page > 0 {
+		return s.fileContentPage(repo.Name, file, fileArgs.Page, fileArgs.PageSize, fileArgs.Summary, fileArgs.Force)
+	}
+
 	startLine := fileArgs.StartLine
 	endLine := fileArgs.EndLine
 	if startLine == 0 && endLine == 0 {
 		startLine = 1
-		endLine = 200 // default window to avoid dumping entire files
+		endLine = defaultFileWindow // default window to avoid dumping entire files
 	}
-	if startLine < 0 {
+	if startLine <= 0 {
 		startLine = 1
 	}
 	if endLine < 0 {
@@ -1301,10 +2866,37 @@ func (s *Server) handleGetFileContent(args any) map[string]any {
 	}
 
 	snippet, startLine, endLine, totalLines := extractLineWindow(file.Content, startLine, endLine)
-	text := formatter.FileContent(repo.Name, file.FilePath, file.FileType, file.SizeBytes, snippet, startLine, endLine, totalLines, !fileArgs.Summary)
+	if fileArgs.EndLine <= 0 && !fileArgs.Summary && !fileArgs.Force && endLine-startLine+1 > fullFileLineLimit {
+		return ErrorResponse(fmt.Sprintf("File '%s' has %d lines; returning lines %d-%d would exceed the %d line limit. Request a window with start_line/end_line, walk the file with page/page_size (%d pages of %d lines), or set force to return it anyway.",
+			file.FilePath, totalLines, startLine, endLine, fullFileLineLimit, pageCount(totalLines, defaultFileWindow), defaultFileWindow))
+	}
+	text := formatter.FileContent(s.style, repo.Name, file.FilePath, file.FileType, file.SizeBytes, snippet, startLine, endLine, totalLines, !fileArgs.Summary)
+	return SuccessResponse(text)
+}
+
+// fileContentPage returns one page_size-line page of file, so agents can walk large files in
+// chunks without guessing line numbers. Pages are capped at fullFileLineLimit lines unless force is set.
+func (s *Server) fileContentPage(repoName string, file *database.RepositoryFile, page, pageSize int, summary, force bool) map[string]any {
+	if pageSize <= 0 {
+		pageSize = defaultFileWindow
+	} else if pageSize > fullFileLineLimit && !force {
+		pageSize = fullFileLineLimit
+	}
+	totalLines := lineCount(file.Content)
+	pages := max(pageCount(totalLines, pageSize), 1)
+	if page > pages {
+		return ErrorResponse(fmt.Sprintf("Page %d is past the end of '%s': %d lines make %d pages of %d lines", page, file.FilePath, totalLines, pages, pageSize))
+	}
+
+	snippet, startLine, endLine, _ := extractLineWindow(file.Content, (page-1)*pageSize+1, page*pageSize)
+	text := formatter.FileContentPage(s.style, repoName, file.FilePath, file.FileType, file.SizeBytes, snippet, startLine, endLine, totalLines, page, pages, pageSize, !summary)
 	return SuccessResponse(text)
 }
 
+func pageCount(lines, pageSize int) int {
+	return (lines + pageSize - 1) / pageSize
+}
+
 func (s *Server) handleGetResourceDocs(args any) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -1333,7 +2925,7 @@ func (s *Server) handleGetResourceDocs(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to load repository files: %v", err))
 	}
 
-	docSuffix := strings.TrimPrefix(resource.Name, "azurerm_")
+	docSuffix := strings.TrimPrefix(resource.Name, s.providerPrefix())
 	docFile := findDocumentationFile(files, docSuffix, resource.Kind)
 	if docFile == nil {
 		return ErrorResponse(fmt.Sprintf("Documentation not found for '%s'. Ensure the repository sync is up-to-date.", resource.Name))
@@ -1342,7 +2934,7 @@ func (s *Server) handleGetResourceDocs(args any) map[string]any {
 	content := stripFrontMatter(docFile.Content)
 	sectionText, sectionFound := extractMarkdownSection(content, params.Section)
 
-	text := formatter.ResourceDocs(resource.Name, resource.Kind, docFile.FilePath, params.Section, sectionFound, sectionText)
+	text := formatter.ResourceDocs(s.style, resource.Name, resource.Kind, docFile.FilePath, params.Section, sectionFound, sectionText)
 	return SuccessResponse(text)
 }
 
@@ -1373,27 +2965,16 @@ func (s *Server) handleListResourceTests(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to load repository files: %v", err))
 	}
 
-	shortName := strings.TrimPrefix(resource.Name, "azurerm_")
-	camel := toCamelCase(shortName)
-	var prefixes []string
-	if resource.Kind == "data_source" {
-		prefixes = []string{
-			"TestAccDataSourceAzureRM" + camel,
-			"TestAccDataSourceAzureRm" + camel,
-		}
-	} else {
-		prefixes = []string{
-			"TestAccAzureRM" + camel,
-			"TestAccAzAPI" + camel,
-		}
-	}
+	prefixes := s.resourceTestPrefixes(resource)
 
 	var matches []formatter.ResourceTestFile
+	indexedTests := false
 	for i := range files {
 		file := files[i]
 		if !strings.HasSuffix(file.FileName, "_test.go") {
 			continue
 		}
+		indexedTests = true
 		tests := parseTestFunctions(file.Content, prefixes)
 		if len(tests) == 0 {
 			continue
@@ -1404,10 +2985,112 @@ func (s *Server) handleListResourceTests(args any) map[string]any {
 		})
 	}
 
-	text := formatter.ResourceTestOverview(resource.Name, resource.Kind, matches)
+	if !indexedTests && s.excludeTests {
+		return ErrorResponse("Test files were not indexed: the server was started with --include-tests=false. Restart without it and run sync_provider to index acceptance tests.")
+	}
+
+	text := formatter.ResourceTestOverview(s.style, resource.Name, resource.Kind, matches)
 	return SuccessResponse(text)
 }
 
+// resourceTestPrefixes returns the acceptance test name prefixes used for a resource or data source.
+func (s *Server) resourceTestPrefixes(resource *database.ProviderResource) []string {
+	camel := toCamelCase(strings.TrimPrefix(resource.Name, s.providerPrefix()))
+	if resource.Kind == "data_source" {
+		return []string{
+			"TestAccDataSourceAzureRM" + camel,
+			"TestAccDataSourceAzureRm" + camel,
+		}
+	}
+	return []string{
+		"TestAccAzureRM" + camel,
+		"TestAccAzAPI" + camel,
+	}
+}
+
+func (s *Server) handleGetResourceTest(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+		TestName     string `json:"test_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	testName := strings.TrimSpace(params.TestName)
+	if name == "" || testName == "" {
+		return ErrorResponse("resource_name and test_name are required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+
+	files, err := s.db.GetRepositoryFiles(resource.RepositoryID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository files: %v", err))
+	}
+
+	// Test names are only unique within a package, so prefer the resource's own directory.
+	resourceDir := ""
+	if resource.FilePath.Valid {
+		resourceDir = path.Dir(resource.FilePath.String)
+	}
+	var found *formatter.ResourceTestSource
+	indexedTests := false
+	var available []string
+	for i := range files {
+		file := files[i]
+		if !strings.HasSuffix(file.FileName, "_test.go") {
+			continue
+		}
+		indexedTests = true
+		available = append(available, parseTestFunctions(file.Content, s.resourceTestPrefixes(resource))...)
+		if found != nil && path.Dir(found.FilePath) == resourceDir {
+			continue
+		}
+		source, startLine, endLine, ok := extractFunctionSource(file.Content, testName)
+		if !ok {
+			continue
+		}
+		if found == nil || path.Dir(file.FilePath) == resourceDir {
+			found = &formatter.ResourceTestSource{
+				FilePath:  file.FilePath,
+				StartLine: startLine,
+				EndLine:   endLine,
+				Source:    source,
+			}
+		}
+	}
+
+	if !indexedTests && s.excludeTests {
+		return ErrorResponse("Test files were not indexed: the server was started with --include-tests=false. Restart without it and run sync_provider to index acceptance tests.")
+	}
+	if found == nil {
+		msg := fmt.Sprintf("Test '%s' not found in the indexed test files", testName)
+		if len(available) > 0 {
+			sort.Strings(available)
+			msg += fmt.Sprintf(". Tests for %s: %s", resource.Name, strings.Join(available, ", "))
+		}
+		return ErrorResponse(msg)
+	}
+
+	return SuccessResponse(formatter.ResourceTest(s.style, resource.Name, testName, *found))
+}
+
+// featureFlagFiles hold the provider's feature flag table and the structs and defaults of its
+// nested features block.
+var featureFlagFiles = []string{
+	"internal/features/config/features.go",
+	"internal/features/user_flags.go",
+	"internal/features/defaults.go",
+}
+
 func (s *Server) handleListFeatureFlags() map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -1418,13 +3101,18 @@ func (s *Server) handleListFeatureFlags() map[string]any {
 		return ErrorResponse(fmt.Sprintf("No provider repository data available. Run sync_provider first. Error: %v", err))
 	}
 
-	file, err := s.db.GetFile(repo.Name, "internal/features/config/features.go")
-	if err != nil {
-		return ErrorResponse("Feature configuration file not found. Ensure the repository sync includes internal/features/config/features.go.")
+	var sources []string
+	for _, path := range featureFlagFiles {
+		if file, err := s.db.GetFile(repo.Name, path); err == nil {
+			sources = append(sources, file.Content)
+		}
+	}
+	if len(sources) == 0 {
+		return ErrorResponse(fmt.Sprintf("Feature configuration files not found. Ensure the repository sync includes %s.", strings.Join(featureFlagFiles, " or ")))
 	}
 
-	flags := parseFeatureFlags(file.Content)
-	text := formatter.FeatureFlagList(flags)
+	flags := parseFeatureFlags(sources...)
+	text := formatter.FeatureFlagList(s.style, flags)
 	return SuccessResponse(text)
 }
 
@@ -1437,6 +3125,7 @@ func (s *Server) handleSearchValidations(args any) map[string]any {
 		Contains       string `json:"contains"`
 		ResourcePrefix string `json:"resource_prefix"`
 		Limit          int    `json:"limit"`
+		DescMaxChars   int    `json:"desc_max_chars"`
 	}](args)
 	if err != nil {
 		return ErrorResponse("Error: Invalid parameters")
@@ -1454,7 +3143,7 @@ func (s *Server) handleSearchValidations(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to search provider attributes: %v", err))
 	}
 
-	text := formatter.ProviderAttributeSearch(results)
+	text := formatter.ProviderAttributeSearch(s.style, results, s.descriptionLimit(params.DescMaxChars))
 	return SuccessResponse(text)
 }
 
@@ -1498,7 +3187,7 @@ func (s *Server) handleGetResourceBehaviors(args any) map[string]any {
 		info.FunctionName = src.FunctionName.String
 	}
 
-	text := formatter.ResourceBehaviors(resource.Name, resource.Kind, info)
+	text := formatter.ResourceBehaviors(s.style, resource.Name, resource.Kind, info)
 	return SuccessResponse(text)
 }
 
@@ -1546,7 +3235,7 @@ func (s *Server) handleGetExample(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Example '%s' not found. Verify the path under examples/.", normalized))
 	}
 
-	text := formatter.ExampleDirectory(prefix, exampleFiles)
+	text := formatter.ExampleDirectory(s.style, prefix, exampleFiles)
 	return SuccessResponse(text)
 }
 
@@ -1690,20 +3379,50 @@ func attributeHasFlag(attr database.ProviderAttribute, flag string) bool {
 	}
 }
 
-func (s *Server) startSyncJob(jobType string, runner func() (*indexer.SyncProgress, error)) *SyncJob {
+// jobContext returns the context background sync jobs run under: the Run context while the server
+// is serving, or a background context when handlers are invoked directly.
+func (s *Server) jobContext() context.Context {
+	s.jobsMutex.RLock()
+	defer s.jobsMutex.RUnlock()
+	if s.runCtx != nil {
+		return s.runCtx
+	}
+	return context.Background()
+}
+
+// startSyncJob runs runner in the background as a tracked job. The context passed to runner reports
+// sync progress back onto the job, so sync_status can show it before the job completes.
+func (s *Server) startSyncJob(jobType string, runner func(ctx context.Context) (*indexer.SyncProgress, error)) *SyncJob {
 	jobID := fmt.Sprintf("%s-%d", jobType, time.Now().UnixNano())
 	job := &SyncJob{
 		ID:        jobID,
 		Type:      jobType,
 		Status:    "running",
 		StartedAt: time.Now(),
+		Progress:  &indexer.SyncProgress{},
 	}
 
 	s.jobsMutex.Lock()
 	s.jobs[jobID] = job
+	if s.closing {
+		now := time.Now()
+		job.Status = "failed"
+		job.Error = "server is shutting down"
+		job.CompletedAt = &now
+		snapshot := *job
+		s.jobsMutex.Unlock()
+		return &snapshot
+	}
+	s.jobRunners.Add(1)
+	snapshot := *job
 	s.jobsMutex.Unlock()
 
+	ctx := indexer.WithProgressReporter(s.jobContext(), func(progress indexer.SyncProgress) {
+		s.updateJobProgress(jobID, &progress)
+	})
+
 	go func() {
+		defer s.jobRunners.Done()
 		headline := fmt.Sprintf("Sync job %s (%s)", jobID, jobType)
 		defer func() {
 			if r := recover(); r != nil {
@@ -1713,7 +3432,7 @@ func (s *Server) startSyncJob(jobType string, runner func() (*indexer.SyncProgre
 			}
 		}()
 
-		progress, err := runner()
+		progress, err := runner(ctx)
 		if err != nil {
 			log.Printf("%s failed: %v", headline, err)
 			s.completeJobWithError(jobID, err.Error())
@@ -1724,7 +3443,16 @@ func (s *Server) startSyncJob(jobType string, runner func() (*indexer.SyncProgre
 		s.completeJobWithSuccess(jobID, progress)
 	}()
 
-	return job
+	return &snapshot
+}
+
+// updateJobProgress records the live progress of a running job; completion replaces it with the final tally.
+func (s *Server) updateJobProgress(jobID string, progress *indexer.SyncProgress) {
+	s.jobsMutex.Lock()
+	if job, ok := s.jobs[jobID]; ok && job.Status == "running" {
+		job.Progress = progress
+	}
+	s.jobsMutex.Unlock()
 }
 
 func (s *Server) completeJobWithError(jobID, errMsg string) {
@@ -1749,11 +3477,16 @@ func (s *Server) completeJobWithSuccess(jobID string, progress *indexer.SyncProg
 	s.jobsMutex.Unlock()
 }
 
+// getJob returns a snapshot of the job so callers can read it while the sync goroutine updates the original.
 func (s *Server) getJob(jobID string) (*SyncJob, bool) {
 	s.jobsMutex.RLock()
 	defer s.jobsMutex.RUnlock()
 	job, ok := s.jobs[jobID]
-	return job, ok
+	if !ok {
+		return nil, false
+	}
+	snapshot := *job
+	return &snapshot, true
 }
 
 func (s *Server) listJobs() []*SyncJob {
@@ -1761,7 +3494,8 @@ func (s *Server) listJobs() []*SyncJob {
 	defer s.jobsMutex.RUnlock()
 	jobs := make([]*SyncJob, 0, len(s.jobs))
 	for _, job := range s.jobs {
-		jobs = append(jobs, job)
+		snapshot := *job
+		jobs = append(jobs, &snapshot)
 	}
 	sort.Slice(jobs, func(i, j int) bool {
 		return jobs[i].StartedAt.After(jobs[j].StartedAt)
@@ -1771,11 +3505,17 @@ func (s *Server) listJobs() []*SyncJob {
 
 func (s *Server) formatJobDetails(job *SyncJob) string {
 	progressText := ""
-	if job.Progress != nil {
-		progressText = formatter.SyncProgress(job.Progress)
+	switch {
+	case job.Status == "running":
+		typical := s.typicalJobDuration(job.Type)
+		interval := s.suggestedPollInterval(time.Since(job.StartedAt), typical)
+		progressText = formatter.RunningSyncProgress(s.style, job.Progress) + formatter.PollGuidance(typical, interval)
+	case job.Progress != nil:
+		progressText = formatter.SyncProgress(s.style, job.Progress)
 	}
 
 	return formatter.JobDetails(
+		s.style,
 		job.ID,
 		job.Type,
 		job.Status,
@@ -1786,6 +3526,44 @@ func (s *Server) formatJobDetails(job *SyncJob) string {
 	)
 }
 
+// typicalJobDuration returns how long the most recent completed job of jobType took, or zero when
+// none has completed since the server started.
+func (s *Server) typicalJobDuration(jobType string) time.Duration {
+	for _, job := range s.listJobs() {
+		if job.Type == jobType && job.Status == "completed" && job.CompletedAt != nil {
+			return job.CompletedAt.Sub(job.StartedAt)
+		}
+	}
+	return 0
+}
+
+const (
+	minPollInterval = 5 * time.Second
+	maxPollInterval = time.Minute
+)
+
+// suggestedPollInterval picks how long an agent should wait before polling a running job again.
+// A configured interval wins; otherwise the wait is half the time the previous job of the same type
+// still had left at this point, or grows with elapsed time when there is no such job or it is overrunning.
+func (s *Server) suggestedPollInterval(elapsed, typical time.Duration) time.Duration {
+	if s.pollInterval > 0 {
+		return s.pollInterval
+	}
+
+	var wait time.Duration
+	switch {
+	case typical > elapsed:
+		wait = (typical - elapsed) / 2
+	case elapsed < time.Minute:
+		wait = 10 * time.Second
+	case elapsed < 5*time.Minute:
+		wait = 30 * time.Second
+	default:
+		wait = maxPollInterval
+	}
+	return min(max(wait, minPollInterval), maxPollInterval)
+}
+
 func (s *Server) formatJobList(jobs []*SyncJob) string {
 	jobInfos := make([]formatter.JobInfo, len(jobs))
 	for i, job := range jobs {
@@ -1796,24 +3574,27 @@ func (s *Server) formatJobList(jobs []*SyncJob) string {
 			StartedAt:   job.StartedAt,
 			CompletedAt: job.CompletedAt,
 		}
+		if job.Status == "running" && job.Progress != nil {
+			jobInfos[i].ProcessedRepos = job.Progress.ProcessedRepos
+			jobInfos[i].TotalRepos = job.Progress.TotalRepos
+		}
 	}
-	return formatter.JobList(jobInfos)
+	return formatter.JobList(s.style, jobInfos)
 }
 
 func (s *Server) sendResponse(response Message) {
 	data, err := json.Marshal(response)
 	if err != nil {
-		log.Printf("Failed to marshal response: %v", err)
-		return
-	}
-
-	if s.writer == nil {
-		log.Printf("No writer configured, dropping response: %s", string(data))
+		log.Printf("Error: failed to marshal response: %v", err)
 		return
 	}
 
-	if _, err := fmt.Fprintln(s.writer, string(data)); err != nil {
-		log.Printf("Failed to write response: %v", err)
+	if err := s.writeLine(data); err != nil {
+		if errors.Is(err, errNoWriter) {
+			log.Printf("No writer configured, dropping response: %s", string(data))
+			return
+		}
+		log.Printf("Error: failed to write response: %v", err)
 		return
 	}
 	log.Printf("Sent: %s", string(data))
@@ -1831,6 +3612,25 @@ func (s *Server) sendError(code int, message string, id any) {
 	s.sendResponse(response)
 }
 
+// repositoryNotFound reports an unknown repository together with the repositories that are indexed,
+// rather than guessing one of them.
+func (s *Server) repositoryNotFound(name string) map[string]any {
+	msg := fmt.Sprintf("Repository '%s' not found", name)
+	repositories, err := s.db.ListRepositories()
+	if err != nil {
+		return ErrorResponse(msg)
+	}
+	if len(repositories) == 0 {
+		return ErrorResponse(msg + "; no repositories are indexed yet, run sync_provider first")
+	}
+
+	names := make([]string, 0, len(repositories))
+	for _, repo := range repositories {
+		names = append(names, repo.Name)
+	}
+	return ErrorResponse(msg + ". Indexed repositories: " + strings.Join(names, ", "))
+}
+
 func (s *Server) resolveRepository(nameOrAlias string) (*database.Repository, error) {
 	if m, err := s.db.GetRepository(nameOrAlias); err == nil {
 		return m, nil
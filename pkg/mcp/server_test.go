@@ -1,17 +1,22 @@
 package mcp
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
 	"github.com/dkooll/aztfmcp/internal/indexer"
 	"github.com/dkooll/aztfmcp/internal/testutil"
 )
@@ -29,6 +34,45 @@ func TestHandleMessageUnknownMethod(t *testing.T) {
 	}
 }
 
+func TestHandleInitializeNegotiatesProtocolVersion(t *testing.T) {
+	initialize := func(s *Server, params any) map[string]any {
+		var buf bytes.Buffer
+		s.writer = &buf
+		s.handleMessage(Message{JSONRPC: "2.0", Method: "initialize", ID: 1, Params: params})
+		result, ok := decodeMessage(t, buf.String()).Result.(map[string]any)
+		if !ok {
+			t.Fatalf("expected initialize result, got %s", buf.String())
+		}
+		return result
+	}
+
+	s := NewServer("test.db", "", "org", "repo")
+	cases := []struct {
+		params any
+		want   string
+	}{
+		{map[string]any{"protocolVersion": "2024-11-05"}, "2024-11-05"},
+		{map[string]any{"protocolVersion": "2025-06-18"}, "2025-06-18"},
+		{map[string]any{"protocolVersion": "2099-01-01"}, "2025-06-18"},
+		{nil, "2025-06-18"},
+	}
+	for _, tc := range cases {
+		if got := initialize(s, tc.params)["protocolVersion"]; got != tc.want {
+			t.Errorf("params %v: protocolVersion = %v, want %s", tc.params, got, tc.want)
+		}
+	}
+
+	info := initialize(s, nil)["serverInfo"].(map[string]any)
+	if info["name"] != "az-cn-azurerm" || info["version"] != "1.0.0" {
+		t.Fatalf("expected default serverInfo, got %v", info)
+	}
+	custom := NewServer("test.db", "", "org", "repo", WithServerInfo("azurerm-index", "2.3.0"))
+	info = initialize(custom, nil)["serverInfo"].(map[string]any)
+	if info["name"] != "azurerm-index" || info["version"] != "2.3.0" {
+		t.Fatalf("expected configured serverInfo, got %v", info)
+	}
+}
+
 func TestHandleInitializeAndToolsList(t *testing.T) {
 	var buf bytes.Buffer
 	s := NewServer("test.db", "", "org", "repo")
@@ -57,7 +101,7 @@ func TestStartSyncJobCompletes(t *testing.T) {
 	s := NewServer("test.db", "", "org", "repo")
 
 	done := make(chan struct{})
-	job := s.startSyncJob("test", func() (*indexer.SyncProgress, error) {
+	job := s.startSyncJob("test", func(context.Context) (*indexer.SyncProgress, error) {
 		close(done)
 		return &indexer.SyncProgress{UpdatedRepos: []string{"repo"}}, nil
 	})
@@ -72,7 +116,7 @@ func TestStartSyncJobCompletes(t *testing.T) {
 func TestStartSyncJobError(t *testing.T) {
 	s := NewServer("test.db", "", "org", "repo")
 
-	job := s.startSyncJob("test-error", func() (*indexer.SyncProgress, error) {
+	job := s.startSyncJob("test-error", func(context.Context) (*indexer.SyncProgress, error) {
 		return nil, fmt.Errorf("boom")
 	})
 
@@ -106,6 +150,292 @@ func TestRunLoopStopsWithContext(t *testing.T) {
 	}
 }
 
+func TestRunToolCallsDoNotBlockLoop(t *testing.T) {
+	release := make(chan struct{})
+	s := NewServer("test.db", "", "org", "repo")
+	s.db = testutil.NewTestDB(t)
+	s.syncer = &blockingSyncer{release: release}
+
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	runDone := make(chan error, 1)
+	go func() {
+		runDone <- s.Run(context.Background(), inR, outW)
+		outW.Close()
+	}()
+
+	scanner := bufio.NewScanner(outR)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	next := func() Message {
+		t.Helper()
+		if !scanner.Scan() {
+			t.Fatalf("expected a response line: %v", scanner.Err())
+		}
+		return decodeMessage(t, scanner.Text())
+	}
+
+	fmt.Fprintln(inW, `{"jsonrpc":"2.0","method":"tools/call","id":1,"params":{"name":"sync_updates_provider","arguments":{}}}`)
+	fmt.Fprintln(inW, `{"jsonrpc":"2.0","method":"tools/list","id":2}`)
+
+	if msg := next(); msg.ID != float64(2) {
+		t.Fatalf("expected tools/list to answer while the slow tool is running, got id %v", msg.ID)
+	}
+
+	close(release)
+	if msg := next(); msg.ID != float64(1) || msg.Error != nil {
+		t.Fatalf("expected slow tool response after release, got %+v", msg)
+	}
+
+	inW.Close()
+	if err := <-runDone; err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+}
+
+func TestRunShutdownCancelsJobsAndClosesDB(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	syncer := &cancellableSyncer{started: make(chan struct{})}
+	s := NewServer("test.db", "", "org", "repo")
+	s.db = db
+	s.syncer = syncer
+
+	inR, inW := io.Pipe()
+	defer inW.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	runDone := make(chan error, 1)
+	go func() {
+		runDone <- s.Run(ctx, inR, io.Discard)
+	}()
+
+	fmt.Fprintln(inW, `{"jsonrpc":"2.0","method":"tools/call","id":1,"params":{"name":"sync_provider","arguments":{}}}`)
+	select {
+	case <-syncer.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sync job did not start")
+	}
+
+	cancel()
+	select {
+	case err := <-runDone:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected Run to stop with context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+
+	jobs := s.listJobs()
+	if len(jobs) != 1 || jobs[0].Status != "failed" || !strings.Contains(jobs[0].Error, "context canceled") {
+		t.Fatalf("expected the running sync to be cancelled before shutdown finished, got %+v", jobs)
+	}
+	if _, err := db.ListRepositories(); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Fatalf("expected the database to be closed, got %v", err)
+	}
+	resp, _ := s.dispatchTool(context.Background(), "list_resources", map[string]any{})
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "closed") {
+		t.Fatalf("expected handlers running after shutdown to report the closed database, got %q", text)
+	}
+
+	job := s.startSyncJob("full_sync", func(context.Context) (*indexer.SyncProgress, error) {
+		t.Error("no sync job should run after shutdown")
+		return nil, nil
+	})
+	if job.Status != "failed" || !strings.Contains(job.Error, "shutting down") {
+		t.Fatalf("expected new jobs to be refused after shutdown, got %+v", job)
+	}
+}
+
+func TestDispatchToolReadOnlyRejectsWriteTools(t *testing.T) {
+	s := NewServer("test.db", "", "org", "repo", WithDBMode(database.ModeReadOnly))
+	s.db = testutil.NewTestDB(t)
+
+	resp, found := s.dispatchTool(context.Background(), "sync_provider", nil)
+	if !found {
+		t.Fatalf("expected sync_provider to be recognised")
+	}
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "read-only") {
+		t.Fatalf("expected read-only rejection, got %q", text)
+	}
+
+	resp, _ = s.dispatchTool(context.Background(), "list_resources", map[string]any{})
+	if text := resp["content"].([]ContentBlock)[0].Text; strings.Contains(text, "read-only") {
+		t.Fatalf("query tools should remain available, got %q", text)
+	}
+}
+
+func TestEnsureDBCorruptFile(t *testing.T) {
+	writeGarbage := func(t *testing.T) string {
+		t.Helper()
+		dbPath := filepath.Join(t.TempDir(), "azurerm-provider.db")
+		if err := os.WriteFile(dbPath, []byte(strings.Repeat("garbage", 1024)), 0o644); err != nil {
+			t.Fatalf("write garbage: %v", err)
+		}
+		return dbPath
+	}
+
+	t.Run("friendly error", func(t *testing.T) {
+		dbPath := writeGarbage(t)
+		s := NewServer(dbPath, "", "org", "repo")
+
+		resp, _ := s.dispatchTool(context.Background(), "list_resources", map[string]any{})
+		text := resp["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "corrupt") || !strings.Contains(text, "sync_provider") || !strings.Contains(text, "--auto-repair") {
+			t.Fatalf("expected rebuild guidance, got %q", text)
+		}
+	})
+
+	t.Run("auto repair", func(t *testing.T) {
+		dbPath := writeGarbage(t)
+		s := NewServer(dbPath, "", "org", "repo", WithAutoRepair(true))
+
+		if err := s.ensureDB(); err != nil {
+			if strings.Contains(err.Error(), "fts5") {
+				t.Skipf("sqlite3 built without fts5 module: %v", err)
+			}
+			t.Fatalf("expected auto-repair to recreate the index, got %v", err)
+		}
+		t.Cleanup(func() { _ = s.db.Close() })
+
+		backups, _ := filepath.Glob(dbPath + ".corrupt-*")
+		if len(backups) != 1 {
+			t.Fatalf("expected corrupt file moved aside, got %v", backups)
+		}
+	})
+}
+
+func TestHandleToolsCallTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	s := NewServer("test.db", "", "org", "repo", WithToolTimeout(20*time.Millisecond))
+	s.db = testutil.NewTestDB(t)
+	s.syncer = &blockingSyncer{release: release}
+	var buf bytes.Buffer
+	s.writer = &buf
+
+	if got := s.timeoutFor("list_resources"); got != 20*time.Millisecond {
+		t.Fatalf("expected configured default timeout, got %s", got)
+	}
+	if got := s.timeoutFor("sync_updates_provider"); got != longRunningToolTimeouts["sync_updates_provider"] {
+		t.Fatalf("expected long-running override, got %s", got)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	s.handleToolsCall(ctx, Message{
+		JSONRPC: "2.0",
+		ID:      7,
+		Params:  map[string]any{"name": "sync_updates_provider", "arguments": map[string]any{}},
+	})
+
+	msg := decodeMessage(t, buf.String())
+	if msg.ID != float64(7) {
+		t.Fatalf("expected response for id 7, got %+v", msg)
+	}
+	result := msg.Result.(map[string]any)
+	text := result["content"].([]any)[0].(map[string]any)["text"].(string)
+	if !strings.Contains(text, "timed out") {
+		t.Fatalf("expected timeout message, got %s", text)
+	}
+}
+
+func TestShutdownWaitsForTimedOutHandlers(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	release := make(chan struct{})
+	s := NewServer("test.db", "", "org", "repo")
+	s.db = db
+	s.syncer = &blockingSyncer{release: release}
+	var buf bytes.Buffer
+	s.writer = &buf
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	s.handleToolsCall(ctx, Message{
+		JSONRPC: "2.0",
+		ID:      1,
+		Params:  map[string]any{"name": "sync_updates_provider", "arguments": map[string]any{}},
+	})
+	if text := decodeMessage(t, buf.String()).Result.(map[string]any)["content"].([]any)[0].(map[string]any)["text"].(string); !strings.Contains(text, "timed out") {
+		t.Fatalf("expected the tool to time out, got %s", text)
+	}
+
+	// The handler is still blocked in SyncUpdates; shutdown must not close the database under it.
+	shutdownDone := make(chan struct{})
+	go func() {
+		s.shutdown()
+		close(shutdownDone)
+	}()
+	select {
+	case <-shutdownDone:
+		t.Fatal("shutdown returned while a timed-out handler was still running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if _, err := db.ListRepositories(); err != nil {
+		t.Fatalf("expected the database to stay open for the running handler, got %v", err)
+	}
+
+	close(release)
+	select {
+	case <-shutdownDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not return after the handler finished")
+	}
+	if _, err := db.ListRepositories(); err == nil || !strings.Contains(err.Error(), "closed") {
+		t.Fatalf("expected the database to be closed after the handler finished, got %v", err)
+	}
+}
+
+func TestHandleToolsCallPlainStyle(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+
+	s := NewServer("test.db", "", "org", "terraform-provider-azurerm", WithStyle(formatter.Style{Plain: true}))
+	s.db = db
+	var buf bytes.Buffer
+	s.writer = &buf
+
+	s.handleToolsCall(context.Background(), Message{
+		JSONRPC: "2.0",
+		ID:      8,
+		Params:  map[string]any{"name": "list_resources", "arguments": map[string]any{}},
+	})
+
+	msg := decodeMessage(t, buf.String())
+	text := msg.Result.(map[string]any)["content"].([]any)[0].(map[string]any)["text"].(string)
+	if !strings.Contains(text, "azurerm_example") {
+		t.Fatalf("expected resource in output, got %s", text)
+	}
+	if strings.Contains(text, "**") || strings.Contains(text, "# ") || strings.Contains(text, "|") {
+		t.Fatalf("expected plain output without markdown markup, got %s", text)
+	}
+}
+
+func TestHandleToolsCallPlainStyleKeepsFileContent(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	content := "# Example\n\n| Name | Type |\n|------|------|\n| `name` | string |\n"
+	testutil.InsertFile(t, db, repo.ID, "README.md", "markdown", content)
+
+	s := NewServer("test.db", "", "org", "terraform-provider-azurerm", WithStyle(formatter.Style{Plain: true}))
+	s.db = db
+	var buf bytes.Buffer
+	s.writer = &buf
+
+	s.handleToolsCall(context.Background(), Message{
+		JSONRPC: "2.0",
+		ID:      9,
+		Params:  map[string]any{"name": "get_file_content", "arguments": map[string]any{"file_path": "README.md"}},
+	})
+
+	msg := decodeMessage(t, buf.String())
+	text := msg.Result.(map[string]any)["content"].([]any)[0].(map[string]any)["text"].(string)
+	if !strings.Contains(text, content) {
+		t.Fatalf("expected file content unchanged in plain style, got %s", text)
+	}
+}
+
 func decodeMessage(t *testing.T, data string) Message {
 	t.Helper()
 	var msg Message
@@ -131,7 +461,7 @@ func TestHandleSyncStatusAndJobList(t *testing.T) {
 	s := NewServer("test.db", "", "org", "repo")
 
 	done := make(chan struct{})
-	job := s.startSyncJob("test", func() (*indexer.SyncProgress, error) {
+	job := s.startSyncJob("test", func(context.Context) (*indexer.SyncProgress, error) {
 		close(done)
 		return &indexer.SyncProgress{UpdatedRepos: []string{"repo"}}, nil
 	})
@@ -152,6 +482,59 @@ func TestHandleSyncStatusAndJobList(t *testing.T) {
 	}
 }
 
+func TestHandleSyncStatusRunningJob(t *testing.T) {
+	s := NewServer("test.db", "", "org", "repo")
+
+	release := make(chan struct{})
+	defer close(release)
+	job := s.startSyncJob("full_sync", func(context.Context) (*indexer.SyncProgress, error) {
+		<-release
+		return &indexer.SyncProgress{}, nil
+	})
+	if job.Progress == nil {
+		t.Fatalf("expected progress attached to the job as soon as it starts")
+	}
+	s.updateJobProgress(job.ID, &indexer.SyncProgress{TotalRepos: 4, ProcessedRepos: 1, CurrentRepo: "terraform-provider-azurerm"})
+
+	resp := s.handleSyncStatus(map[string]any{"job_id": job.ID})
+	text := resp["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{"RUNNING", "Elapsed:", "Processed: 1/4 repositories", "Current repository: terraform-provider-azurerm", "Check again in 10 seconds."} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in running job status, got: %s", want, text)
+		}
+	}
+
+	list := s.handleSyncStatus(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(list, "RUNNING (1/4 repositories)") {
+		t.Fatalf("expected running counts in job list, got: %s", list)
+	}
+}
+
+func TestSuggestedPollInterval(t *testing.T) {
+	s := NewServer("test.db", "", "org", "repo")
+	cases := []struct {
+		elapsed, typical, want time.Duration
+	}{
+		{elapsed: 5 * time.Second, want: 10 * time.Second},
+		{elapsed: 2 * time.Minute, want: 30 * time.Second},
+		{elapsed: 10 * time.Minute, want: time.Minute},
+		{elapsed: time.Minute, typical: 3 * time.Minute, want: time.Minute},
+		{elapsed: time.Minute, typical: 80 * time.Second, want: 10 * time.Second},
+		{elapsed: time.Minute, typical: 62 * time.Second, want: 5 * time.Second},
+		{elapsed: 4 * time.Minute, typical: 3 * time.Minute, want: 30 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := s.suggestedPollInterval(tc.elapsed, tc.typical); got != tc.want {
+			t.Fatalf("suggestedPollInterval(%s, %s) = %s, want %s", tc.elapsed, tc.typical, got, tc.want)
+		}
+	}
+
+	fixed := NewServer("test.db", "", "org", "repo", WithPollInterval(45*time.Second))
+	if got := fixed.suggestedPollInterval(5*time.Second, 0); got != 45*time.Second {
+		t.Fatalf("expected configured interval, got %s", got)
+	}
+}
+
 func TestHandleToolsCallKeyHandlers(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -273,7 +656,7 @@ var Features = map[string]struct{
 		t.Run(tt.name, func(t *testing.T) {
 			var buf bytes.Buffer
 			s.writer = &buf
-			s.handleToolsCall(Message{
+			s.handleToolsCall(context.Background(), Message{
 				JSONRPC: "2.0",
 				ID:      99,
 				Params: map[string]any{
@@ -356,6 +739,7 @@ func TestHandleListResources(t *testing.T) {
 	testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/vnet.go")
 	testutil.InsertResource(t, db, repo.ID, "azurerm_subnet", "resource", "internal/services/network/subnet.go")
 	testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "data_source", "internal/services/network/vnet_data.go")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_machine_power", "action", "internal/services/compute/virtual_machine_power_action.go")
 
 	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
 	s.db = db
@@ -390,6 +774,13 @@ func TestHandleListResources(t *testing.T) {
 		}
 	})
 
+	t.Run("filter_by_kind_action", func(t *testing.T) {
+		text := s.handleListResources(map[string]any{"kind": "action"})["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "azurerm_virtual_machine_power") || strings.Contains(text, "azurerm_subnet") {
+			t.Fatalf("expected only action definitions, got %s", text)
+		}
+	})
+
 	t.Run("invalid_kind_returns_error", func(t *testing.T) {
 		resp := s.handleListResources(map[string]any{"kind": "invalid"})
 		content := resp["content"].([]ContentBlock)
@@ -399,6 +790,50 @@ func TestHandleListResources(t *testing.T) {
 	})
 }
 
+func TestHandleListResourcesByCategory(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	insertService := func(name, categories string) sql.NullInt64 {
+		id, err := db.InsertProviderService(&database.ProviderService{
+			RepositoryID:      repo.ID,
+			Name:              name,
+			WebsiteCategories: sql.NullString{String: categories, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("insert service: %v", err)
+		}
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+	insertResource := func(name, kind string, serviceID sql.NullInt64) {
+		if _, err := db.InsertProviderResource(&database.ProviderResource{RepositoryID: repo.ID, ServiceID: serviceID, Name: name, Kind: kind}); err != nil {
+			t.Fatalf("insert resource: %v", err)
+		}
+	}
+	containers := insertService("ContainerServices", "Container")
+	databases := insertService("CosmosDB", "CosmosDB (DocumentDB),Database")
+	insertResource("azurerm_kubernetes_cluster", "resource", containers)
+	insertResource("azurerm_kubernetes_cluster", "data_source", containers)
+	insertResource("azurerm_cosmosdb_account", "resource", databases)
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleListResources(map[string]any{"category": "database"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "azurerm_cosmosdb_account") || strings.Contains(text, "azurerm_kubernetes_cluster") {
+		t.Fatalf("expected only the Database category, got %s", text)
+	}
+
+	text = s.handleListResources(map[string]any{"category": "Container", "kind": "data_source"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "azurerm_kubernetes_cluster") || !strings.Contains(text, "(1)") {
+		t.Fatalf("expected the container data source only, got %s", text)
+	}
+
+	text = s.handleListResources(map[string]any{"category": "Networking"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "Available categories: Container, CosmosDB (DocumentDB), Database") {
+		t.Fatalf("expected available categories on a miss, got %s", text)
+	}
+}
+
 func TestHandleGetResourceSchema(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -420,6 +855,11 @@ func TestHandleGetResourceSchema(t *testing.T) {
 		Type:     sql.NullString{String: "List", Valid: true},
 		Required: true,
 	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:     "guid",
+		Type:     sql.NullString{String: "String", Valid: true},
+		Computed: true,
+	})
 
 	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
 	s.db = db
@@ -445,6 +885,21 @@ func TestHandleGetResourceSchema(t *testing.T) {
 		}
 	})
 
+	t.Run("section", func(t *testing.T) {
+		call := func(section string) string {
+			return s.handleGetResourceSchema(map[string]any{"name": "azurerm_virtual_network", "section": section})["content"].([]ContentBlock)[0].Text
+		}
+		if text := call("arguments"); !strings.Contains(text, "address_space") || strings.Contains(text, "guid") {
+			t.Fatalf("expected only configurable arguments, got %q", text)
+		}
+		if text := call("attributes"); !strings.Contains(text, "guid") || strings.Contains(text, "address_space") {
+			t.Fatalf("expected only computed outputs, got %q", text)
+		}
+		if text := call("outputs"); !strings.Contains(text, "section must be") {
+			t.Fatalf("expected invalid section error, got %q", text)
+		}
+	})
+
 	t.Run("resource_not_found", func(t *testing.T) {
 		resp := s.handleGetResourceSchema(map[string]any{"name": "azurerm_nonexistent"})
 		content := resp["content"].([]ContentBlock)
@@ -509,6 +964,49 @@ func TestHandleSearchResourceAttributes(t *testing.T) {
 			t.Fatalf("expected sensitive attributes, got %q", text)
 		}
 	})
+
+	t.Run("exclude_flags", func(t *testing.T) {
+		resp := s.handleSearchResourceAttributes(map[string]any{
+			"name_contains": "subnet_id",
+			"exclude_flags": []string{"required"},
+			"compact":       true,
+		})
+		content := resp["content"].([]ContentBlock)
+		text := content[0].Text
+		if !strings.Contains(text, "azurerm_subnet") || strings.Contains(text, "azurerm_virtual_network") {
+			t.Fatalf("expected only the optional subnet_id, got %q", text)
+		}
+	})
+}
+
+func TestHandleSearchAttributeDescriptions(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	storage := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{
+		Name:        "min_tls_version",
+		Optional:    true,
+		Description: sql.NullString{String: "The minimum supported TLS version for the storage account.", Valid: true},
+	})
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{
+		Name:        "access_tier",
+		Optional:    true,
+		Description: sql.NullString{String: "Defines the access tier for the account.", Valid: true},
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleSearchAttributeDescriptions(map[string]any{"query": "the field that sets the TLS minimum version"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| 1 | azurerm_storage_account | `min_tls_version` |") {
+		t.Fatalf("expected min_tls_version ranked first, got %s", text)
+	}
+
+	resp = s.handleSearchAttributeDescriptions(map[string]any{"query": "  "})
+	if text := resp["content"].([]ContentBlock)[0].Text; text != "query is required" {
+		t.Fatalf("expected query validation error, got %s", text)
+	}
 }
 
 func TestHandleSearchCode(t *testing.T) {
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+)
+
+// maxSimilarResults caps find_similar_resources output; larger limits are clamped with a note.
+const maxSimilarResults = 50
+
+type attributeNameSet map[string]struct{}
+
+// attributeNameIndex caches the attribute-name set of every resource so find_similar_resources
+// does not query each resource's attributes on every call. The cache is rebuilt when the
+// attribute table's fingerprint changes, which happens on every sync.
+type attributeNameIndex struct {
+	mu          sync.Mutex
+	fingerprint database.AttributeFingerprint
+	sets        map[int64]attributeNameSet
+}
+
+// load returns the cached sets, rebuilding them first when the index is stale. The returned map
+// is shared and must not be modified.
+func (idx *attributeNameIndex) load(db *database.DB) (map[int64]attributeNameSet, error) {
+	fp, err := db.GetAttributeFingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.sets != nil && idx.fingerprint == fp {
+		return idx.sets, nil
+	}
+
+	names, err := db.ListAttributeNamesByResource("resource")
+	if err != nil {
+		return nil, err
+	}
+	sets := make(map[int64]attributeNameSet, len(names))
+	for resourceID, list := range names {
+		sets[resourceID] = newAttributeNameSet(list)
+	}
+	idx.sets, idx.fingerprint = sets, fp
+	return sets, nil
+}
+
+func newAttributeNameSet(names []string) attributeNameSet {
+	set := make(attributeNameSet, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// similarityAbove returns the Jaccard similarity of a and b and their shared names when it is at
+// least threshold. The size ratio bounds the score from above, so pairs that cannot reach the
+// threshold are rejected before their intersection is counted.
+func similarityAbove(a, b attributeNameSet, threshold float64) (float64, []string, bool) {
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	if len(large) == 0 || float64(len(small))/float64(len(large)) < threshold {
+		return 0, nil, false
+	}
+
+	var common []string
+	for name := range small {
+		if _, ok := large[name]; ok {
+			common = append(common, name)
+		}
+	}
+	score := float64(len(common)) / float64(len(a)+len(b)-len(common))
+	if score < threshold {
+		return 0, nil, false
+	}
+	sort.Strings(common)
+	return score, common, true
+}
@@ -1,6 +1,12 @@
 package mcp
 
-import "github.com/dkooll/aztfmcp/internal/indexer"
+import (
+	"context"
+	"fmt"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/indexer"
+)
 
 type fakeSyncer struct {
 	fullProgress   *indexer.SyncProgress
@@ -8,12 +14,17 @@ type fakeSyncer struct {
 	err            error
 	compareResult  *indexer.GitHubCompareResult
 	compareErr     error
+	tags           []indexer.GitHubTag
+	tagsErr        error
+	schemas        map[string][]database.ProviderAttribute // keyed by "ref|resource"
+	cacheStats     indexer.CacheStats
+	cacheCleared   int
 }
 
 // Compile-time check: fakeSyncer implements the syncer interface used by Server.
 var _ Syncer = (*fakeSyncer)(nil)
 
-func (f *fakeSyncer) SyncAll() (*indexer.SyncProgress, error) {
+func (f *fakeSyncer) SyncAll(context.Context) (*indexer.SyncProgress, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -23,7 +34,7 @@ func (f *fakeSyncer) SyncAll() (*indexer.SyncProgress, error) {
 	return &indexer.SyncProgress{}, nil
 }
 
-func (f *fakeSyncer) SyncUpdates() (*indexer.SyncProgress, error) {
+func (f *fakeSyncer) SyncUpdates(context.Context) (*indexer.SyncProgress, error) {
 	if f.err != nil {
 		return nil, f.err
 	}
@@ -39,3 +50,52 @@ func (f *fakeSyncer) CompareTags(_, _ string) (*indexer.GitHubCompareResult, err
 	}
 	return f.compareResult, nil
 }
+
+func (f *fakeSyncer) ListTags() ([]indexer.GitHubTag, error) {
+	if f.tagsErr != nil {
+		return nil, f.tagsErr
+	}
+	return f.tags, nil
+}
+
+func (f *fakeSyncer) ResourceSchemaAtRef(_, ref, resourceName string) ([]database.ProviderAttribute, error) {
+	attrs, ok := f.schemas[ref+"|"+resourceName]
+	if !ok {
+		return nil, fmt.Errorf("404 Not Found")
+	}
+	return attrs, nil
+}
+
+func (f *fakeSyncer) ClearCache() int {
+	cleared := f.cacheStats.Entries
+	f.cacheStats = indexer.CacheStats{TTL: f.cacheStats.TTL}
+	f.cacheCleared++
+	return cleared
+}
+
+func (f *fakeSyncer) CacheStats() indexer.CacheStats {
+	return f.cacheStats
+}
+
+// blockingSyncer holds SyncUpdates open until release is closed, simulating a slow GitHub call.
+type blockingSyncer struct {
+	fakeSyncer
+	release chan struct{}
+}
+
+func (b *blockingSyncer) SyncUpdates(context.Context) (*indexer.SyncProgress, error) {
+	<-b.release
+	return &indexer.SyncProgress{}, nil
+}
+
+// cancellableSyncer runs a full sync that only returns once its context is cancelled.
+type cancellableSyncer struct {
+	fakeSyncer
+	started chan struct{}
+}
+
+func (c *cancellableSyncer) SyncAll(ctx context.Context) (*indexer.SyncProgress, error) {
+	close(c.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
@@ -2,8 +2,11 @@ package mcp
 
 import (
 	"fmt"
+	"log"
 	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/dkooll/aztfmcp/internal/database"
 	"github.com/dkooll/aztfmcp/internal/formatter"
@@ -65,11 +68,7 @@ func (s *Server) handleAnalyzeUpdateBehavior(args any) map[string]any {
 			suggestions = append(suggestions, fmt.Sprintf("- %s (%s)", attr.Name, tag))
 		}
 
-		text := fmt.Sprintf(
-			"# Update Behavior: %s.%s\n\nAttribute '%s' not found in resource schema.\n\nClosest available attributes (ForceNew/in-place):\n%s\n",
-			resourceName, attributePath, attributePath, strings.Join(suggestions, "\n"),
-		)
-		return SuccessResponse(text)
+		return SuccessResponse(formatter.UpdateBehaviorAttributeMissing(s.style, resourceName, attributePath, suggestions))
 	}
 
 	source, _ := s.db.GetProviderResourceSource(resource.ID)
@@ -81,6 +80,7 @@ func (s *Server) handleAnalyzeUpdateBehavior(args any) map[string]any {
 	}
 
 	text := formatter.UpdateBehaviorAnalysis(
+		s.style,
 		resourceName,
 		attributePath,
 		!targetAttr.ForceNew,
@@ -109,6 +109,7 @@ func (s *Server) handleCompareResources(args any) map[string]any {
 
 	resourceA, _ := argsMap["resource_a"].(string)
 	resourceB, _ := argsMap["resource_b"].(string)
+	onlyDifferences, _ := argsMap["only_differences"].(bool)
 	maxNames := 30
 	if v, ok := argsMap["max_names"].(float64); ok {
 		if v < 0 {
@@ -140,6 +141,9 @@ func (s *Server) handleCompareResources(args any) map[string]any {
 	uniqueB := findUniqueAttributes(attrsB, attrsA)
 
 	commonTrimmed, commonTruncated := trimStrings(common, maxNames)
+	if onlyDifferences {
+		commonTrimmed, commonTruncated = nil, false
+	}
 	uniqueATrimmed, aTruncated := trimStrings(uniqueA, maxNames)
 	uniqueBTrimmed, bTruncated := trimStrings(uniqueB, maxNames)
 
@@ -159,6 +163,7 @@ func (s *Server) handleCompareResources(args any) map[string]any {
 	}
 
 	text := formatter.ResourceComparison(
+		s.style,
 		resourceA,
 		resourceB,
 		similarity,
@@ -174,6 +179,9 @@ func (s *Server) handleCompareResources(args any) map[string]any {
 		uniqueBTrimmed,
 		commonTruncated || aTruncated || bTruncated,
 	)
+	if onlyDifferences {
+		text += formatter.AttributeFlagDifferences(s.style, resourceA, resourceB, attrsA, attrsB, true)
+	}
 
 	return SuccessResponse(text)
 }
@@ -213,38 +221,53 @@ func (s *Server) handleFindSimilarResources(args any) map[string]any {
 		return map[string]any{"error": fmt.Sprintf("Failed to get attributes: %v", err)}
 	}
 
+	capped := limit <= 0 || limit > maxSimilarResults
+	if capped {
+		limit = maxSimilarResults
+	}
+
 	allResources, err := s.db.ListProviderResources("resource", 0)
 	if err != nil {
 		return map[string]any{"error": fmt.Sprintf("Failed to list resources: %v", err)}
 	}
 
-	similarities := []SimilarityScore{}
+	started := time.Now()
+	nameSets, err := s.attributeNames.load(s.db)
+	if err != nil {
+		return map[string]any{"error": fmt.Sprintf("Failed to load attribute names: %v", err)}
+	}
+
+	targetNames := make([]string, len(targetAttrs))
+	for i, attr := range targetAttrs {
+		targetNames[i] = attr.Name
+	}
+	targetSet := newAttributeNameSet(targetNames)
 
+	similarities := []SimilarityScore{}
+	compared := 0
 	for _, resource := range allResources {
 		if resource.ID == targetResource.ID {
 			continue
 		}
 
-		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
-		if err != nil {
+		compared++
+		score, common, ok := similarityAbove(targetSet, nameSets[resource.ID], threshold)
+		if !ok {
 			continue
 		}
-
-		score := calculateJaccardSimilarity(targetAttrs, attrs)
-
-		if score >= threshold {
-			similarities = append(similarities, SimilarityScore{
-				Resource:         resource,
-				Score:            score,
-				CommonAttributes: findCommonAttributes(targetAttrs, attrs),
-			})
-		}
+		similarities = append(similarities, SimilarityScore{
+			Resource:         resource,
+			Score:            score,
+			CommonAttributes: common,
+		})
 	}
+	log.Printf("Debug: find_similar_resources compared %s against %d resources in %s", targetResource.Name, compared, time.Since(started).Round(time.Microsecond))
 
-	sort.Slice(similarities, func(i, j int) bool {
+	sort.SliceStable(similarities, func(i, j int) bool {
 		return similarities[i].Score > similarities[j].Score
 	})
 
+	matches := len(similarities)
 	if len(similarities) > limit {
 		similarities = similarities[:limit]
 	}
@@ -260,15 +283,161 @@ func (s *Server) handleFindSimilarResources(args any) map[string]any {
 	}
 
 	text := formatter.SimilarResources(
+		s.style,
 		resourceName,
 		threshold,
-		len(similarities),
+		matches,
 		formatterResources,
 	)
+	if capped && matches > limit {
+		text += formatter.SimilarResourcesCapped(s.style, limit, matches, maxSimilarResults)
+	}
 
 	return SuccessResponse(text)
 }
 
+// Match tiers reported by find_similar_attributes, strongest first.
+const (
+	attributeMatchExact       = "exact name"
+	attributeMatchNormalized  = "normalized name"
+	attributeMatchDescription = "description"
+)
+
+// attributeDescriptionThreshold is the minimum Jaccard similarity of two descriptions' word sets.
+const attributeDescriptionThreshold = 0.3
+
+// attributeNameFillers are name words that rarely change meaning, so `enabled` style flags and
+// their `is_`/`_enabled` spellings normalize to the same key.
+var attributeNameFillers = map[string]bool{"is": true, "enable": true, "enabled": true, "the": true}
+
+func (s *Server) handleFindSimilarAttributes(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceA string `json:"resource_a"`
+		Attribute string `json:"attribute"`
+		ResourceB string `json:"resource_b"`
+		Limit     int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	if params.ResourceA == "" || params.Attribute == "" || params.ResourceB == "" {
+		return ErrorResponse("resource_a, attribute and resource_b are required")
+	}
+	limit := params.Limit
+	if limit <= 0 || limit > maxSimilarResults {
+		limit = 10
+	}
+
+	schemaA, err := s.flattenedResourceSchema(params.ResourceA)
+	if err != nil {
+		return s.resourceNotFound(params.ResourceA)
+	}
+	source, ok := schemaA[params.Attribute]
+	if !ok {
+		return ErrorResponse(fmt.Sprintf("Attribute '%s' not found on %s", params.Attribute, params.ResourceA))
+	}
+	schemaB, err := s.flattenedResourceSchema(params.ResourceB)
+	if err != nil {
+		return s.resourceNotFound(params.ResourceB)
+	}
+
+	matches := similarAttributes(params.Attribute, source, schemaB)
+	total := len(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return SuccessResponse(formatter.SimilarAttributes(s.style, params.ResourceA, params.Attribute, params.ResourceB, source, matches, total))
+}
+
+// flattenedResourceSchema loads a resource's attributes keyed by dotted path.
+func (s *Server) flattenedResourceSchema(name string) (map[string]database.NestedAttribute, error) {
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return nil, err
+	}
+	return flattenSchema(attrs), nil
+}
+
+// similarAttributes ranks the attributes of target against source: exact leaf-name matches first,
+// then normalized-name matches, then attributes whose descriptions overlap. Each candidate is
+// reported once, under the strongest tier it reaches.
+func similarAttributes(path string, source database.NestedAttribute, target map[string]database.NestedAttribute) []formatter.AttributeMatch {
+	sourceKey := normalizeAttributeName(source.Name)
+	sourceWords := descriptionWordSet(source.Description)
+
+	var matches []formatter.AttributeMatch
+	for candidatePath, attr := range target {
+		match := formatter.AttributeMatch{Path: candidatePath, Attribute: attr}
+		switch {
+		case attr.Name == source.Name:
+			match.Match, match.Score = attributeMatchExact, 1
+			if candidatePath != path {
+				match.Score = 0.9
+			}
+		case sourceKey != "" && normalizeAttributeName(attr.Name) == sourceKey:
+			match.Match, match.Score = attributeMatchNormalized, 0.8
+		default:
+			score, _, ok := similarityAbove(sourceWords, descriptionWordSet(attr.Description), attributeDescriptionThreshold)
+			if !ok {
+				continue
+			}
+			match.Match, match.Score = attributeMatchDescription, score
+		}
+		matches = append(matches, match)
+	}
+
+	tier := map[string]int{attributeMatchExact: 0, attributeMatchNormalized: 1, attributeMatchDescription: 2}
+	sort.Slice(matches, func(i, j int) bool {
+		if tier[matches[i].Match] != tier[matches[j].Match] {
+			return tier[matches[i].Match] < tier[matches[j].Match]
+		}
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Path < matches[j].Path
+	})
+	return matches
+}
+
+// normalizeAttributeName reduces a name to its meaningful words, dropping filler words and plural
+// endings, so public_network_access_enabled and public_network_access compare equal.
+func normalizeAttributeName(name string) string {
+	var words []string
+	for _, word := range strings.Split(aliasKey(name, ""), "_") {
+		if word == "" || attributeNameFillers[word] {
+			continue
+		}
+		if len(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			word = strings.TrimSuffix(word, "s")
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, "_")
+}
+
+// descriptionWordSet collects the lowercase words of a description, ignoring short words so
+// articles and prepositions do not inflate the similarity of unrelated descriptions.
+func descriptionWordSet(description string) attributeNameSet {
+	words := strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+	kept := words[:0]
+	for _, word := range words {
+		if len(word) > 3 {
+			kept = append(kept, word)
+		}
+	}
+	return newAttributeNameSet(kept)
+}
+
 func (s *Server) handleExplainBreakingChange(args any) map[string]any {
 	if err := s.ensureDB(); err != nil {
 		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
@@ -314,6 +483,7 @@ func (s *Server) handleExplainBreakingChange(args any) map[string]any {
 	}
 
 	text := formatter.BreakingChangeExplanation(
+		s.style,
 		resourceName,
 		attributeName,
 		targetAttr.ForceNew,
@@ -409,6 +579,7 @@ func (s *Server) handleSuggestValidationImprovements(args any) map[string]any {
 	}
 
 	text := formatter.ValidationSuggestions(
+		s.style,
 		resourceName,
 		len(attrs),
 		len(suggestions),
@@ -478,6 +649,7 @@ func (s *Server) handleTraceAttributeDependencies(args any) map[string]any {
 	}
 
 	text := formatter.AttributeDependencies(
+		s.style,
 		resourceName,
 		attributeName,
 		conflicts,
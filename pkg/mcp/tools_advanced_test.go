@@ -67,6 +67,34 @@ func TestHandleCompareResources(t *testing.T) {
 	}
 }
 
+func TestHandleCompareResourcesOnlyDifferences(t *testing.T) {
+	s, resource := setupServerWithResource(t, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true, ForceNew: true})
+	testutil.InsertAttribute(t, s.db, resource.ID, database.ProviderAttribute{Name: "location", Type: sqlNull("pluginsdk.TypeString"), Required: true, ForceNew: true})
+	other := testutil.InsertResource(t, s.db, resource.RepositoryID, "azurerm_other", "resource", "")
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true})
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "location", Type: sqlNull("pluginsdk.TypeString"), Required: true, ForceNew: true})
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "other_only"})
+
+	text := s.handleCompareResources(map[string]any{
+		"resource_a":       resource.Name,
+		"resource_b":       other.Name,
+		"only_differences": true,
+	})["content"].([]ContentBlock)[0].Text
+
+	if strings.Contains(text, "### Shared Attributes") || strings.Contains(text, "| location |") {
+		t.Fatalf("expected shared attributes omitted, got %s", text)
+	}
+	for _, want := range []string{
+		"### Unique to azurerm_other\n\n- `other_only`",
+		"### Flag Differences (1)",
+		"| name | String, required+force_new | String, required |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in comparison, got %s", want, text)
+		}
+	}
+}
+
 func TestHandleFindSimilarResources(t *testing.T) {
 	s, resource := setupServerWithResource(t, database.ProviderAttribute{Name: "name"})
 	other := testutil.InsertResource(t, s.db, resource.RepositoryID, "azurerm_other", "resource", "")
@@ -94,6 +122,105 @@ func TestHandleFindSimilarResources(t *testing.T) {
 	}
 }
 
+func TestHandleFindSimilarResourcesRefreshesNameIndex(t *testing.T) {
+	s, resource := setupServerWithResource(t, database.ProviderAttribute{Name: "name"})
+	call := func() string {
+		return s.handleFindSimilarResources(map[string]any{
+			"resource_name":        resource.Name,
+			"similarity_threshold": 0.5,
+		})["content"].([]ContentBlock)[0].Text
+	}
+
+	if text := call(); strings.Contains(text, "azurerm_late") {
+		t.Fatalf("unexpected match before insert: %s", text)
+	}
+
+	late := testutil.InsertResource(t, s.db, resource.RepositoryID, "azurerm_late", "resource", "")
+	testutil.InsertAttribute(t, s.db, late.ID, database.ProviderAttribute{Name: "name"})
+	if text := call(); !strings.Contains(text, "azurerm_late") {
+		t.Fatalf("expected cached attribute names to refresh after the table changed, got %s", text)
+	}
+}
+
+func TestSimilarityAbove(t *testing.T) {
+	target := newAttributeNameSet([]string{"name", "location", "tags"})
+
+	score, common, ok := similarityAbove(target, newAttributeNameSet([]string{"name", "location", "sku", "tags"}), 0.7)
+	if !ok || score != 0.75 || strings.Join(common, ",") != "location,name,tags" {
+		t.Fatalf("unexpected match: score=%v common=%v ok=%v", score, common, ok)
+	}
+
+	// 3 of 10 names can never reach 0.5, whatever the overlap.
+	large := newAttributeNameSet([]string{"name", "location", "tags", "a", "b", "c", "d", "e", "f", "g"})
+	if _, _, ok := similarityAbove(target, large, 0.5); ok {
+		t.Fatal("expected the size bound to reject the pair")
+	}
+}
+
+func TestHandleFindSimilarAttributes(t *testing.T) {
+	s, resource := setupServerWithResource(t, database.ProviderAttribute{
+		Name:        "public_network_access_enabled",
+		Optional:    true,
+		Description: sqlNull("Whether public network access is allowed for this storage account"),
+	})
+	testutil.InsertAttribute(t, s.db, resource.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	other := testutil.InsertResource(t, s.db, resource.RepositoryID, "azurerm_other", "resource", "")
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "public_network_access", Optional: true})
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{
+		Name:        "allow_public_access",
+		Optional:    true,
+		Description: sqlNull("Whether public network access is allowed for this vault"),
+	})
+	testutil.InsertAttribute(t, s.db, other.ID, database.ProviderAttribute{Name: "sku_name", Required: true})
+
+	call := func(args map[string]any) string {
+		t.Helper()
+		return s.handleFindSimilarAttributes(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call(map[string]any{"resource_a": resource.Name, "attribute": "public_network_access_enabled", "resource_b": other.Name})
+	for _, want := range []string{
+		"**Candidates Found**: 2",
+		"| public_network_access | normalized name | 80% |",
+		"| allow_public_access | description |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+	if strings.Index(text, "| public_network_access |") > strings.Index(text, "| allow_public_access |") {
+		t.Fatalf("expected name matches ranked before description matches, got %s", text)
+	}
+	if strings.Contains(text, "sku_name") {
+		t.Fatalf("unexpected unrelated attribute, got %s", text)
+	}
+
+	if text := call(map[string]any{"resource_a": resource.Name, "attribute": "tags", "resource_b": other.Name}); !strings.Contains(text, "| tags | exact name | 100% |") {
+		t.Fatalf("expected exact name match, got %s", text)
+	}
+	if text := call(map[string]any{"resource_a": resource.Name, "attribute": "missing", "resource_b": other.Name}); !strings.Contains(text, "not found") {
+		t.Fatalf("expected unknown attribute error, got %s", text)
+	}
+	if text := call(map[string]any{"resource_a": resource.Name, "attribute": "tags", "resource_b": "azurerm_missing"}); !strings.Contains(text, "not found") {
+		t.Fatalf("expected unknown resource error, got %s", text)
+	}
+}
+
+func TestNormalizeAttributeName(t *testing.T) {
+	for name, want := range map[string]string{
+		"public_network_access_enabled": "public_network_access",
+		"is_hns_enabled":                "hns",
+		"ip_rules":                      "ip_rule",
+		"enabled":                       "",
+		"access":                        "access",
+	} {
+		if got := normalizeAttributeName(name); got != want {
+			t.Errorf("normalizeAttributeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func TestHandleExplainBreakingChange(t *testing.T) {
 	s, resource := setupServerWithResource(t, database.ProviderAttribute{
 		Name:     "location",
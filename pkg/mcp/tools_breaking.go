@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+func (s *Server) handleScanBreakingChanges(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		FromVersion    string `json:"from_version"`
+		ToVersion      string `json:"to_version"`
+		ResourcePrefix string `json:"resource_prefix"`
+		MaxResources   int    `json:"max_resources"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	fromVersion := strings.TrimPrefix(strings.TrimSpace(params.FromVersion), "v")
+	toVersion := strings.TrimPrefix(strings.TrimSpace(params.ToVersion), "v")
+	if fromVersion == "" || toVersion == "" {
+		return ErrorResponse("from_version and to_version are required")
+	}
+	if compareVersions(fromVersion, toVersion) >= 0 {
+		return ErrorResponse("from_version must be older than to_version")
+	}
+
+	maxResources := params.MaxResources
+	if maxResources == 0 {
+		maxResources = 25
+	} else if maxResources < 0 {
+		maxResources = 0
+	}
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+
+	if s.syncer == nil {
+		return ErrorResponse("Syncer is not initialized; run a sync first")
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository: %v", err))
+	}
+
+	releases, err := s.db.ListProviderReleases(repo.ID, 0)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load releases: %v", err))
+	}
+
+	fromTag, toTag := normalizeDiffTag(fromVersion), normalizeDiffTag(toVersion)
+	var inRange int
+	var names []string
+	seen := make(map[string]bool)
+	for _, release := range releases {
+		switch release.Version {
+		case fromVersion:
+			fromTag = ifEmpty(release.Tag, fromTag)
+		case toVersion:
+			toTag = ifEmpty(release.Tag, toTag)
+		}
+		if compareVersions(release.Version, fromVersion) <= 0 || compareVersions(release.Version, toVersion) > 0 {
+			continue
+		}
+		inRange++
+
+		entries, err := s.db.GetProviderReleaseEntries(release.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load release entries: %v", err))
+		}
+		for _, entry := range entries {
+			name := entry.ResourceName.String
+			if name == "" || seen[name] || (prefix != "" && !strings.HasPrefix(name, prefix)) {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if inRange == 0 {
+		return ErrorResponse(fmt.Sprintf("No indexed releases between v%s and v%s. Run sync_provider or backfill_release first.", fromVersion, toVersion))
+	}
+	sort.Strings(names)
+
+	var findings []formatter.BreakingChangeFinding
+	var skipped []formatter.BreakingChangeSkip
+	scanned := 0
+	for _, name := range names {
+		if maxResources > 0 && scanned >= maxResources {
+			skipped = append(skipped, formatter.BreakingChangeSkip{Resource: name, Reason: fmt.Sprintf("max_resources (%d) reached", maxResources)})
+			continue
+		}
+
+		resource, err := s.db.GetProviderResource(name)
+		if err != nil || !resource.FilePath.Valid || resource.FilePath.String == "" {
+			skipped = append(skipped, formatter.BreakingChangeSkip{Resource: name, Reason: "no indexed implementation file"})
+			continue
+		}
+		filePath := resource.FilePath.String
+
+		before, err := s.syncer.ResourceSchemaAtRef(filePath, fromTag, name)
+		if err != nil {
+			skipped = append(skipped, formatter.BreakingChangeSkip{Resource: name, Reason: fmt.Sprintf("schema unavailable at %s: %v", fromTag, err)})
+			continue
+		}
+		after, err := s.syncer.ResourceSchemaAtRef(filePath, toTag, name)
+		if err != nil {
+			skipped = append(skipped, formatter.BreakingChangeSkip{Resource: name, Reason: fmt.Sprintf("schema unavailable at %s: %v", toTag, err)})
+			continue
+		}
+
+		scanned++
+		findings = append(findings, diffBreakingSchema(name, before, after)...)
+	}
+
+	text := formatter.BreakingChangeScan(s.style, fromVersion, toVersion, inRange, scanned, findings, skipped)
+	return SuccessResponse(text)
+}
+
+// diffBreakingSchema compares two parsed schemas, including nested block children, and returns
+// the changes that can break an existing configuration on upgrade.
+func diffBreakingSchema(resource string, before, after []database.ProviderAttribute) []formatter.BreakingChangeFinding {
+	old := flattenSchema(before)
+	updated := flattenSchema(after)
+
+	paths := make([]string, 0, len(old)+len(updated))
+	for path := range old {
+		paths = append(paths, path)
+	}
+	for path := range updated {
+		if _, ok := old[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	var findings []formatter.BreakingChangeFinding
+	for _, path := range paths {
+		prev, hadPrev := old[path]
+		next, hasNext := updated[path]
+		switch {
+		case hadPrev && !hasNext:
+			if parentRemoved(path, updated) {
+				continue
+			}
+			findings = append(findings, formatter.BreakingChangeFinding{Resource: resource, Path: path, Change: "removed", Detail: "attribute no longer exists in the schema"})
+		case !hadPrev && hasNext:
+			if next.Required && !parentRemoved(path, old) {
+				findings = append(findings, formatter.BreakingChangeFinding{Resource: resource, Path: path, Change: "added_required", Detail: "new attribute must be set"})
+			}
+		default:
+			if next.ForceNew && !prev.ForceNew {
+				findings = append(findings, formatter.BreakingChangeFinding{Resource: resource, Path: path, Change: "force_new", Detail: "changes now recreate the resource"})
+			}
+			if prev.Optional && !next.Optional {
+				detail := "now computed-only"
+				if next.Required {
+					detail = "now required"
+				}
+				findings = append(findings, formatter.BreakingChangeFinding{Resource: resource, Path: path, Change: "lost_optional", Detail: detail})
+			}
+		}
+	}
+	return findings
+}
+
+func flattenSchema(attrs []database.ProviderAttribute) map[string]database.NestedAttribute {
+	out := make(map[string]database.NestedAttribute)
+	var walk func(prefix string, nodes []database.NestedAttribute)
+	walk = func(prefix string, nodes []database.NestedAttribute) {
+		for _, node := range nodes {
+			path := node.Name
+			if prefix != "" {
+				path = prefix + "." + node.Name
+			}
+			out[path] = node
+			walk(path, node.Children)
+		}
+	}
+	nodes := make([]database.NestedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		nodes = append(nodes, database.NestedAttributeFromProvider(attr))
+	}
+	walk("", nodes)
+	return out
+}
+
+// parentRemoved reports whether an ancestor block of path is missing from schema, so only the
+// outermost removed (or added) block is reported.
+func parentRemoved(path string, schema map[string]database.NestedAttribute) bool {
+	idx := strings.LastIndex(path, ".")
+	if idx < 0 {
+		return false
+	}
+	_, ok := schema[path[:idx]]
+	return !ok
+}
+
+// compareVersions orders dotted numeric versions such as 4.52.0; a missing segment counts as zero
+// and any pre-release suffix is ignored.
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := range max(len(as), len(bs)) {
+		var av, bv int
+		if i < len(as) {
+			av = leadingInt(as[i])
+		}
+		if i < len(bs) {
+			bv = leadingInt(bs[i])
+		}
+		if av != bv {
+			if av < bv {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func leadingInt(segment string) int {
+	end := 0
+	for end < len(segment) && segment[end] >= '0' && segment[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(segment[:end])
+	return n
+}
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestHandleScanBreakingChanges(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster", "resource", "internal/services/containers/kubernetes_cluster_resource.go")
+	testutil.InsertRelease(t, db, repo.ID, "4.40.0", "v4.40.0", "v4.39.0")
+	rel := testutil.InsertRelease(t, db, repo.ID, "4.41.0", "v4.41.0", "v4.40.0")
+	testutil.ReplaceReleaseEntries(t, db, rel.ID, []database.ProviderReleaseEntry{
+		{
+			ReleaseID:    rel.ID,
+			EntryKey:     "breaking-changes-4-41-0-000",
+			Section:      "BREAKING CHANGES",
+			Title:        "azurerm_kubernetes_cluster - dns_prefix now forces a new resource",
+			ResourceName: sqlNull("azurerm_kubernetes_cluster"),
+		},
+	})
+
+	nested := func(children ...database.NestedAttribute) sql.NullString {
+		return sqlNull(database.EncodeNestedSchema(children))
+	}
+	before := []database.ProviderAttribute{
+		{Name: "dns_prefix", Optional: true},
+		{Name: "sku_tier", Optional: true},
+		{Name: "legacy_flag", Optional: true},
+		{Name: "default_node_pool", Required: true, NestedBlock: true, ElemSchemaJSON: nested(
+			database.NestedAttribute{Name: "vm_size", Required: true},
+			database.NestedAttribute{Name: "os_sku", Optional: true},
+		)},
+	}
+	after := []database.ProviderAttribute{
+		{Name: "dns_prefix", Optional: true, ForceNew: true},
+		{Name: "sku_tier", Required: true},
+		{Name: "node_resource_group", Required: true},
+		{Name: "default_node_pool", Required: true, NestedBlock: true, ElemSchemaJSON: nested(
+			database.NestedAttribute{Name: "vm_size", Required: true},
+		)},
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	s.syncer = &fakeSyncer{schemas: map[string][]database.ProviderAttribute{
+		"v4.40.0|azurerm_kubernetes_cluster": before,
+		"v4.41.0|azurerm_kubernetes_cluster": after,
+	}}
+
+	resp := s.handleScanBreakingChanges(map[string]any{"from_version": "4.40.0", "to_version": "v4.41.0"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"| azurerm_kubernetes_cluster | dns_prefix | force_new |",
+		"| azurerm_kubernetes_cluster | sku_tier | lost_optional | now required |",
+		"| azurerm_kubernetes_cluster | legacy_flag | removed |",
+		"| azurerm_kubernetes_cluster | default_node_pool.os_sku | removed |",
+		"| azurerm_kubernetes_cluster | node_resource_group | added_required |",
+		"**Resources Diffed**: 1",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in scan output:\n%s", want, text)
+		}
+	}
+
+	resp = s.handleScanBreakingChanges(map[string]any{"from_version": "4.41.0", "to_version": "4.40.0"})
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "must be older") {
+		t.Fatalf("expected ordering error, got %s", text)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"4.9.0", "4.10.0", -1},
+		{"v4.52.0", "4.52.0", 0},
+		{"4.52", "4.52.0", 0},
+		{"5.0.0-beta1", "4.99.1", 1},
+	}
+	for _, tc := range cases {
+		if got := compareVersions(tc.a, tc.b); got != tc.want {
+			t.Fatalf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
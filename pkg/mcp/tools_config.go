@@ -0,0 +1,213 @@
+package mcp
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+// Issue kinds reported by validate_config.
+const (
+	configSyntaxError     = "syntax_error"
+	configUnknownArgument = "unknown_argument"
+	configMissingRequired = "missing_required"
+	configComputedOnly    = "computed_only"
+	configWrongForm       = "wrong_form"
+)
+
+// Terraform meta-arguments and meta-blocks, accepted in any resource or data block.
+var (
+	configMetaArguments = []string{"count", "for_each", "provider", "depends_on"}
+	configMetaBlocks    = []string{"lifecycle", "provisioner", "connection", "timeouts"}
+)
+
+func (s *Server) handleValidateConfig(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+		HCL          string `json:"hcl"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	if strings.TrimSpace(params.HCL) == "" {
+		return ErrorResponse("hcl is required")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+
+	file, diags := hclsyntax.ParseConfig([]byte(params.HCL), "config.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return SuccessResponse(formatter.ConfigValidation(s.style, name, "", configSyntaxIssues(diags)))
+	}
+
+	name, body, err := configResourceBody(file.Body.(*hclsyntax.Body), name)
+	if err != nil {
+		return ErrorResponse(err.Error())
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	issues := validateConfigBody(body, nestedSchemaTree(attrs), "", true)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return SuccessResponse(formatter.ConfigValidation(s.style, resource.Name, resource.Kind, issues))
+}
+
+// configResourceBody picks the body to validate: the resource or data block for name (or the only
+// such block when name is empty), or the whole input when it holds bare arguments.
+func configResourceBody(body *hclsyntax.Body, name string) (string, *hclsyntax.Body, error) {
+	var blocks []*hclsyntax.Block
+	for _, block := range body.Blocks {
+		if (block.Type == "resource" || block.Type == "data") && len(block.Labels) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+
+	if len(blocks) == 0 {
+		if name == "" {
+			return "", nil, fmt.Errorf("resource_name is required when hcl has no resource block")
+		}
+		return name, body, nil
+	}
+
+	if name == "" {
+		if len(blocks) > 1 {
+			return "", nil, fmt.Errorf("hcl declares %d resource blocks; pass resource_name to pick one", len(blocks))
+		}
+		return blocks[0].Labels[0], blocks[0].Body, nil
+	}
+
+	for _, block := range blocks {
+		if block.Labels[0] == name {
+			return name, block.Body, nil
+		}
+	}
+	return "", nil, fmt.Errorf("hcl has no resource block for '%s'", name)
+}
+
+func configSyntaxIssues(diags hcl.Diagnostics) []formatter.ConfigIssue {
+	var issues []formatter.ConfigIssue
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+		issue := formatter.ConfigIssue{Kind: configSyntaxError, Message: strings.TrimSpace(diag.Summary + ": " + diag.Detail)}
+		if diag.Subject != nil {
+			issue.Line = diag.Subject.Start.Line
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// validateConfigBody checks the arguments and blocks of body against one level of the schema tree,
+// descending into nested blocks whose children are known. Meta-arguments are only accepted at the top.
+func validateConfigBody(body *hclsyntax.Body, level []database.NestedAttribute, path string, top bool) []formatter.ConfigIssue {
+	schema := make(map[string]database.NestedAttribute, len(level))
+	for _, attr := range level {
+		schema[attr.Name] = attr
+	}
+
+	var issues []formatter.ConfigIssue
+	seen := make(map[string]bool)
+
+	names := make([]string, 0, len(body.Attributes))
+	for name := range body.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		line := body.Attributes[name].SrcRange.Start.Line
+		attr, ok := schema[name]
+		switch {
+		case !ok && top && slices.Contains(configMetaArguments, name):
+		case !ok:
+			issues = append(issues, unknownConfigArgument(path+name, name, line, level))
+		case attr.NestedBlock:
+			seen[name] = true
+			issues = append(issues, formatter.ConfigIssue{Kind: configWrongForm, Path: path + name, Line: line,
+				Message: fmt.Sprintf("'%s' is a nested block; write it as `%s { ... }`", name, name)})
+		default:
+			seen[name] = true
+			if attr.Computed && !attr.Optional && !attr.Required {
+				issues = append(issues, formatter.ConfigIssue{Kind: configComputedOnly, Path: path + name, Line: line,
+					Message: fmt.Sprintf("'%s' is computed by the provider and cannot be set", name)})
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		name := block.Type
+		content := block.Body
+		if name == "dynamic" && len(block.Labels) > 0 {
+			name = block.Labels[0]
+			content = nil
+			for _, inner := range block.Body.Blocks {
+				if inner.Type == "content" {
+					content = inner.Body
+				}
+			}
+		}
+
+		line := block.TypeRange.Start.Line
+		attr, ok := schema[name]
+		switch {
+		case !ok && top && slices.Contains(configMetaBlocks, name):
+		case !ok:
+			issues = append(issues, unknownConfigArgument(path+name, name, line, level))
+		case !attr.NestedBlock:
+			seen[name] = true
+			issues = append(issues, formatter.ConfigIssue{Kind: configWrongForm, Path: path + name, Line: line,
+				Message: fmt.Sprintf("'%s' is an argument; write it as `%s = ...`", name, name)})
+		default:
+			seen[name] = true
+			if attr.Computed && !attr.Optional && !attr.Required {
+				issues = append(issues, formatter.ConfigIssue{Kind: configComputedOnly, Path: path + name, Line: line,
+					Message: fmt.Sprintf("'%s' is computed by the provider and cannot be set", name)})
+			}
+			if content != nil && len(attr.Children) > 0 {
+				issues = append(issues, validateConfigBody(content, attr.Children, path+name+".", false)...)
+			}
+		}
+	}
+
+	for _, attr := range level {
+		if attr.Required && !seen[attr.Name] {
+			issues = append(issues, formatter.ConfigIssue{Kind: configMissingRequired, Path: path + attr.Name, Line: body.SrcRange.Start.Line,
+				Message: fmt.Sprintf("required argument '%s' is not set", attr.Name)})
+		}
+	}
+	return issues
+}
+
+// unknownConfigArgument reports a name the schema does not declare, suggesting the closest names at that level.
+func unknownConfigArgument(path, name string, line int, level []database.NestedAttribute) formatter.ConfigIssue {
+	msg := fmt.Sprintf("'%s' is not an argument of this block", name)
+
+	var suggestions []string
+	for _, candidate := range nestedAttributeNames(level) {
+		if levenshtein(name, candidate) <= max(2, len(name)/3) {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	if len(suggestions) > 0 {
+		msg += "; did you mean " + strings.Join(suggestions, ", ") + "?"
+	}
+	return formatter.ConfigIssue{Kind: configUnknownArgument, Path: path, Line: line, Message: msg}
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestHandleValidateConfig(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "location", Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "account_tier", Optional: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "primary_access_key", Computed: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "network_rules",
+		Optional:    true,
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "default_action", Required: true},
+			{Name: "ip_rules", Optional: true},
+		})),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	call := func(args map[string]any) string {
+		t.Helper()
+		resp := s.handleValidateConfig(args)
+		return resp["content"].([]ContentBlock)[0].Text
+	}
+
+	valid := call(map[string]any{"hcl": `
+resource "azurerm_storage_account" "example" {
+  count        = 1
+  name         = "example"
+  location     = "westeurope"
+  account_tier = "Standard"
+
+  network_rules {
+    default_action = "Deny"
+  }
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+`})
+	if !strings.Contains(valid, "**Issues**: 0") {
+		t.Fatalf("expected a clean block to pass, got: %s", valid)
+	}
+
+	invalid := call(map[string]any{"hcl": `
+resource "azurerm_storage_account" "example" {
+  name               = "example"
+  acount_tier        = "Standard"
+  primary_access_key = "secret"
+
+  tags {
+  }
+
+  network_rules {
+    ip_rules = ["10.0.0.1"]
+  }
+}
+`})
+	for _, want := range []string{
+		"**Issues**: 5",
+		"| 4 | unknown_argument | acount_tier | 'acount_tier' is not an argument of this block; did you mean account_tier? |",
+		"| 5 | computed_only | primary_access_key |",
+		"| 7 | wrong_form | tags |",
+		"| 10 | missing_required | network_rules.default_action |",
+		"missing_required | location |",
+	} {
+		if !strings.Contains(invalid, want) {
+			t.Fatalf("expected %q in output, got: %s", want, invalid)
+		}
+	}
+
+	bare := call(map[string]any{"resource_name": "azurerm_storage_account", "hcl": "name = \"x\"\nlocation = \"y\"\nnetwork_rules = []\n"})
+	if !strings.Contains(bare, "| 3 | wrong_form | network_rules |") {
+		t.Fatalf("expected bare arguments validated against resource_name, got: %s", bare)
+	}
+
+	syntax := call(map[string]any{"hcl": `resource "azurerm_storage_account" "example" {`})
+	if !strings.Contains(syntax, "syntax_error") {
+		t.Fatalf("expected syntax error to be reported, got: %s", syntax)
+	}
+
+	if text := call(map[string]any{"hcl": `name = "x"`}); !strings.Contains(text, "resource_name is required") {
+		t.Fatalf("expected resource_name to be required for bare arguments, got: %s", text)
+	}
+	if text := call(map[string]any{"hcl": `resource "azurerm_missing" "x" {}`}); !strings.Contains(text, "not found") {
+		t.Fatalf("expected unknown resource error, got: %s", text)
+	}
+}
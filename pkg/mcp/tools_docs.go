@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+// docGuidesDir is where the provider keeps its long-form guides.
+const docGuidesDir = "website/docs/guides/"
+
+// defaultReadmeMaxChars caps get_provider_readme output unless the caller asks for more.
+const defaultReadmeMaxChars = 8000
+
+func (s *Server) handleGetProviderReadme(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		MaxChars int `json:"max_chars"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	maxChars := params.MaxChars
+	if maxChars == 0 {
+		maxChars = defaultReadmeMaxChars
+	} else if maxChars < 0 {
+		maxChars = 0
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	return SuccessResponse(formatter.ProviderReadme(s.style, ifEmpty(repo.FullName, repo.Name), repo.ReadmeContent, maxChars))
+}
+
+func (s *Server) handleListDocGuides() map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	files, err := s.db.ListRepositoryFilesUnder(repo.ID, docGuidesDir)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list guides: %v", err))
+	}
+
+	var guides []formatter.DocGuide
+	for _, file := range files {
+		if !strings.HasSuffix(file.FilePath, ".markdown") && !strings.HasSuffix(file.FilePath, ".md") {
+			continue
+		}
+		guides = append(guides, formatter.DocGuide{
+			Path:        file.FilePath,
+			Title:       frontMatterField(file.Content, "page_title"),
+			Description: frontMatterField(file.Content, "description"),
+		})
+	}
+
+	return SuccessResponse(formatter.DocGuides(s.style, docGuidesDir, guides))
+}
+
+// frontMatterField reads a scalar from a doc page's YAML front matter, including block scalars
+// such as "description: |-" whose text sits on the following indented lines.
+func frontMatterField(content, key string) string {
+	lines := strings.Split(strings.TrimSpace(content), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return ""
+	}
+
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "---" {
+			break
+		}
+		value, ok := strings.CutPrefix(line, key+":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value != "" && !strings.HasPrefix(value, "|") && !strings.HasPrefix(value, ">") {
+			return strings.Trim(value, `"'`)
+		}
+
+		var parts []string
+		for _, next := range lines[i+1:] {
+			if !strings.HasPrefix(next, " ") && !strings.HasPrefix(next, "\t") {
+				break
+			}
+			parts = append(parts, strings.TrimSpace(next))
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
@@ -86,6 +86,59 @@ func TestAccAzAPIExample_other(t *testing.T) {}
 	}
 }
 
+func TestHandleListResourceTestsWhenTestsExcluded(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm", WithIncludeTests(false))
+	s.db = db
+
+	resp := s.handleListResourceTests(map[string]any{"name": res.Name})
+	content := resp["content"].([]ContentBlock)
+	if !strings.Contains(content[0].Text, "--include-tests=false") {
+		t.Fatalf("expected excluded-tests message, got %s", content[0].Text)
+	}
+}
+
+func TestHandleGetResourceTest(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+	testContent := `package example
+
+import "testing"
+
+// TestAccAzureRMExample_basic covers the minimal configuration.
+func TestAccAzureRMExample_basic(t *testing.T) {
+	t.Log("basic")
+}
+
+func TestAccAzureRMExample_update(t *testing.T) {}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/example/resource_test.go", "go", testContent)
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleGetResourceTest(map[string]any{"resource_name": res.Name, "test_name": "TestAccAzureRMExample_basic"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{"internal/example/resource_test.go", "**Lines:** 5-8", "// TestAccAzureRMExample_basic covers", `t.Log("basic")`} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "TestAccAzureRMExample_update") {
+		t.Fatalf("expected only the requested test, got %s", text)
+	}
+
+	resp = s.handleGetResourceTest(map[string]any{"resource_name": res.Name, "test_name": "TestAccAzureRMExample_missing"})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "not found") || !strings.Contains(text, "TestAccAzureRMExample_update") {
+		t.Fatalf("expected available tests to be listed, got %s", text)
+	}
+}
+
 func TestHandleListFeatureFlags(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -110,6 +163,95 @@ var Features = map[string]struct{
 	}
 }
 
+func TestHandleListFeatureFlagsNestedBlock(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	userFlags := `
+package features
+
+type UserFeatures struct {
+	KeyVault       KeyVaultFeatures
+	VirtualMachine VirtualMachineFeatures
+	HCICluster     HCIClusterFeatures
+	Subscription   SubscriptionFeatures
+}
+
+type KeyVaultFeatures struct {
+	// PurgeSoftDeleteOnDestroy purges soft-deleted vaults when they are destroyed.
+	PurgeSoftDeleteOnDestroy    bool
+	RecoverSoftDeletedKeyVaults bool
+}
+
+type VirtualMachineFeatures struct {
+	DeleteOSDiskOnDeletion bool
+	SkipShutdownAndForceDelete bool
+	GracefulShutdown       bool // deprecated
+}
+
+type HCIClusterFeatures struct {
+	ExpandResourcesOnDestroy bool
+}
+
+type SubscriptionFeatures struct {
+	PreventCancellationOnDestroy bool
+	RetryCount                   int
+}
+`
+	defaults := `
+package features
+
+func Default() UserFeatures {
+	return UserFeatures{
+		KeyVault: KeyVaultFeatures{
+			PurgeSoftDeleteOnDestroy:    true,
+			RecoverSoftDeletedKeyVaults: true,
+		},
+		VirtualMachine: VirtualMachineFeatures{
+			DeleteOSDiskOnDeletion:     true,
+			SkipShutdownAndForceDelete: false,
+		},
+	}
+}
+`
+	testutil.InsertFile(t, db, repo.ID, "internal/features/user_flags.go", "go", userFlags)
+	testutil.InsertFile(t, db, repo.ID, "internal/features/defaults.go", "go", defaults)
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleListFeatureFlags()["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"# Feature Flags (7)",
+		"## features {} Block",
+		"### hci_cluster\n\n- `expand_resources_on_destroy`\n",
+		"### key_vault\n\n- `purge_soft_delete_on_destroy` (default: true): PurgeSoftDeleteOnDestroy purges soft-deleted vaults when they are destroyed.",
+		"- `recover_soft_deleted_key_vaults` (default: true)",
+		"### subscription\n\n- `prevent_cancellation_on_destroy`\n",
+		"- `delete_os_disk_on_deletion` (default: true)",
+		"- `graceful_shutdown`: deprecated",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in feature flags, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "retry_count") {
+		t.Fatalf("expected non-boolean fields skipped, got %s", text)
+	}
+}
+
+func TestFeatureFieldName(t *testing.T) {
+	for name, want := range map[string]string{
+		"PurgeSoftDeleteOnDestroy": "purge_soft_delete_on_destroy",
+		"DeleteOSDiskOnDeletion":   "delete_os_disk_on_deletion",
+		"HCICluster":               "hci_cluster",
+		"ApiManagement":            "api_management",
+	} {
+		if got := featureFieldName(name); got != want {
+			t.Errorf("featureFieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
 func TestHandleGetResourceBehaviors(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -132,3 +274,66 @@ Importer: &schema.ResourceImporter{},
 		t.Fatalf("expected timeouts info, got %s", content[0].Text)
 	}
 }
+
+func TestHandleGetProviderReadme(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetProviderReadme(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "No README is stored") {
+		t.Fatalf("expected missing README note, got: %s", text)
+	}
+
+	repo.ReadmeContent = "# Terraform Provider for Azure\n\nIntro paragraph.\n\n## Usage\n\nMore text here."
+	if _, err := db.InsertRepository(repo); err != nil {
+		t.Fatalf("update repository: %v", err)
+	}
+
+	text = s.handleGetProviderReadme(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "More text here.") || strings.Contains(text, "_Truncated") {
+		t.Fatalf("expected the full README, got: %s", text)
+	}
+
+	text = s.handleGetProviderReadme(map[string]any{"max_chars": 50})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "Intro paragraph.") || strings.Contains(text, "## Usage") || !strings.Contains(text, "get_file_content") {
+		t.Fatalf("expected README cut at a line boundary with a note, got: %s", text)
+	}
+}
+
+func TestHandleListDocGuides(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertFile(t, db, repo.ID, "website/docs/guides/azure_cli.html.markdown", "markdown", strings.Join([]string{
+		"---",
+		"layout: \"azurerm\"",
+		"page_title: \"Azure Provider: Authenticating via the Azure CLI\"",
+		"description: |-",
+		"  This guide will cover how to use the Azure CLI",
+		"  as authentication for the Azure Provider.",
+		"---",
+		"# Azure Provider: Authenticating using the Azure CLI",
+	}, "\n"))
+	testutil.InsertFile(t, db, repo.ID, "website/docs/guides/features-block.html.markdown", "markdown", "# Features Block")
+	testutil.InsertFile(t, db, repo.ID, "website/docs/guides/images/diagram.png", "other", "")
+	testutil.InsertFile(t, db, repo.ID, "website/docs/r/virtual_network.html.markdown", "markdown", "# Virtual Network")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleListDocGuides()["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Guides**: 2",
+		"| Azure Provider: Authenticating via the Azure CLI | This guide will cover how to use the Azure CLI as authentication for the Azure Provider. | website/docs/guides/azure_cli.html.markdown |",
+		"| features-block | - | website/docs/guides/features-block.html.markdown |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q, got: %s", want, text)
+		}
+	}
+	if strings.Contains(text, "virtual_network") || strings.Contains(text, "diagram.png") {
+		t.Fatalf("expected only guide pages, got: %s", text)
+	}
+}
@@ -0,0 +1,80 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+func (s *Server) handleListDirectory(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Repository string `json:"repository"`
+		PathPrefix string `json:"path_prefix"`
+		Depth      int    `json:"depth"`
+		Limit      int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	repoName := ifEmpty(strings.TrimSpace(params.Repository), s.repoShortName())
+	repo, err := s.resolveRepository(repoName)
+	if err != nil {
+		return s.repositoryNotFound(repoName)
+	}
+
+	depth := params.Depth
+	if depth == 0 {
+		depth = 1
+	} else if depth < 0 {
+		depth = 0
+	}
+	limit := params.Limit
+	if limit == 0 {
+		limit = 200
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	dir := strings.Trim(strings.TrimSpace(params.PathPrefix), "/")
+	if dir != "" {
+		dir += "/"
+	}
+	files, err := s.db.ListRepositoryFileEntriesUnder(repo.ID, dir)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list files: %v", err))
+	}
+
+	entries, totalBytes := directoryEntries(dir, files, depth)
+	return SuccessResponse(formatter.DirectoryListing(s.style, repo.Name, dir, entries, depth, len(files), totalBytes, limit))
+}
+
+// directoryEntries lists files up to depth levels below dir and collapses deeper files into
+// their subdirectory at that depth; depth 0 lists the whole subtree. files must be sorted by path.
+func directoryEntries(dir string, files []database.RepositoryFile, depth int) ([]formatter.DirectoryEntry, int64) {
+	var entries []formatter.DirectoryEntry
+	var totalBytes int64
+	for _, file := range files {
+		totalBytes += file.SizeBytes
+		parts := strings.Split(strings.TrimPrefix(file.FilePath, dir), "/")
+		if depth == 0 || len(parts) <= depth {
+			entries = append(entries, formatter.DirectoryEntry{Path: file.FilePath, Files: 1, SizeBytes: file.SizeBytes})
+			continue
+		}
+
+		sub := dir + strings.Join(parts[:depth], "/")
+		if n := len(entries); n > 0 && entries[n-1].Dir && entries[n-1].Path == sub {
+			entries[n-1].Files++
+			entries[n-1].SizeBytes += file.SizeBytes
+			continue
+		}
+		entries = append(entries, formatter.DirectoryEntry{Path: sub, Dir: true, Files: 1, SizeBytes: file.SizeBytes})
+	}
+	return entries, totalBytes
+}
@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -15,11 +16,30 @@ func TestHandleGetFileContent(t *testing.T) {
 
 		resp := s.handleGetFileContent(map[string]any{"file_path": "missing.txt"})
 		content := resp["content"].([]ContentBlock)
-		if !strings.Contains(content[0].Text, "Repository") {
+		if !strings.Contains(content[0].Text, "Repository 'repo' not found") || !strings.Contains(content[0].Text, "no repositories are indexed") {
 			t.Fatalf("expected repository error, got %v", content[0].Text)
 		}
 	})
 
+	t.Run("unknown repository is not guessed", func(t *testing.T) {
+		db := testutil.NewTestDB(t)
+		repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+		testutil.InsertFile(t, db, repo.ID, "README.md", "markdown", "# azurerm")
+
+		s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+		s.db = db
+
+		text := s.handleGetFileContent(map[string]any{"repository": "terraform-provider-google", "file_path": "README.md"})["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "Repository 'terraform-provider-google' not found") || !strings.Contains(text, "Indexed repositories: terraform-provider-azurerm") {
+			t.Fatalf("expected unknown repository to be reported, got %s", text)
+		}
+
+		text = s.handleGetFileContent(map[string]any{"file_path": "README.md"})["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "# azurerm") {
+			t.Fatalf("expected the configured repository by default, got %s", text)
+		}
+	})
+
 	t.Run("returns snippet with lines", func(t *testing.T) {
 		db := testutil.NewTestDB(t)
 		repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -52,6 +72,129 @@ func TestHandleGetFileContent(t *testing.T) {
 	})
 }
 
+func TestHandleGetFileContentLargeFiles(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	lines := make([]string, 2500)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line%d", i+1)
+	}
+	testutil.InsertFile(t, db, repo.ID, "big.go", "go", strings.Join(lines, "\n"))
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	get := func(args map[string]any) string {
+		args["file_path"] = "big.go"
+		return s.handleGetFileContent(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	t.Run("full file is rejected without force", func(t *testing.T) {
+		text := get(map[string]any{"end_line": -1})
+		if !strings.Contains(text, "has 2500 lines") || !strings.Contains(text, "13 pages of 200 lines") || strings.Contains(text, "line1\n") {
+			t.Fatalf("expected the full-file guard, got %s", text)
+		}
+
+		text = get(map[string]any{"start_line": 400})
+		if !strings.Contains(text, "lines 400-2500") {
+			t.Fatalf("expected open-ended windows to be guarded, got %s", text)
+		}
+	})
+
+	t.Run("force and explicit windows bypass the guard", func(t *testing.T) {
+		text := get(map[string]any{"end_line": -1, "force": true})
+		if !strings.Contains(text, "Lines:** 1-2500 of 2500") || !strings.Contains(text, "line2500") {
+			t.Fatalf("expected the full file with force, got %s", text)
+		}
+
+		text = get(map[string]any{"start_line": 1, "end_line": 2400})
+		if !strings.Contains(text, "Lines:** 1-2400 of 2500") {
+			t.Fatalf("expected an explicit window to be served, got %s", text)
+		}
+
+		text = get(map[string]any{"end_line": -1, "summary": true})
+		if !strings.Contains(text, "Lines:** 1-2500 of 2500") {
+			t.Fatalf("expected summaries to skip the guard, got %s", text)
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		text := get(map[string]any{"page": 2})
+		if !strings.Contains(text, "Lines:** 201-400 of 2500") || !strings.Contains(text, "Page:** 2 of 13 (200 lines per page). Next: page 3") {
+			t.Fatalf("expected the second default-sized page, got %s", text)
+		}
+		if !strings.Contains(text, "line201\n") || strings.Contains(text, "line200\n") || strings.Contains(text, "line401") {
+			t.Fatalf("expected only the lines of page 2, got %s", text)
+		}
+
+		text = get(map[string]any{"page": 3, "page_size": 1000})
+		if !strings.Contains(text, "Lines:** 2001-2500 of 2500") || !strings.Contains(text, "Page:** 3 of 3 (1000 lines per page)\n") {
+			t.Fatalf("expected the short last page without a next hint, got %s", text)
+		}
+
+		text = get(map[string]any{"page": 4, "page_size": 1000})
+		if !strings.Contains(text, "Page 4 is past the end") || !strings.Contains(text, "3 pages of 1000 lines") {
+			t.Fatalf("expected a past-the-end error, got %s", text)
+		}
+
+		text = get(map[string]any{"page": 1, "page_size": 5000})
+		if !strings.Contains(text, "Lines:** 1-2000 of 2500") || !strings.Contains(text, "Page:** 1 of 2 (2000 lines per page)") {
+			t.Fatalf("expected page_size capped at the full-file limit, got %s", text)
+		}
+
+		text = get(map[string]any{"page": 1, "page_size": 5000, "force": true})
+		if !strings.Contains(text, "Lines:** 1-2500 of 2500") || !strings.Contains(text, "Page:** 1 of 1 (5000 lines per page)") {
+			t.Fatalf("expected force to lift the page_size cap, got %s", text)
+		}
+	})
+}
+
+func TestHandleListDirectory(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertFile(t, db, repo.ID, "main.go", "go", "package main")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/network/client/client.go", "go", "package client")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/network/registration.go", "go", "package network")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/network/validate/name.go", "go", "package validate")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/network/validate/name_test.go", "go", "package validate_test")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/networkfunction/registration.go", "go", "package networkfunction")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	list := func(args map[string]any) string {
+		return s.handleListDirectory(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := list(map[string]any{"path_prefix": "/internal/services/network/"})
+	for _, want := range []string{
+		"**Files:** 4",
+		"| internal/services/network/client/ | 1 |",
+		"| internal/services/network/registration.go | 1 | 15 B |",
+		"| internal/services/network/validate/ | 2 | 37 B |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in listing, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "networkfunction") || strings.Contains(text, "package") {
+		t.Fatalf("expected only the directory's files and no content, got %s", text)
+	}
+
+	text = list(map[string]any{"path_prefix": "internal/services/network", "depth": -1})
+	if !strings.Contains(text, "| internal/services/network/validate/name_test.go |") || strings.Contains(text, "validate/ |") {
+		t.Fatalf("expected the whole subtree with depth -1, got %s", text)
+	}
+
+	text = list(map[string]any{"depth": 2, "limit": 1})
+	if !strings.Contains(text, "| internal/services/ | 5 |") || !strings.Contains(text, "Showing 1 of 2 entries") {
+		t.Fatalf("expected a truncated root listing, got %s", text)
+	}
+
+	text = list(map[string]any{"path_prefix": "internal/services/missing"})
+	if !strings.Contains(text, "No indexed files under 'internal/services/missing/'") {
+		t.Fatalf("expected an empty-directory note, got %s", text)
+	}
+}
+
 func TestHandleGetSchemaSource(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -86,6 +229,33 @@ func TestHandleGetSchemaSource(t *testing.T) {
 			t.Fatalf("expected schema snippet, got %s", content[0].Text)
 		}
 	})
+
+	t.Run("attributes unavailable", func(t *testing.T) {
+		resp := s.handleGetSchemaSource(map[string]any{
+			"name":    "azurerm_example",
+			"section": "attributes",
+		})
+		content := resp["content"].([]ContentBlock)
+		if !strings.Contains(content[0].Text, "not available") {
+			t.Fatalf("expected unavailable error, got %s", content[0].Text)
+		}
+	})
+
+	t.Run("returns attribute lines", func(t *testing.T) {
+		lines := []database.SchemaAttributeLine{{Name: "name", Line: 42}, {Name: "location", Line: 47}}
+		if err := db.SetProviderResourceSourceAttributeLines(res.ID, lines); err != nil {
+			t.Fatalf("failed to set attribute lines: %v", err)
+		}
+
+		resp := s.handleGetSchemaSource(map[string]any{
+			"name":    "azurerm_example",
+			"section": "attributes",
+		})
+		content := resp["content"].([]ContentBlock)
+		if !strings.Contains(content[0].Text, "| name | 42 |") || !strings.Contains(content[0].Text, "| location | 47 |") {
+			t.Fatalf("expected attribute lines, got %s", content[0].Text)
+		}
+	})
 }
 
 func TestHandleSearchResourcesAndAttributes(t *testing.T) {
@@ -122,6 +292,34 @@ func TestHandleSearchResourcesAndAttributes(t *testing.T) {
 		}
 	})
 
+	t.Run("count only", func(t *testing.T) {
+		resp := s.handleSearchResourceAttributes(map[string]any{"resource_prefix": "azurerm_", "count_only": true})
+		text := resp["content"].([]ContentBlock)[0].Text
+		if text != "2 attributes match the filters" {
+			t.Fatalf("expected attribute count, got %s", text)
+		}
+
+		resp = s.handleSearchResources(map[string]any{"query": "example", "count_only": true})
+		text = resp["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "1 resources match 'example'") {
+			t.Fatalf("expected resource count, got %s", text)
+		}
+	})
+
+	t.Run("search resources by kind", func(t *testing.T) {
+		resp := s.handleSearchResources(map[string]any{"query": "example", "kind": "data_source"})
+		text := resp["content"].([]ContentBlock)[0].Text
+		if strings.Contains(text, "azurerm_example") {
+			t.Fatalf("expected no data source matches, got %s", text)
+		}
+
+		resp = s.handleSearchResources(map[string]any{"query": "example", "kind": "provider"})
+		text = resp["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "kind must be") {
+			t.Fatalf("expected kind error, got %s", text)
+		}
+	})
+
 	t.Run("list resources compact", func(t *testing.T) {
 		resp := s.handleListResources(map[string]any{"compact": true, "limit": 10, "kind": "resource"})
 		content := resp["content"].([]ContentBlock)
@@ -83,3 +83,26 @@ func TestBuildDependencyGraph(t *testing.T) {
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestClosestResourceNames(t *testing.T) {
+	names := []string{"azurerm_virtual_network", "azurerm_virtual_network_peering", "azurerm_storage_account"}
+
+	got := closestResourceNames("virtual netwrk", "azurerm_", names, 5)
+	if len(got) == 0 || got[0] != "azurerm_virtual_network" {
+		t.Fatalf("expected azurerm_virtual_network first, got %v", got)
+	}
+	for _, name := range got {
+		if name == "azurerm_storage_account" {
+			t.Fatalf("unrelated name suggested: %v", got)
+		}
+	}
+
+	if got := closestResourceNames("kubernetes", "azurerm_", names, 5); len(got) != 0 {
+		t.Fatalf("expected no suggestions, got %v", got)
+	}
+
+	azuread := []string{"azuread_group", "azuread_group_member", "azuread_application"}
+	if got := closestResourceNames("azuread_grup", "azuread_", azuread, 5); len(got) == 0 || got[0] != "azuread_group" {
+		t.Fatalf("expected azuread_group first, got %v", got)
+	}
+}
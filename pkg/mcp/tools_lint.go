@@ -0,0 +1,637 @@
+package mcp
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+var descriptionStyleRuleOrder = []string{styleMissing, styleCapitalization, styleTrailingPeriod, styleWhitespace, styleEnumValues}
+
+func (s *Server) handleCheckDescriptionStyle(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName   string `json:"resource_name"`
+		Service        string `json:"service"`
+		ResourcePrefix string `json:"resource_prefix"`
+		IncludeMissing bool   `json:"include_missing"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 50
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	var results []database.ProviderAttributeSearchResult
+	var scopes []string
+	if name := strings.TrimSpace(params.ResourceName); name != "" {
+		resource, err := s.db.GetProviderResource(name)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Resource '%s' not found", name))
+		}
+		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+		}
+		for _, attr := range attrs {
+			results = append(results, database.ProviderAttributeSearchResult{Attribute: attr, ResourceName: resource.Name, ResourceKind: resource.Kind})
+		}
+		scopes = append(scopes, resource.Name)
+	} else {
+		service := strings.TrimSpace(params.Service)
+		prefix := strings.TrimSpace(params.ResourcePrefix)
+		results, err = s.db.SearchProviderAttributes(database.AttributeSearchFilters{
+			ServiceName:    service,
+			ResourcePrefix: prefix,
+			Limit:          validationScanLimit,
+		})
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to search provider attributes: %v", err))
+		}
+		if service != "" {
+			scopes = append(scopes, "service "+service)
+		}
+		if prefix != "" {
+			scopes = append(scopes, prefix+"*")
+		}
+	}
+	scope := "all resources"
+	if len(scopes) > 0 {
+		scope = strings.Join(scopes, ", ")
+	}
+
+	counts := make(map[string]int)
+	checked := 0
+	total := 0
+	var violations []formatter.DescriptionStyleViolation
+
+	var visit func(resourceName, path string, attr database.NestedAttribute)
+	visit = func(resourceName, path string, attr database.NestedAttribute) {
+		if strings.TrimSpace(attr.Description) != "" || params.IncludeMissing {
+			checked++
+		}
+		values, _ := parseAllowedValues(attr.Validation)
+		if issues := descriptionStyleIssues(attr.Description, values, params.IncludeMissing); len(issues) > 0 {
+			total++
+			for _, issue := range issues {
+				counts[issue]++
+			}
+			if limit == 0 || len(violations) < limit {
+				violations = append(violations, formatter.DescriptionStyleViolation{
+					ResourceName:  resourceName,
+					AttributePath: path,
+					Rules:         issues,
+					Description:   attr.Description,
+				})
+			}
+		}
+		for _, child := range attr.Children {
+			visit(resourceName, path+"."+child.Name, child)
+		}
+	}
+
+	for _, res := range results {
+		attr := database.NestedAttributeFromProvider(res.Attribute)
+		visit(res.ResourceName, attr.Name, attr)
+	}
+
+	rules := make([]formatter.DescriptionStyleRule, 0, len(descriptionStyleRuleOrder))
+	for _, name := range descriptionStyleRuleOrder {
+		rules = append(rules, formatter.DescriptionStyleRule{Name: name, Hint: styleRuleHints[name], Count: counts[name]})
+	}
+
+	text := formatter.DescriptionStyleReport(s.style, scope, checked, rules, violations, total)
+	return SuccessResponse(text)
+}
+
+// Constraint group checks reported by lint_constraint_groups.
+const (
+	constraintMissingReference = "missing_reference"
+	constraintMissingSelf      = "missing_self"
+	constraintNotReciprocated  = "not_reciprocated"
+	constraintGroupMismatch    = "group_mismatch"
+	constraintRedundant        = "redundant_conflict"
+)
+
+var constraintCheckHints = map[string]string{
+	constraintMissingReference: "references an attribute that does not exist in the schema",
+	constraintMissingSelf:      "group does not list the attribute declaring it",
+	constraintNotReciprocated:  "a group member does not declare the group back",
+	constraintGroupMismatch:    "group members declare different member lists",
+	constraintRedundant:        "ConflictsWith repeats members of the attribute's ExactlyOneOf group, which already excludes them",
+}
+
+var constraintCheckOrder = []string{constraintMissingReference, constraintMissingSelf, constraintNotReciprocated, constraintGroupMismatch, constraintRedundant}
+
+func (s *Server) handleLintConstraintGroups(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	if name == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	nested := make([]database.NestedAttribute, 0, len(attrs))
+	for _, attr := range attrs {
+		nested = append(nested, database.NestedAttributeFromProvider(attr))
+	}
+	checked, anomalies := constraintGroupAnomalies(nested)
+
+	counts := make(map[string]int)
+	for _, anomaly := range anomalies {
+		counts[anomaly.Check]++
+	}
+	checks := make([]formatter.ConstraintCheck, 0, len(constraintCheckOrder))
+	for _, check := range constraintCheckOrder {
+		checks = append(checks, formatter.ConstraintCheck{Name: check, Hint: constraintCheckHints[check], Count: counts[check]})
+	}
+
+	return SuccessResponse(formatter.ConstraintGroupReport(s.style, resource.Name, checked, checks, anomalies))
+}
+
+func (s *Server) handleGetConstraintsDiagram(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	if name == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	return SuccessResponse(formatter.ConstraintsDiagram(s.style, resource.Name, constraintGraph(nestedSchemaTree(attrs))))
+}
+
+// constraintGraph collects the ConflictsWith pairs and the ExactlyOneOf and AtLeastOneOf groups of
+// a schema. Pairs and groups declared from several members are kept once.
+func constraintGraph(attrs []database.NestedAttribute) formatter.ConstraintGraph {
+	var graph formatter.ConstraintGraph
+	seen := make(map[string]bool)
+	addNode := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			graph.Nodes = append(graph.Nodes, path)
+		}
+	}
+
+	pairs := make(map[[2]string]bool)
+	groups := make(map[string]bool)
+	addGroup := func(kind string, members []string) {
+		members = slices.Compact(slices.Sorted(slices.Values(members)))
+		if len(members) < 2 {
+			return
+		}
+		key := kind + "|" + strings.Join(members, ",")
+		if groups[key] {
+			return
+		}
+		groups[key] = true
+		for _, member := range members {
+			addNode(member)
+		}
+		graph.Groups = append(graph.Groups, formatter.ConstraintGraphGroup{Kind: kind, Members: members})
+	}
+
+	var walk func(prefix string, attr database.NestedAttribute)
+	walk = func(prefix string, attr database.NestedAttribute) {
+		path := prefix + attr.Name
+		for _, other := range constraintPaths(attr.ConflictsWith) {
+			if other == path {
+				continue
+			}
+			pair := [2]string{min(path, other), max(path, other)}
+			if pairs[pair] {
+				continue
+			}
+			pairs[pair] = true
+			addNode(pair[0])
+			addNode(pair[1])
+			graph.Conflicts = append(graph.Conflicts, pair)
+		}
+		addGroup("ExactlyOneOf", append(constraintPaths(attr.ExactlyOneOf), path))
+		addGroup("AtLeastOneOf", append(constraintPaths(attr.AtLeastOneOf), path))
+		for _, child := range attr.Children {
+			walk(path+".", child)
+		}
+	}
+	for _, attr := range attrs {
+		walk("", attr)
+	}
+
+	slices.Sort(graph.Nodes)
+	slices.SortFunc(graph.Conflicts, func(a, b [2]string) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+	slices.SortFunc(graph.Groups, func(a, b formatter.ConstraintGraphGroup) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), slices.Compare(a.Members, b.Members))
+	})
+	return graph
+}
+
+// constraintAttr is an attribute's constraint declarations keyed by schema path.
+type constraintAttr struct {
+	exactlyOneOf  []string
+	atLeastOneOf  []string
+	conflictsWith []string
+}
+
+// constraintGroupAnomalies cross-checks the ExactlyOneOf, AtLeastOneOf and ConflictsWith lists of
+// a schema. It returns how many attributes declare at least one resolvable reference, and the anomalies found.
+// References are compared as paths without list indexes, so "block.0.child" matches block.child;
+// entries that are not literal paths (such as a shared variable) cannot be resolved and are skipped.
+func constraintGroupAnomalies(attrs []database.NestedAttribute) (int, []formatter.ConstraintAnomaly) {
+	known := make(map[string]bool)
+	declared := make(map[string]constraintAttr)
+	var order []string
+	var walk func(prefix string, attr database.NestedAttribute)
+	walk = func(prefix string, attr database.NestedAttribute) {
+		path := prefix + attr.Name
+		known[path] = true
+		c := constraintAttr{
+			exactlyOneOf:  constraintPaths(attr.ExactlyOneOf),
+			atLeastOneOf:  constraintPaths(attr.AtLeastOneOf),
+			conflictsWith: constraintPaths(attr.ConflictsWith),
+		}
+		if len(c.exactlyOneOf)+len(c.atLeastOneOf)+len(c.conflictsWith) > 0 {
+			declared[path] = c
+			order = append(order, path)
+		}
+		for _, child := range attr.Children {
+			walk(path+".", child)
+		}
+	}
+	for _, attr := range attrs {
+		walk("", attr)
+	}
+	slices.Sort(order)
+
+	var anomalies []formatter.ConstraintAnomaly
+	reported := make(map[string]bool)
+	report := func(check, path, constraint string, involved ...string) {
+		key := []string{path}
+		if check == constraintGroupMismatch {
+			// Both members see the same mismatch; report the pair once.
+			key = append(key, involved...)
+			slices.Sort(key)
+		} else {
+			key = append(key, involved...)
+		}
+		id := check + "|" + constraint + "|" + strings.Join(key, ",")
+		if reported[id] {
+			return
+		}
+		reported[id] = true
+		anomalies = append(anomalies, formatter.ConstraintAnomaly{Check: check, Attribute: path, Constraint: constraint, Involved: involved})
+	}
+
+	groupOf := func(c constraintAttr, constraint string) []string {
+		if constraint == "ExactlyOneOf" {
+			return c.exactlyOneOf
+		}
+		return c.atLeastOneOf
+	}
+
+	for _, path := range order {
+		c := declared[path]
+		for _, constraint := range []string{"ExactlyOneOf", "AtLeastOneOf"} {
+			group := groupOf(c, constraint)
+			if len(group) == 0 {
+				continue
+			}
+			if !slices.Contains(group, path) {
+				report(constraintMissingSelf, path, constraint)
+			}
+			for _, member := range group {
+				if member == path {
+					continue
+				}
+				if !known[member] {
+					report(constraintMissingReference, path, constraint, member)
+					continue
+				}
+				memberGroup := groupOf(declared[member], constraint)
+				switch {
+				case len(memberGroup) == 0:
+					report(constraintNotReciprocated, path, constraint, member)
+				case !sameMembers(group, memberGroup):
+					report(constraintGroupMismatch, path, constraint, member)
+				}
+			}
+		}
+		for _, other := range c.conflictsWith {
+			if !known[other] {
+				report(constraintMissingReference, path, "ConflictsWith", other)
+			} else if other != path && slices.Contains(c.exactlyOneOf, other) {
+				report(constraintRedundant, path, "ConflictsWith", other)
+			}
+		}
+	}
+	return len(order), anomalies
+}
+
+// constraintPaths parses a stored constraint list into schema paths without list indexes.
+func constraintPaths(list string) []string {
+	var paths []string
+	for _, ref := range parseConflictsList(list) {
+		ref = strings.Trim(ref, `"`)
+		if strings.Trim(ref, "abcdefghijklmnopqrstuvwxyz0123456789_.") != "" {
+			continue
+		}
+		if key := schemaPathKey(ref); key != "" {
+			paths = append(paths, key)
+		}
+	}
+	return paths
+}
+
+func sameMembers(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+func (s *Server) handleFindShadowedAttributes(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	if name == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	shadowed, unresolved := shadowedAttributes(nestedSchemaTree(attrs))
+	return SuccessResponse(formatter.ShadowedAttributes(s.style, resource.Name, shadowed, unresolved))
+}
+
+// shadowedAttributes finds attributes inside nested blocks that share a name with a top-level
+// attribute. It also returns the nested blocks without a stored schema, which could not be checked.
+func shadowedAttributes(tree []database.NestedAttribute) ([]formatter.ShadowedAttribute, []string) {
+	top := make(map[string]database.NestedAttribute, len(tree))
+	for _, attr := range tree {
+		top[attr.Name] = attr
+	}
+
+	var shadowed []formatter.ShadowedAttribute
+	var unresolved []string
+	var walk func(level []database.NestedAttribute, prefix string)
+	walk = func(level []database.NestedAttribute, prefix string) {
+		for _, attr := range level {
+			path := prefix + attr.Name
+			if outer, ok := top[attr.Name]; ok && prefix != "" {
+				shadowed = append(shadowed, formatter.ShadowedAttribute{
+					Path:        path,
+					TopLevel:    outer,
+					Nested:      attr,
+					Differences: schemaDifferences(outer, attr),
+				})
+			}
+			if !attr.NestedBlock {
+				continue
+			}
+			if len(attr.Children) == 0 {
+				unresolved = append(unresolved, path)
+				continue
+			}
+			walk(attr.Children, path+".")
+		}
+	}
+	walk(tree, "")
+	return shadowed, unresolved
+}
+
+// schemaDifferences lists the aspects in which two same-named attributes behave differently.
+func schemaDifferences(a, b database.NestedAttribute) []string {
+	var diffs []string
+	if a.NestedBlock != b.NestedBlock {
+		diffs = append(diffs, "block vs argument")
+	} else if a.Type != b.Type {
+		diffs = append(diffs, "type")
+	}
+	if a.Required != b.Required || a.Optional != b.Optional || a.Computed != b.Computed {
+		diffs = append(diffs, "required/optional/computed")
+	}
+	if a.ForceNew != b.ForceNew {
+		diffs = append(diffs, "force_new")
+	}
+	if a.Sensitive != b.Sensitive {
+		diffs = append(diffs, "sensitive")
+	}
+	if (a.Deprecated == "") != (b.Deprecated == "") {
+		diffs = append(diffs, "deprecation")
+	}
+	if a.Validation != b.Validation {
+		diffs = append(diffs, "validation")
+	}
+	return diffs
+}
+
+// Traversal depth bounds for get_attribute_conflicts_transitive.
+const (
+	defaultConstraintDepth = 5
+	maxConstraintDepth     = 10
+)
+
+func (s *Server) handleGetAttributeConflictsTransitive(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName  string `json:"resource_name"`
+		AttributeName string `json:"attribute_name"`
+		MaxDepth      int    `json:"max_depth"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	start := schemaPathKey(params.AttributeName)
+	if name == "" || start == "" {
+		return ErrorResponse("resource_name and attribute_name are required")
+	}
+	depth := params.MaxDepth
+	if depth <= 0 {
+		depth = defaultConstraintDepth
+	}
+	depth = min(depth, maxConstraintDepth)
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	requiredWith := make(map[string]string)
+	for _, attr := range attrs {
+		if attr.RequiredWith.Valid {
+			requiredWith[attr.Name] = attr.RequiredWith.String
+		}
+	}
+	known, edges := constraintEdges(nestedSchemaTree(attrs), requiredWith)
+	if !known[start] {
+		return ErrorResponse(fmt.Sprintf("Attribute '%s' not found on %s", start, resource.Name))
+	}
+
+	nodes, component, truncated := constraintClosure(start, edges, depth)
+	return SuccessResponse(formatter.ConstraintClosure(s.style, resource.Name, start, depth, nodes, component, truncated))
+}
+
+// constraintEdges lists a schema's ConflictsWith, ExactlyOneOf, AtLeastOneOf and RequiredWith
+// declarations as edges from the declaring attribute to each path it names, together with the set
+// of known attribute paths. RequiredWith is only stored for top-level attributes, so it is passed
+// separately, keyed by attribute name. Symmetric constraints declared from both ends are kept once.
+func constraintEdges(attrs []database.NestedAttribute, requiredWith map[string]string) (map[string]bool, []formatter.ConstraintEdge) {
+	known := make(map[string]bool)
+	seen := make(map[string]bool)
+	var edges []formatter.ConstraintEdge
+	add := func(kind, from, to string) {
+		if from == to {
+			return
+		}
+		key := kind + "|" + from + "|" + to
+		if kind != formatter.ConstraintRequiredWith {
+			key = kind + "|" + min(from, to) + "|" + max(from, to)
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		edges = append(edges, formatter.ConstraintEdge{Kind: kind, From: from, To: to})
+	}
+
+	var walk func(prefix string, attr database.NestedAttribute)
+	walk = func(prefix string, attr database.NestedAttribute) {
+		path := prefix + attr.Name
+		known[path] = true
+		for _, other := range constraintPaths(attr.ConflictsWith) {
+			add(formatter.ConstraintConflictsWith, path, other)
+		}
+		for _, other := range constraintPaths(attr.ExactlyOneOf) {
+			add(formatter.ConstraintExactlyOneOf, path, other)
+		}
+		for _, other := range constraintPaths(attr.AtLeastOneOf) {
+			add(formatter.ConstraintAtLeastOneOf, path, other)
+		}
+		if prefix == "" {
+			for _, other := range constraintPaths(requiredWith[attr.Name]) {
+				add(formatter.ConstraintRequiredWith, path, other)
+			}
+		}
+		for _, child := range attr.Children {
+			walk(path+".", child)
+		}
+	}
+	for _, attr := range attrs {
+		walk("", attr)
+	}
+	return known, edges
+}
+
+// constraintClosure walks the constraint edges outward from start in both directions, breadth
+// first and up to maxDepth hops. Attributes are visited once, so cycles end the walk. It returns
+// the attributes reached, the edges among them, and whether attributes lay beyond maxDepth.
+func constraintClosure(start string, edges []formatter.ConstraintEdge, maxDepth int) ([]formatter.ConstraintNode, []formatter.ConstraintEdge, bool) {
+	adjacent := make(map[string][]formatter.ConstraintEdge)
+	for _, edge := range edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge)
+		adjacent[edge.To] = append(adjacent[edge.To], edge)
+	}
+
+	visited := map[string]bool{start: true}
+	nodes := []formatter.ConstraintNode{{Path: start}}
+	truncated := false
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[i]
+		for _, edge := range adjacent[node.Path] {
+			other := edge.To
+			if other == node.Path {
+				other = edge.From
+			}
+			if visited[other] {
+				continue
+			}
+			if node.Depth == maxDepth {
+				truncated = true
+				continue
+			}
+			visited[other] = true
+			nodes = append(nodes, formatter.ConstraintNode{Path: other, Depth: node.Depth + 1, Via: edge})
+		}
+	}
+
+	var component []formatter.ConstraintEdge
+	for _, edge := range edges {
+		if visited[edge.From] && visited[edge.To] {
+			component = append(component, edge)
+		}
+	}
+	slices.SortFunc(component, func(a, b formatter.ConstraintEdge) int {
+		return cmp.Or(cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.From, b.From), cmp.Compare(a.To, b.To))
+	})
+	return nodes, component, truncated
+}
@@ -0,0 +1,288 @@
+package mcp
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestDescriptionStyleIssues(t *testing.T) {
+	tests := []struct {
+		name   string
+		desc   string
+		values []string
+		want   []string
+	}{
+		{"conforming", "The name of the storage account.", nil, nil},
+		{"lowercase without period", "the name of the storage account", nil, []string{styleCapitalization, styleTrailingPeriod}},
+		{"period inside closing paren", "Specifies the tier (defaults to `Standard`.)", nil, nil},
+		{"repeated whitespace", "The  name.", nil, []string{styleWhitespace}},
+		{"enum values listed", "The tier. Possible values are `Standard` and `Premium`.", []string{"Standard", "Premium"}, nil},
+		{"enum values missing", "The tier to use.", []string{"Standard", "Premium"}, []string{styleEnumValues}},
+		{"empty ignored", "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := descriptionStyleIssues(tt.desc, tt.values, false)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("descriptionStyleIssues(%q) = %v, want %v", tt.desc, got, tt.want)
+			}
+		})
+	}
+
+	if got := descriptionStyleIssues("  ", nil, true); !slices.Equal(got, []string{styleMissing}) {
+		t.Fatalf("expected missing rule when requested, got %v", got)
+	}
+}
+
+func TestHandleCheckDescriptionStyle(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	serviceID, err := db.InsertProviderService(&database.ProviderService{RepositoryID: repo.ID, Name: "Storage"})
+	if err != nil {
+		t.Fatalf("insert service: %v", err)
+	}
+	resourceID, err := db.InsertProviderResource(&database.ProviderResource{
+		RepositoryID: repo.ID,
+		ServiceID:    sql.NullInt64{Int64: serviceID, Valid: true},
+		Name:         "azurerm_storage_account",
+		Kind:         "resource",
+	})
+	if err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	testutil.InsertAttribute(t, db, resourceID, database.ProviderAttribute{Name: "name", Description: sqlNull("the name of the storage account")})
+	testutil.InsertAttribute(t, db, resourceID, database.ProviderAttribute{Name: "location", Description: sqlNull("The Azure region.")})
+	testutil.InsertAttribute(t, db, resourceID, database.ProviderAttribute{
+		Name:        "account_tier",
+		Description: sqlNull("The tier to use."),
+		Validation:  sqlNull(`validation.StringInSlice([]string{"Standard", "Premium"}, false)`),
+	})
+
+	other := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, other.ID, database.ProviderAttribute{Name: "name", Description: sqlNull("lowercase here")})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleCheckDescriptionStyle(map[string]any{"service": "storage"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| azurerm_storage_account.name | capitalization, trailing_period |") {
+		t.Fatalf("expected lowercase/no-period violation, got %s", text)
+	}
+	if !strings.Contains(text, "| azurerm_storage_account.account_tier | enum_values |") {
+		t.Fatalf("expected enum values violation, got %s", text)
+	}
+	if strings.Contains(text, "azurerm_storage_account.location") || strings.Contains(text, "azurerm_virtual_network") {
+		t.Fatalf("expected conforming and out-of-scope attributes to be omitted, got %s", text)
+	}
+	if !strings.Contains(text, "**Descriptions Checked**: 3") || !strings.Contains(text, "**Violations**: 2") {
+		t.Fatalf("expected summary counts, got %s", text)
+	}
+
+	single := s.handleCheckDescriptionStyle(map[string]any{"resource_name": "azurerm_virtual_network"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(single, "azurerm_virtual_network.name") {
+		t.Fatalf("expected resource-scoped violation, got %s", single)
+	}
+}
+
+func TestConstraintGroupAnomalies(t *testing.T) {
+	attrs := []database.NestedAttribute{
+		{Name: "a", ExactlyOneOf: "a, b", ConflictsWith: "b"},
+		{Name: "b", ExactlyOneOf: "a, b, c"},
+		{Name: "d", AtLeastOneOf: "d, e"},
+		{Name: "e"},
+		{Name: "f", ExactlyOneOf: "g, h"},
+		{Name: "g", ExactlyOneOf: "g, h"},
+		{Name: "h", ExactlyOneOf: "h, g"},
+		{Name: "i", ConflictsWith: "sharedConflicts"},
+		{Name: "block", Children: []database.NestedAttribute{
+			{Name: "x", ExactlyOneOf: "block.0.x, block.0.y"},
+			{Name: "y", ExactlyOneOf: "block.0.x, block.0.y"},
+		}},
+	}
+
+	checked, anomalies := constraintGroupAnomalies(attrs)
+	if checked != 8 {
+		t.Fatalf("expected 8 attributes with resolvable constraints, got %d", checked)
+	}
+	var got []string
+	for _, a := range anomalies {
+		got = append(got, a.Check+" "+a.Attribute+" "+a.Constraint+" "+strings.Join(a.Involved, ","))
+	}
+	want := []string{
+		"group_mismatch a ExactlyOneOf b",
+		"redundant_conflict a ConflictsWith b",
+		"missing_reference b ExactlyOneOf c",
+		"not_reciprocated d AtLeastOneOf e",
+		"missing_self f ExactlyOneOf ",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("anomalies = %q, want %q", got, want)
+	}
+}
+
+func TestHandleLintConstraintGroups(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "key_vault_id", Optional: true, ExactlyOneOf: sql.NullString{String: "key_vault_id, managed_hsm_id", Valid: true}})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "managed_hsm_id", Optional: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleLintConstraintGroups(map[string]any{"resource_name": res.Name})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{"**Anomalies**: 1", "**not_reciprocated** (1)", "| not_reciprocated | key_vault_id | ExactlyOneOf | managed_hsm_id |"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in report, got %s", want, text)
+		}
+	}
+}
+
+func TestConstraintGraph(t *testing.T) {
+	graph := constraintGraph([]database.NestedAttribute{
+		{Name: "key_vault_id", ExactlyOneOf: `"key_vault_id", "managed_hsm_id"`},
+		{Name: "managed_hsm_id", ExactlyOneOf: `"key_vault_id", "managed_hsm_id"`, ConflictsWith: `"key_size"`},
+		{Name: "key_size", ConflictsWith: `"managed_hsm_id"`},
+		{Name: "name"},
+		{Name: "rotation_policy", NestedBlock: true, Children: []database.NestedAttribute{
+			{Name: "expire_after", AtLeastOneOf: `"rotation_policy.0.expire_after", "rotation_policy.0.automatic"`},
+		}},
+	})
+
+	if want := []string{"key_size", "key_vault_id", "managed_hsm_id", "rotation_policy.automatic", "rotation_policy.expire_after"}; !slices.Equal(graph.Nodes, want) {
+		t.Fatalf("nodes = %v, want %v", graph.Nodes, want)
+	}
+	if len(graph.Conflicts) != 1 || graph.Conflicts[0] != [2]string{"key_size", "managed_hsm_id"} {
+		t.Fatalf("expected the reciprocal conflict once, got %v", graph.Conflicts)
+	}
+	if len(graph.Groups) != 2 || graph.Groups[0].Kind != "AtLeastOneOf" || graph.Groups[1].Kind != "ExactlyOneOf" {
+		t.Fatalf("expected one group of each kind, got %+v", graph.Groups)
+	}
+	if !slices.Equal(graph.Groups[1].Members, []string{"key_vault_id", "managed_hsm_id"}) {
+		t.Fatalf("unexpected ExactlyOneOf members: %v", graph.Groups[1].Members)
+	}
+}
+
+func TestHandleGetConstraintsDiagram(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "key_vault_id", Optional: true, ConflictsWith: sql.NullString{String: `"managed_hsm_id"`, Valid: true}})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "managed_hsm_id", Optional: true})
+	plain := testutil.InsertResource(t, db, repo.ID, "azurerm_plain", "resource", "internal/plain/resource.go")
+	testutil.InsertAttribute(t, db, plain.ID, database.ProviderAttribute{Name: "name", Required: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetConstraintsDiagram(map[string]any{"resource_name": res.Name})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{"```mermaid", `a1["key_vault_id"]`, "a1 -. conflicts .- a2"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in diagram, got %s", want, text)
+		}
+	}
+
+	text = s.handleGetConstraintsDiagram(map[string]any{"resource_name": plain.Name})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "No ConflictsWith") {
+		t.Fatalf("expected empty note, got %s", text)
+	}
+}
+
+func TestHandleFindShadowedAttributes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Required: true, ForceNew: true, Type: sqlNull("pluginsdk.TypeString")})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "tags", Optional: true, Type: sqlNull("pluginsdk.TypeMap")})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "node_pool",
+		Optional:    true,
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "name", Optional: true, Type: "pluginsdk.TypeString"},
+			{Name: "tags", Optional: true, Type: "pluginsdk.TypeMap"},
+			{Name: "vm_size", Required: true, Type: "pluginsdk.TypeString"},
+		})),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "identity", Optional: true, NestedBlock: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindShadowedAttributes(map[string]any{"resource_name": res.Name})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Collisions**: 2",
+		"| node_pool.name | String (required, force_new) | String (optional) | required/optional/computed, force_new |",
+		"| node_pool.tags | Map (optional) | Map (optional) | - |",
+		"**Unchecked Blocks**: 1",
+		"- identity",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "vm_size") {
+		t.Fatalf("expected only colliding names to be reported, got:\n%s", text)
+	}
+
+	if text := s.handleFindShadowedAttributes(map[string]any{})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "resource_name is required") {
+		t.Fatalf("expected resource_name required error, got %s", text)
+	}
+}
+
+func TestHandleGetAttributeConflictsTransitive(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "internal/example/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "a", Optional: true, ConflictsWith: sqlNull(`"b"`)})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "b", Optional: true, RequiredWith: sqlNull(`"c"`)})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "c", Optional: true, ConflictsWith: sqlNull(`"a"`)})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "block",
+		Optional:    true,
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "d", Optional: true, ExactlyOneOf: `"block.0.d", "c"`},
+		})),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "lonely", Optional: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	call := func(args map[string]any) string {
+		t.Helper()
+		return s.handleGetAttributeConflictsTransitive(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call(map[string]any{"resource_name": res.Name, "attribute_name": "a"})
+	for _, want := range []string{
+		"**Connected Attributes**: 3",
+		"**Constraints**: 4",
+		"| b | 1 | ConflictsWith a → b |",
+		"| c | 1 | ConflictsWith c → a |",
+		"| block.d | 2 | ExactlyOneOf block.d → c |",
+		"| b | RequiredWith | c |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in closure, got:\n%s", want, text)
+		}
+	}
+
+	shallow := call(map[string]any{"resource_name": res.Name, "attribute_name": "a", "max_depth": 1})
+	if strings.Contains(shallow, "| block.d |") || !strings.Contains(shallow, "beyond depth 1") {
+		t.Fatalf("expected traversal to stop at depth 1, got:\n%s", shallow)
+	}
+
+	if text := call(map[string]any{"resource_name": res.Name, "attribute_name": "lonely"}); !strings.Contains(text, "declares no constraints") {
+		t.Fatalf("expected unconstrained note, got %s", text)
+	}
+	if text := call(map[string]any{"resource_name": res.Name, "attribute_name": "missing"}); !strings.Contains(text, "not found") {
+		t.Fatalf("expected unknown attribute error, got %s", text)
+	}
+}
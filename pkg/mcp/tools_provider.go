@@ -0,0 +1,724 @@
+package mcp
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+func (s *Server) handleProviderOverview(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Limit int `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 25
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	overview, err := s.db.GetProviderOverview()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load provider overview: %v", err))
+	}
+
+	unresolved, err := s.db.ListProviderResourcesWithoutAttributes(limit)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list unresolved resources: %v", err))
+	}
+
+	text := formatter.ProviderOverview(s.style, overview, unresolved)
+	if s.syncer != nil {
+		text += formatter.GitHubCacheStats(s.style, s.syncer.CacheStats(), time.Now())
+	}
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleListProviders() map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	repos, err := s.db.ListRepositories()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list repositories: %v", err))
+	}
+
+	counts, err := s.db.CountResourcesByRepository()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to count resources: %v", err))
+	}
+
+	providers := make([]formatter.ProviderSummary, 0, len(repos))
+	for _, repo := range repos {
+		c := counts[repo.ID]
+		providers = append(providers, formatter.ProviderSummary{
+			Repository:  repo,
+			Resources:   c.Resources,
+			DataSources: c.DataSources,
+		})
+	}
+	return SuccessResponse(formatter.ProviderList(s.style, providers))
+}
+
+func (s *Server) handleFindGlobalResources(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 50
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	placements, err := s.db.ListResourceLocationPlacements(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load location attributes: %v", err))
+	}
+
+	regional := 0
+	var withoutLocation, optionalLocation []database.ResourceLocationPlacement
+	for _, p := range placements {
+		switch {
+		case !p.HasLocation:
+			withoutLocation = append(withoutLocation, p)
+		case p.Required:
+			regional++
+		default:
+			optionalLocation = append(optionalLocation, p)
+		}
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.GlobalResources(s.style, scope, regional, withoutLocation, optionalLocation, limit)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleFindResourcesWithCustomDiff(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListResourcesWithCustomizeDiff(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list resources with CustomizeDiff: %v", err))
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.ResourcesWithCustomDiff(s.style, scope, resources, limit)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleFindNonImportableResources(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListNonImportableResources(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list non-importable resources: %v", err))
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.NonImportableResources(s.style, scope, resources, limit)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleListUnresolvedResources(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListUnresolvedResources(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list unresolved resources: %v", err))
+	}
+
+	scope := "all definitions"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.UnresolvedResources(s.style, scope, resources, limit)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleFindResourcesWithoutTimeouts(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListResourcesWithoutTimeouts(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list resources without timeouts: %v", err))
+	}
+
+	scope := "all definitions"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.ResourcesWithoutTimeouts(s.style, scope, resources, limit)
+	return SuccessResponse(text)
+}
+
+// defaultCommonArguments are the standard arguments nearly every top-level Azure resource declares.
+var defaultCommonArguments = []string{"resource_group_name", "location"}
+
+func (s *Server) handleFindResourcesMissingCommonArgs(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string   `json:"resource_prefix"`
+		Kind           string   `json:"kind"`
+		Arguments      []string `json:"arguments"`
+		Limit          int      `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	kind := ifEmpty(strings.TrimSpace(params.Kind), "resource")
+	if kind != "resource" && kind != "data_source" {
+		return ErrorResponse("kind must be 'resource' or 'data_source'")
+	}
+
+	names := uniqueStrings(params.Arguments)
+	if len(names) == 0 {
+		names = defaultCommonArguments
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	coverage, err := s.db.ListResourcesMissingArguments(names, kind, prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list resources missing arguments: %v", err))
+	}
+
+	gaps := make([]formatter.MissingArgumentsResource, 0, len(coverage))
+	for _, c := range coverage {
+		var missing []string
+		for _, name := range names {
+			if !slices.Contains(c.Present, name) {
+				missing = append(missing, name)
+			}
+		}
+		gaps = append(gaps, formatter.MissingArgumentsResource{
+			Resource:   c.Resource,
+			Missing:    missing,
+			Assessment: missingArgumentsAssessment(c, missing),
+		})
+	}
+
+	scope := "all " + kind + "s"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+	return SuccessResponse(formatter.ResourcesMissingArguments(s.style, scope, names, gaps, limit))
+}
+
+// missingArgumentsAssessment judges whether missing arguments are a parser gap or a genuinely
+// unusual definition, such as a child resource addressed through its parent's ID.
+func missingArgumentsAssessment(c database.ResourceArgumentCoverage, missing []string) string {
+	if c.AttributeCount == 0 {
+		return "likely parse miss: no parsed schema"
+	}
+	for _, name := range missing {
+		if strings.Contains(c.SchemaSnippet, `"`+name+`"`) {
+			return "likely parse miss: " + name + " appears in the schema source"
+		}
+	}
+	if len(c.ParentIDs) > 0 {
+		return "likely intentional: child resource scoped by " + strings.Join(c.ParentIDs, ", ")
+	}
+	return "review: no parent ID argument found"
+}
+
+// Caps for search_resources_by_attribute_combination.
+const (
+	maxAttributeCombination     = 10
+	maxAttributeCombinationRows = 500
+)
+
+func (s *Server) handleSearchResourcesByAttributeCombination(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Attributes     []string `json:"attributes"`
+		Kind           string   `json:"kind"`
+		ResourcePrefix string   `json:"resource_prefix"`
+		Limit          int      `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	var names []string
+	for _, name := range params.Attributes {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ErrorResponse("attributes must list at least one attribute name")
+	}
+	if len(names) > maxAttributeCombination {
+		return ErrorResponse(fmt.Sprintf("attributes accepts at most %d names", maxAttributeCombination))
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, maxAttributeCombinationRows)
+
+	kind := strings.TrimSpace(params.Kind)
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListResourcesWithAttributes(names, kind, prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to search resources: %v", err))
+	}
+
+	var scopes []string
+	if kind != "" {
+		scopes = append(scopes, kind)
+	}
+	if prefix != "" {
+		scopes = append(scopes, prefix+"*")
+	}
+	scope := "all definitions"
+	if len(scopes) > 0 {
+		scope = strings.Join(scopes, ", ")
+	}
+
+	return SuccessResponse(formatter.ResourcesWithAttributes(s.style, names, scope, resources, limit))
+}
+
+func (s *Server) handleGetDeprecations(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName   string `json:"resource_name"`
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	if name := strings.TrimSpace(params.ResourceName); name != "" {
+		resource, err := s.db.GetProviderResource(name)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Resource '%s' not found", name))
+		}
+		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+		}
+
+		var resources []database.ProviderResource
+		if resource.DeprecationMessage.Valid && resource.DeprecationMessage.String != "" {
+			resources = append(resources, *resource)
+		}
+		var deprecated []database.DeprecatedAttribute
+		for _, attr := range attrs {
+			if attr.Deprecated.Valid && attr.Deprecated.String != "" {
+				deprecated = append(deprecated, database.DeprecatedAttribute{
+					ResourceName: resource.Name,
+					ResourceKind: resource.Kind,
+					Name:         attr.Name,
+					Message:      attr.Deprecated.String,
+				})
+			}
+		}
+		return SuccessResponse(formatter.Deprecations(s.style, resource.Name, resources, deprecated, limit))
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListDeprecatedResources(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list deprecated resources: %v", err))
+	}
+	attrs, err := s.db.ListDeprecatedAttributes(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list deprecated attributes: %v", err))
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+	return SuccessResponse(formatter.Deprecations(s.style, scope, resources, attrs, limit))
+}
+
+var (
+	// deprecationCuePattern matches the phrases deprecation messages use to introduce a replacement.
+	deprecationCuePattern = regexp.MustCompile(`(?i)\b(?:in fav(?:ou)?r of|renamed to|replaced by|superseded by|migrate to|moved (?:to|into)|use)\b`)
+	// cueTargetPattern matches an unquoted snake_case identifier directly following such a cue.
+	cueTargetPattern = regexp.MustCompile(`(?i)\b(?:in fav(?:ou)?r of|renamed to|replaced by|superseded by|migrate to|moved (?:to|into)|use)\s+(?:the\s+)?([a-z][a-z0-9]*(?:_[a-z0-9]+)+(?:\.[a-z0-9_]+)*)`)
+	// quotedIdentifierPattern matches identifiers wrapped in backticks or double quotes.
+	quotedIdentifierPattern = regexp.MustCompile("[`\"]([A-Za-z][A-Za-z0-9_.]*)[`\"]")
+	versionReferencePattern = regexp.MustCompile(`^v\d`)
+)
+
+func (s *Server) handleFindDeprecationReplacements(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListDeprecatedResources(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list deprecated resources: %v", err))
+	}
+	attrs, err := s.db.ListDeprecatedAttributes(prefix)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list deprecated attributes: %v", err))
+	}
+
+	providerPrefix := s.providerPrefix()
+	resourceExists := func(name string) bool {
+		_, err := s.db.GetProviderResource(name)
+		return err == nil
+	}
+	attributeNames := make(map[string]map[string]bool)
+	attributeExists := func(resourceName, name string) bool {
+		names, ok := attributeNames[resourceName]
+		if !ok {
+			names = make(map[string]bool)
+			if resource, err := s.db.GetProviderResource(resourceName); err == nil {
+				if resourceAttrs, err := s.db.GetProviderResourceAttributes(resource.ID); err == nil {
+					for _, a := range resourceAttrs {
+						names[a.Name] = true
+					}
+				}
+			}
+			attributeNames[resourceName] = names
+		}
+		return names[name]
+	}
+
+	var mappings []formatter.DeprecationReplacement
+	unnamed := 0
+	for _, r := range resources {
+		target := deprecationReplacement(r.DeprecationMessage.String, r.Name, providerPrefix)
+		if target == "" {
+			unnamed++
+			continue
+		}
+		mappings = append(mappings, formatter.DeprecationReplacement{
+			Deprecated:  r.Name,
+			Kind:        r.Kind,
+			Replacement: target,
+			Indexed:     resourceExists(target),
+			Message:     r.DeprecationMessage.String,
+		})
+	}
+	for _, a := range attrs {
+		target := deprecationReplacement(a.Message, a.Name, providerPrefix)
+		if target == "" {
+			unnamed++
+			continue
+		}
+		mapping := formatter.DeprecationReplacement{
+			Deprecated: a.ResourceName + "." + a.Name,
+			Kind:       "attribute",
+			Message:    a.Message,
+		}
+		if strings.HasPrefix(target, providerPrefix) {
+			mapping.Replacement = target
+			mapping.Indexed = resourceExists(target)
+		} else {
+			mapping.Replacement = a.ResourceName + "." + target
+			mapping.Indexed = attributeExists(a.ResourceName, target)
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+	return SuccessResponse(formatter.DeprecationReplacements(s.style, scope, mappings, unnamed, limit))
+}
+
+// deprecationReplacement extracts the replacement named in a deprecation message: the first
+// quoted identifier, provider resource name or snake_case identifier following a cue such as
+// "in favour of" or "renamed to", else the first reference anywhere. References to the deprecated name itself and bare version numbers
+// are skipped. It returns "" when the message names no replacement.
+func deprecationReplacement(message, deprecatedName, providerPrefix string) string {
+	type reference struct {
+		name string
+		pos  int
+	}
+	var refs []reference
+	add := func(name string, pos int) {
+		name = strings.TrimRight(name, ".")
+		if name == "" || name == deprecatedName || strings.HasSuffix(name, "."+deprecatedName) || versionReferencePattern.MatchString(name) {
+			return
+		}
+		refs = append(refs, reference{name: name, pos: pos})
+	}
+	for _, m := range quotedIdentifierPattern.FindAllStringSubmatchIndex(message, -1) {
+		add(message[m[2]:m[3]], m[0])
+	}
+	for _, m := range cueTargetPattern.FindAllStringSubmatchIndex(message, -1) {
+		add(message[m[2]:m[3]], m[2])
+	}
+	resourcePattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(providerPrefix) + `[a-z0-9_]+\b`)
+	for _, m := range resourcePattern.FindAllStringIndex(message, -1) {
+		add(message[m[0]:m[1]], m[0])
+	}
+	if len(refs) == 0 {
+		return ""
+	}
+	slices.SortStableFunc(refs, func(a, b reference) int { return a.pos - b.pos })
+
+	if cue := deprecationCuePattern.FindStringIndex(message); cue != nil {
+		for _, ref := range refs {
+			if ref.pos >= cue[1] {
+				return ref.name
+			}
+		}
+	}
+	return refs[0].name
+}
+
+func (s *Server) handleGetParseCacheStatus(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Limit int `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 50
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	summary, err := s.db.GetParseCacheSummary()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load parse cache: %v", err))
+	}
+
+	stale, err := s.db.ListStaleParseCacheEntries()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to check parse cache hashes: %v", err))
+	}
+
+	text := formatter.ParseCacheStatus(s.style, summary, stale, limit)
+	return SuccessResponse(text)
+}
+
+// resourceSupportsImport reports whether a resource can be imported, returning nil when that
+// cannot be determined (data sources, or untyped resources without stored source).
+func resourceSupportsImport(resource *database.ProviderResource, src *database.ProviderResourceSource) *bool {
+	if resource.Kind != "resource" {
+		return nil
+	}
+	supported := true
+	if resource.RegistrationType.String == "typed" {
+		return &supported
+	}
+	if src == nil {
+		return nil
+	}
+	supported = src.ImporterSnippet.Valid && strings.TrimSpace(src.ImporterSnippet.String) != ""
+	return &supported
+}
+
+func (s *Server) handleAttributeUsage(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Name             string `json:"name"`
+		Exact            bool   `json:"exact"`
+		Limit            int    `json:"limit"`
+		IncludeResources bool   `json:"include_resources"`
+		ResourceLimit    int    `json:"resource_limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return ErrorResponse("name is required")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 20
+	} else if limit < 0 {
+		limit = 0
+	}
+	resourceLimit := params.ResourceLimit
+	if resourceLimit == 0 {
+		resourceLimit = 10
+	} else if resourceLimit < 0 {
+		resourceLimit = 0
+	}
+
+	usages, err := s.db.ListAttributeUsage(name, params.Exact, limit)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to count attribute usage: %v", err))
+	}
+
+	var resources map[string][]database.ProviderResource
+	if params.IncludeResources {
+		resources = make(map[string][]database.ProviderResource, len(usages))
+		for _, u := range usages {
+			defs, err := s.db.ListResourcesWithAttribute(u.Name, resourceLimit)
+			if err != nil {
+				return ErrorResponse(fmt.Sprintf("Failed to list resources for %s: %v", u.Name, err))
+			}
+			resources[u.Name] = defs
+		}
+	}
+
+	text := formatter.AttributeUsage(s.style, name, params.Exact, usages, resources)
+	return SuccessResponse(text)
+}
@@ -0,0 +1,599 @@
+package mcp
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/indexer"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestHandleProviderOverview(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	serviceID, err := db.InsertProviderService(&database.ProviderService{RepositoryID: repo.ID, Name: "Network"})
+	if err != nil {
+		t.Fatalf("insert service: %v", err)
+	}
+
+	insert := func(name, kind, registration string, withService bool) int64 {
+		res := &database.ProviderResource{
+			RepositoryID:     repo.ID,
+			Name:             name,
+			Kind:             kind,
+			RegistrationType: sqlNull(registration),
+		}
+		if withService {
+			res.ServiceID = sql.NullInt64{Int64: serviceID, Valid: true}
+		}
+		id, err := db.InsertProviderResource(res)
+		if err != nil {
+			t.Fatalf("insert %s: %v", name, err)
+		}
+		return id
+	}
+
+	vnet := insert("azurerm_virtual_network", "resource", "untyped", true)
+	subnetData := insert("azurerm_subnet", "data_source", "untyped", true)
+	insert("azurerm_network_manager", "resource", "typed", false)
+	testutil.InsertAttribute(t, db, vnet, database.ProviderAttribute{Name: "name"})
+	testutil.InsertAttribute(t, db, subnetData, database.ProviderAttribute{Name: "name"})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleProviderOverview(map[string]any{})
+	text := resp["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Definitions**: 3",
+		"**Services**: 1",
+		"**Linked to a Service**: 2",
+		"**Without Parsed Attributes**: 1",
+		"- data_source: 1",
+		"- resource: 2",
+		"- typed: 1",
+		"- untyped: 2",
+		"| azurerm_network_manager | resource | typed |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in overview, got %s", want, text)
+		}
+	}
+}
+
+func TestHandleListProviders(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	if text := s.handleListProviders()["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "No providers indexed yet") {
+		t.Fatalf("expected empty message, got %s", text)
+	}
+
+	azurerm := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertRepository(t, db, "terraform-provider-azuread")
+	testutil.InsertResource(t, db, azurerm.ID, "azurerm_virtual_network", "resource", "")
+	testutil.InsertResource(t, db, azurerm.ID, "azurerm_subnet", "resource", "")
+	testutil.InsertResource(t, db, azurerm.ID, "azurerm_subnet", "data_source", "")
+
+	text := s.handleListProviders()["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"| terraform-provider-azuread | terraform-provider-azuread | 0 | 0 |",
+		"| terraform-provider-azurerm | terraform-provider-azurerm | 2 | 1 |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in provider list, got %s", want, text)
+		}
+	}
+	if strings.Index(text, "terraform-provider-azuread") > strings.Index(text, "terraform-provider-azurerm") {
+		t.Fatalf("expected providers ordered by name, got %s", text)
+	}
+}
+
+func TestHandleFindResourcesMissingCommonArgs(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	complete := testutil.InsertResource(t, db, repo.ID, "azurerm_network_security_group", "resource", "")
+	testutil.InsertAttribute(t, db, complete.ID, database.ProviderAttribute{Name: "resource_group_name", Required: true})
+	testutil.InsertAttribute(t, db, complete.ID, database.ProviderAttribute{Name: "location", Required: true})
+
+	association := testutil.InsertResource(t, db, repo.ID, "azurerm_network_subnet_association", "resource", "")
+	testutil.InsertAttribute(t, db, association.ID, database.ProviderAttribute{Name: "subnet_id", Required: true, ForceNew: true})
+
+	gateway := testutil.InsertResource(t, db, repo.ID, "azurerm_network_gateway", "resource", "")
+	testutil.InsertAttribute(t, db, gateway.ID, database.ProviderAttribute{Name: "resource_group_name", Required: true})
+	if err := db.UpsertProviderResourceSource(gateway.ID, "resourceNetworkGateway", "gateway.go", "", `"location": helpers.Location(),`, "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+
+	testutil.InsertResource(t, db, repo.ID, "azurerm_network_unparsed", "resource", "")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_network_lookup", "data_source", "")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindResourcesMissingCommonArgs(map[string]any{"resource_prefix": "azurerm_network_"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Matches**: 3",
+		"| azurerm_network_gateway | location | likely parse miss: location appears in the schema source |",
+		"| azurerm_network_subnet_association | resource_group_name, location | likely intentional: child resource scoped by subnet_id |",
+		"| azurerm_network_unparsed | resource_group_name, location | likely parse miss: no parsed schema |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in audit, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "azurerm_network_security_group") || strings.Contains(text, "azurerm_network_lookup") {
+		t.Fatalf("expected complete resources and data sources excluded, got %s", text)
+	}
+
+	text = s.handleFindResourcesMissingCommonArgs(map[string]any{"resource_prefix": "azurerm_network_", "arguments": []any{"location"}, "limit": 1})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Expected**: location") || !strings.Contains(text, "_Showing 1 of 3._") {
+		t.Fatalf("expected custom arguments and limit, got %s", text)
+	}
+
+	text = s.handleFindResourcesMissingCommonArgs(map[string]any{"kind": "provider"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "kind must be") {
+		t.Fatalf("expected kind validation error, got %s", text)
+	}
+}
+
+func TestHandleClearGitHubCache(t *testing.T) {
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = testutil.NewTestDB(t)
+	fake := &fakeSyncer{cacheStats: indexer.CacheStats{
+		Entries: 3,
+		Bytes:   2048,
+		Oldest:  time.Now().Add(-5 * time.Minute),
+		TTL:     indexer.DefaultCacheTTL,
+	}}
+	s.syncer = fake
+
+	overview := s.handleProviderOverview(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(overview, "## GitHub Cache") || !strings.Contains(overview, "**Entries**: 3 (0 expired)") || !strings.Contains(overview, "**Size**: 2.0 KiB") {
+		t.Fatalf("expected cache stats in overview, got %s", overview)
+	}
+
+	text := s.handleClearGitHubCache()["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "Cleared 3 cached GitHub responses") || fake.cacheCleared != 1 {
+		t.Fatalf("expected cache cleared, got %s", text)
+	}
+	text = s.handleClearGitHubCache()["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "already empty") {
+		t.Fatalf("expected empty cache message, got %s", text)
+	}
+}
+
+func TestHandleGetParseCacheStatus(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	empty := s.handleGetParseCacheStatus(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(empty, "The parse cache is empty") {
+		t.Fatalf("expected empty cache message, got %s", empty)
+	}
+
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertFile(t, db, repo.ID, "internal/services/network/virtual_network_resource.go", "go", "package network // edited")
+	if err := db.UpsertParseCacheEntry(&database.ParseCacheEntry{
+		FilePath:       "internal/services/network/virtual_network_resource.go",
+		ContentHash:    database.ContentHash("package network"),
+		ResourceCount:  1,
+		AttributeCount: 12,
+	}); err != nil {
+		t.Fatalf("upsert cache: %v", err)
+	}
+
+	text := s.handleGetParseCacheStatus(map[string]any{})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Cached Files**: 1 of 1 indexed Go files",
+		"**Parsed Attributes**: 12",
+		"**Stale Entries**: 1",
+		"| internal/services/network/virtual_network_resource.go | hash changed | 1 | 12 |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in status, got %s", want, text)
+		}
+	}
+}
+
+func TestHandleFindGlobalResources(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	regional := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, regional.ID, database.ProviderAttribute{Name: "location", Required: true, ForceNew: true})
+	testutil.InsertAttribute(t, db, regional.ID, database.ProviderAttribute{Name: "name", Required: true})
+
+	global := testutil.InsertResource(t, db, repo.ID, "azurerm_dns_zone", "resource", "internal/services/dns/dns_zone_resource.go")
+	testutil.InsertAttribute(t, db, global.ID, database.ProviderAttribute{Name: "name", Required: true})
+
+	optional := testutil.InsertResource(t, db, repo.ID, "azurerm_resource_group_template_deployment", "resource", "internal/services/resource/resource_group_template_deployment_resource.go")
+	testutil.InsertAttribute(t, db, optional.ID, database.ProviderAttribute{Name: "location", Optional: true, Computed: true})
+
+	dataSource := testutil.InsertResource(t, db, repo.ID, "azurerm_client_config", "data_source", "internal/services/authorization/client_config_data_source.go")
+	testutil.InsertAttribute(t, db, dataSource.ID, database.ProviderAttribute{Name: "tenant_id", Computed: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindGlobalResources(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Scanned**: 3 resources") || !strings.Contains(text, "**Regional (required location)**: 1") {
+		t.Fatalf("expected counts, got %s", text)
+	}
+	if !strings.Contains(text, "- azurerm_dns_zone") {
+		t.Fatalf("expected global-style resource listed, got %s", text)
+	}
+	if !strings.Contains(text, "| azurerm_resource_group_template_deployment | optional, computed |") {
+		t.Fatalf("expected optional location resource, got %s", text)
+	}
+	if strings.Contains(text, "azurerm_virtual_network") || strings.Contains(text, "azurerm_client_config") {
+		t.Fatalf("regional resources and data sources should not be listed, got %s", text)
+	}
+
+	scoped := s.handleFindGlobalResources(map[string]any{"resource_prefix": "azurerm_virtual"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(scoped, "**Scanned**: 1 resources") || strings.Contains(scoped, "azurerm_dns_zone") {
+		t.Fatalf("expected prefix to scope results, got %s", scoped)
+	}
+}
+
+func TestHandleFindResourcesWithCustomDiff(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	cluster := testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster", "resource", "internal/services/containers/kubernetes_cluster_resource.go")
+	if err := db.UpsertProviderResourceSource(cluster.ID, "resourceKubernetesCluster", "", "", "", "pluginsdk.CustomDiffWithAll()", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	pool := testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster_node_pool", "resource", "internal/services/containers/kubernetes_cluster_node_pool_resource.go")
+	if err := db.UpsertProviderResourceSource(pool.ID, "resourceKubernetesClusterNodePool", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	profile := testutil.InsertResource(t, db, repo.ID, "azurerm_cdn_profile", "resource", "internal/services/cdn/cdn_profile_resource.go")
+	if err := db.UpsertProviderResourceSource(profile.ID, "resourceCdnProfile", "", "", "", "customdiff.ForceNewIfChange()", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindResourcesWithCustomDiff(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Matches**: 2") || !strings.Contains(text, "| azurerm_cdn_profile | resource |") {
+		t.Fatalf("expected both CustomizeDiff resources, got %s", text)
+	}
+	if strings.Contains(text, "azurerm_kubernetes_cluster_node_pool") {
+		t.Fatalf("resource without CustomizeDiff should not be listed, got %s", text)
+	}
+
+	scoped := s.handleFindResourcesWithCustomDiff(map[string]any{"resource_prefix": "azurerm_kubernetes_"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(scoped, "**Matches**: 1") || strings.Contains(scoped, "azurerm_cdn_profile") {
+		t.Fatalf("expected prefix to scope results, got %s", scoped)
+	}
+}
+
+func TestHandleFindNonImportableResources(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	account := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	if err := db.UpsertProviderResourceSource(account.ID, "resourceStorageAccount", "", "", "", "", "", "", "pluginsdk.ImporterValidatingResourceId(...)"); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	extension := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_machine_extension_run", "resource", "internal/services/compute/virtual_machine_extension_run_resource.go")
+	if err := db.UpsertProviderResourceSource(extension.ID, "resourceVirtualMachineExtensionRun", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	dataSource := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "data_source", "internal/services/storage/storage_account_data_source.go")
+	if err := db.UpsertProviderResourceSource(dataSource.ID, "dataSourceStorageAccount", "", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindNonImportableResources(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Matches**: 1") || !strings.Contains(text, "| azurerm_virtual_machine_extension_run |") {
+		t.Fatalf("expected resource without Importer listed, got %s", text)
+	}
+	if strings.Contains(text, "azurerm_storage_account") {
+		t.Fatalf("importable resources and data sources should not be listed, got %s", text)
+	}
+
+	schema := s.handleGetResourceSchema(map[string]any{"name": "azurerm_virtual_machine_extension_run"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(schema, "**Supports Import:** No") {
+		t.Fatalf("expected schema header to flag missing import support, got %s", schema)
+	}
+	schema = s.handleGetResourceSchema(map[string]any{"name": "azurerm_storage_account"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(schema, "**Supports Import:** Yes") {
+		t.Fatalf("expected schema header to report import support, got %s", schema)
+	}
+}
+
+func TestHandleListUnresolvedResources(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	insert := func(name, reason string) *database.ProviderResource {
+		res := &database.ProviderResource{
+			RepositoryID:     repo.ID,
+			Name:             name,
+			Kind:             "resource",
+			UnresolvedReason: sql.NullString{String: reason, Valid: reason != ""},
+		}
+		id, err := db.InsertProviderResource(res)
+		if err != nil {
+			t.Fatalf("insert %s: %v", name, err)
+		}
+		res.ID = id
+		return res
+	}
+	insert("azurerm_network_manager", database.UnresolvedTyped)
+	insert("azurerm_network_profile", database.UnresolvedMissingFunction)
+	insert("azurerm_network_watcher", "")
+	parsed := insert("azurerm_network_interface", "")
+	testutil.InsertAttribute(t, db, parsed.ID, database.ProviderAttribute{Name: "name", Required: true})
+	insert("azurerm_storage_sync", database.UnresolvedParseError)
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleListUnresolvedResources(map[string]any{"resource_prefix": "azurerm_network_"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Matches**: 3",
+		"| azurerm_network_manager | resource | typed resource (schema in struct methods) |",
+		"| azurerm_network_profile | resource | registered function not found |",
+		"| azurerm_network_watcher | resource | schema parsed without attributes |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "azurerm_network_interface") || strings.Contains(text, "azurerm_storage_sync") {
+		t.Fatalf("expected parsed and out-of-scope resources to be excluded, got %s", text)
+	}
+
+	text = s.handleListUnresolvedResources(map[string]any{"limit": 1})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "_Showing 1 of 4._") {
+		t.Fatalf("expected capped list, got %s", text)
+	}
+}
+
+func TestHandleFindResourcesWithoutTimeouts(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	withSource := func(name, kind, timeouts string) {
+		res := testutil.InsertResource(t, db, repo.ID, name, kind, "internal/services/network/"+name+".go")
+		if err := db.UpsertProviderResourceSource(res.ID, "fn", res.FilePath.String, "", "", "", timeouts, "", ""); err != nil {
+			t.Fatalf("upsert source: %v", err)
+		}
+	}
+	withSource("azurerm_network_profile", "resource", "")
+	withSource("azurerm_network_profile", "data_source", "")
+	withSource("azurerm_network_watcher", "resource", "&pluginsdk.ResourceTimeout{Create: pluginsdk.DefaultTimeout(30 * time.Minute)}")
+	withSource("azurerm_storage_sync", "resource", "")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_network_manager", "resource", "internal/services/network/manager.go")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindResourcesWithoutTimeouts(map[string]any{"resource_prefix": "azurerm_network_"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Matches**: 2",
+		"| azurerm_network_profile | resource |",
+		"| azurerm_network_profile | data_source |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q, got %s", want, text)
+		}
+	}
+	for _, unwanted := range []string{"azurerm_network_watcher", "azurerm_network_manager", "azurerm_storage_sync"} {
+		if strings.Contains(text, unwanted) {
+			t.Fatalf("expected %s to be excluded, got %s", unwanted, text)
+		}
+	}
+
+	text = s.handleFindResourcesWithoutTimeouts(map[string]any{"limit": 1})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "_Showing 1 of 3._") {
+		t.Fatalf("expected capped list, got %s", text)
+	}
+}
+
+func TestHandleGetDeprecations(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	legacy := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_legacy", "resource", "internal/services/storage/legacy_resource.go")
+	legacy.DeprecationMessage = sql.NullString{String: "use azurerm_storage_account instead", Valid: true}
+	if _, err := db.InsertProviderResource(legacy); err != nil {
+		t.Fatalf("update resource: %v", err)
+	}
+	account := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{
+		Name:       "enable_https_traffic_only",
+		Optional:   true,
+		Deprecated: sql.NullString{String: "use https_traffic_only_enabled | instead", Valid: true},
+	})
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{Name: "name", Required: true})
+	cluster := testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster", "resource", "internal/services/containers/kubernetes_cluster_resource.go")
+	testutil.InsertAttribute(t, db, cluster.ID, database.ProviderAttribute{
+		Name:       "api_server_authorized_ip_ranges",
+		Optional:   true,
+		Deprecated: sql.NullString{String: "moved into api_server_access_profile", Valid: true},
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetDeprecations(map[string]any{"resource_prefix": "azurerm_storage_"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| azurerm_storage_legacy | resource | use azurerm_storage_account instead |") {
+		t.Fatalf("expected deprecated resource listed, got %s", text)
+	}
+	if !strings.Contains(text, "| azurerm_storage_account | enable_https_traffic_only | use https_traffic_only_enabled \\| instead |") {
+		t.Fatalf("expected deprecated attribute with escaped message, got %s", text)
+	}
+	if strings.Contains(text, "api_server_authorized_ip_ranges") || strings.Contains(text, "| name |") {
+		t.Fatalf("expected only deprecated attributes within the prefix, got %s", text)
+	}
+
+	text = s.handleGetDeprecations(map[string]any{"resource_name": "azurerm_kubernetes_cluster"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Deprecated Resources**: 0") || !strings.Contains(text, "api_server_authorized_ip_ranges") {
+		t.Fatalf("expected cluster attribute deprecation, got %s", text)
+	}
+
+	text = s.handleGetDeprecations(map[string]any{"limit": 1})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "_Showing 1 of 2._") {
+		t.Fatalf("expected attribute list capped, got %s", text)
+	}
+
+	text = s.handleGetDeprecations(map[string]any{"resource_name": "azurerm_missing"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "not found") {
+		t.Fatalf("expected not found error, got %s", text)
+	}
+}
+
+func TestHandleFindDeprecationReplacements(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	legacy := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_legacy", "resource", "")
+	legacy.DeprecationMessage = sql.NullString{String: "use azurerm_storage_account instead", Valid: true}
+	if _, err := db.InsertProviderResource(legacy); err != nil {
+		t.Fatalf("update resource: %v", err)
+	}
+	account := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "")
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{
+		Name:       "enable_https_traffic_only",
+		Deprecated: sql.NullString{String: "The `enable_https_traffic_only` property has been superseded by `https_traffic_only_enabled` and will be removed in `v4.0` of the AzureRM Provider.", Valid: true},
+	})
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{Name: "https_traffic_only_enabled"})
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{
+		Name:       "allow_blob_public_access",
+		Deprecated: sql.NullString{String: "this property is no longer supported", Valid: true},
+	})
+	cluster := testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster", "resource", "")
+	testutil.InsertAttribute(t, db, cluster.ID, database.ProviderAttribute{
+		Name:       "api_server_authorized_ip_ranges",
+		Deprecated: sql.NullString{String: "moved into api_server_access_profile", Valid: true},
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindDeprecationReplacements(map[string]any{})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Mappings**: 3",
+		"**Without a Named Replacement**: 1",
+		"| azurerm_storage_legacy | resource | azurerm_storage_account | yes |",
+		"| azurerm_storage_account.enable_https_traffic_only | attribute | azurerm_storage_account.https_traffic_only_enabled | yes |",
+		"| azurerm_kubernetes_cluster.api_server_authorized_ip_ranges | attribute | azurerm_kubernetes_cluster.api_server_access_profile | no |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in replacements, got %s", want, text)
+		}
+	}
+
+	text = s.handleFindDeprecationReplacements(map[string]any{"resource_prefix": "azurerm_kubernetes_"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Mappings**: 1") || strings.Contains(text, "azurerm_storage") {
+		t.Fatalf("expected prefix scoping, got %s", text)
+	}
+}
+
+func TestDeprecationReplacement(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"`foo` has been renamed to `foo_enabled`", "foo_enabled"},
+		{"Deprecated in favour of the `bar_block` block", "bar_block"},
+		{"Use azurerm_new_thing instead of this resource", "azurerm_new_thing"},
+		{"`foo` will be removed in `v4.0`", ""},
+		{"This field is no longer used", ""},
+	}
+	for _, tt := range tests {
+		if got := deprecationReplacement(tt.message, "foo", "azurerm_"); got != tt.want {
+			t.Errorf("deprecationReplacement(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestHandleAttributeUsage(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	vnet := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, vnet.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	account := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	testutil.InsertAttribute(t, db, account.ID, database.ProviderAttribute{Name: "blob_tags_enabled", Optional: true})
+	accountData := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "data_source", "internal/services/storage/storage_account_data_source.go")
+	testutil.InsertAttribute(t, db, accountData.ID, database.ProviderAttribute{Name: "tags", Computed: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleAttributeUsage(map[string]any{"name": "tags"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| tags | 2 | 1 | 3 |") || !strings.Contains(text, "| blob_tags_enabled | 1 | 0 | 1 |") {
+		t.Fatalf("expected substring usage counts, got %s", text)
+	}
+
+	resp = s.handleAttributeUsage(map[string]any{"name": "tags", "exact": true, "include_resources": true, "resource_limit": 2})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if strings.Contains(text, "blob_tags_enabled") {
+		t.Fatalf("exact match should exclude substrings: %s", text)
+	}
+	if !strings.Contains(text, "- azurerm_storage_account (data_source)") || !strings.Contains(text, "_Showing 2 of 3._") {
+		t.Fatalf("expected limited resource listing, got %s", text)
+	}
+
+	resp = s.handleAttributeUsage(map[string]any{})
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "name is required") {
+		t.Fatalf("expected name error, got %s", text)
+	}
+}
+
+func TestHandleSearchResourcesByAttributeCombination(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	for name, attrs := range map[string][]string{
+		"azurerm_storage_account": {"name", "tags", "identity"},
+		"azurerm_key_vault":       {"name", "tags"},
+		"azurerm_linux_web_app":   {"name", "tags", "identity"},
+	} {
+		res := testutil.InsertResource(t, db, repo.ID, name, "resource", "internal/"+name+".go")
+		for _, attr := range attrs {
+			testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: attr})
+		}
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	call := func(args map[string]any) string {
+		t.Helper()
+		return s.handleSearchResourcesByAttributeCombination(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call(map[string]any{"attributes": []any{"tags", " identity ", "tags"}, "limit": 1})
+	for _, want := range []string{"# Resources With tags + identity (all definitions)", "**Matches**: 2", "| azurerm_linux_web_app | resource |", "_Showing 1 of 2._"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q, got %s", want, text)
+		}
+	}
+
+	if text := call(map[string]any{"attributes": []any{}}); !strings.Contains(text, "at least one attribute") {
+		t.Fatalf("expected empty attributes to be rejected, got %s", text)
+	}
+	many := make([]any, maxAttributeCombination+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("attr_%d", i)
+	}
+	if text := call(map[string]any{"attributes": many}); !strings.Contains(text, "at most 10") {
+		t.Fatalf("expected too many attributes to be rejected, got %s", text)
+	}
+}
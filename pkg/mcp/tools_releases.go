@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/dkooll/aztfmcp/internal/database"
@@ -13,6 +14,13 @@ import (
 	"github.com/dkooll/aztfmcp/internal/indexer"
 )
 
+var (
+	propertyAdditionPattern = regexp.MustCompile(`(?i)support for (?:the )?(?:new )?(.+?)\s+(?:propert(?:y|ies)|arguments?|attributes?|blocks?|fields?)\b`)
+	attributeTokenPattern   = regexp.MustCompile(`^[a-z][a-z0-9_.]*$`)
+	// Release headings such as "## 4.48.0 (October 10, 2025)" or "## [4.48.0]".
+	changelogHeadingPattern = regexp.MustCompile(`(?m)^##\s*\[?v?(\d+(?:\.\d+)+[0-9A-Za-z.+-]*)\]?`)
+)
+
 type releaseSummaryArgs struct {
 	Version string   `json:"version"`
 	Fields  []string `json:"fields"`
@@ -163,7 +171,7 @@ func (s *Server) handleGetReleaseSnippet(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to fetch GitHub compare diff: %v", err))
 	}
 
-	filename, patch := locatePatchForEntry(compare, entry, query, entryFilePath)
+	filename, patch := locatePatchForEntry(compare, entry, query, entryFilePath, s.providerPrefix())
 	if filename == "" || patch == "" {
 		return ErrorResponse("Diff data not available for that entry. Try a different query or rerun the incremental sync.")
 	}
@@ -182,7 +190,7 @@ func (s *Server) handleGetReleaseSnippet(args any) map[string]any {
 	}
 
 	trimmed, truncated := trimPatchLines(patch, maxLines)
-	text := formatReleaseSnippetResponse(repo, release, entry, filename, trimmed, truncated, maxLines, includeHeader, includeFile, includeDiff, includeCompare)
+	text := formatter.ReleaseSnippet(s.style, repo, release, entry, filename, trimmed, truncated, maxLines, includeHeader, includeFile, includeDiff, includeCompare)
 	return SuccessResponse(text)
 }
 
@@ -193,6 +201,7 @@ func (s *Server) primaryRepository() (*database.Repository, error) {
 
 type backfillReleaseArgs struct {
 	Version string `json:"version"`
+	Force   bool   `json:"force"`
 }
 
 func (s *Server) handleBackfillRelease(args any) map[string]any {
@@ -213,6 +222,25 @@ func (s *Server) handleBackfillRelease(args any) map[string]any {
 		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
 	}
 
+	ver := strings.TrimSpace(params.Version)
+	normalizedVersion := strings.TrimPrefix(strings.ToLower(ver), "v")
+	tag := ver
+	if !strings.HasPrefix(strings.ToLower(tag), "v") {
+		tag = "v" + tag
+	}
+
+	// A release with a comparison URL came from a full sync and carries commit metadata the
+	// changelog does not; only replace it when asked to.
+	if !params.Force {
+		existing, entries, err := s.fullSyncRelease(repo.ID, normalizedVersion)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load existing release: %v", err))
+		}
+		if existing != nil {
+			return SuccessResponse(fmt.Sprintf("Release %s already present with %d entries from a full sync; pass force=true to replace it with changelog-only data", existing.Tag, entries))
+		}
+	}
+
 	// Load the stored CHANGELOG.md from DB
 	file, err := s.db.GetFile(repo.Name, "CHANGELOG.md")
 	if err != nil {
@@ -225,23 +253,116 @@ func (s *Server) handleBackfillRelease(args any) map[string]any {
 		return ErrorResponse("CHANGELOG.md is empty")
 	}
 
-	ver := strings.TrimSpace(params.Version)
-	normalizedVersion := strings.TrimPrefix(strings.ToLower(ver), "v")
-	tag := ver
-	if !strings.HasPrefix(strings.ToLower(tag), "v") {
-		tag = "v" + tag
-	}
-
 	relBlock, date, ok := extractReleaseBlock(raw, normalizedVersion)
 	if !ok {
 		return ErrorResponse(fmt.Sprintf("Version %s not found in changelog", ver))
 	}
 
-	entries := parseReleaseEntriesFromBlock(relBlock)
+	entries, err := s.storeChangelogRelease(repo.ID, normalizedVersion, tag, relBlock, date)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to backfill release %s: %v", tag, err))
+	}
+
+	return SuccessResponse(fmt.Sprintf("Backfilled release %s with %d entries", tag, entries))
+}
+
+type backfillReleasesArgs struct {
+	Since string `json:"since"`
+	Force bool   `json:"force"`
+}
+
+// handleBackfillReleases stores every changelog release from since up to the newest heading, so
+// release history for summaries and breaking-change scans can be filled in one call.
+func (s *Server) handleBackfillReleases(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[backfillReleasesArgs](args)
+	if err != nil || strings.TrimSpace(params.Since) == "" {
+		return ErrorResponse("since is required")
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	file, err := s.db.GetFile(repo.Name, "CHANGELOG.md")
+	if err != nil {
+		return ErrorResponse("CHANGELOG.md not found in local index; run a full sync first")
+	}
+	raw := strings.TrimSpace(file.Content)
+	if raw == "" {
+		return ErrorResponse("CHANGELOG.md is empty")
+	}
+
+	since := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(params.Since)), "v")
+	var stored []formatter.BackfilledRelease
+	var skipped []formatter.BackfillSkip
+	for _, version := range changelogVersions(raw) {
+		if compareVersions(version, since) < 0 {
+			continue
+		}
+		block, date, ok := extractReleaseBlock(raw, version)
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(date, "unreleased") {
+			skipped = append(skipped, formatter.BackfillSkip{Version: version, Reason: "not released yet"})
+			continue
+		}
+		if !params.Force {
+			existing, entries, err := s.fullSyncRelease(repo.ID, version)
+			if err != nil {
+				return ErrorResponse(fmt.Sprintf("Failed to load existing release %s: %v", version, err))
+			}
+			if existing != nil {
+				skipped = append(skipped, formatter.BackfillSkip{Version: version, Reason: fmt.Sprintf("already present with %d entries from a full sync", entries)})
+				continue
+			}
+		}
+
+		entries, err := s.storeChangelogRelease(repo.ID, version, "v"+version, block, date)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to backfill release v%s: %v", version, err))
+		}
+		stored = append(stored, formatter.BackfilledRelease{Version: version, Date: date, Entries: entries})
+	}
+
+	if len(stored) == 0 && len(skipped) == 0 {
+		return ErrorResponse(fmt.Sprintf("No changelog releases found at or after %s", params.Since))
+	}
+	return SuccessResponse(formatter.ReleaseBackfill(s.style, since, stored, skipped))
+}
+
+// fullSyncRelease returns the stored release for version, with its entry count, when it came from
+// a full sync; such releases carry a comparison URL and commit metadata the changelog does not.
+func (s *Server) fullSyncRelease(repoID int64, version string) (*database.ProviderRelease, int, error) {
+	existing, entries, err := s.db.GetReleaseWithEntriesByVersion(repoID, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(entries) == 0 || !existing.ComparisonURL.Valid || existing.ComparisonURL.String == "" {
+		return nil, 0, nil
+	}
+	return existing, len(entries), nil
+}
+
+// storeChangelogRelease upserts a release parsed from its changelog block and replaces its entries,
+// returning how many entries were stored.
+func (s *Server) storeChangelogRelease(repoID int64, version, tag, block, date string) (int, error) {
+	entries := parseReleaseEntriesFromBlock(block)
 
 	rel := &database.ProviderRelease{
-		RepositoryID:  repo.ID,
-		Version:       normalizedVersion,
+		RepositoryID:  repoID,
+		Version:       version,
 		Tag:           tag,
 		ReleaseDate:   sql.NullString{String: date, Valid: date != ""},
 		ComparisonURL: sql.NullString{},
@@ -249,14 +370,27 @@ func (s *Server) handleBackfillRelease(args any) map[string]any {
 
 	releaseID, err := s.db.UpsertProviderRelease(rel)
 	if err != nil {
-		return ErrorResponse(fmt.Sprintf("Failed to store release: %v", err))
+		return 0, fmt.Errorf("store release: %w", err)
 	}
 
 	if err := s.db.ReplaceReleaseEntries(releaseID, entries); err != nil {
-		return ErrorResponse(fmt.Sprintf("Failed to store release entries: %v", err))
+		return 0, fmt.Errorf("store release entries: %w", err)
 	}
+	return len(entries), nil
+}
 
-	return SuccessResponse(fmt.Sprintf("Backfilled release %s with %d entries", tag, len(entries)))
+// changelogVersions lists the versions of all release headings in changelog order, newest first.
+func changelogVersions(changelog string) []string {
+	var versions []string
+	seen := make(map[string]bool)
+	for _, match := range changelogHeadingPattern.FindAllStringSubmatch(changelog, -1) {
+		version := strings.ToLower(match[1])
+		if !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+	return versions
 }
 
 // extractReleaseBlock finds the section for a specific version and returns its text and date.
@@ -376,12 +510,12 @@ func selectReleaseEntry(entries []database.ProviderReleaseEntry, query string, f
 	return nil
 }
 
-func locatePatchForEntry(compare *indexer.GitHubCompareResult, entry *database.ProviderReleaseEntry, query string, filePath string) (string, string) {
+func locatePatchForEntry(compare *indexer.GitHubCompareResult, entry *database.ProviderReleaseEntry, query, filePath, prefix string) (string, string) {
 	if compare == nil {
 		return "", ""
 	}
 
-	preferredTargets := buildReleaseEntryTargets(entry, query, filePath)
+	preferredTargets := buildReleaseEntryTargets(entry, query, filePath, prefix)
 	bestScore := -1 << 30
 	bestFile := ""
 	bestPatch := ""
@@ -413,7 +547,7 @@ type releaseEntryTargets struct {
 	preferredDirSegments []string
 }
 
-func buildReleaseEntryTargets(entry *database.ProviderReleaseEntry, query string, filePath string) releaseEntryTargets {
+func buildReleaseEntryTargets(entry *database.ProviderReleaseEntry, query, filePath, prefix string) releaseEntryTargets {
 	targets := releaseEntryTargets{}
 
 	if filePath != "" {
@@ -429,13 +563,13 @@ func buildReleaseEntryTargets(entry *database.ProviderReleaseEntry, query string
 
 	if entry.ResourceName.Valid {
 		nameLower := strings.ToLower(entry.ResourceName.String)
-		trimmed := strings.TrimPrefix(nameLower, "azurerm_")
+		trimmed := strings.TrimPrefix(nameLower, prefix)
 		targets.filenameTokens = append(targets.filenameTokens, nameLower, trimmed)
 		targets.contentTokens = append(targets.contentTokens, nameLower, trimmed)
 		if trimmed != "" {
 			targets.filenameTokens = append(targets.filenameTokens, trimmed+"_resource")
 		}
-		camel := toCamelCaseToken(entry.ResourceName.String)
+		camel := toCamelCaseToken(entry.ResourceName.String, prefix)
 		if camel != "" {
 			targets.contentTokens = append(targets.contentTokens, camel)
 		}
@@ -527,12 +661,12 @@ func uniqueStrings(values []string) []string {
 	return out
 }
 
-func toCamelCaseToken(val string) string {
+func toCamelCaseToken(val, prefix string) string {
 	val = strings.TrimSpace(val)
 	if val == "" {
 		return ""
 	}
-	val = strings.TrimPrefix(strings.ToLower(val), "azurerm_")
+	val = strings.TrimPrefix(strings.ToLower(val), prefix)
 	parts := strings.FieldsFunc(val, func(r rune) bool {
 		return r == '_' || r == '-' || r == ' '
 	})
@@ -565,34 +699,6 @@ func trimPatchLines(patch string, maxLines int) (string, bool) {
 	return strings.Join(lines, "\n"), truncated
 }
 
-func formatReleaseSnippetResponse(repo *database.Repository, release *database.ProviderRelease, entry *database.ProviderReleaseEntry, filename, patch string, truncated bool, maxLines int, includeHeader, includeFile, includeDiff, includeCompare bool) string {
-	var b strings.Builder
-	fullName := repo.FullName
-	if fullName == "" {
-		fullName = repo.Name
-	}
-
-	if includeHeader {
-		fmt.Fprintf(&b, "Release %s – %s\n", release.Version, entry.Title)
-		fmt.Fprintf(&b, "Repository: %s\n", fullName)
-	}
-	if includeFile && filename != "" {
-		fmt.Fprintf(&b, "File: %s\n", filename)
-	}
-	if includeDiff {
-		b.WriteString("```diff\n")
-		b.WriteString(patch)
-		b.WriteString("\n```")
-		if truncated {
-			fmt.Fprintf(&b, "\n… showing first %d diff lines", maxLines)
-		}
-	}
-	if includeCompare && release.ComparisonURL.Valid && release.ComparisonURL.String != "" {
-		fmt.Fprintf(&b, "\nCompare: %s", release.ComparisonURL.String)
-	}
-	return b.String()
-}
-
 func fieldIncluded(fields []string, target string) bool {
 	if len(fields) == 0 {
 		return true
@@ -617,3 +723,763 @@ func minimalReleaseSummary(repoName string, release *database.ProviderRelease) s
 	}
 	return strings.TrimSpace(b.String())
 }
+
+func (s *Server) handleListResourceLifecycle(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		RemovedOnly    bool   `json:"removed_only"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	resources, err := s.db.ListProviderResourceLifecycle(prefix, params.RemovedOnly)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load resource lifecycle: %v", err))
+	}
+
+	scope := "all definitions"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+	if params.RemovedOnly {
+		scope += ", removed only"
+	}
+
+	text := formatter.ResourceLifecycle(s.style, scope, resources)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleAttributeIntroducedIn(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName  string `json:"resource_name"`
+		AttributeName string `json:"attribute_name"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.ResourceName) == "" || strings.TrimSpace(params.AttributeName) == "" {
+		return ErrorResponse("resource_name and attribute_name are required")
+	}
+
+	resource, err := s.db.GetProviderResource(strings.TrimSpace(params.ResourceName))
+	if err != nil {
+		return s.resourceNotFound(strings.TrimSpace(params.ResourceName))
+	}
+	attrPath := schemaPathKey(params.AttributeName)
+
+	info := formatter.AttributeIntroduction{
+		ResourceName:    resource.Name,
+		Attribute:       attrPath,
+		ResourceAddedIn: resource.VersionAdded.String,
+	}
+
+	versions, err := s.db.ListResourceSnapshotVersions(resource.RepositoryID, resource.Name, resource.Kind)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load schema snapshots: %v", err))
+	}
+	sort.Slice(versions, func(i, j int) bool { return compareVersions(versions[i], versions[j]) < 0 })
+	info.SnapshotVersions = versions
+
+	var lastAbsent string
+	for _, version := range versions {
+		attrs, err := s.db.GetSnapshotAttributes(resource.RepositoryID, resource.Name, resource.Kind, version)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load v%s schema snapshot: %v", version, err))
+		}
+		if _, ok := flattenSchema(attrs)[attrPath]; ok {
+			info.Source = "snapshots"
+			info.Version = version
+			info.AbsentIn = lastAbsent
+			break
+		}
+		lastAbsent = version
+	}
+
+	if info.Source == "" {
+		version, title, err := s.attributeAdditionFromChangelog(resource.Name, attrPath)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to scan release entries: %v", err))
+		}
+		if version != "" {
+			info.Source = "changelog"
+			info.Version = version
+			info.EntryTitle = title
+		}
+	}
+
+	if info.Source == "" {
+		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load schema for %s: %v", resource.Name, err))
+		}
+		if _, ok := flattenSchema(attrs)[attrPath]; !ok {
+			return ErrorResponse(fmt.Sprintf("Attribute '%s' not found in %s or any recorded snapshot", attrPath, resource.Name))
+		}
+	}
+
+	return SuccessResponse(formatter.AttributeIntroducedIn(s.style, info))
+}
+
+// attributeAdditionFromChangelog returns the oldest indexed release whose entries announce support
+// for attribute on resourceName. Nested paths match on their last segment.
+func (s *Server) attributeAdditionFromChangelog(resourceName, attribute string) (string, string, error) {
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	releases, err := s.db.ListProviderReleases(repo.ID, 0)
+	if err != nil {
+		return "", "", err
+	}
+	sort.SliceStable(releases, func(i, j int) bool { return compareVersions(releases[i].Version, releases[j].Version) < 0 })
+
+	leaf := attribute[strings.LastIndex(attribute, ".")+1:]
+	namePattern := indexer.ResourceNamePattern(s.providerPrefix())
+	for _, release := range releases {
+		entries, err := s.db.GetProviderReleaseEntries(release.ID)
+		if err != nil {
+			return "", "", err
+		}
+		for _, entry := range entries {
+			for _, addition := range parsePropertyAdditions(entry, namePattern) {
+				if addition.ResourceName == resourceName && addition.Attribute == leaf {
+					return release.Version, entry.Title, nil
+				}
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// schemaPathKey normalises a dotted attribute path to the form used by flattenSchema by dropping
+// list indexes, so site_config.0.always_on becomes site_config.always_on.
+func schemaPathKey(attrPath string) string {
+	var segments []string
+	for _, segment := range strings.Split(strings.TrimSpace(attrPath), ".") {
+		segment = strings.TrimSpace(segment)
+		if segment == "" || strings.Trim(segment, "0123456789") == "" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+func (s *Server) handleListTags(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Limit int `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 20
+	} else if limit < 0 {
+		limit = 0
+	}
+
+	if s.syncer == nil {
+		return ErrorResponse("GitHub client is not initialized")
+	}
+
+	tags, err := s.syncer.ListTags()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to list tags: %v", err))
+	}
+
+	total := len(tags)
+	if limit > 0 && len(tags) > limit {
+		tags = tags[:limit]
+	}
+
+	repoName := s.repo
+	if !strings.Contains(repoName, "/") && s.org != "" {
+		repoName = s.org + "/" + repoName
+	}
+
+	text := formatter.TagList(s.style, repoName, tags, total)
+	return SuccessResponse(text)
+}
+
+// githubCompareFileCap is the maximum number of files GitHub includes in a compare response.
+const githubCompareFileCap = 300
+
+func (s *Server) handleDiffFile(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		FilePath string `json:"file_path"`
+		BaseTag  string `json:"base_tag"`
+		HeadTag  string `json:"head_tag"`
+		MaxLines int    `json:"max_lines"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	filePath := strings.TrimPrefix(strings.TrimSpace(params.FilePath), "/")
+	baseTag := normalizeDiffTag(params.BaseTag)
+	headTag := normalizeDiffTag(params.HeadTag)
+	if filePath == "" || baseTag == "" || headTag == "" {
+		return ErrorResponse("file_path, base_tag, and head_tag are required")
+	}
+
+	if s.syncer == nil {
+		return ErrorResponse("Syncer is not initialized; run a sync first")
+	}
+
+	compare, err := s.syncer.CompareTags(baseTag, headTag)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to fetch GitHub compare diff: %v", err))
+	}
+
+	var match *indexer.GitHubCompareFile
+	if compare != nil {
+		for i := range compare.Files {
+			if compare.Files[i].Filename == filePath || compare.Files[i].PreviousFilename == filePath {
+				match = &compare.Files[i]
+				break
+			}
+		}
+	}
+
+	if match == nil {
+		text := fmt.Sprintf("File '%s' was not changed between %s and %s.", filePath, baseTag, headTag)
+		if compare != nil && len(compare.Files) >= githubCompareFileCap {
+			text += fmt.Sprintf("\n\nNote: GitHub returned the maximum of %d changed files, so later files may be missing from the comparison; try a narrower tag range.", githubCompareFileCap)
+		}
+		return SuccessResponse(text)
+	}
+
+	maxLines := params.MaxLines
+	if maxLines == 0 {
+		maxLines = 200
+	} else if maxLines < 0 {
+		maxLines = 0
+	}
+
+	patch, truncated := trimPatchLines(match.Patch, maxLines)
+
+	repoName := s.repo
+	if !strings.Contains(repoName, "/") && s.org != "" {
+		repoName = s.org + "/" + repoName
+	}
+
+	text := formatter.FileDiff(s.style, repoName, baseTag, headTag, *match, patch, truncated, maxLines)
+	return SuccessResponse(text)
+}
+
+// normalizeDiffTag accepts bare versions such as 4.52.0 and returns the provider's v-prefixed tag.
+func normalizeDiffTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if tag != "" && tag[0] >= '0' && tag[0] <= '9' {
+		return "v" + tag
+	}
+	return tag
+}
+
+func (s *Server) handleGetIndexedVersion() map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	release, err := s.db.GetLatestProviderRelease(repo.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return ErrorResponse(fmt.Sprintf("Failed to load release metadata: %v", err))
+	}
+
+	return SuccessResponse(formatter.IndexedVersion(s.style, formatter.IndexedVersionInfo{
+		Repository:  ifEmpty(repo.FullName, repo.Name),
+		Release:     release,
+		Ref:         s.ref,
+		LastUpdated: repo.LastUpdated,
+		SyncedAt:    repo.SyncedAt,
+	}))
+}
+
+func (s *Server) handleGetReleaseFileStats(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Version string `json:"version"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.Version) == "" {
+		return ErrorResponse("version is required")
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	version := strings.TrimSpace(params.Version)
+	release, err := s.db.GetProviderReleaseByVersion(repo.ID, strings.TrimPrefix(version, "v"))
+	if err != nil {
+		release, err = s.db.GetProviderReleaseByTag(repo.ID, normalizeDiffTag(version))
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse(fmt.Sprintf("No release metadata found for version %s", version))
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load release metadata: %v", err))
+	}
+	if !release.PreviousTag.Valid || release.PreviousTag.String == "" {
+		return ErrorResponse("Unable to compute file stats for the earliest release (missing previous tag)")
+	}
+
+	if s.syncer == nil {
+		return ErrorResponse("Syncer is not initialized; run a sync first")
+	}
+
+	compare, err := s.syncer.CompareTags(release.PreviousTag.String, release.Tag)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to fetch GitHub compare diff: %v", err))
+	}
+
+	var files []indexer.GitHubCompareFile
+	if compare != nil {
+		files = compare.Files
+	}
+	text := formatter.ReleaseFileStats(s.style, release.PreviousTag.String, release.Tag, releaseAreaStats(files), len(files) >= githubCompareFileCap)
+	return SuccessResponse(text)
+}
+
+// releaseAreaStats groups compare files by the area they touch, ordered by most files changed.
+func releaseAreaStats(files []indexer.GitHubCompareFile) []formatter.ReleaseAreaStats {
+	byArea := make(map[string]*formatter.ReleaseAreaStats)
+	for _, f := range files {
+		area := changedFileArea(f.Filename)
+		stats, ok := byArea[area]
+		if !ok {
+			stats = &formatter.ReleaseAreaStats{Area: area}
+			byArea[area] = stats
+		}
+		switch f.Status {
+		case "added", "copied":
+			stats.Added++
+		case "removed":
+			stats.Removed++
+		case "renamed":
+			stats.Renamed++
+		default:
+			stats.Modified++
+		}
+		stats.Additions += f.Additions
+		stats.Deletions += f.Deletions
+	}
+
+	result := make([]formatter.ReleaseAreaStats, 0, len(byArea))
+	for _, stats := range byArea {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if ti, tj := result[i].Files(), result[j].Files(); ti != tj {
+			return ti > tj
+		}
+		return result[i].Area < result[j].Area
+	})
+	return result
+}
+
+// changedFileArea maps a repository path to a reporting area: the service directory name for
+// internal/services/<service>/..., otherwise the top-level directory.
+func changedFileArea(filePath string) string {
+	if rest, ok := strings.CutPrefix(filePath, "internal/services/"); ok {
+		if service, _, found := strings.Cut(rest, "/"); found {
+			return service
+		}
+	}
+	if top, _, found := strings.Cut(filePath, "/"); found {
+		return top + "/"
+	}
+	return "(repository root)"
+}
+
+func (s *Server) handleRecentlyAddedProperties(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Releases       int    `json:"releases"`
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	releaseCount := params.Releases
+	if releaseCount == 0 {
+		releaseCount = 5
+	} else if releaseCount < 0 {
+		releaseCount = 0
+	}
+	limit := params.Limit
+	if limit == 0 {
+		limit = 100
+	} else if limit < 0 {
+		limit = 0
+	}
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository: %v", err))
+	}
+
+	releases, err := s.db.ListProviderReleases(repo.ID, releaseCount)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load releases: %v", err))
+	}
+	if len(releases) == 0 {
+		return ErrorResponse("No release data indexed. Run sync_provider to populate release metadata.")
+	}
+
+	seen := make(map[string]bool)
+	namePattern := indexer.ResourceNamePattern(s.providerPrefix())
+	var additions []formatter.PropertyAddition
+	for _, release := range releases {
+		entries, err := s.db.GetProviderReleaseEntries(release.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load release entries: %v", err))
+		}
+		for _, entry := range entries {
+			for _, addition := range parsePropertyAdditions(entry, namePattern) {
+				if prefix != "" && !strings.HasPrefix(addition.ResourceName, prefix) {
+					continue
+				}
+				key := addition.ResourceName + "." + addition.Attribute
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				addition.Version = release.Version
+				additions = append(additions, addition)
+			}
+		}
+	}
+
+	if limit > 0 && len(additions) > limit {
+		additions = additions[:limit]
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.RecentlyAddedProperties(s.style, scope, len(releases), additions)
+	return SuccessResponse(text)
+}
+
+// parsePropertyAdditions extracts resource/attribute pairs from entries phrased like
+// "`azurerm_x` - support for the `foo` and `bar` properties".
+func parsePropertyAdditions(entry database.ProviderReleaseEntry, namePattern *regexp.Regexp) []formatter.PropertyAddition {
+	text := strings.ReplaceAll(entry.Title, "`", "")
+	if entry.Details.Valid && entry.Details.String != "" {
+		text += "\n" + strings.ReplaceAll(entry.Details.String, "`", "")
+	}
+
+	var additions []formatter.PropertyAddition
+	for _, loc := range propertyAdditionPattern.FindAllStringSubmatchIndex(text, -1) {
+		resources := uniqueStrings(namePattern.FindAllString(strings.ToLower(text[:loc[0]]), -1))
+		if len(resources) == 0 && entry.ResourceName.Valid && entry.ResourceName.String != "" {
+			resources = []string{entry.ResourceName.String}
+		}
+		if len(resources) == 0 {
+			continue
+		}
+
+		for _, attr := range splitAttributeList(text[loc[2]:loc[3]], namePattern) {
+			for _, resource := range resources {
+				additions = append(additions, formatter.PropertyAddition{
+					ResourceName: resource,
+					Attribute:    attr,
+				})
+			}
+		}
+	}
+	return additions
+}
+
+func splitAttributeList(list string, namePattern *regexp.Regexp) []string {
+	replacer := strings.NewReplacer(" and ", ",", " or ", ",", "/", ",", "&", ",")
+	var attrs []string
+	for _, part := range strings.Split(replacer.Replace(list), ",") {
+		part = strings.TrimSpace(part)
+		part = strings.TrimPrefix(part, "the ")
+		part = strings.TrimSpace(part)
+		if !attributeTokenPattern.MatchString(part) || isResourceNameToken(part, namePattern) {
+			continue
+		}
+		attrs = append(attrs, part)
+	}
+	return uniqueStrings(attrs)
+}
+
+// isResourceNameToken reports whether part starts with a resource name of the provider.
+func isResourceNameToken(part string, namePattern *regexp.Regexp) bool {
+	loc := namePattern.FindStringIndex(part)
+	return loc != nil && loc[0] == 0
+}
+
+// handleWhatsNewForResource finds the latest release whose changelog mentions a resource and shows
+// those entries together with the diff of the resource's file in that release.
+func (s *Server) handleWhatsNewForResource(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+		MaxContext   int    `json:"max_context_lines"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	name := strings.TrimSpace(params.ResourceName)
+	if name == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+
+	releases, err := s.db.ListReleasesMentioning(repo.ID, resource.Name)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load release metadata: %v", err))
+	}
+
+	var release *database.ProviderRelease
+	var matched []database.ProviderReleaseEntry
+	for i := range releases {
+		entries, err := s.db.GetProviderReleaseEntries(releases[i].ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load release entries: %v", err))
+		}
+		for _, entry := range entries {
+			if entryMentionsResource(entry, resource.Name) {
+				matched = append(matched, entry)
+			}
+		}
+		if len(matched) > 0 {
+			release = &releases[i]
+			break
+		}
+	}
+	if release == nil {
+		return SuccessResponse(fmt.Sprintf("No indexed release mentions %s. Run sync_provider or backfill_releases to index older releases.", resource.Name))
+	}
+
+	maxLines := params.MaxContext
+	if maxLines <= 0 {
+		maxLines = 24
+	}
+
+	change := formatter.ResourceChange{Release: release, Entries: matched, MaxLines: maxLines}
+	switch {
+	case !release.PreviousTag.Valid || release.PreviousTag.String == "":
+		change.DiffNote = "No diff available: the release has no previous tag to compare against."
+	case s.syncer == nil:
+		change.DiffNote = "No diff available: the syncer is not initialized; run a sync first."
+	default:
+		compare, err := s.syncer.CompareTags(release.PreviousTag.String, release.Tag)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to fetch GitHub compare diff: %v", err))
+		}
+		filename, patch := locatePatchForEntry(compare, &matched[0], resource.Name, resource.FilePath.String, s.providerPrefix())
+		if patch == "" {
+			change.DiffNote = "No diff in this release's compare data matches the resource."
+		} else {
+			change.File = filename
+			change.Patch, change.Truncated = trimPatchLines(patch, maxLines)
+		}
+	}
+
+	return SuccessResponse(formatter.WhatsNewForResource(s.style, resource.Name, change))
+}
+
+// entryMentionsResource reports whether a release entry is about name: parsed as its resource, or
+// named in its title as a whole word, so azurerm_foo does not match azurerm_foo_bar.
+func entryMentionsResource(entry database.ProviderReleaseEntry, name string) bool {
+	if entry.ResourceName.Valid && strings.EqualFold(entry.ResourceName.String, name) {
+		return true
+	}
+	title := strings.ToLower(entry.Title)
+	name = strings.ToLower(name)
+	for offset := 0; ; {
+		idx := strings.Index(title[offset:], name)
+		if idx < 0 {
+			return false
+		}
+		end := offset + idx + len(name)
+		if end == len(title) || !isIdentifierByte(title[end]) {
+			return true
+		}
+		offset = end
+	}
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+// newResourceKinds labels the changelog change types that introduce a resource.
+var newResourceKinds = map[string]string{
+	"new_resource":      "resource",
+	"new_data_source":   "data source",
+	"new_ephemeral":     "ephemeral resource",
+	"new_list_resource": "list resource",
+	"new_action":        "action",
+}
+
+// handleGetProviderDiffSummary groups the changelog entries between two versions by impact, so
+// users can see what an upgrade brings before diffing schemas.
+func (s *Server) handleGetProviderDiffSummary(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Version     string `json:"version"`
+		FromVersion string `json:"from_version"`
+		MaxItems    int    `json:"max_items"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+	target := strings.TrimPrefix(strings.TrimSpace(params.Version), "v")
+	if target == "" {
+		return ErrorResponse("version is required")
+	}
+	maxItems := params.MaxItems
+	if maxItems == 0 {
+		maxItems = 25
+	} else if maxItems < 0 {
+		maxItems = 0
+	}
+
+	repo, err := s.primaryRepository()
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrorResponse("Repository has not been synced yet")
+		}
+		return ErrorResponse(fmt.Sprintf("Failed to load repository metadata: %v", err))
+	}
+	latest, err := s.db.GetLatestProviderRelease(repo.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrorResponse("No releases indexed yet. Run sync_provider first.")
+	}
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load release metadata: %v", err))
+	}
+
+	if compareVersions(target, latest.Version) > 0 {
+		return ErrorResponse(fmt.Sprintf("v%s is newer than the indexed changelog (latest v%s). Restart the server with --ref v%s and run sync_provider to index it.", target, latest.Version, target))
+	}
+
+	// Without from_version the range runs from the target up to the indexed release.
+	fromVersion, toVersion := target, latest.Version
+	if from := strings.TrimPrefix(strings.TrimSpace(params.FromVersion), "v"); from != "" {
+		fromVersion, toVersion = from, target
+	}
+	if compareVersions(fromVersion, toVersion) >= 0 {
+		return ErrorResponse(fmt.Sprintf("v%s is not older than v%s; there is nothing to summarize", fromVersion, toVersion))
+	}
+
+	releases, err := s.db.ListProviderReleases(repo.ID, 0)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load releases: %v", err))
+	}
+
+	summary := formatter.ProviderDiff{FromVersion: fromVersion, ToVersion: toVersion, Indexed: latest.Version, MaxItems: maxItems}
+	namePattern := indexer.ResourceNamePattern(s.providerPrefix())
+	touched := make(map[string]bool)
+	for _, release := range releases {
+		if compareVersions(release.Version, fromVersion) <= 0 || compareVersions(release.Version, toVersion) > 0 {
+			continue
+		}
+		summary.Releases++
+
+		entries, err := s.db.GetProviderReleaseEntries(release.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load release entries: %v", err))
+		}
+		for _, entry := range entries {
+			names := namePattern.FindAllString(strings.ToLower(entry.Title), -1)
+			for _, name := range names {
+				touched[name] = true
+			}
+			item := formatter.ProviderDiffItem{Resource: entry.ResourceName.String, Version: release.Version, Title: entry.Title}
+
+			changeType := entry.ChangeType.String
+			if changeType == "" {
+				changeType = indexer.ChangeType(entry.Section, entry.Title)
+			}
+			switch kind, isNew := newResourceKinds[changeType]; {
+			case isNew:
+				for _, name := range uniqueStrings(names) {
+					summary.New = append(summary.New, formatter.ProviderDiffItem{Resource: name, Kind: kind, Version: release.Version, Title: entry.Title})
+				}
+			case indexer.IsResourceRemoval(entry.Title):
+				summary.Removed = append(summary.Removed, item)
+			case changeType == "breaking_change":
+				summary.Breaking = append(summary.Breaking, item)
+			case changeType == "deprecation":
+				summary.Deprecations = append(summary.Deprecations, item)
+			case changeType == "enhancement":
+				summary.Enhancements++
+			case changeType == "bugfix":
+				summary.BugFixes++
+			}
+		}
+	}
+	if summary.Releases == 0 {
+		return ErrorResponse(fmt.Sprintf("No indexed releases between v%s and v%s. Run backfill_releases to index older releases.", fromVersion, toVersion))
+	}
+	summary.Touched = len(touched)
+
+	return SuccessResponse(formatter.ProviderDiffSummary(s.style, summary))
+}
@@ -2,6 +2,7 @@ package mcp
 
 import (
 	"database/sql"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -94,10 +95,156 @@ func TestHandleGetReleaseSnippet(t *testing.T) {
 	}
 }
 
+func TestHandleDiffFile(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	s.syncer = &fakeSyncer{
+		compareResult: &indexer.GitHubCompareResult{
+			Files: []indexer.GitHubCompareFile{
+				{
+					Filename: "internal/services/network/virtual_network_resource.go",
+					Status:   "modified",
+					Patch:    "@@ -1 +1 @@\n+virtual network change",
+				},
+				{
+					Filename: "internal/services/storage/storage_account_resource.go",
+					Status:   "modified",
+					Patch:    "@@ -1 +1 @@\n+storage change",
+				},
+			},
+		},
+	}
+
+	resp := s.handleDiffFile(map[string]any{
+		"file_path": "internal/services/storage/storage_account_resource.go",
+		"base_tag":  "4.51.0",
+		"head_tag":  "v4.52.0",
+	})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "storage change") || strings.Contains(text, "virtual network change") {
+		t.Fatalf("expected only the requested file's patch, got %q", text)
+	}
+	if !strings.Contains(text, "v4.51.0...v4.52.0") {
+		t.Fatalf("expected normalized tags in header, got %q", text)
+	}
+
+	resp = s.handleDiffFile(map[string]any{
+		"file_path": "internal/services/compute/linux_virtual_machine_resource.go",
+		"base_tag":  "v4.51.0",
+		"head_tag":  "v4.52.0",
+	})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "was not changed between v4.51.0 and v4.52.0") {
+		t.Fatalf("expected unchanged message, got %q", text)
+	}
+
+	resp = s.handleDiffFile(map[string]any{"file_path": "main.go"})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "are required") {
+		t.Fatalf("expected required-args error, got %q", text)
+	}
+}
+
 func sqlNull(val string) sql.NullString {
 	return sql.NullString{String: val, Valid: val != ""}
 }
 
+func TestHandleGetIndexedVersion(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	if text := s.handleGetIndexedVersion()["content"].([]ContentBlock)[0].Text; text != "Repository has not been synced yet" {
+		t.Fatalf("expected unsynced message, got %s", text)
+	}
+
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	for _, rel := range []*database.ProviderRelease{
+		{RepositoryID: repo.ID, Version: "4.51.0", Tag: "v4.51.0", ReleaseDate: sqlNull("2025-10-30")},
+		{RepositoryID: repo.ID, Version: "4.52.0", Tag: "v4.52.0", ReleaseDate: sqlNull("2025-11-06")},
+	} {
+		if _, err := db.UpsertProviderRelease(rel); err != nil {
+			t.Fatalf("upsert release: %v", err)
+		}
+	}
+
+	text := s.handleGetIndexedVersion()["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Latest release:** 4.52.0 (v4.52.0)",
+		"**Released:** November 6, 2025",
+		"**Synced ref:** default branch",
+		"**Synced at:**",
+		"changes released after 4.52.0",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+
+	s.ref = "v4.52.0"
+	text = s.handleGetIndexedVersion()["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Synced ref:** v4.52.0") || strings.Contains(text, "default branch") {
+		t.Fatalf("expected pinned ref, got %s", text)
+	}
+}
+
+func TestHandleGetReleaseFileStats(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	if _, err := db.UpsertProviderRelease(&database.ProviderRelease{
+		RepositoryID: repo.ID,
+		Version:      "4.52.0",
+		Tag:          "v4.52.0",
+		PreviousTag:  sql.NullString{String: "v4.51.0", Valid: true},
+	}); err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+	if _, err := db.UpsertProviderRelease(&database.ProviderRelease{RepositoryID: repo.ID, Version: "4.0.0", Tag: "v4.0.0"}); err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	s.syncer = &fakeSyncer{
+		compareResult: &indexer.GitHubCompareResult{
+			Files: []indexer.GitHubCompareFile{
+				{Filename: "internal/services/network/virtual_network_resource.go", Status: "modified", Additions: 4, Deletions: 1},
+				{Filename: "internal/services/network/subnet_resource.go", Status: "added", Additions: 10},
+				{Filename: "internal/services/storage/legacy.go", Status: "removed", Deletions: 7},
+				{Filename: "website/docs/r/subnet.html.markdown", Status: "added", Additions: 3},
+				{Filename: "CHANGELOG.md", Status: "modified", Additions: 2},
+			},
+		},
+	}
+
+	text := s.handleGetReleaseFileStats(map[string]any{"version": "v4.52.0"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"v4.51.0...v4.52.0",
+		"**Files**: 5 (2 added, 2 modified, 1 removed, 0 renamed)",
+		"| network | 2 | 1 | 1 | 0 | 0 | +14 -1 |",
+		"| storage | 1 | 0 | 0 | 1 | 0 | +0 -7 |",
+		"| website/ | 1 | 1 | 0 | 0 | 0 | +3 -0 |",
+		"| (repository root) | 1 | 0 | 1 | 0 | 0 | +2 -0 |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in stats, got %s", want, text)
+		}
+	}
+	if strings.Index(text, "| network |") > strings.Index(text, "| storage |") {
+		t.Fatalf("expected areas ordered by changed files, got %s", text)
+	}
+
+	if text := s.handleGetReleaseFileStats(map[string]any{"version": "4.0.0"})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "missing previous tag") {
+		t.Fatalf("expected earliest-release error, got %s", text)
+	}
+	if text := s.handleGetReleaseFileStats(map[string]any{"version": "9.9.9"})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "No release metadata") {
+		t.Fatalf("expected unknown version error, got %s", text)
+	}
+}
+
 func TestHandleBackfillRelease(t *testing.T) {
 	db := testutil.NewTestDB(t)
 	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
@@ -160,6 +307,44 @@ FEATURES:
 		}
 	})
 
+	t.Run("synced_release_requires_force", func(t *testing.T) {
+		releaseID, err := db.UpsertProviderRelease(&database.ProviderRelease{
+			RepositoryID:  repo.ID,
+			Version:       "4.48.0",
+			Tag:           "v4.48.0",
+			CommitSHA:     sql.NullString{String: "abc123", Valid: true},
+			ComparisonURL: sql.NullString{String: "https://github.com/hashicorp/terraform-provider-azurerm/compare/v4.47.0...v4.48.0", Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("upsert release: %v", err)
+		}
+		synced := []database.ProviderReleaseEntry{
+			{ReleaseID: releaseID, Section: "FEATURES", EntryKey: "a", Title: "one", OrderIndex: 0},
+			{ReleaseID: releaseID, Section: "FEATURES", EntryKey: "b", Title: "two", OrderIndex: 1},
+			{ReleaseID: releaseID, Section: "FEATURES", EntryKey: "c", Title: "three", OrderIndex: 2},
+			{ReleaseID: releaseID, Section: "FEATURES", EntryKey: "d", Title: "four", OrderIndex: 3},
+		}
+		if err := db.ReplaceReleaseEntries(releaseID, synced); err != nil {
+			t.Fatalf("replace entries: %v", err)
+		}
+
+		text := s.handleBackfillRelease(map[string]any{"version": "4.48.0"})["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "already present with 4 entries") || !strings.Contains(text, "force=true") {
+			t.Fatalf("expected already-present notice, got %q", text)
+		}
+		if rel, _, err := db.GetReleaseWithEntriesByVersion(repo.ID, "4.48.0"); err != nil || !rel.ComparisonURL.Valid || !rel.CommitSHA.Valid {
+			t.Fatalf("expected synced release metadata to be kept, got %+v err=%v", rel, err)
+		}
+
+		text = s.handleBackfillRelease(map[string]any{"version": "4.48.0", "force": true})["content"].([]ContentBlock)[0].Text
+		if !strings.Contains(text, "Backfilled release v4.48.0") {
+			t.Fatalf("expected forced backfill, got %q", text)
+		}
+		if rel, entries, err := db.GetReleaseWithEntriesByVersion(repo.ID, "4.48.0"); err != nil || rel.ComparisonURL.Valid || len(entries) == len(synced) {
+			t.Fatalf("expected changelog data to replace the synced release, got %+v (%d entries) err=%v", rel, len(entries), err)
+		}
+	})
+
 	t.Run("version_not_found", func(t *testing.T) {
 		resp := s.handleBackfillRelease(map[string]any{"version": "9.99.0"})
 		content := resp["content"].([]ContentBlock)
@@ -188,3 +373,426 @@ FEATURES:
 		}
 	})
 }
+
+func TestHandleBackfillReleases(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	changelog := `# Changelog
+
+## 4.50.0 (Unreleased)
+
+FEATURES:
+
+* azurerm_next: upcoming
+
+## 4.49.0 (2024-02-01)
+
+FEATURES:
+
+* **New Resource:** azurerm_foo
+
+BUG FIXES:
+
+* azurerm_baz: fixed issue
+
+## 4.48.0 (2024-01-15)
+
+ENHANCEMENTS:
+
+* azurerm_bar: added support for thing
+
+## 4.47.0 (2024-01-01)
+
+FEATURES:
+
+* Initial release
+`
+	testutil.InsertFile(t, db, repo.ID, "CHANGELOG.md", "markdown", changelog)
+
+	releaseID, err := db.UpsertProviderRelease(&database.ProviderRelease{
+		RepositoryID:  repo.ID,
+		Version:       "4.49.0",
+		Tag:           "v4.49.0",
+		ComparisonURL: sql.NullString{String: "https://github.com/hashicorp/terraform-provider-azurerm/compare/v4.48.0...v4.49.0", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("upsert release: %v", err)
+	}
+	if err := db.ReplaceReleaseEntries(releaseID, []database.ProviderReleaseEntry{{ReleaseID: releaseID, Section: "FEATURES", EntryKey: "a", Title: "synced"}}); err != nil {
+		t.Fatalf("replace entries: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleBackfillReleases(map[string]any{"since": "v4.48.0"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Releases stored**: 1",
+		"**Entries stored**: 1",
+		"| v4.48.0 | 2024-01-15 | 1 |",
+		"- v4.50.0: not released yet",
+		"- v4.49.0: already present with 1 entries from a full sync",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+	if _, err := db.GetProviderReleaseByVersion(repo.ID, "4.47.0"); err == nil {
+		t.Fatal("expected releases older than since to be left alone")
+	}
+
+	text = s.handleBackfillReleases(map[string]any{"since": "4.47.0", "force": true})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Releases stored**: 3") || !strings.Contains(text, "**Entries stored**: 4") {
+		t.Fatalf("expected forced backfill of three releases, got:\n%s", text)
+	}
+	if rel, entries, err := db.GetReleaseWithEntriesByVersion(repo.ID, "4.49.0"); err != nil || rel.ComparisonURL.Valid || len(entries) != 2 {
+		t.Fatalf("expected changelog data to replace the synced release, got %+v (%d entries) err=%v", rel, len(entries), err)
+	}
+
+	if text := s.handleBackfillReleases(map[string]any{"since": "9.0.0"})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "No changelog releases") {
+		t.Fatalf("expected no-releases error, got %s", text)
+	}
+	if text := s.handleBackfillReleases(map[string]any{})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "since is required") {
+		t.Fatalf("expected since required error, got %s", text)
+	}
+}
+
+func TestHandleWhatsNewForResource(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_subnet", "resource", "internal/services/network/subnet_resource.go")
+
+	older := testutil.InsertRelease(t, db, repo.ID, "1.0.0", "v1.0.0", "v0.9.0")
+	testutil.ReplaceReleaseEntries(t, db, older.ID, []database.ProviderReleaseEntry{
+		{ReleaseID: older.ID, EntryKey: "a", Section: "FEATURES", Title: "New Resource: azurerm_virtual_network", ResourceName: sqlNull("azurerm_virtual_network")},
+	})
+	latest := testutil.InsertRelease(t, db, repo.ID, "1.1.0", "v1.1.0", "v1.0.0")
+	latest.ReleaseDate = sqlNull("2099-01-01")
+	if _, err := db.UpsertProviderRelease(latest); err != nil {
+		t.Fatalf("failed to update release: %v", err)
+	}
+	testutil.ReplaceReleaseEntries(t, db, latest.ID, []database.ProviderReleaseEntry{
+		{ReleaseID: latest.ID, EntryKey: "a", Section: "ENHANCEMENTS", Title: "`azurerm_virtual_network` - support for the `private_endpoint_vnet_policies` property"},
+		{ReleaseID: latest.ID, EntryKey: "b", Section: "BUG FIXES", Title: "`azurerm_virtual_network_peering` - fix a crash"},
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	s.syncer = &fakeSyncer{
+		compareResult: &indexer.GitHubCompareResult{
+			Files: []indexer.GitHubCompareFile{
+				{Filename: "internal/services/network/subnet_resource.go", Patch: "@@ -1 +1 @@\n+subnet change"},
+				{Filename: res.FilePath.String, Patch: "@@ -1 +1 @@\n+private_endpoint_vnet_policies"},
+			},
+		},
+	}
+
+	text := s.handleWhatsNewForResource(map[string]any{"resource_name": "azurerm_virtual_network"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"**Release:** 1.1.0 (v1.1.0)",
+		"- [ENHANCEMENTS] `azurerm_virtual_network` - support for",
+		"**File:** internal/services/network/virtual_network_resource.go",
+		"+private_endpoint_vnet_policies",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "peering") {
+		t.Fatalf("expected entries about other resources to be left out, got:\n%s", text)
+	}
+
+	text = s.handleWhatsNewForResource(map[string]any{"resource_name": "azurerm_subnet"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "No indexed release mentions azurerm_subnet") {
+		t.Fatalf("expected no-release notice, got %s", text)
+	}
+
+	if text := s.handleWhatsNewForResource(map[string]any{})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "resource_name is required") {
+		t.Fatalf("expected resource_name required error, got %s", text)
+	}
+}
+
+func TestHandleListResourceLifecycle(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_mssql_server", "resource", "internal/services/mssql/mssql_server_resource.go")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	if _, err := db.UpdateProviderResourceLifecycle(repo.ID, "azurerm_mssql_server", "resource", "3.1.0", ""); err != nil {
+		t.Fatalf("update lifecycle: %v", err)
+	}
+	if _, err := db.InsertProviderResource(&database.ProviderResource{
+		RepositoryID:   repo.ID,
+		Name:           "azurerm_sql_server",
+		Kind:           "resource",
+		VersionAdded:   sqlNull("3.0.0"),
+		VersionRemoved: sqlNull("4.0.0"),
+	}); err != nil {
+		t.Fatalf("insert removed resource: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleListResourceLifecycle(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| azurerm_sql_server | resource | v3.0.0 | v4.0.0 |") || !strings.Contains(text, "| azurerm_mssql_server | resource | v3.1.0 | - |") {
+		t.Fatalf("expected lifecycle rows, got %s", text)
+	}
+	if strings.Contains(text, "azurerm_virtual_network") {
+		t.Fatalf("resources without lifecycle data should be omitted, got %s", text)
+	}
+
+	removed := s.handleListResourceLifecycle(map[string]any{"removed_only": true})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(removed, "azurerm_sql_server") || strings.Contains(removed, "azurerm_mssql_server") {
+		t.Fatalf("expected only removed resources, got %s", removed)
+	}
+
+	schema := s.handleGetResourceSchema(map[string]any{"name": "azurerm_sql_server"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(schema, "**Added In:** v3.0.0") || !strings.Contains(schema, "**Removed In:** v4.0.0") {
+		t.Fatalf("expected lifecycle in schema header, got %s", schema)
+	}
+}
+
+func TestHandleListTags(t *testing.T) {
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = testutil.NewTestDB(t)
+
+	tags := make([]indexer.GitHubTag, 3)
+	for i, name := range []string{"v4.52.0", "v4.51.0", "v4.50.0"} {
+		tags[i].Name = name
+		tags[i].Commit.SHA = fmt.Sprintf("%d234567890abcdef", i+1)
+	}
+	s.syncer = &fakeSyncer{tags: tags}
+
+	text := s.handleListTags(map[string]any{"limit": 2})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "# Tags for hashicorp/terraform-provider-azurerm") {
+		t.Fatalf("expected header, got %s", text)
+	}
+	if !strings.Contains(text, "| v4.52.0 | 1234567 |") || !strings.Contains(text, "| v4.51.0 | 2234567 |") {
+		t.Fatalf("expected tags with short SHAs, got %s", text)
+	}
+	if strings.Contains(text, "v4.50.0") || !strings.Contains(text, "Showing 2 of 3") {
+		t.Fatalf("expected limit to apply, got %s", text)
+	}
+
+	s.syncer = &fakeSyncer{tagsErr: fmt.Errorf("rate limit exceeded")}
+	failed := s.handleListTags(map[string]any{})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(failed, "rate limit exceeded") {
+		t.Fatalf("expected error to surface, got %s", failed)
+	}
+}
+
+func TestParsePropertyAdditions(t *testing.T) {
+	namePattern := indexer.ResourceNamePattern(indexer.DefaultProviderPrefix)
+	additions := parsePropertyAdditions(database.ProviderReleaseEntry{
+		Title: "`azurerm_storage_account` - Support for the `foo` property ([#123](https://github.com/hashicorp/terraform-provider-azurerm/pull/123))",
+	}, namePattern)
+	if len(additions) != 1 {
+		t.Fatalf("expected one addition, got %#v", additions)
+	}
+	if additions[0].ResourceName != "azurerm_storage_account" || additions[0].Attribute != "foo" {
+		t.Fatalf("unexpected addition: %#v", additions[0])
+	}
+
+	additions = parsePropertyAdditions(database.ProviderReleaseEntry{
+		Title: "azurerm_linux_web_app, azurerm_windows_web_app - support for the bar and baz_enabled properties",
+	}, namePattern)
+	if len(additions) != 4 {
+		t.Fatalf("expected four resource/attribute pairs, got %#v", additions)
+	}
+
+	if got := parsePropertyAdditions(database.ProviderReleaseEntry{Title: "azurerm_key_vault - fix crash when reading"}, namePattern); len(got) != 0 {
+		t.Fatalf("expected no additions for unrelated entry, got %#v", got)
+	}
+
+	additions = parsePropertyAdditions(database.ProviderReleaseEntry{
+		Title: "`azuread_group` - support for the `writeback_enabled` property",
+	}, indexer.ResourceNamePattern("azuread_"))
+	if len(additions) != 1 || additions[0].ResourceName != "azuread_group" || additions[0].Attribute != "writeback_enabled" {
+		t.Fatalf("expected azuread_group/writeback_enabled addition, got %#v", additions)
+	}
+}
+
+func TestHandleRecentlyAddedProperties(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	rel := testutil.InsertRelease(t, db, repo.ID, "4.52.0", "v4.52.0", "v4.51.0")
+	testutil.ReplaceReleaseEntries(t, db, rel.ID, []database.ProviderReleaseEntry{
+		{
+			ReleaseID:    rel.ID,
+			EntryKey:     "enhancements-4-52-0-000",
+			Section:      "ENHANCEMENTS",
+			Title:        "azurerm_kubernetes_cluster - support for the node_provisioning_profile block",
+			ResourceName: sqlNull("azurerm_kubernetes_cluster"),
+		},
+		{
+			ReleaseID:    rel.ID,
+			EntryKey:     "enhancements-4-52-0-001",
+			Section:      "ENHANCEMENTS",
+			Title:        "azurerm_storage_account - support for the foo property",
+			ResourceName: sqlNull("azurerm_storage_account"),
+		},
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleRecentlyAddedProperties(map[string]any{"resource_prefix": "azurerm_kubernetes_"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| v4.52.0 | azurerm_kubernetes_cluster | node_provisioning_profile |") {
+		t.Fatalf("expected kubernetes addition, got %q", text)
+	}
+	if strings.Contains(text, "azurerm_storage_account") {
+		t.Fatalf("prefix filter not applied: %q", text)
+	}
+}
+
+func TestHandleAttributeIntroducedIn(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_kubernetes_cluster", "resource", "internal/services/containers/kubernetes_cluster_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "node_provisioning_profile", Optional: true, NestedBlock: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "default_node_pool",
+		Required:    true,
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "zones", Optional: true},
+		})),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "legacy_field", Optional: true})
+
+	pool := func(children ...database.NestedAttribute) database.ProviderAttribute {
+		return database.ProviderAttribute{Name: "default_node_pool", Required: true, NestedBlock: true, ElemSchemaJSON: sqlNull(database.EncodeNestedSchema(children))}
+	}
+	for version, attrs := range map[string][]database.ProviderAttribute{
+		"3.9.0":  {{Name: "name", Required: true}, pool()},
+		"3.10.0": {{Name: "name", Required: true}, pool(database.NestedAttribute{Name: "zones", Optional: true})},
+	} {
+		if err := db.ReplaceSchemaSnapshot(repo.ID, version, "azurerm_kubernetes_cluster", "resource", attrs); err != nil {
+			t.Fatalf("record snapshot: %v", err)
+		}
+	}
+
+	rel := testutil.InsertRelease(t, db, repo.ID, "4.52.0", "v4.52.0", "v4.51.0")
+	testutil.ReplaceReleaseEntries(t, db, rel.ID, []database.ProviderReleaseEntry{{
+		ReleaseID:    rel.ID,
+		EntryKey:     "enhancements-4-52-0-000",
+		Section:      "ENHANCEMENTS",
+		Title:        "azurerm_kubernetes_cluster - support for the node_provisioning_profile block",
+		ResourceName: sqlNull("azurerm_kubernetes_cluster"),
+	}})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(attr string) string {
+		resp := s.handleAttributeIntroducedIn(map[string]any{"resource_name": "azurerm_kubernetes_cluster", "attribute_name": attr})
+		return resp["content"].([]ContentBlock)[0].Text
+	}
+
+	t.Run("from snapshots", func(t *testing.T) {
+		text := call("default_node_pool.0.zones")
+		if !strings.Contains(text, "**Introduced In:** v3.10.0") || !strings.Contains(text, "**Absent In:** v3.9.0") {
+			t.Fatalf("expected snapshot-based answer, got %s", text)
+		}
+	})
+
+	t.Run("present in oldest snapshot", func(t *testing.T) {
+		text := call("name")
+		if !strings.Contains(text, "**Introduced In:** v3.9.0") || !strings.Contains(text, "may predate it") {
+			t.Fatalf("expected lower-bound answer, got %s", text)
+		}
+	})
+
+	t.Run("changelog fallback", func(t *testing.T) {
+		text := call("node_provisioning_profile")
+		if !strings.Contains(text, "**Introduced In:** v4.52.0") || !strings.Contains(text, "**Source:** changelog") {
+			t.Fatalf("expected changelog-based answer, got %s", text)
+		}
+	})
+
+	t.Run("no evidence", func(t *testing.T) {
+		text := call("legacy_field")
+		if !strings.Contains(text, "No schema snapshot or changelog entry") {
+			t.Fatalf("expected no-evidence message, got %s", text)
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		text := call("does_not_exist")
+		if !strings.Contains(text, "not found in azurerm_kubernetes_cluster") {
+			t.Fatalf("expected not found error, got %s", text)
+		}
+	})
+}
+
+func TestHandleGetProviderDiffSummary(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+
+	releases := map[string][]database.ProviderReleaseEntry{
+		"1.0.0": {
+			{EntryKey: "a", Section: "FEATURES", Title: "**New Resource:** `azurerm_old_thing`", ChangeType: sqlNull("new_resource")},
+		},
+		"1.1.0": {
+			{EntryKey: "a", Section: "FEATURES", Title: "**New Data Source:** `azurerm_subnet_ids`", ChangeType: sqlNull("new_data_source")},
+			{EntryKey: "b", Section: "ENHANCEMENTS", Title: "`azurerm_subnet` - support for the `sharing_scope` property", ChangeType: sqlNull("enhancement")},
+			{EntryKey: "c", Section: "BUG FIXES", Title: "`azurerm_subnet` - fix a crash", ChangeType: sqlNull("bugfix")},
+		},
+		"2.0.0": {
+			{EntryKey: "a", Section: "BREAKING CHANGES", Title: "`azurerm_virtual_network` - the `address_space` property is now required", ResourceName: sqlNull("azurerm_virtual_network"), ChangeType: sqlNull("breaking_change")},
+			{EntryKey: "b", Section: "BREAKING CHANGES", Title: "**Removed Resource:** `azurerm_legacy_gateway`", ChangeType: sqlNull("breaking_change")},
+			{EntryKey: "c", Section: "NOTES", Title: "`azurerm_subnet` - the `enforce_private_link` property is deprecated", ChangeType: sqlNull("deprecation")},
+		},
+	}
+	for i, version := range []string{"1.0.0", "1.1.0", "2.0.0"} {
+		release := testutil.InsertRelease(t, db, repo.ID, version, "v"+version, "")
+		release.ReleaseDate = sqlNull(fmt.Sprintf("2025-0%d-01", i+1))
+		if _, err := db.UpsertProviderRelease(release); err != nil {
+			t.Fatalf("failed to update release: %v", err)
+		}
+		testutil.ReplaceReleaseEntries(t, db, release.ID, releases[version])
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(args map[string]any) string {
+		t.Helper()
+		return s.handleGetProviderDiffSummary(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call(map[string]any{"version": "1.0.0"})
+	for _, want := range []string{
+		"# Provider Diff Summary: v1.0.0 → v2.0.0",
+		"**Releases In Range**: 2",
+		"| New resources | 1 |",
+		"| Removed resources | 1 |",
+		"| Breaking changes | 1 |",
+		"| Enhancements | 1 |",
+		"| azurerm_subnet_ids | data source | 1.1.0 |",
+		"| azurerm_virtual_network | 2.0.0 | `azurerm_virtual_network` - the `address_space` property is now required |",
+		"**Removed Resource:** `azurerm_legacy_gateway`",
+		"## Deprecations (1)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "azurerm_old_thing") {
+		t.Fatalf("expected releases at or before the start of the range to be excluded, got:\n%s", text)
+	}
+
+	text = call(map[string]any{"from_version": "v1.0.0", "version": "1.1.0"})
+	if !strings.Contains(text, "v1.0.0 → v1.1.0") || !strings.Contains(text, "| Breaking changes | 0 |") {
+		t.Fatalf("expected from_version to bound the range, got:\n%s", text)
+	}
+
+	if text := call(map[string]any{"version": "3.0.0"}); !strings.Contains(text, "newer than the indexed changelog") {
+		t.Fatalf("expected error for versions past the index, got: %s", text)
+	}
+	if text := call(map[string]any{"version": "2.0.0"}); !strings.Contains(text, "not older than") {
+		t.Fatalf("expected error for an empty range, got: %s", text)
+	}
+}
@@ -0,0 +1,392 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+func (s *Server) handleValidateAttributePath(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+		Path         string `json:"path"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	path := strings.Trim(strings.TrimSpace(params.Path), ".")
+	if resourceName == "" || path == "" {
+		return ErrorResponse("resource_name and path are required")
+	}
+
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", resourceName))
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	result := resolveAttributePath(nestedSchemaTree(attrs), path)
+	text := formatter.AttributePath(s.style, resource.Name, result)
+	return SuccessResponse(text)
+}
+
+const maxBatchResourceNames = 20
+
+func (s *Server) handleGetResourcesSchema(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Names        []string `json:"names"`
+		Compact      bool     `json:"compact"`
+		MaxRows      int      `json:"max_rows"`
+		DescMaxChars int      `json:"desc_max_chars"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	names := uniqueStrings(params.Names)
+	if len(names) == 0 {
+		return ErrorResponse("names is required")
+	}
+	if len(names) > maxBatchResourceNames {
+		return ErrorResponse(fmt.Sprintf("At most %d names can be requested at once (got %d)", maxBatchResourceNames, len(names)))
+	}
+
+	if params.MaxRows == 0 {
+		params.MaxRows = 50
+	} else if params.MaxRows < 0 {
+		params.MaxRows = 0
+	}
+
+	var sections []string
+	var errs []formatter.ResourceSchemaBatchError
+	for _, name := range names {
+		resource, err := s.db.GetProviderResource(name)
+		if err != nil {
+			errs = append(errs, formatter.ResourceSchemaBatchError{Name: name, Message: "not found"})
+			continue
+		}
+
+		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			errs = append(errs, formatter.ResourceSchemaBatchError{Name: name, Message: fmt.Sprintf("failed to load schema: %v", err)})
+			continue
+		}
+
+		filtered, summary := filterProviderAttributes(attrs, nil, nil, false, false, schemaSectionAll, params.MaxRows)
+		opts := formatter.SchemaRenderOptions{
+			FilterSummary: summary,
+			Compact:       params.Compact,
+			Filtered:      params.MaxRows > 0,
+			DescMaxChars:  s.descriptionLimit(params.DescMaxChars),
+		}
+		sections = append(sections, formatter.ProviderResourceDetail(s.style, resource, filtered, opts))
+	}
+
+	text := formatter.ResourceSchemaBatch(s.style, sections, errs)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleGetAttribute(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName  string `json:"resource_name"`
+		AttributeName string `json:"attribute_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	path := strings.Trim(strings.TrimSpace(params.AttributeName), ".")
+	if resourceName == "" || path == "" {
+		return ErrorResponse("resource_name and attribute_name are required")
+	}
+
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", resourceName))
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	result := resolveAttributePath(nestedSchemaTree(attrs), path)
+	if !result.Valid || result.Leaf == nil {
+		return ErrorResponse(fmt.Sprintf("Attribute '%s' not found on '%s'", path, resource.Name))
+	}
+
+	text := formatter.AttributeDetail(s.style, resource.Name, path, *result.Leaf)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleExplainAttribute(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName  string `json:"resource_name"`
+		AttributeName string `json:"attribute_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	path := strings.Trim(strings.TrimSpace(params.AttributeName), ".")
+	if resourceName == "" || path == "" {
+		return ErrorResponse("resource_name and attribute_name are required")
+	}
+
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", resourceName))
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	result := resolveAttributePath(nestedSchemaTree(attrs), path)
+	if !result.Valid || result.Leaf == nil {
+		return ErrorResponse(fmt.Sprintf("Attribute '%s' not found on '%s'", path, resource.Name))
+	}
+
+	explanation := formatter.AttributeExplanation{
+		ResourceName: resource.Name,
+		Path:         path,
+		Attribute:    *result.Leaf,
+	}
+	explanation.AllowedValues, explanation.IgnoreCase = parseAllowedValues(result.Leaf.Validation)
+	// RequiredWith and Default are only stored on top-level attributes.
+	if !strings.Contains(path, ".") {
+		for _, attr := range attrs {
+			if attr.Name == path {
+				explanation.RequiredWith = attr.RequiredWith.String
+				explanation.DefaultValue = attr.DefaultValue.String
+				break
+			}
+		}
+	}
+
+	files, err := s.db.GetRepositoryFiles(resource.RepositoryID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository files: %v", err))
+	}
+	if docFile := findDocumentationFile(files, strings.TrimPrefix(resource.Name, s.providerPrefix()), resource.Kind); docFile != nil {
+		explanation.DocFile = docFile.FilePath
+		explanation.DocParagraphs = attributeDocParagraphs(stripFrontMatter(docFile.Content), path)
+	}
+
+	return SuccessResponse(formatter.ExplainAttribute(s.style, explanation))
+}
+
+func (s *Server) handleGenerateDocsStub(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Name string `json:"name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return ErrorResponse("name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", name))
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	text := formatter.DocsStub(resource, attrs)
+	return SuccessResponse(text)
+}
+
+// handleExportTFSchema renders a resource in the `terraform providers schema -json` format.
+func (s *Server) handleExportTFSchema(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	name := strings.TrimSpace(params.ResourceName)
+	if name == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resource, err := s.db.GetProviderResource(name)
+	if err != nil {
+		return s.resourceNotFound(name)
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	source := fmt.Sprintf("registry.terraform.io/%s/%s", strings.ToLower(ifEmpty(s.org, "hashicorp")), strings.TrimPrefix(s.repo, "terraform-provider-"))
+	schemaJSON, err := formatter.TerraformSchemaJSON(source, resource, attrs)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to encode schema: %v", err))
+	}
+	return SuccessResponse(formatter.TerraformSchemaExport(s.style, resource.Name, schemaJSON))
+}
+
+func (s *Server) handleGetAttributeTree(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+		MaxDepth     int    `json:"max_depth"`
+		MaxLines     int    `json:"max_lines"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	if resourceName == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	maxDepth := params.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = 6
+	} else if maxDepth < 0 {
+		maxDepth = 0
+	}
+	maxLines := params.MaxLines
+	if maxLines == 0 {
+		maxLines = 400
+	} else if maxLines < 0 {
+		maxLines = 0
+	}
+
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return s.resourceNotFound(resourceName)
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	text := formatter.AttributeTree(s.style, resource.Name, nestedSchemaTree(attrs), maxDepth, maxLines)
+	return SuccessResponse(text)
+}
+
+// compareWithSibling diffs a definition against the definition of kind sharing its name, e.g.
+// azurerm_key_vault the resource against azurerm_key_vault the data source. maxNames caps each
+// attribute list; 0 shows everything.
+func (s *Server) compareWithSibling(resource *database.ProviderResource, kind string, maxNames int) map[string]any {
+	if kind != "resource" && kind != "data_source" {
+		return ErrorResponse("compare_with must be 'data_source' or 'resource'")
+	}
+	current := resource
+	if current.Kind == kind {
+		other := "data_source"
+		if kind == "data_source" {
+			other = "resource"
+		}
+		sibling, err := s.db.GetProviderResourceOfKind(resource.Name, other)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("%s is a %s; no %s of the same name is indexed to compare with", resource.Name, formatter.KindLabel(kind), formatter.KindLabel(other)))
+		}
+		current = sibling
+	}
+	sibling, err := s.db.GetProviderResourceOfKind(current.Name, kind)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("No %s named %s is indexed", formatter.KindLabel(kind), current.Name))
+	}
+
+	attrsA, err := s.db.GetProviderResourceAttributes(current.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load schema for %s: %v", current.Name, err))
+	}
+	attrsB, err := s.db.GetProviderResourceAttributes(sibling.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load schema for %s: %v", sibling.Name, err))
+	}
+
+	common := findCommonAttributes(attrsA, attrsB)
+	uniqueA := findUniqueAttributes(attrsA, attrsB)
+	uniqueB := findUniqueAttributes(attrsB, attrsA)
+	commonTrimmed, commonTruncated := trimStrings(common, maxNames)
+	uniqueATrimmed, aTruncated := trimStrings(uniqueA, maxNames)
+	uniqueBTrimmed, bTruncated := trimStrings(uniqueB, maxNames)
+
+	forceNewA, forceNewB := 0, 0
+	for _, attr := range attrsA {
+		if attr.ForceNew {
+			forceNewA++
+		}
+	}
+	for _, attr := range attrsB {
+		if attr.ForceNew {
+			forceNewB++
+		}
+	}
+
+	labelA := fmt.Sprintf("%s (%s)", current.Name, formatter.KindLabel(current.Kind))
+	labelB := fmt.Sprintf("%s (%s)", sibling.Name, formatter.KindLabel(sibling.Kind))
+	text := formatter.ResourceComparison(
+		s.style,
+		labelA,
+		labelB,
+		calculateJaccardSimilarity(attrsA, attrsB),
+		len(attrsA),
+		len(attrsB),
+		len(common),
+		len(uniqueA),
+		len(uniqueB),
+		forceNewA,
+		forceNewB,
+		commonTrimmed,
+		uniqueATrimmed,
+		uniqueBTrimmed,
+		commonTruncated || aTruncated || bTruncated,
+	)
+	text += formatter.AttributeFlagDifferences(s.style, labelA, labelB, attrsA, attrsB, false)
+	return SuccessResponse(text)
+}
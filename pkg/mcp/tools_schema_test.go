@@ -0,0 +1,467 @@
+package mcp
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestHandleValidateAttributePath(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_linux_web_app", "resource", "internal/services/appservice/linux_web_app_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "site_config",
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "always_on", Type: "pluginsdk.TypeBool", Optional: true},
+			{Name: "application_stack", NestedBlock: true, Children: []database.NestedAttribute{
+				{Name: "docker_image_name", Type: "pluginsdk.TypeString", Optional: true},
+				{Name: "dotnet_version", Type: "pluginsdk.TypeString", Optional: true},
+			}},
+		})),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Required: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	call := func(path string) string {
+		resp := s.handleValidateAttributePath(map[string]any{"resource_name": "azurerm_linux_web_app", "path": path})
+		return resp["content"].([]ContentBlock)[0].Text
+	}
+
+	t.Run("valid nested path", func(t *testing.T) {
+		text := call("site_config.0.application_stack.0.docker_image_name")
+		if !strings.Contains(text, "**Valid path**") || !strings.Contains(text, "pluginsdk.TypeString") {
+			t.Fatalf("expected valid path, got %s", text)
+		}
+	})
+
+	t.Run("unknown nested segment", func(t *testing.T) {
+		text := call("site_config.0.application_stack.0.docker_image")
+		if !strings.Contains(text, "**Invalid path**") || !strings.Contains(text, "`docker_image` (segment 5)") {
+			t.Fatalf("expected failure at docker_image, got %s", text)
+		}
+		if !strings.Contains(text, "- docker_image_name") || !strings.Contains(text, "- dotnet_version") {
+			t.Fatalf("expected alternatives at failing level, got %s", text)
+		}
+	})
+
+	t.Run("segment below primitive", func(t *testing.T) {
+		text := call("name.value")
+		if !strings.Contains(text, "'name' is not a nested block") {
+			t.Fatalf("expected primitive error, got %s", text)
+		}
+	})
+
+	t.Run("missing args", func(t *testing.T) {
+		resp := s.handleValidateAttributePath(map[string]any{"resource_name": "azurerm_linux_web_app"})
+		if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "required") {
+			t.Fatalf("expected required error, got %s", text)
+		}
+	})
+}
+
+func TestHandleGetResourcesSchema(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	linux := testutil.InsertResource(t, db, repo.ID, "azurerm_linux_virtual_machine", "resource", "linux.go")
+	windows := testutil.InsertResource(t, db, repo.ID, "azurerm_windows_virtual_machine", "resource", "windows.go")
+	testutil.InsertAttribute(t, db, linux.ID, database.ProviderAttribute{Name: "admin_ssh_key"})
+	testutil.InsertAttribute(t, db, windows.ID, database.ProviderAttribute{Name: "admin_password"})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleGetResourcesSchema(map[string]any{
+		"names": []any{"azurerm_linux_virtual_machine", "azurerm_windows_virtual_machine", "azurerm_missing", "azurerm_linux_virtual_machine"},
+	})
+	text := resp["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{"(2 found, 1 errors)", "admin_ssh_key", "admin_password", "## Errors", "- azurerm_missing: not found"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in batch output, got %s", want, text)
+		}
+	}
+
+	names := make([]any, maxBatchResourceNames+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("azurerm_resource_%d", i)
+	}
+	resp = s.handleGetResourcesSchema(map[string]any{"names": names})
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "At most 20 names") {
+		t.Fatalf("expected cap error, got %s", text)
+	}
+}
+
+func TestHandleGetAttribute(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	longDesc := "Specifies the supported Azure location where the resource exists. Changing this forces a new resource to be created."
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "location", Required: true, ForceNew: true, Description: sqlNull(longDesc)})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "network_rules",
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "bypass", Type: "pluginsdk.TypeSet", Optional: true, Description: "Traffic allowed to bypass the rules."},
+		})),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm", WithDescriptionMaxChars(20))
+	s.db = db
+
+	call := func(handler func(any) map[string]any, args map[string]any) string {
+		return handler(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	t.Run("schema truncates to server default", func(t *testing.T) {
+		text := call(s.handleGetResourceSchema, map[string]any{"name": "azurerm_storage_account"})
+		if !strings.Contains(text, "Specifies the suppor…") || strings.Contains(text, "resource exists") {
+			t.Fatalf("expected truncated description, got %s", text)
+		}
+	})
+
+	t.Run("per-call override disables truncation", func(t *testing.T) {
+		text := call(s.handleGetResourceSchema, map[string]any{"name": "azurerm_storage_account", "desc_max_chars": -1})
+		if !strings.Contains(text, longDesc) {
+			t.Fatalf("expected full description, got %s", text)
+		}
+	})
+
+	t.Run("get_attribute returns full text", func(t *testing.T) {
+		text := call(s.handleGetAttribute, map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "location"})
+		if !strings.Contains(text, longDesc) || !strings.Contains(text, "force_new") {
+			t.Fatalf("expected full description and flags, got %s", text)
+		}
+	})
+
+	t.Run("get_attribute resolves nested path", func(t *testing.T) {
+		text := call(s.handleGetAttribute, map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "network_rules.0.bypass"})
+		if !strings.Contains(text, "Traffic allowed to bypass the rules.") {
+			t.Fatalf("expected nested attribute description, got %s", text)
+		}
+	})
+
+	t.Run("get_attribute unknown attribute", func(t *testing.T) {
+		text := call(s.handleGetAttribute, map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "missing"})
+		if !strings.Contains(text, "not found") {
+			t.Fatalf("expected not found error, got %s", text)
+		}
+	})
+}
+
+func TestHandleExplainAttribute(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:         "account_tier",
+		Required:     true,
+		ForceNew:     true,
+		Validation:   sqlNull(`validation.StringInSlice([]string{"Standard", "Premium"}, false)`),
+		DefaultValue: sqlNull(`"Standard"`),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "network_rules",
+		NestedBlock: true,
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "default_action", Type: "pluginsdk.TypeString", Required: true},
+		})),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "tags", Optional: true})
+	docs := strings.Join([]string{
+		"---",
+		"subcategory: \"Storage\"",
+		"---",
+		"",
+		"# azurerm_storage_account",
+		"",
+		"## Arguments Reference",
+		"",
+		"* `account_tier` - (Required) Defines the Tier to use for this storage account.",
+		"  Changing this forces a new resource to be created.",
+		"",
+		"* `network_rules` - (Optional) A `network_rules` block as documented below.",
+		"",
+		"---",
+		"",
+		"A `network_rules` block supports the following:",
+		"",
+		"* `default_action` - (Required) Specifies the default action of allow or deny when no other rules match.",
+		"",
+		"A `share_properties` block supports the following:",
+		"",
+		"* `default_action` - (Optional) An unrelated share setting.",
+	}, "\n")
+	testutil.InsertFile(t, db, repo.ID, "website/docs/r/storage_account.html.markdown", "markdown", docs)
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	call := func(args map[string]any) string {
+		return s.handleExplainAttribute(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	t.Run("combines schema constraints and docs", func(t *testing.T) {
+		text := call(map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "account_tier"})
+		for _, want := range []string{
+			"force_new",
+			"**Allowed Values**: `Standard`, `Premium`",
+			"**Default**: `\"Standard\"`",
+			"Defines the Tier to use for this storage account. Changing this forces a new resource to be created.",
+		} {
+			if !strings.Contains(text, want) {
+				t.Fatalf("expected %q in explanation, got %s", want, text)
+			}
+		}
+	})
+
+	t.Run("nested path uses the parent block paragraph", func(t *testing.T) {
+		text := call(map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "network_rules.0.default_action"})
+		if !strings.Contains(text, "allow or deny") || strings.Contains(text, "unrelated share setting") {
+			t.Fatalf("expected only the network_rules paragraph, got %s", text)
+		}
+	})
+
+	t.Run("undocumented attribute", func(t *testing.T) {
+		text := call(map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "tags"})
+		if !strings.Contains(text, "is not described in website/docs/r/storage_account.html.markdown") {
+			t.Fatalf("expected missing documentation note, got %s", text)
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		text := call(map[string]any{"resource_name": "azurerm_storage_account", "attribute_name": "missing"})
+		if !strings.Contains(text, "not found") {
+			t.Fatalf("expected not found error, got %s", text)
+		}
+	})
+}
+
+func TestHandleGenerateDocsStub(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "address_space", Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "guid", Computed: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGenerateDocsStub(map[string]any{"name": "azurerm_virtual_network"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "## Arguments Reference") || !strings.Contains(text, "* `address_space` - (Required)") {
+		t.Fatalf("expected arguments section, got %s", text)
+	}
+	if !strings.Contains(text, "## Attributes Reference") || !strings.Contains(text, "* `guid` -") {
+		t.Fatalf("expected attributes section, got %s", text)
+	}
+
+	missing := s.handleGenerateDocsStub(map[string]any{"name": "azurerm_missing"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(missing, "not found") {
+		t.Fatalf("expected not found error, got %s", missing)
+	}
+}
+
+func TestHandleExportTFSchema(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_resource_group", "data_source", "internal/services/resource/resource_group_data_source.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleExportTFSchema(map[string]any{"resource_name": "azurerm_resource_group"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"```json",
+		`"registry.terraform.io/hashicorp/azurerm": {`,
+		`"data_source_schemas": {`,
+		`"azurerm_resource_group": {`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+
+	if text := s.handleExportTFSchema(map[string]any{"resource_name": "azurerm_missing"})["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "not found") {
+		t.Fatalf("expected not found error, got %s", text)
+	}
+}
+
+func TestHandleGetResourceSchemaResolvesHumanNames(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "address_space", Required: true})
+	testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network_peering", "resource", "internal/services/network/virtual_network_peering_resource.go")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleGetResourceSchema(map[string]any{"name": "virtual network"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "azurerm_virtual_network") || !strings.Contains(text, "address_space") {
+		t.Fatalf("expected schema resolved from human name, got %s", text)
+	}
+
+	resp = s.handleGetResourceSchema(map[string]any{"name": "azurerm_virtual_netwrok"})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "not found") || !strings.Contains(text, "Did you mean: azurerm_virtual_network") {
+		t.Fatalf("expected fuzzy suggestion, got %s", text)
+	}
+}
+
+func TestHandleGetResourceSchemaAtVersion(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "dns_endpoint_type", Optional: true})
+	if err := db.ReplaceSchemaSnapshot(repo.ID, "3.0.0", "azurerm_storage_account", "resource", []database.ProviderAttribute{
+		{Name: "name", Required: true},
+		{Name: "enable_https_traffic_only", Optional: true},
+	}); err != nil {
+		t.Fatalf("record snapshot: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetResourceSchema(map[string]any{"name": "azurerm_storage_account", "version": "v3.0.0"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Schema Snapshot:** v3.0.0") || !strings.Contains(text, "enable_https_traffic_only") {
+		t.Fatalf("expected 3.0.0 snapshot, got %s", text)
+	}
+	if strings.Contains(text, "dns_endpoint_type") {
+		t.Fatalf("snapshot should not include current-only attributes, got %s", text)
+	}
+
+	resp := s.handleGetResourceSchema(map[string]any{"name": "azurerm_storage_account", "version": "2.99.0"})
+	if text := resp["content"].([]ContentBlock)[0].Text; !strings.Contains(text, "No schema snapshot") || !strings.Contains(text, "--ref v2.99.0") {
+		t.Fatalf("expected missing-snapshot hint, got %s", text)
+	}
+}
+
+func TestHandleGetResourceSchemaCompareWith(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	resource := testutil.InsertResource(t, db, repo.ID, "azurerm_key_vault", "resource", "")
+	testutil.InsertAttribute(t, db, resource.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true, ForceNew: true})
+	testutil.InsertAttribute(t, db, resource.ID, database.ProviderAttribute{Name: "sku_name", Type: sqlNull("pluginsdk.TypeString"), Required: true})
+	testutil.InsertAttribute(t, db, resource.ID, database.ProviderAttribute{Name: "purge_protection_enabled", Type: sqlNull("pluginsdk.TypeBool"), Optional: true})
+	dataSource := testutil.InsertResource(t, db, repo.ID, "azurerm_key_vault", "data_source", "")
+	testutil.InsertAttribute(t, db, dataSource.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true})
+	testutil.InsertAttribute(t, db, dataSource.ID, database.ProviderAttribute{Name: "sku_name", Type: sqlNull("pluginsdk.TypeString"), Computed: true})
+	testutil.InsertAttribute(t, db, dataSource.ID, database.ProviderAttribute{Name: "vault_uri", Type: sqlNull("pluginsdk.TypeString"), Computed: true})
+	testutil.InsertResource(t, db, repo.ID, "azurerm_key_vault_key", "resource", "")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetResourceSchema(map[string]any{"name": "azurerm_key_vault", "compare_with": "data_source"})["content"].([]ContentBlock)[0].Text
+	for _, want := range []string{
+		"# Resource Comparison: azurerm_key_vault (resource) vs azurerm_key_vault (data source)",
+		"### Unique to azurerm_key_vault (resource)\n\n- `purge_protection_enabled`",
+		"### Unique to azurerm_key_vault (data source)\n\n- `vault_uri`",
+		"### Flag Differences (1)",
+		"| sku_name | String, required | String, computed |",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in comparison, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "| name |") {
+		t.Fatalf("expected ForceNew-only difference to be ignored, got %s", text)
+	}
+
+	text = s.handleGetResourceSchema(map[string]any{"name": "azurerm_key_vault", "compare_with": "resource"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "# Resource Comparison: azurerm_key_vault (data source) vs azurerm_key_vault (resource)") {
+		t.Fatalf("expected data source compared with resource, got %s", text)
+	}
+
+	text = s.handleGetResourceSchema(map[string]any{"name": "azurerm_key_vault_key", "compare_with": "data_source"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "No data source named azurerm_key_vault_key is indexed") {
+		t.Fatalf("expected missing sibling error, got %s", text)
+	}
+
+	text = s.handleGetResourceSchema(map[string]any{"name": "azurerm_key_vault", "compare_with": "module"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "compare_with must be") {
+		t.Fatalf("expected validation error, got %s", text)
+	}
+}
+
+func TestHandleGetResourceSchemaSingleBlocksOnly(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_linux_virtual_machine", "resource", "")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "os_disk", Type: sqlNull("pluginsdk.TypeList"), Required: true, NestedBlock: true, MaxItems: sql.NullInt64{Int64: 1, Valid: true}})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "secret", Type: sqlNull("pluginsdk.TypeList"), Optional: true, NestedBlock: true})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleGetResourceSchema(map[string]any{"name": "azurerm_linux_virtual_machine"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| os_disk | pluginsdk.TypeList (single block (object), 1 required) |") || !strings.Contains(text, "| secret | pluginsdk.TypeList (repeatable block, 0+ allowed) |") {
+		t.Fatalf("expected single and repeatable blocks told apart, got %s", text)
+	}
+
+	text = s.handleGetResourceSchema(map[string]any{"name": "azurerm_linux_virtual_machine", "single_blocks_only": true})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| os_disk |") || strings.Contains(text, "| secret |") || strings.Contains(text, "| name |") {
+		t.Fatalf("expected only the single block, got %s", text)
+	}
+	if !strings.Contains(text, "single_blocks_only") {
+		t.Fatalf("expected the filter in the summary, got %s", text)
+	}
+}
+
+func TestHandleGetAttributeTree(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_linux_web_app", "resource", "internal/services/appservice/linux_web_app_resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{Name: "name", Type: sqlNull("pluginsdk.TypeString"), Required: true, ForceNew: true})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:        "site_config",
+		Optional:    true,
+		NestedBlock: true,
+		MaxItems:    sql.NullInt64{Int64: 1, Valid: true},
+		ElemSchemaJSON: sqlNull(database.EncodeNestedSchema([]database.NestedAttribute{
+			{Name: "application_stack", Optional: true, NestedBlock: true, Children: []database.NestedAttribute{
+				{Name: "docker_image", Type: "pluginsdk.TypeString", Optional: true},
+			}},
+		})),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(args map[string]any) string {
+		args["resource_name"] = "azurerm_linux_web_app"
+		return s.handleGetAttributeTree(args)["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call(map[string]any{})
+	for _, want := range []string{
+		"name (Required, String, ForceNew)",
+		"site_config/ (Optional, single block (object), 0 or 1 allowed)",
+		"\n  application_stack/ (Optional, repeatable block, 0+ allowed)",
+		"\n    docker_image (Optional, String)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in tree, got %s", want, text)
+		}
+	}
+
+	text = call(map[string]any{"max_depth": 1})
+	if !strings.Contains(text, "site_config/ (Optional, single block (object), 0 or 1 allowed) … 1 nested attribute(s)") || strings.Contains(text, "docker_image") {
+		t.Fatalf("expected site_config collapsed at depth 1, got %s", text)
+	}
+
+	text = call(map[string]any{"max_lines": 2})
+	if strings.Contains(text, "docker_image") || !strings.Contains(text, "_Showing 2 of 4 lines") {
+		t.Fatalf("expected output bounded to 2 lines, got %s", text)
+	}
+}
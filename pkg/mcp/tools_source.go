@@ -0,0 +1,241 @@
+package mcp
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+	"github.com/dkooll/aztfmcp/internal/indexer"
+)
+
+func (s *Server) handleGetSourceURL(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.ResourceName) == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return s.resourceNotFound(resourceName)
+	}
+
+	filePath := resource.FilePath.String
+	var functionName string
+	var startLine, endLine int
+	if src, err := s.db.GetProviderResourceSource(resource.ID); err == nil {
+		functionName = src.FunctionName.String
+		if src.FilePath.Valid && src.FilePath.String != "" {
+			filePath = src.FilePath.String
+		}
+		startLine, endLine = int(src.FunctionStartLine.Int64), int(src.FunctionEndLine.Int64)
+	}
+	if filePath == "" {
+		return ErrorResponse(fmt.Sprintf("No source file recorded for %s. Try running sync_provider.", resource.Name))
+	}
+
+	repo, err := s.db.GetRepositoryByID(resource.RepositoryID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository: %v", err))
+	}
+	repoURL := s.repositoryURL(repo)
+
+	if startLine == 0 && functionName != "" {
+		if file, err := s.db.GetFile(repo.Name, filePath); err == nil {
+			startLine, endLine = functionLineRange(file.Content, functionName)
+		}
+	}
+
+	ref := ifEmpty(s.ref, "HEAD")
+	link := sourceBlobURL(repoURL, ref, filePath, startLine, endLine)
+	text := formatter.SourceURL(s.style, resource.Name, link, filePath, functionName, ref, startLine, endLine)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleGetRegistrationInfo(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.ResourceName) == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return s.resourceNotFound(resourceName)
+	}
+
+	info := formatter.RegistrationInfo{
+		ResourceName: resource.Name,
+		Kind:         resource.Kind,
+		FilePath:     resource.FilePath.String,
+	}
+	if resource.ServiceID.Valid {
+		if svc, err := s.db.GetProviderServiceByID(resource.ServiceID.Int64); err == nil {
+			info.ServiceName = svc.Name
+			info.ServiceFile = svc.FilePath.String
+			info.GitHubLabel = svc.GitHubLabel.String
+			for _, category := range strings.Split(svc.WebsiteCategories.String, ",") {
+				if category = strings.TrimSpace(category); category != "" {
+					info.WebsiteCategories = append(info.WebsiteCategories, category)
+				}
+			}
+		}
+	}
+
+	if repo, err := s.db.GetRepositoryByID(resource.RepositoryID); err == nil {
+		repoURL := s.repositoryURL(repo)
+		info.NewIssueURL = repoURL + "/issues/new/choose"
+		if info.GitHubLabel != "" {
+			query := fmt.Sprintf("is:issue is:open label:%q", info.GitHubLabel)
+			info.IssuesURL = repoURL + "/issues?q=" + url.QueryEscape(query)
+		}
+	}
+
+	return SuccessResponse(formatter.Registration(s.style, info))
+}
+
+func (s *Server) handleGetSDKClients(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.ResourceName) == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return s.resourceNotFound(resourceName)
+	}
+
+	src, err := s.db.GetProviderResourceSource(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("No source is stored for %s. Try running sync_provider.", resource.Name))
+	}
+	packages, recorded, err := src.ImportedSDKPackages()
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to decode SDK packages for %s: %v", resource.Name, err))
+	}
+	if !recorded {
+		return ErrorResponse(fmt.Sprintf("SDK packages were not recorded for %s. Run sync_provider to refresh the index.", resource.Name))
+	}
+
+	filePath := ifEmpty(src.FilePath.String, resource.FilePath.String)
+	return SuccessResponse(formatter.SDKClients(s.style, resource.Name, resource.Kind, filePath, packages))
+}
+
+func (s *Server) handleLocateResource(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName string `json:"resource_name"`
+	}](args)
+	if err != nil || strings.TrimSpace(params.ResourceName) == "" {
+		return ErrorResponse("resource_name is required")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return s.resourceNotFound(resourceName)
+	}
+
+	loc := formatter.ResourceLocationInfo{
+		ResourceName:       resource.Name,
+		Kind:               resource.Kind,
+		ImplementationFile: resource.FilePath.String,
+	}
+	if src, err := s.db.GetProviderResourceSource(resource.ID); err == nil {
+		loc.FunctionName = src.FunctionName.String
+		if src.FilePath.Valid && src.FilePath.String != "" {
+			loc.ImplementationFile = src.FilePath.String
+		}
+	}
+	if resource.ServiceID.Valid {
+		if svc, err := s.db.GetProviderServiceByID(resource.ServiceID.Int64); err == nil {
+			loc.ServiceName = svc.Name
+			loc.RegistrationFile = svc.FilePath.String
+		}
+	}
+
+	files, err := s.db.GetRepositoryFiles(resource.RepositoryID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load repository files: %v", err))
+	}
+	if docFile := findDocumentationFile(files, strings.TrimPrefix(resource.Name, s.providerPrefix()), resource.Kind); docFile != nil {
+		loc.DocFile = docFile.FilePath
+	}
+
+	return SuccessResponse(formatter.ResourceLocation(s.style, loc))
+}
+
+// providerPrefix returns the resource name prefix of the configured provider repository,
+// such as azurerm_ for terraform-provider-azurerm.
+func (s *Server) providerPrefix() string {
+	return indexer.ProviderPrefix(s.repo)
+}
+
+// repositoryURL returns the repository's GitHub web URL, falling back to the configured
+// org and repo when the index did not record one.
+func (s *Server) repositoryURL(repo *database.Repository) string {
+	repoURL := strings.TrimSuffix(repo.RepoURL, "/")
+	if repoURL == "" {
+		repoURL = "https://github.com/" + ifEmpty(repo.FullName, s.org+"/"+s.repoShortName())
+	}
+	return repoURL
+}
+
+// sourceBlobURL builds a GitHub blob URL, anchored to a line range when one is known.
+func sourceBlobURL(repoURL, ref, filePath string, startLine, endLine int) string {
+	segments := strings.Split(strings.TrimPrefix(filePath, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	link := fmt.Sprintf("%s/blob/%s/%s", repoURL, ref, strings.Join(segments, "/"))
+	switch {
+	case startLine > 0 && endLine > startLine:
+		link += fmt.Sprintf("#L%d-L%d", startLine, endLine)
+	case startLine > 0:
+		link += fmt.Sprintf("#L%d", startLine)
+	}
+	return link
+}
+
+// functionLineRange locates a top-level function declaration in Go source and returns its first
+// and last line (1-based), or zeros when it is not found.
+func functionLineRange(content, functionName string) (int, int) {
+	lines := strings.Split(content, "\n")
+	prefix := "func " + functionName + "("
+	for i, line := range lines {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		for j := i; j < len(lines); j++ {
+			if strings.TrimRight(lines[j], " \t\r") == "}" {
+				return i + 1, j + 1
+			}
+		}
+		return i + 1, 0
+	}
+	return 0, 0
+}
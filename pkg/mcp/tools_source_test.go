@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/testutil"
+)
+
+func TestHandleGetSourceURL(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	filePath := "internal/services/network/virtual_network_resource.go"
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", filePath)
+	testutil.InsertFile(t, db, repo.ID, filePath, "go", "package network\n\nfunc resourceVirtualNetwork() *pluginsdk.Resource {\n\treturn &pluginsdk.Resource{}\n}\n")
+	if err := db.UpsertProviderResourceSource(res.ID, "resourceVirtualNetwork", filePath, "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	testutil.InsertResource(t, db, repo.ID, "azurerm_subnet", "resource", "internal/services/network/subnet_resource.go")
+
+	call := func(s *Server, name string) string {
+		resp := s.handleGetSourceURL(map[string]any{"resource_name": name})
+		return resp["content"].([]ContentBlock)[0].Text
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := call(s, "azurerm_virtual_network")
+	want := "https://github.com/example/terraform-provider-azurerm/blob/HEAD/" + filePath + "#L3-L5"
+	if !strings.Contains(text, "**URL:** "+want) || !strings.Contains(text, "**Function:** resourceVirtualNetwork") {
+		t.Fatalf("expected anchored HEAD permalink, got %s", text)
+	}
+
+	text = call(s, "azurerm_subnet")
+	if !strings.Contains(text, "/blob/HEAD/internal/services/network/subnet_resource.go\n") {
+		t.Fatalf("expected file-level permalink without anchor, got %s", text)
+	}
+
+	pinned := NewServer("", "", "hashicorp", "terraform-provider-azurerm", WithRef("v4.52.0"))
+	pinned.db = db
+	if text := call(pinned, "azurerm_virtual_network"); !strings.Contains(text, "/blob/v4.52.0/") || strings.Contains(text, "may drift") {
+		t.Fatalf("expected permalink pinned to the ref, got %s", text)
+	}
+
+	if text := call(s, "azurerm_virtual_netwrk"); !strings.Contains(text, "not found") {
+		t.Fatalf("expected not found, got %s", text)
+	}
+}
+
+func TestHandleGetSDKClients(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	filePath := "internal/services/compute/linux_virtual_machine_resource.go"
+	vm := testutil.InsertResource(t, db, repo.ID, "azurerm_linux_virtual_machine", "resource", filePath)
+	if err := db.UpsertProviderResourceSource(vm.ID, "resourceLinuxVirtualMachine", filePath, "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	if err := db.SetProviderResourceSourceSDKPackages(vm.ID, []database.SDKPackage{{
+		Path:       "github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines",
+		Plane:      "resource-manager",
+		Service:    "compute",
+		APIVersion: "2024-03-01",
+		Resource:   "virtualmachines",
+	}}); err != nil {
+		t.Fatalf("set sdk packages: %v", err)
+	}
+	legacy := testutil.InsertResource(t, db, repo.ID, "azurerm_legacy", "resource", "")
+	if err := db.UpsertProviderResourceSource(legacy.ID, "resourceLegacy", "legacy.go", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	plain := testutil.InsertResource(t, db, repo.ID, "azurerm_plain", "resource", "")
+	if err := db.UpsertProviderResourceSource(plain.ID, "resourcePlain", "plain.go", "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	if err := db.SetProviderResourceSourceSDKPackages(plain.ID, nil); err != nil {
+		t.Fatalf("set sdk packages: %v", err)
+	}
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(name string) string {
+		return s.handleGetSDKClients(map[string]any{"resource_name": name})["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call("azurerm_linux_virtual_machine")
+	if !strings.Contains(text, "**File:** "+filePath) || !strings.Contains(text, "| compute | 2024-03-01 | virtualmachines | resource-manager | `github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines` |") {
+		t.Fatalf("expected the imported package, got %s", text)
+	}
+	if text := call("azurerm_legacy"); !strings.Contains(text, "were not recorded") {
+		t.Fatalf("expected a re-sync hint for sources without packages, got %s", text)
+	}
+	if text := call("azurerm_plain"); !strings.Contains(text, "imports no go-azure-sdk API packages") {
+		t.Fatalf("expected an empty-import note, got %s", text)
+	}
+	if text := call("azurerm_linux_virtual_machin"); !strings.Contains(text, "not found") {
+		t.Fatalf("expected not found, got %s", text)
+	}
+}
+
+func TestFunctionLineRange(t *testing.T) {
+	src := "package x\n\nfunc other() {}\n\nfunc target(a int) {\n\tif a > 0 {\n\t}\n}\n"
+	if start, end := functionLineRange(src, "target"); start != 5 || end != 8 {
+		t.Fatalf("expected lines 5-8, got %d-%d", start, end)
+	}
+	if start, end := functionLineRange(src, "missing"); start != 0 || end != 0 {
+		t.Fatalf("expected no range for missing function, got %d-%d", start, end)
+	}
+}
+
+func TestHandleGetRegistrationInfo(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	serviceID, err := db.InsertProviderService(&database.ProviderService{
+		RepositoryID:      repo.ID,
+		Name:              "Storage",
+		FilePath:          sql.NullString{String: "internal/services/storage/registration.go", Valid: true},
+		WebsiteCategories: sql.NullString{String: "Storage", Valid: true},
+		GitHubLabel:       sql.NullString{String: "service/storage", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("insert service: %v", err)
+	}
+	if _, err := db.InsertProviderResource(&database.ProviderResource{
+		RepositoryID: repo.ID,
+		ServiceID:    sql.NullInt64{Int64: serviceID, Valid: true},
+		Name:         "azurerm_storage_account",
+		Kind:         "resource",
+	}); err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	testutil.InsertResource(t, db, repo.ID, "azurerm_orphan", "resource", "internal/services/orphan/orphan_resource.go")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(name string) string {
+		return s.handleGetRegistrationInfo(map[string]any{"resource_name": name})["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call("azurerm_storage_account")
+	for _, want := range []string{
+		"**Name:** Storage",
+		"**Website Categories:** Storage",
+		"**GitHub Label:** `service/storage`",
+		"https://github.com/example/terraform-provider-azurerm/issues?q=is%3Aissue+is%3Aopen+label%3A%22service%2Fstorage%22",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+
+	if text := call("azurerm_orphan"); !strings.Contains(text, "No service registration") {
+		t.Fatalf("expected missing service note, got %s", text)
+	}
+	if text := call("azurerm_missing"); !strings.Contains(text, "not found") {
+		t.Fatalf("expected not found, got %s", text)
+	}
+}
+
+func TestHandleLocateResource(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	serviceID, err := db.InsertProviderService(&database.ProviderService{
+		RepositoryID: repo.ID,
+		Name:         "KeyVault",
+		FilePath:     sql.NullString{String: "internal/services/keyvault/registration.go", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("insert service: %v", err)
+	}
+	implPath := "internal/services/keyvault/key_vault_resource.go"
+	resourceID, err := db.InsertProviderResource(&database.ProviderResource{
+		RepositoryID: repo.ID,
+		ServiceID:    sql.NullInt64{Int64: serviceID, Valid: true},
+		Name:         "azurerm_key_vault",
+		Kind:         "resource",
+		FilePath:     sql.NullString{String: implPath, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("insert resource: %v", err)
+	}
+	if err := db.UpsertProviderResourceSource(resourceID, "resourceKeyVault", implPath, "", "", "", "", "", ""); err != nil {
+		t.Fatalf("upsert source: %v", err)
+	}
+	testutil.InsertFile(t, db, repo.ID, "website/docs/r/key_vault.html.markdown", "markdown", "# azurerm_key_vault")
+	testutil.InsertResource(t, db, repo.ID, "azurerm_orphan", "resource", "internal/services/orphan/orphan_resource.go")
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+	call := func(name string) string {
+		return s.handleLocateResource(map[string]any{"resource_name": name})["content"].([]ContentBlock)[0].Text
+	}
+
+	text := call("azurerm_key_vault")
+	for _, want := range []string{
+		"**Implementation:** " + implPath,
+		"**Function:** resourceKeyVault",
+		"**Registration:** internal/services/keyvault/registration.go (service KeyVault)",
+		"**Documentation:** website/docs/r/key_vault.html.markdown",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+	if strings.Contains(text, "stale") {
+		t.Fatalf("did not expect a stale-index note, got %s", text)
+	}
+
+	if text := call("azurerm_orphan"); !strings.Contains(text, "**Documentation:** _not found_") || !strings.Contains(text, "run sync_provider") {
+		t.Fatalf("expected missing paths to be flagged, got %s", text)
+	}
+}
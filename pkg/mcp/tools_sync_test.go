@@ -1,10 +1,12 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/dkooll/aztfmcp/internal/database"
 	"github.com/dkooll/aztfmcp/internal/indexer"
 	"github.com/dkooll/aztfmcp/internal/testutil"
 )
@@ -14,18 +16,28 @@ type fakeSyncerProgress struct {
 	err      error
 }
 
-func (f *fakeSyncerProgress) SyncAll() (*indexer.SyncProgress, error)     { return f.progress, f.err }
-func (f *fakeSyncerProgress) SyncUpdates() (*indexer.SyncProgress, error) { return f.progress, f.err }
+func (f *fakeSyncerProgress) SyncAll(context.Context) (*indexer.SyncProgress, error) {
+	return f.progress, f.err
+}
+func (f *fakeSyncerProgress) SyncUpdates(context.Context) (*indexer.SyncProgress, error) {
+	return f.progress, f.err
+}
 func (f *fakeSyncerProgress) CompareTags(baseTag, headTag string) (*indexer.GitHubCompareResult, error) {
 	return nil, nil
 }
+func (f *fakeSyncerProgress) ListTags() ([]indexer.GitHubTag, error) { return nil, f.err }
+func (f *fakeSyncerProgress) ResourceSchemaAtRef(_, _, _ string) ([]database.ProviderAttribute, error) {
+	return nil, f.err
+}
+func (f *fakeSyncerProgress) ClearCache() int                { return 0 }
+func (f *fakeSyncerProgress) CacheStats() indexer.CacheStats { return indexer.CacheStats{} }
 
 func TestHandleSyncProviderUpdatesError(t *testing.T) {
 	s := NewServer("test.db", "", "org", "repo")
 	s.db = testutil.NewTestDB(t)
 	s.syncer = &fakeSyncerProgress{err: fmt.Errorf("boom")}
 
-	resp := s.handleSyncProviderUpdates()
+	resp := s.handleSyncProviderUpdates(context.Background())
 	content := resp["content"].([]ContentBlock)
 	if !strings.Contains(content[0].Text, "boom") {
 		t.Fatalf("expected sync error, got %s", content[0].Text)
@@ -0,0 +1,237 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dkooll/aztfmcp/internal/database"
+	"github.com/dkooll/aztfmcp/internal/formatter"
+)
+
+const validationScanLimit = 100000
+
+func (s *Server) handleValidationCategories(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName   string `json:"resource_name"`
+		ResourcePrefix string `json:"resource_prefix"`
+		Examples       int    `json:"examples"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	exampleLimit := params.Examples
+	if exampleLimit <= 0 {
+		exampleLimit = 5
+	}
+
+	var results []database.ProviderAttributeSearchResult
+	scope := "all resources"
+	if name := strings.TrimSpace(params.ResourceName); name != "" {
+		resource, err := s.db.GetProviderResource(name)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Resource '%s' not found", name))
+		}
+		attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+		}
+		for _, attr := range attrs {
+			results = append(results, database.ProviderAttributeSearchResult{Attribute: attr, ResourceName: resource.Name, ResourceKind: resource.Kind})
+		}
+		scope = resource.Name
+	} else {
+		prefix := strings.TrimSpace(params.ResourcePrefix)
+		results, err = s.db.SearchProviderAttributes(database.AttributeSearchFilters{
+			ResourcePrefix: prefix,
+			HasValidation:  true,
+			Limit:          validationScanLimit,
+		})
+		if err != nil {
+			return ErrorResponse(fmt.Sprintf("Failed to search provider attributes: %v", err))
+		}
+		if prefix != "" {
+			scope = prefix + "*"
+		}
+	}
+
+	buckets := make(map[string]*formatter.ValidationCategory)
+	validated := 0
+	for _, res := range results {
+		categories := classifyValidation(res.Attribute.Validation.String)
+		if len(categories) == 0 {
+			continue
+		}
+		validated++
+		for _, category := range categories {
+			bucket, ok := buckets[category]
+			if !ok {
+				bucket = &formatter.ValidationCategory{Name: category}
+				buckets[category] = bucket
+			}
+			bucket.Count++
+			if len(bucket.Examples) < exampleLimit {
+				bucket.Examples = append(bucket.Examples, res.ResourceName+"."+res.Attribute.Name)
+			}
+		}
+	}
+
+	var categories []formatter.ValidationCategory
+	for _, name := range validationCategoryOrder {
+		if bucket, ok := buckets[name]; ok {
+			categories = append(categories, *bucket)
+		}
+	}
+
+	text := formatter.ValidationCategories(s.style, scope, validated, categories)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleGetAllowedValues(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourceName  string `json:"resource_name"`
+		AttributeName string `json:"attribute_name"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	resourceName := strings.TrimSpace(params.ResourceName)
+	attributeName := strings.TrimSpace(params.AttributeName)
+	if resourceName == "" || attributeName == "" {
+		return ErrorResponse("resource_name and attribute_name are required")
+	}
+
+	resource, err := s.db.GetProviderResource(resourceName)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Resource '%s' not found", resourceName))
+	}
+
+	attrs, err := s.db.GetProviderResourceAttributes(resource.ID)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to load attributes: %v", err))
+	}
+
+	for _, attr := range attrs {
+		if attr.Name != attributeName {
+			continue
+		}
+		if !attr.Validation.Valid || strings.TrimSpace(attr.Validation.String) == "" {
+			return ErrorResponse(fmt.Sprintf("Attribute '%s' on '%s' has no validation", attributeName, resource.Name))
+		}
+		values, ignoreCase := parseAllowedValues(attr.Validation.String)
+		text := formatter.AllowedValues(s.style, resource.Name, attr.Name, attr.Validation.String, values, ignoreCase)
+		return SuccessResponse(text)
+	}
+
+	return ErrorResponse(fmt.Sprintf("Attribute '%s' not found on '%s'", attributeName, resource.Name))
+}
+
+func (s *Server) handleFindAttributesWithValue(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		Value          string `json:"value"`
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	value := strings.TrimSpace(params.Value)
+	if value == "" {
+		return ErrorResponse("value is required")
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	// The LIKE prefilter is case-insensitive; exact enum membership is checked after parsing.
+	candidates, err := s.db.SearchProviderAttributes(database.AttributeSearchFilters{
+		ResourcePrefix:     strings.TrimSpace(params.ResourcePrefix),
+		ValidationContains: value,
+		HasValidation:      true,
+		Limit:              validationScanLimit,
+	})
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to search provider attributes: %v", err))
+	}
+
+	var matches []formatter.AllowedValueMatch
+	for _, candidate := range candidates {
+		values, ignoreCase := parseAllowedValues(candidate.Attribute.Validation.String)
+		if !enumContains(values, value, ignoreCase) {
+			continue
+		}
+		matches = append(matches, formatter.AllowedValueMatch{
+			ResourceName:  candidate.ResourceName,
+			AttributeName: candidate.Attribute.Name,
+			Values:        values,
+		})
+		if len(matches) >= limit {
+			break
+		}
+	}
+
+	text := formatter.AttributesWithValue(s.style, value, matches)
+	return SuccessResponse(text)
+}
+
+func (s *Server) handleFindUnvalidatedAttributes(args any) map[string]any {
+	if err := s.ensureDB(); err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to initialize database: %v", err))
+	}
+
+	params, err := UnmarshalArgs[struct {
+		ResourcePrefix string `json:"resource_prefix"`
+		Limit          int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return ErrorResponse("Error: Invalid parameters")
+	}
+
+	limit := params.Limit
+	if limit == 0 {
+		limit = 50
+	} else if limit < 0 {
+		limit = validationScanLimit
+	}
+
+	prefix := strings.TrimSpace(params.ResourcePrefix)
+	filters := database.AttributeSearchFilters{
+		ResourcePrefix:    prefix,
+		MissingValidation: true,
+		Types:             []string{"String", "Int"},
+		ConfigurableOnly:  true,
+		Limit:             limit,
+	}
+
+	total, err := s.db.CountProviderAttributes(filters)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to count provider attributes: %v", err))
+	}
+	results, err := s.db.SearchProviderAttributes(filters)
+	if err != nil {
+		return ErrorResponse(fmt.Sprintf("Failed to search provider attributes: %v", err))
+	}
+
+	scope := "all resources"
+	if prefix != "" {
+		scope = prefix + "*"
+	}
+
+	text := formatter.UnvalidatedAttributes(s.style, scope, total, results)
+	return SuccessResponse(text)
+}
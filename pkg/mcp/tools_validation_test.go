@@ -31,3 +31,129 @@ func TestHandleSearchValidations(t *testing.T) {
 		t.Fatalf("expected validated attribute only, got %s", content[0].Text)
 	}
 }
+
+func TestHandleValidationCategories(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "path/to/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:       "name",
+		Validation: sqlNull("validation.StringLenBetween(1, 64)"),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:       "sku",
+		Validation: sqlNull(`validation.StringInSlice([]string{"Basic", "Standard"}, false)`),
+	})
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:       "address_prefix",
+		Validation: sqlNull("validation.IsCIDR"),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleValidationCategories(map[string]any{"resource_name": "azurerm_example"})
+	content := resp["content"].([]ContentBlock)
+	text := content[0].Text
+	for _, want := range []string{"**Validated Attributes**: 3", "| string_length | 1 | azurerm_example.name |", "| enum | 1 | azurerm_example.sku |", "| cidr_ip | 1 | azurerm_example.address_prefix |"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in output, got %s", want, text)
+		}
+	}
+
+	resp = s.handleValidationCategories(map[string]any{"resource_name": "azurerm_missing"})
+	content = resp["content"].([]ContentBlock)
+	if !strings.Contains(content[0].Text, "not found") {
+		t.Fatalf("expected not found error, got %s", content[0].Text)
+	}
+}
+
+func TestHandleGetAllowedValues(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	res := testutil.InsertResource(t, db, repo.ID, "azurerm_example", "resource", "path/to/resource.go")
+	testutil.InsertAttribute(t, db, res.ID, database.ProviderAttribute{
+		Name:       "sku",
+		Validation: sqlNull(`validation.StringInSlice([]string{"Basic", "Standard"}, false)`),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleGetAllowedValues(map[string]any{"resource_name": "azurerm_example", "attribute_name": "sku"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "- `Basic`") || !strings.Contains(text, "- `Standard`") {
+		t.Fatalf("expected allowed values, got %s", text)
+	}
+
+	resp = s.handleGetAllowedValues(map[string]any{"resource_name": "azurerm_example"})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "required") {
+		t.Fatalf("expected required error, got %s", text)
+	}
+}
+
+func TestHandleFindAttributesWithValue(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	storage := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "storage.go")
+	redis := testutil.InsertResource(t, db, repo.ID, "azurerm_redis_cache", "resource", "redis.go")
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{
+		Name:       "account_tier",
+		Validation: sqlNull(`validation.StringInSlice([]string{"Standard", "Premium"}, false)`),
+	})
+	testutil.InsertAttribute(t, db, redis.ID, database.ProviderAttribute{
+		Name:       "sku_name",
+		Validation: sqlNull(`validation.StringInSlice([]string{"Basic", "Standard", "PremiumV2"}, false)`),
+	})
+	testutil.InsertAttribute(t, db, redis.ID, database.ProviderAttribute{
+		Name:       "family",
+		Validation: sqlNull(`validation.StringInSlice([]string{"C", "P"}, true)`),
+	})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	resp := s.handleFindAttributesWithValue(map[string]any{"value": "Premium"})
+	text := resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| azurerm_storage_account | account_tier |") || strings.Contains(text, "sku_name") {
+		t.Fatalf("expected exact enum match only, got %s", text)
+	}
+
+	resp = s.handleFindAttributesWithValue(map[string]any{"value": "p"})
+	text = resp["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "| azurerm_redis_cache | family |") || strings.Contains(text, "account_tier") {
+		t.Fatalf("expected case-insensitive match on family only, got %s", text)
+	}
+}
+
+func TestHandleFindUnvalidatedAttributes(t *testing.T) {
+	db := testutil.NewTestDB(t)
+	repo := testutil.InsertRepository(t, db, "terraform-provider-azurerm")
+	storage := testutil.InsertResource(t, db, repo.ID, "azurerm_storage_account", "resource", "internal/services/storage/storage_account_resource.go")
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{Name: "name", Required: true, Type: sqlNull("pluginsdk.TypeString"), Validation: sqlNull("validate.StorageAccountName")})
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{Name: "dns_endpoint_type", Optional: true, Type: sqlNull("pluginsdk.TypeString")})
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{Name: "min_tls_retention_days", Optional: true, Computed: true, Type: sqlNull("pluginsdk.TypeInt")})
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{Name: "primary_blob_endpoint", Computed: true, Type: sqlNull("pluginsdk.TypeString")})
+	testutil.InsertAttribute(t, db, storage.ID, database.ProviderAttribute{Name: "https_traffic_only_enabled", Optional: true, Type: sqlNull("pluginsdk.TypeBool")})
+	network := testutil.InsertResource(t, db, repo.ID, "azurerm_virtual_network", "resource", "internal/services/network/virtual_network_resource.go")
+	testutil.InsertAttribute(t, db, network.ID, database.ProviderAttribute{Name: "bgp_community", Optional: true, Type: sqlNull("pluginsdk.TypeString")})
+
+	s := NewServer("", "", "hashicorp", "terraform-provider-azurerm")
+	s.db = db
+
+	text := s.handleFindUnvalidatedAttributes(map[string]any{"resource_prefix": "azurerm_storage_"})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Matches**: 2") || !strings.Contains(text, "| azurerm_storage_account | dns_endpoint_type |") || !strings.Contains(text, "min_tls_retention_days") {
+		t.Fatalf("expected unvalidated storage attributes, got %s", text)
+	}
+	for _, excluded := range []string{"| name |", "primary_blob_endpoint", "https_traffic_only_enabled", "bgp_community"} {
+		if strings.Contains(text, excluded) {
+			t.Fatalf("did not expect %q in %s", excluded, text)
+		}
+	}
+
+	text = s.handleFindUnvalidatedAttributes(map[string]any{"limit": 1})["content"].([]ContentBlock)[0].Text
+	if !strings.Contains(text, "**Matches**: 3") || !strings.Contains(text, "Showing 1 of 3") {
+		t.Fatalf("expected truncated listing, got %s", text)
+	}
+}
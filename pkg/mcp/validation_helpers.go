@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+const (
+	validationCategoryStringLength = "string_length"
+	validationCategoryRegex        = "regex"
+	validationCategoryEnum         = "enum"
+	validationCategoryNumericRange = "numeric_range"
+	validationCategoryNetwork      = "cidr_ip"
+	validationCategoryUUID         = "uuid"
+	validationCategoryOther        = "other"
+)
+
+var validationCategoryOrder = []string{
+	validationCategoryStringLength,
+	validationCategoryRegex,
+	validationCategoryEnum,
+	validationCategoryNumericRange,
+	validationCategoryNetwork,
+	validationCategoryUUID,
+	validationCategoryOther,
+}
+
+var validationCategoryMarkers = map[string][]string{
+	validationCategoryStringLength: {"StringLenBetween", "StringLenAtLeast", "StringLenAtMost", "StringIsNotEmpty", "NoZeroValues"},
+	validationCategoryRegex:        {"StringMatch", "StringDoesNotMatch", "regexp.MustCompile", "regexp.Compile", "StringIsValidRegExp"},
+	validationCategoryEnum:         {"StringInSlice", "IntInSlice", "StringNotInSlice", "IntNotInSlice", "PossibleValuesFor"},
+	validationCategoryNumericRange: {"IntBetween", "IntAtLeast", "IntAtMost", "IntDivisibleBy", "FloatBetween", "FloatAtLeast", "FloatAtMost", "IsPortNumber"},
+	validationCategoryNetwork:      {"CIDR", "IPAddress", "IPv4", "IPv6"},
+	validationCategoryUUID:         {"UUID"},
+}
+
+// classifyValidation maps a stored validation expression onto one or more categories.
+// Composite validators (validation.All / validation.Any) can fall into several buckets.
+func classifyValidation(validation string) []string {
+	validation = strings.TrimSpace(validation)
+	if validation == "" {
+		return nil
+	}
+
+	var categories []string
+	for _, category := range validationCategoryOrder {
+		for _, marker := range validationCategoryMarkers[category] {
+			if strings.Contains(validation, marker) {
+				categories = append(categories, category)
+				break
+			}
+		}
+	}
+
+	if len(categories) == 0 {
+		categories = append(categories, validationCategoryOther)
+	}
+	return categories
+}
+
+// parseAllowedValues extracts the literal values passed to StringInSlice/IntInSlice
+// validators. ignoreCase reports the StringInSlice case-insensitivity flag.
+func parseAllowedValues(validation string) (values []string, ignoreCase bool) {
+	expr, err := parser.ParseExpr(strings.TrimSpace(validation))
+	if err != nil {
+		return nil, false
+	}
+
+	seen := make(map[string]struct{})
+	ast.Inspect(expr, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		name := identName(call.Fun)
+		if idx := strings.LastIndex(name, "."); idx >= 0 {
+			name = name[idx+1:]
+		}
+		if name != "StringInSlice" && name != "IntInSlice" {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		for _, elt := range lit.Elts {
+			basic, ok := elt.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			value := basic.Value
+			if basic.Kind == token.STRING {
+				if unquoted, err := strconv.Unquote(value); err == nil {
+					value = unquoted
+				}
+			}
+			if _, dup := seen[value]; dup {
+				continue
+			}
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
+		if name == "StringInSlice" && len(call.Args) > 1 {
+			if flag, ok := call.Args[1].(*ast.Ident); ok && flag.Name == "true" {
+				ignoreCase = true
+			}
+		}
+		return false
+	})
+	return values, ignoreCase
+}
+
+func enumContains(values []string, value string, ignoreCase bool) bool {
+	for _, v := range values {
+		if v == value || (ignoreCase && strings.EqualFold(v, value)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package mcp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClassifyValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation string
+		want       []string
+	}{
+		{"string length", "validation.StringLenBetween(1, 64)", []string{"string_length"}},
+		{"enum", `validation.StringInSlice([]string{"Basic", "Standard"}, false)`, []string{"enum"}},
+		{"cidr", "validation.IsCIDR", []string{"cidr_ip"}},
+		{"composite", "validation.All(validation.StringLenBetween(1, 10), validation.IsUUID)", []string{"string_length", "uuid"}},
+		{"custom", "validate.StorageAccountName", []string{"other"}},
+		{"empty", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyValidation(tt.validation)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("classifyValidation(%q) = %v, want %v", tt.validation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAllowedValues(t *testing.T) {
+	values, ignoreCase := parseAllowedValues(`validation.StringInSlice([]string{
+	"Basic",
+	"Standard",
+	"Premium",
+}, true)`)
+	if !slices.Equal(values, []string{"Basic", "Standard", "Premium"}) {
+		t.Fatalf("unexpected values: %v", values)
+	}
+	if !ignoreCase {
+		t.Fatalf("expected ignoreCase to be true")
+	}
+
+	nested, _ := parseAllowedValues(`validation.All(validation.StringIsNotEmpty, validation.StringInSlice([]string{string(storage.Hot), "Cool"}, false))`)
+	if !slices.Equal(nested, []string{"Cool"}) {
+		t.Fatalf("expected literal values from nested validator, got %v", nested)
+	}
+
+	if got, _ := parseAllowedValues("validation.StringIsNotEmpty"); len(got) != 0 {
+		t.Fatalf("expected no values, got %v", got)
+	}
+}